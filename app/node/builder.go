@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/venus-auth/jwtclient"
 	"github.com/filecoin-project/venus/app/submodule/dagservice"
 	"github.com/filecoin-project/venus/app/submodule/eth"
@@ -25,6 +26,7 @@ import (
 	"github.com/filecoin-project/venus/app/submodule/storagenetworking"
 	"github.com/filecoin-project/venus/app/submodule/syncer"
 	"github.com/filecoin-project/venus/app/submodule/wallet"
+	"github.com/filecoin-project/venus/pkg/beacon"
 	chain2 "github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/clock"
 	"github.com/filecoin-project/venus/pkg/journal"
@@ -50,6 +52,8 @@ type Builder struct {
 	genBlk         types.BlockHeader
 	walletPassword []byte
 	authURL        string
+	beacon         beacon.Schedule
+	simulation     *simulation
 }
 
 // New creates a new node.
@@ -99,6 +103,7 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 		offlineMode: b.offlineMode,
 		repo:        b.repo,
 		chainClock:  b.chainClock,
+		simulation:  b.simulation,
 	}
 
 	// modules
@@ -160,18 +165,22 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 	nd.market = market.NewMarketModule(nd.chain.API(), nd.syncer.Stmgr)
 
 	blockDelay := b.repo.Config().NetworkParams.BlockDelay
-	nd.common = common.NewCommonModule(nd.chain, nd.network, blockDelay)
+	nd.common = common.NewCommonModule(nd.chain, nd.network, nd.mpool, blockDelay, b.repo.Config().Health)
 
 	sqlitePath, err := b.repo.SqlitePath()
 	if err != nil {
 		return nil, err
 	}
-	if nd.eth, err = eth.NewEthSubModule(ctx, b.repo.Config(), nd.chain, nd.mpool, sqlitePath); err != nil {
+	if nd.eth, err = eth.NewEthSubModule(ctx, b.repo.Config(), nd.chain, nd.mpool, nd.network, sqlitePath); err != nil {
 		return nil, err
 	}
 
 	apiBuilder := NewBuilder()
 	apiBuilder.NameSpace("Filecoin")
+	if fevmCfg := b.repo.Config().FevmConfig; fevmCfg.WSKeepaliveTimeout > 0 {
+		// a connection is dropped once no pong arrived within one keepalive interval plus the timeout
+		apiBuilder.ServerOptions(jsonrpc.WithServerTimeout(time.Duration(fevmCfg.WSKeepaliveInterval + fevmCfg.WSKeepaliveTimeout)))
+	}
 
 	err = apiBuilder.AddServices(nd.configModule,
 		nd.blockstore,
@@ -3,6 +3,7 @@ package node
 import (
 	"time"
 
+	"github.com/filecoin-project/venus/pkg/beacon"
 	"github.com/filecoin-project/venus/pkg/clock"
 	"github.com/filecoin-project/venus/pkg/journal"
 	"github.com/filecoin-project/venus/pkg/repo"
@@ -66,6 +67,11 @@ func (b builder) OfflineMode() bool {
 	return b.offlineMode
 }
 
+// BeaconSchedule get the beacon schedule overriding the drand schedule of the network
+func (b builder) BeaconSchedule() beacon.Schedule {
+	return b.beacon
+}
+
 // Verify export ffi verify
 func (b builder) Verifier() ffiwrapper.Verifier {
 	return b.verifier
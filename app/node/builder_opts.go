@@ -5,6 +5,7 @@ import (
 
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/venus/pkg/beacon"
 	"github.com/filecoin-project/venus/pkg/clock"
 	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/constants"
@@ -93,6 +94,15 @@ func ChainClockConfigOption(clk clock.ChainEpochClock) BuilderOpt {
 	}
 }
 
+// BeaconScheduleOption returns a function that sets the beacon schedule to use instead of the drand
+// schedule of the network.
+func BeaconScheduleOption(schedule beacon.Schedule) BuilderOpt {
+	return func(c *Builder) error {
+		c.beacon = schedule
+		return nil
+	}
+}
+
 // JournalConfigOption returns a function that sets the journal to use in the node.
 func JournalConfigOption(jrl journal.Journal) BuilderOpt {
 	return func(c *Builder) error {
@@ -0,0 +1,105 @@
+package node
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// newCompressHandler gzips responses of at least threshold bytes for clients accepting gzip.
+// Websocket upgrades are passed through untouched.
+func newCompressHandler(next http.Handler, threshold int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") ||
+			strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		gw := &gzipResponseWriter{ResponseWriter: w, threshold: threshold, status: http.StatusOK}
+		defer gw.close()
+
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// gzipResponseWriter buffers the response until threshold bytes were written, smaller
+// responses are sent as they are.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	threshold int
+
+	status  int
+	buf     []byte
+	decided bool
+	gz      *gzip.Writer
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.status = status
+}
+
+func (w *gzipResponseWriter) Write(p []byte) (int, error) {
+	if w.decided {
+		if w.gz != nil {
+			return w.gz.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.threshold {
+		if err := w.decide(w.ResponseWriter.Header().Get("Content-Encoding") == ""); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends buffered data, a response flushed before reaching the threshold is not compressed.
+func (w *gzipResponseWriter) Flush() {
+	if !w.decided {
+		if err := w.decide(false); err != nil {
+			return
+		}
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *gzipResponseWriter) decide(compress bool) error {
+	w.decided = true
+	if compress {
+		h := w.ResponseWriter.Header()
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+func (w *gzipResponseWriter) close() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+	}
+}
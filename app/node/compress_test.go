@@ -0,0 +1,55 @@
+package node
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressHandler(t *testing.T) {
+	tf.UnitTest(t)
+
+	large := bytes.Repeat([]byte(`{"jsonrpc":"2.0"}`), 100)
+	small := []byte(`{"jsonrpc":"2.0"}`)
+	handler := newCompressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("size") == "large" {
+			_, _ = w.Write(large)
+			return
+		}
+		_, _ = w.Write(small)
+	}), 1024)
+
+	serve := func(target string, gzipped bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, nil)
+		if gzipped {
+			req.Header.Set("Accept-Encoding", "gzip")
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/rpc/v1?size=large", true)
+	require.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	zr, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	require.Equal(t, large, body)
+
+	// below the threshold
+	rec = serve("/rpc/v1?size=small", true)
+	require.Empty(t, rec.Header().Get("Content-Encoding"))
+	require.Equal(t, small, rec.Body.Bytes())
+
+	// client does not accept gzip
+	rec = serve("/rpc/v1?size=large", false)
+	require.Empty(t, rec.Header().Get("Content-Encoding"))
+	require.Equal(t, large, rec.Body.Bytes())
+}
@@ -0,0 +1,140 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// tooManyConnections is written to connections over the limit before they are closed
+const tooManyConnections = "HTTP/1.1 429 Too Many Requests\r\nContent-Type: text/plain; charset=utf-8\r\nContent-Length: 21\r\nConnection: close\r\n\r\ntoo many connections\n"
+
+// connLimitListener rejects connections from a remote address that already holds the maximum
+// number of open connections. It counts connections rather than requests, a websocket or a
+// keep-alive connection holds its slot until it is closed. Loopback addresses and the addresses
+// of the allowlist are not limited.
+type connLimitListener struct {
+	net.Listener
+	maxPerIP  int64
+	allowlist []*net.IPNet
+
+	active sync.Map // remote ip -> *int64
+}
+
+func newConnLimitListener(l net.Listener, maxPerIP int, allowlist []*net.IPNet) net.Listener {
+	if maxPerIP <= 0 {
+		return l
+	}
+	return &connLimitListener{Listener: l, maxPerIP: int64(maxPerIP), allowlist: allowlist}
+}
+
+// parseConnLimitAllowlist parses the ips and cidrs of the allowlist
+func parseConnLimitAllowlist(addrs []string) ([]*net.IPNet, error) {
+	allowlist := make([]*net.IPNet, 0, len(addrs))
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			allowlist = append(allowlist, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid connection limit allowlist entry %q: %w", addr, err)
+		}
+		allowlist = append(allowlist, ipNet)
+	}
+	return allowlist, nil
+}
+
+func (l *connLimitListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		ip := remoteIP(conn)
+		if ip == nil || l.allowed(ip) {
+			return conn, nil
+		}
+		key := ip.String()
+		counter, ok := l.acquire(key)
+		if !ok {
+			log.Warnw("too many connections from remote address, rejecting", "ip", key, "limit", l.maxPerIP)
+			_ = conn.SetWriteDeadline(time.Now().Add(time.Second))
+			_, _ = conn.Write([]byte(tooManyConnections))
+			_ = conn.Close()
+			continue
+		}
+		return &limitedConn{Conn: conn, release: func() { l.release(key, counter) }}, nil
+	}
+}
+
+func (l *connLimitListener) allowed(ip net.IP) bool {
+	if ip.IsLoopback() {
+		return true
+	}
+	for _, ipNet := range l.allowlist {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *connLimitListener) acquire(ip string) (*int64, bool) {
+	for {
+		v, _ := l.active.LoadOrStore(ip, new(int64))
+		counter := v.(*int64)
+		n := atomic.AddInt64(counter, 1)
+		// the counter may have been dropped by release after reaching zero, retry with a fresh one
+		if cur, ok := l.active.Load(ip); !ok || cur != v {
+			atomic.AddInt64(counter, -1)
+			continue
+		}
+		if n > l.maxPerIP {
+			l.release(ip, counter)
+			return nil, false
+		}
+		return counter, true
+	}
+}
+
+func (l *connLimitListener) release(ip string, counter *int64) {
+	if atomic.AddInt64(counter, -1) > 0 {
+		return
+	}
+	if cur, ok := l.active.Load(ip); ok && cur == counter {
+		l.active.Delete(ip)
+	}
+}
+
+// limitedConn frees its slot of the limit once it is closed
+type limitedConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+func (c *limitedConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.release)
+	return err
+}
+
+func remoteIP(conn net.Conn) net.IP {
+	if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+		return addr.IP
+	}
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
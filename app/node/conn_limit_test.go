@@ -0,0 +1,127 @@
+package node
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/stretchr/testify/require"
+)
+
+// pipeListener accepts the server ends of in-memory connections from chosen remote addresses
+type pipeListener struct {
+	conns  chan net.Conn
+	closed chan struct{}
+}
+
+type remoteConn struct {
+	net.Conn
+	remote net.Addr
+}
+
+func (c *remoteConn) RemoteAddr() net.Addr { return c.remote }
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *pipeListener) Close() error {
+	close(l.closed)
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr { return &net.TCPAddr{} }
+
+// dial connects from ip and returns the client end of the connection
+func (l *pipeListener) dial(ip string) net.Conn {
+	server, client := net.Pipe()
+	l.conns <- &remoteConn{Conn: server, remote: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1000}}
+	return client
+}
+
+func TestConnLimitListener(t *testing.T) {
+	tf.UnitTest(t)
+
+	allowlist, err := parseConnLimitAllowlist([]string{"192.168.1.0/24", "10.0.0.3"})
+	require.NoError(t, err)
+	pl := &pipeListener{conns: make(chan net.Conn), closed: make(chan struct{})}
+	l := newConnLimitListener(pl, 2, allowlist)
+	defer l.Close() // nolint: errcheck
+
+	accepted := make(chan net.Conn)
+	go func() {
+		defer close(accepted)
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+	next := func() net.Conn {
+		select {
+		case conn := <-accepted:
+			return conn
+		case <-time.After(5 * time.Second):
+			t.Fatal("connection not accepted")
+			return nil
+		}
+	}
+
+	first := pl.dial("10.0.0.1")
+	firstServer := next()
+	pl.dial("10.0.0.1")
+	next()
+
+	// the same ip is over the limit, the rejected connection is answered and closed
+	rejected := pl.dial("10.0.0.1")
+	resp, err := io.ReadAll(rejected)
+	require.NoError(t, err)
+	require.Contains(t, string(resp), "429 Too Many Requests")
+
+	// other ips are not affected
+	pl.dial("10.0.0.2")
+	next()
+
+	// loopback and allowlisted addresses are not limited
+	for _, ip := range []string{"127.0.0.1", "::1", "192.168.1.5", "10.0.0.3"} {
+		for i := 0; i < 3; i++ {
+			pl.dial(ip)
+			next()
+		}
+	}
+
+	// closed connections free their slots
+	require.NoError(t, first.Close())
+	require.NoError(t, firstServer.Close())
+	pl.dial("10.0.0.1")
+	next()
+}
+
+func TestParseConnLimitAllowlist(t *testing.T) {
+	tf.UnitTest(t)
+
+	allowlist, err := parseConnLimitAllowlist([]string{"10.0.0.1", "fd00::/8"})
+	require.NoError(t, err)
+	require.Len(t, allowlist, 2)
+	require.True(t, allowlist[0].Contains(net.ParseIP("10.0.0.1")))
+	require.False(t, allowlist[0].Contains(net.ParseIP("10.0.0.2")))
+	require.True(t, allowlist[1].Contains(net.ParseIP("fd00::1")))
+
+	_, err = parseConnLimitAllowlist([]string{"10.0.0.0/33"})
+	require.Error(t, err)
+	_, err = parseConnLimitAllowlist([]string{"localhost"})
+	require.Error(t, err)
+
+	// without a limit the listener is not wrapped
+	pl := &pipeListener{}
+	require.Same(t, pl, newConnLimitListener(pl, 0, nil))
+}
@@ -0,0 +1,37 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+)
+
+// newHealthHandler serves the node health score to load balancers. It answers 200 while the
+// score is at least cfg.MinHealthyScore and 503 otherwise, the body is the score in both cases.
+func newHealthHandler(api v1api.ICommon, cfg *config.HealthConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		score, err := api.NodeHealthScore(r.Context())
+		if err != nil {
+			log.Warnf("computing health score: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		status := http.StatusOK
+		if score.Score < cfg.MinHealthyScore {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(score); err != nil {
+			log.Warnf("writing health score: %v", err)
+		}
+	})
+}
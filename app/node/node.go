@@ -35,6 +35,7 @@ import (
 	_ "github.com/filecoin-project/venus/pkg/crypto/secp"      // enable secp signatures
 	"github.com/filecoin-project/venus/pkg/metrics"
 	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/util/reqid"
 	cmds "github.com/ipfs/go-ipfs-cmds"
 	cmdhttp "github.com/ipfs/go-ipfs-cmds/http"
 	logging "github.com/ipfs/go-log/v2"
@@ -65,6 +66,9 @@ type Node struct {
 	// It contains all persistent artifacts of the filecoin node.
 	repo repo.Repo
 
+	// simulation drives the chain clock and mining of a simulation node, see NewSimulationNode
+	simulation *simulation
+
 	// moduls
 	circulatiingSupplyCalculator chain.ICirculatingSupplyCalcualtor
 	//
@@ -192,6 +196,10 @@ func (node *Node) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to start eth module %v", err)
 	}
 
+	if node.simulation != nil && node.simulation.epochDuration > 0 {
+		go node.simulation.run(syncCtx, node)
+	}
+
 	return nil
 }
 
@@ -255,7 +263,11 @@ func (node *Node) RunRPCAndWait(ctx context.Context, rootCmdDaemon *cmds.Command
 		return err
 	}
 
-	netListener := manet.NetListener(apiListener) // nolint
+	allowlist, err := parseConnLimitAllowlist(cfg.API.ConnectionLimitAllowlist)
+	if err != nil {
+		return err
+	}
+	netListener := newConnLimitListener(manet.NetListener(apiListener), cfg.API.MaxConnectionsPerIP, allowlist) // nolint
 	mux := http.NewServeMux()
 	err = node.runRestfulAPI(ctx, mux, rootCmdDaemon) // nolint
 	if err != nil {
@@ -279,10 +291,16 @@ func (node *Node) RunRPCAndWait(ctx context.Context, rootCmdDaemon *cmds.Command
 	authMux := jwtclient.NewAuthMux(localVerifer, node.remoteAuth, mux)
 	authMux.TrustHandle("/debug/pprof/", http.DefaultServeMux)
 	authMux.TrustHandle("/healthcheck", healthcheck.Handler())
+	authMux.TrustHandle("/health", newHealthHandler(node.common, cfg.Health))
+
+	var handler http.Handler = reqid.Handler(authMux)
+	if cfg.API.EnableCompression {
+		handler = newCompressHandler(handler, cfg.API.CompressionThreshold)
+	}
 
 	apiKey, _ := tag.NewKey("api")
 	apiServ := &http.Server{
-		Handler: authMux,
+		Handler: handler,
 		BaseContext: func(listener net.Listener) context.Context {
 			ctx, _ := tag.New(context.Background(),
 				tag.Upsert(apiKey, "venus"))
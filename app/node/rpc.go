@@ -15,9 +15,10 @@ import (
 type RPCService interface{}
 
 type RPCBuilder struct {
-	namespace   []string
-	v0APIStruct []interface{}
-	v1APIStruct []interface{}
+	namespace     []string
+	serverOptions []jsonrpc.ServerOption
+	v0APIStruct   []interface{}
+	v1APIStruct   []interface{}
 }
 
 func NewBuilder() *RPCBuilder {
@@ -29,6 +30,12 @@ func (builder *RPCBuilder) NameSpace(nameSpaece string) *RPCBuilder {
 	return builder
 }
 
+// ServerOptions appends options applied to every server created by Build
+func (builder *RPCBuilder) ServerOptions(opts ...jsonrpc.ServerOption) *RPCBuilder {
+	builder.serverOptions = append(builder.serverOptions, opts...)
+	return builder
+}
+
 func (builder *RPCBuilder) AddServices(services ...RPCService) error {
 	for _, service := range services {
 		err := builder.AddService(service)
@@ -121,6 +128,7 @@ func (builder *RPCBuilder) Build(version string, limiter *ratelimit.RateLimiter)
 	var server *jsonrpc.RPCServer
 	serverOptions := make([]jsonrpc.ServerOption, 0)
 	serverOptions = append(serverOptions, jsonrpc.WithProxyBind(jsonrpc.PBMethod))
+	serverOptions = append(serverOptions, builder.serverOptions...)
 
 	switch version {
 	case "v0":
@@ -180,8 +188,13 @@ func aliasETHAPI(rpcServer *jsonrpc.RPCServer) {
 	rpcServer.AliasMethod("eth_getMessageCidByTransactionHash", "Filecoin.EthGetMessageCidByTransactionHash")
 	rpcServer.AliasMethod("eth_getTransactionCount", "Filecoin.EthGetTransactionCount")
 	rpcServer.AliasMethod("eth_getTransactionReceipt", "Filecoin.EthGetTransactionReceipt")
+	rpcServer.AliasMethod("eth_getBlockReceipts", "Filecoin.EthGetBlockReceipts")
 	rpcServer.AliasMethod("eth_getTransactionByBlockHashAndIndex", "Filecoin.EthGetTransactionByBlockHashAndIndex")
 	rpcServer.AliasMethod("eth_getTransactionByBlockNumberAndIndex", "Filecoin.EthGetTransactionByBlockNumberAndIndex")
+	rpcServer.AliasMethod("eth_getUncleByBlockHashAndIndex", "Filecoin.EthGetUncleByBlockHashAndIndex")
+	rpcServer.AliasMethod("eth_getUncleCountByBlockHash", "Filecoin.EthGetUncleCountByBlockHash")
+	rpcServer.AliasMethod("debug_getBadBlocks", "Filecoin.EthDebugGetBadBlocks")
+	rpcServer.AliasMethod("txpool_content", "Filecoin.EthTxpoolContent")
 
 	rpcServer.AliasMethod("eth_getCode", "Filecoin.EthGetCode")
 	rpcServer.AliasMethod("eth_getStorageAt", "Filecoin.EthGetStorageAt")
@@ -194,7 +207,8 @@ func aliasETHAPI(rpcServer *jsonrpc.RPCServer) {
 	rpcServer.AliasMethod("eth_sendRawTransaction", "Filecoin.EthSendRawTransaction")
 
 	rpcServer.AliasMethod("net_version", "Filecoin.NetVersion")
-	rpcServer.AliasMethod("net_listening", "Filecoin.NetListening")
+	rpcServer.AliasMethod("net_listening", "Filecoin.EthNetListening")
+	rpcServer.AliasMethod("net_peerCount", "Filecoin.EthNetPeerCount")
 
 	rpcServer.AliasMethod("web3_clientVersion", "Filecoin.Web3ClientVersion")
 
@@ -0,0 +1,279 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	acrypto "github.com/filecoin-project/go-state-types/crypto"
+	"github.com/libp2p/go-libp2p"
+	ci "github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/filecoin-project/venus/pkg/beacon"
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/clock"
+	"github.com/filecoin-project/venus/pkg/consensus"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/util/ffiwrapper/impl"
+	"github.com/filecoin-project/venus/pkg/wallet"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// SimConfig configures a node running a deterministic simulation of the chain, see NewSimulationNode.
+type SimConfig struct {
+	// Repo is the repo of the node, it must have been initialized with a genesis block, see Init
+	Repo repo.Repo
+	// Seed seeds the mock beacon, which is the randomness source of the chain, and the libp2p
+	// identity of the node. Simulations with the same genesis and seed produce the same chain.
+	Seed int64
+	// EpochDuration is the wall clock time between two simulated epochs, the chain clock advances
+	// by the block delay of the network every EpochDuration. When it is zero the chain clock only
+	// advances on calls to Node.SimulateEpochs, which keeps tests independent of the wall clock.
+	EpochDuration time.Duration
+	// Miner mines a block every epoch it wins the election, nothing is mined when it is undefined.
+	// The worker key of the miner has to be in the wallet of the node.
+	Miner address.Address
+	// Options are extra options of the node, applied after the ones of the simulation
+	Options []BuilderOpt
+}
+
+// NewSimulationNode creates a node that never touches the real network. Its chain clock is a fake clock
+// starting at the genesis time, its beacon is a mock beacon seeded by cfg.Seed and proofs are not
+// verified. Once started, the node advances its clock by one epoch every cfg.EpochDuration, or on
+// calls to SimulateEpochs, and mines a block for cfg.Miner on top of its head.
+func NewSimulationNode(cfg *SimConfig) (*Node, error) {
+	if cfg.Repo == nil {
+		return nil, errors.New("simulation needs an initialized repo")
+	}
+	if cfg.EpochDuration < 0 {
+		return nil, fmt.Errorf("invalid simulation epoch duration %s", cfg.EpochDuration)
+	}
+
+	ctx := context.TODO()
+	genBlk, err := chain.GenesisBlock(ctx, cfg.Repo.ChainDatastore(), cfg.Repo.Datastore())
+	if err != nil {
+		return nil, fmt.Errorf("loading genesis block: %w", err)
+	}
+
+	repoCfg := cfg.Repo.Config()
+	repoCfg.Bootstrap.Addresses = nil
+	repoCfg.Bootstrap.MinPeerThreshold = 0
+
+	blockDelay := time.Duration(repoCfg.NetworkParams.BlockDelay) * time.Second
+	fakeClock := clock.NewFake(time.Unix(int64(genBlk.Timestamp), 0))
+	chainClock := clock.NewChainClockFromClock(genBlk.Timestamp, blockDelay, fakeClock)
+
+	// the identity is derived from the seed as well, it orders the blocks of a tipset
+	sk, _, err := ci.GenerateEd25519Key(rand.New(rand.NewSource(cfg.Seed))) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("generating peer key: %w", err)
+	}
+
+	opts := []BuilderOpt{
+		func(c *Builder) error {
+			c.repo = cfg.Repo
+			return nil
+		},
+		OfflineMode(true),
+		Libp2pOptions(libp2p.Identity(sk), libp2p.NoListenAddrs),
+		BlockTime(blockDelay),
+		ChainClockConfigOption(chainClock),
+		BeaconScheduleOption(beacon.NewSeededMockSchedule(blockDelay, cfg.Seed)),
+		VerifierConfigOption(&simVerifier{}),
+		func(c *Builder) error {
+			c.simulation = &simulation{
+				clock:         fakeClock,
+				blockDelay:    blockDelay,
+				epochDuration: cfg.EpochDuration,
+				miner:         cfg.Miner,
+			}
+			return nil
+		},
+	}
+	return New(ctx, append(opts, cfg.Options...)...)
+}
+
+// simVerifier accepts every proof and challenges the first proving sector for winning posts,
+// the fake verifier challenges none which would keep the miner from ever being eligible.
+type simVerifier struct {
+	impl.FakeVerifier
+}
+
+func (v *simVerifier) GenerateWinningPoStSectorChallenge(ctx context.Context, proofType abi.RegisteredPoStProof, minerID abi.ActorID, randomness abi.PoStRandomness, eligibleSectorCount uint64) ([]uint64, error) {
+	if eligibleSectorCount == 0 {
+		return nil, nil
+	}
+	return []uint64{0}, nil
+}
+
+// SimulateEpochs advances the chain clock of a simulation node by n epochs, mining on each of them.
+// It returns once the blocks of the last epoch are on the chain, or on the first mining error.
+func (node *Node) SimulateEpochs(ctx context.Context, n int) error {
+	if node.simulation == nil {
+		return errors.New("not a simulation node")
+	}
+	for i := 0; i < n; i++ {
+		if err := node.simulation.step(ctx, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type simulation struct {
+	clock         clock.Fake
+	blockDelay    time.Duration
+	epochDuration time.Duration
+	miner         address.Address
+
+	// lk serializes the epochs of the ticker and of SimulateEpochs
+	lk sync.Mutex
+}
+
+// run advances the chain clock by one epoch every epochDuration and mines on the new epoch until ctx is done.
+func (s *simulation) run(ctx context.Context, node *Node) {
+	ticker := time.NewTicker(s.epochDuration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := s.step(ctx, node); err != nil {
+			log.Warnf("simulation failed to mine epoch %d: %v", node.chainClock.EpochAtTime(s.clock.Now()), err)
+		}
+	}
+}
+
+// step advances the chain clock by one epoch and mines on it.
+func (s *simulation) step(ctx context.Context, node *Node) error {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	s.clock.Advance(s.blockDelay)
+	if s.miner.Empty() {
+		return nil
+	}
+	return s.mineOnce(ctx, node)
+}
+
+// mineOnce mines a block on top of the head for the current epoch if the miner wins the election,
+// otherwise the epoch stays a null round.
+func (s *simulation) mineOnce(ctx context.Context, node *Node) error {
+	head := node.chain.ChainReader.GetHead()
+	round := node.chainClock.EpochAtTime(s.clock.Now())
+	if round <= head.Height() {
+		return nil
+	}
+
+	mbi, err := node.mining.API().MinerGetBaseInfo(ctx, s.miner, round, head.Key())
+	if err != nil {
+		return fmt.Errorf("getting mining base info: %w", err)
+	}
+	if mbi == nil || !mbi.EligibleForMining {
+		return nil
+	}
+
+	rbase := mbi.PrevBeaconEntry
+	if len(mbi.BeaconEntries) > 0 {
+		rbase = mbi.BeaconEntries[len(mbi.BeaconEntries)-1]
+	}
+	signer := walletSigner{node.wallet.Wallet}
+
+	buf := new(bytes.Buffer)
+	if err := s.miner.MarshalCBOR(buf); err != nil {
+		return fmt.Errorf("marshaling miner address: %w", err)
+	}
+	electionRand, err := chain.DrawRandomness(rbase.Data, acrypto.DomainSeparationTag_ElectionProofProduction, round, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("drawing election randomness: %w", err)
+	}
+	vrfProof, err := signer.SignBytes(ctx, electionRand, mbi.WorkerKey)
+	if err != nil {
+		return fmt.Errorf("computing election proof: %w", err)
+	}
+	eproof := &types.ElectionProof{VRFProof: vrfProof.Data}
+	eproof.WinCount = eproof.ComputeWinCount(mbi.MinerPower, mbi.NetworkPower)
+	if eproof.WinCount < 1 {
+		return nil
+	}
+
+	bSmokeHeight := round > node.repo.Config().NetworkParams.ForkUpgradeParam.UpgradeSmokeHeight
+	ticket, err := consensus.NewTicketMachine(node.chain.ChainReader).MakeTicket(ctx, head.Key(), round-constants.TicketRandomnessLookback,
+		s.miner, &rbase, bSmokeHeight, mbi.WorkerKey, signer)
+	if err != nil {
+		return fmt.Errorf("making ticket: %w", err)
+	}
+
+	postProof, err := mbi.Sectors[0].SealProof.RegisteredWinningPoStProof()
+	if err != nil {
+		return fmt.Errorf("getting winning post proof type: %w", err)
+	}
+
+	msgs, err := node.mpool.MPool.SelectMessages(ctx, head, ticket.Quality())
+	if err != nil {
+		return fmt.Errorf("selecting messages: %w", err)
+	}
+
+	blk, err := node.mining.API().MinerCreateBlock(ctx, &types.BlockTemplate{
+		Miner:            s.miner,
+		Parents:          head.Key(),
+		Ticket:           &ticket,
+		Eproof:           eproof,
+		BeaconValues:     mbi.BeaconEntries,
+		Messages:         msgs,
+		Epoch:            round,
+		Timestamp:        head.MinTimestamp() + uint64(round-head.Height())*uint64(s.blockDelay/time.Second),
+		WinningPoStProof: []builtin.PoStProof{{PoStProof: postProof, ProofBytes: []byte("valid proof")}},
+	})
+	if err != nil {
+		return fmt.Errorf("creating block: %w", err)
+	}
+
+	// the block is synced asynchronously, wait for it so the next epoch is mined on top of it
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	headChanges := node.chain.ChainReader.SubHeadChanges(ctx)
+	if err := node.syncer.API().SyncSubmitBlock(ctx, blk); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case changes, ok := <-headChanges:
+			if !ok {
+				return errors.New("head change subscription closed")
+			}
+			for _, change := range changes {
+				if change.Type != types.HCRevert && change.Val.Key().Has(blk.Header.Cid()) {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// walletSigner adapts the wallet of the node to types.Signer
+type walletSigner struct {
+	w *wallet.Wallet
+}
+
+func (s walletSigner) SignBytes(ctx context.Context, data []byte, addr address.Address) (*acrypto.Signature, error) {
+	return s.w.SignBytes(ctx, data, addr)
+}
+
+func (s walletSigner) HasAddress(ctx context.Context, addr address.Address) (bool, error) {
+	return s.w.HasAddress(ctx, addr), nil
+}
@@ -0,0 +1,52 @@
+package node_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/app/node/test"
+	"github.com/filecoin-project/venus/pkg/constants"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestSimulationNodeDeterministic(t *testing.T) {
+	tf.IntegrationTest(t)
+	ctx := context.Background()
+
+	seed, genCfg, _ := test.CreateBootstrapSetup(t)
+	const height = abi.ChainEpoch(10)
+
+	// simulate returns the tipsets up to height of a simulation mining with the genesis miner
+	simulate := func(simSeed int64) []types.TipSetKey {
+		n := test.NewNodeBuilder(t).
+			WithGenesisInit(seed.GenesisInitFunc).
+			WithBuilderOpt(node.MonkeyPatchSetProofTypeOption(constants.DevRegisteredSealProof)).
+			WithSimulation(simSeed, 0, seed.MinerAddr(0)).
+			Build(ctx)
+		seed.GiveKey(ctx, t, n, genCfg.Miners[0].Owner)
+		require.NoError(t, n.Start(ctx))
+		defer n.Stop(ctx)
+
+		chain := n.Chain().ChainReader
+		require.NoError(t, n.SimulateEpochs(ctx, int(height)))
+		// the genesis miner holds all the power, some of the epochs may still be null rounds
+		require.Positive(t, chain.GetHead().Height())
+
+		var keys []types.TipSetKey
+		for h := abi.ChainEpoch(1); h <= height; h++ {
+			ts, err := chain.GetTipSetByHeight(ctx, nil, h, false)
+			require.NoError(t, err)
+			keys = append(keys, ts.Key())
+		}
+		return keys
+	}
+
+	chain := simulate(42)
+	require.Equal(t, chain, simulate(42))
+	require.NotEqual(t, chain, simulate(43))
+}
@@ -73,6 +73,11 @@ func (c *Client) Address() string {
 	return c.address
 }
 
+// Token returns the token authorizing the client's requests.
+func (c *Client) Token() string {
+	return c.token
+}
+
 func (c *Client) run(ctx context.Context, command ...string) (*th.CmdOutput, int, error) {
 	c.tb.Helper()
 	args := []string{
@@ -3,7 +3,9 @@ package test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/filecoin-project/go-address"
 	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/venus/app/node"
@@ -28,6 +30,8 @@ type NodeBuilder struct {
 	configMutations []node.ConfigOpt
 	// Mutations to be applied to the node builder config before building.
 	builderOpts []node.BuilderOpt
+	// Simulation config of the built nodes, they are regular nodes when nil.
+	simulation *node.SimConfig
 
 	tb testing.TB
 }
@@ -56,6 +60,13 @@ func NewNodeBuilder(tb testing.TB) *NodeBuilder {
 	}
 }
 
+// NewSimulationNodeBuilder creates a node builder for simulation nodes that neither mine nor advance
+// their chain clock unless the test calls SimulateEpochs, so the tests don't depend on the wall clock
+// or the network.
+func NewSimulationNodeBuilder(tb testing.TB) *NodeBuilder {
+	return NewNodeBuilder(tb).WithSimulation(0, 0, address.Undef)
+}
+
 // WithGenesisInit sets the built nodes' genesis function.
 func (b *NodeBuilder) WithGenesisInit(gif genesis.InitFunc) *NodeBuilder {
 	b.gif = gif
@@ -80,6 +91,13 @@ func (b *NodeBuilder) WithConfig(cm node.ConfigOpt) *NodeBuilder {
 	return b
 }
 
+// WithSimulation builds simulation nodes seeded with seed, advancing one epoch every epochDuration,
+// or only on SimulateEpochs when it is zero, and mining for miner, see node.NewSimulationNode.
+func (b *NodeBuilder) WithSimulation(seed int64, epochDuration time.Duration, miner address.Address) *NodeBuilder {
+	b.simulation = &node.SimConfig{Seed: seed, EpochDuration: epochDuration, Miner: miner}
+	return b
+}
+
 // Build creates a node as specified by this builder.
 // This many be invoked multiple times to create many nodes.
 func (b *NodeBuilder) Build(ctx context.Context) *node.Node {
@@ -92,6 +110,15 @@ func (b *NodeBuilder) Build(ctx context.Context) *node.Node {
 	b.requireNoError(node.Init(ctx, repo, b.gif, b.initOpts...))
 
 	// Initialize the node.
+	if b.simulation != nil {
+		simCfg := *b.simulation
+		simCfg.Repo = repo
+		simCfg.Options = b.builderOpts
+		nd, err := node.NewSimulationNode(&simCfg)
+		b.requireNoError(err)
+		return nd
+	}
+
 	repoConfigOpts, err := node.OptionsFromRepo(repo)
 	b.requireNoError(err)
 
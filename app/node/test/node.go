@@ -93,6 +93,11 @@ func (cs *ChainSeed) GiveMiner(t *testing.T, nd *node.Node, which int) (address.
 	return m.Address, ownerAddr
 }
 
+// MinerAddr returns the address of the given miner
+func (cs *ChainSeed) MinerAddr(which int) address.Address {
+	return cs.info.Miners[which].Address
+}
+
 // Addr returns the address for the given key
 func (cs *ChainSeed) Addr(t *testing.T, key int) address.Address {
 	t.Helper()
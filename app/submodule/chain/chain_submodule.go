@@ -2,16 +2,24 @@ package chain
 
 import (
 	"context"
+	"path/filepath"
 	"time"
 
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
 	"github.com/ipfs/go-cid"
 	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-varint"
+	tcache "github.com/patrickmn/go-cache"
 
 	apiwrapper "github.com/filecoin-project/venus/app/submodule/chain/v0api"
 	"github.com/filecoin-project/venus/pkg/beacon"
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/consensus"
 	"github.com/filecoin-project/venus/pkg/consensusfault"
+	"github.com/filecoin-project/venus/pkg/events"
+	"github.com/filecoin-project/venus/pkg/events/filter"
 	"github.com/filecoin-project/venus/pkg/fork"
 	"github.com/filecoin-project/venus/pkg/repo"
 	"github.com/filecoin-project/venus/pkg/statemanger"
@@ -39,6 +47,12 @@ type ChainSubmodule struct { //nolint
 	Stmgr *statemanger.Stmgr
 	// Wait for confirm message
 	Waiter *chain.Waiter
+
+	// circulating supply by tipset key, entries live for one epoch
+	circSupplyCache *tcache.Cache
+
+	// FVMEvents stores the native actor events of the applied tipsets, nil unless enabled by the config
+	FVMEvents *filter.FVMEventStore
 }
 
 type chainConfig interface {
@@ -46,6 +60,8 @@ type chainConfig interface {
 	BlockTime() time.Duration
 	Repo() repo.Repo
 	Verifier() ffiwrapper.Verifier
+	// BeaconSchedule overrides the drand schedule of the network when not nil
+	BeaconSchedule() beacon.Schedule
 }
 
 // NewChainSubmodule creates a new chain submodule.
@@ -62,9 +78,12 @@ func NewChainSubmodule(ctx context.Context,
 		return nil, err
 	}
 
-	drand, err := beacon.DrandConfigSchedule(genBlk.Timestamp, repo.Config().NetworkParams.BlockDelay, repo.Config().NetworkParams.DrandSchedule)
-	if err != nil {
-		return nil, err
+	drand := config.BeaconSchedule()
+	if drand == nil {
+		drand, err = beacon.DrandConfigSchedule(genBlk.Timestamp, repo.Config().NetworkParams.BlockDelay, repo.Config().NetworkParams.DrandSchedule)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	messageStore := chain.NewMessageStore(config.Repo().Datastore(), repo.Config().NetworkParams.ForkUpgradeParam)
@@ -88,22 +107,78 @@ func NewChainSubmodule(ctx context.Context,
 		config:       config,
 		Waiter:       waiter,
 		CheckPoint:   chainStore.GetCheckPoint(),
+
+		circSupplyCache: tcache.New(config.BlockTime(), config.BlockTime()*10),
 	}
 	err = store.ChainReader.Load(context.TODO())
 	if err != nil {
 		return nil, err
 	}
+
+	if eventCfg := repo.Config().FevmConfig.Event; eventCfg.EnableFVMEventStore {
+		sqlitePath, err := repo.SqlitePath()
+		if err != nil {
+			return nil, err
+		}
+		store.FVMEvents, err = filter.NewFVMEventStore(filepath.Join(sqlitePath, "fvm_events.db"), abi.ChainEpoch(eventCfg.MaxFilterHeightRange))
+		if err != nil {
+			return nil, err
+		}
+	}
 	return store, nil
 }
 
 // Start loads the chain from disk.
 func (chain *ChainSubmodule) Start(ctx context.Context) error {
-	return chain.Fork.Start(ctx)
+	if err := chain.Fork.Start(ctx); err != nil {
+		return err
+	}
+
+	if chain.FVMEvents != nil {
+		ev, err := events.NewEvents(ctx, chain.API())
+		if err != nil {
+			return err
+		}
+		// ignore returned tipsets
+		_ = ev.Observe(&filter.FVMEventObserver{
+			Store:           chain.FVMEvents,
+			MessageStore:    chain.MessageStore,
+			ChainStore:      chain.ChainReader.Blockstore(),
+			AddressResolver: chain.ResolveEventEmitter,
+		})
+	}
+	return nil
 }
 
 // Stop stop the chain head event
 func (chain *ChainSubmodule) Stop(ctx context.Context) {
 	chain.ChainReader.Stop()
+	if chain.FVMEvents != nil {
+		if err := chain.FVMEvents.Close(); err != nil {
+			log.Warnf("failed to close fvm event store: %v", err)
+		}
+	}
+}
+
+// ResolveEventEmitter returns the address the events of emitter are stored and matched with, its f4
+// address when the EAM assigned it one and its id address otherwise.
+func (chain *ChainSubmodule) ResolveEventEmitter(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) (address.Address, bool) {
+	idAddr, err := address.NewIDAddress(uint64(emitter))
+	if err != nil {
+		return address.Undef, false
+	}
+
+	actor, err := chain.Stmgr.GetActorAt(ctx, idAddr, ts)
+	if err != nil {
+		return address.Undef, false
+	}
+	if actor.Address == nil || actor.Address.Protocol() != address.Delegated {
+		return idAddr, true
+	}
+	if namespace, _, err := varint.FromUvarint(actor.Address.Payload()); err != nil || namespace != builtintypes.EthereumAddressManagerActorID {
+		return idAddr, true
+	}
+	return *actor.Address, true
 }
 
 // API chain module api implement
@@ -9,12 +9,14 @@ import (
 	"io"
 	"math"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/filecoin-project/go-address"
 	amt4 "github.com/filecoin-project/go-amt-ipld/v4"
 	"github.com/filecoin-project/go-state-types/abi"
 	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
 	acrypto "github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/network"
 	miner0 "github.com/filecoin-project/specs-actors/actors/builtin/miner"
@@ -22,12 +24,16 @@ import (
 	cbor "github.com/ipfs/go-ipld-cbor"
 	logging "github.com/ipfs/go-log/v2"
 	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/fork"
 	"github.com/filecoin-project/venus/pkg/statemanger"
+	"github.com/filecoin-project/venus/pkg/util/reqid"
 	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/actors/policy"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/filecoin-project/venus/venus-shared/utils"
@@ -196,6 +202,29 @@ func (cia *chainInfoAPI) ChainGetBlockMessages(ctx context.Context, bid cid.Cid)
 	}, nil
 }
 
+// ChainGetBlockMessagesDecoded is ChainGetBlockMessages with the params of the calls to EVM contracts decoded
+// when the method called is known
+func (cia *chainInfoAPI) ChainGetBlockMessagesDecoded(ctx context.Context, bid cid.Cid) (*types.BlockMessagesDecoded, error) {
+	bm, err := cia.ChainGetBlockMessages(ctx, bid)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &types.BlockMessagesDecoded{
+		BlsMessages:   make([]*types.MessageDecoded, len(bm.BlsMessages)),
+		SecpkMessages: make([]*types.SignedMessageDecoded, len(bm.SecpkMessages)),
+		Cids:          bm.Cids,
+	}
+	for i, m := range bm.BlsMessages {
+		out.BlsMessages[i] = &types.MessageDecoded{Message: m, DecodedParams: decodeEVMParams(m)}
+	}
+	for i, m := range bm.SecpkMessages {
+		out.SecpkMessages[i] = &types.SignedMessageDecoded{Message: m, DecodedParams: decodeEVMParams(&m.Message)}
+	}
+
+	return out, nil
+}
+
 // ChainGetReceipts gets a receipt collection by CID
 func (cia *chainInfoAPI) ChainGetReceipts(ctx context.Context, id cid.Cid) ([]types.MessageReceipt, error) {
 	return cia.chain.MessageStore.LoadReceipts(ctx, id)
@@ -373,6 +402,70 @@ func (cia *chainInfoAPI) StateGetBeaconEntry(ctx context.Context, epoch abi.Chai
 	}
 }
 
+// ChainGetLatestBeaconEntry returns the highest round beacon entry found walking back from the chain head
+func (cia *chainInfoAPI) ChainGetLatestBeaconEntry(ctx context.Context) (*types.BeaconEntry, error) {
+	return cia.chain.ChainReader.GetLatestBeaconEntryFromHead(ctx)
+}
+
+// ChainGetUpgradeSchedule returns the network upgrades enabled for this network ordered by height
+func (cia *chainInfoAPI) ChainGetUpgradeSchedule(ctx context.Context) ([]types.UpgradeInfo, error) {
+	head := cia.chain.ChainReader.GetHead()
+	schedule := cia.chain.Fork.GetUpgradeSchedule()
+	out := make([]types.UpgradeInfo, 0, len(schedule))
+	for _, u := range schedule {
+		out = append(out, types.UpgradeInfo{
+			Height:    u.Height,
+			Network:   u.Network,
+			Migration: u.Name,
+			Passed:    head.Height() >= u.Height,
+		})
+	}
+	return out, nil
+}
+
+// StateGetMessageExecutionContext returns the block including the message within the tipset tsk
+// and the context shared by the messages of the tipset
+func (cia *chainInfoAPI) StateGetMessageExecutionContext(ctx context.Context, msgCID cid.Cid, tsk types.TipSetKey) (*types.MessageExecutionContext, error) {
+	ts, err := cia.ChainGetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
+	}
+
+	blk, err := cia.chain.MessageStore.FindMessageBlock(ctx, ts, msgCID)
+	if err != nil {
+		return nil, fmt.Errorf("looking up message %s in tipset %s: %w", msgCID, tsk, err)
+	}
+
+	_, view, err := cia.chain.Stmgr.ParentStateView(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("loading parent state view of %s: %w", tsk, err)
+	}
+	rst, err := view.LoadRewardState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading reward actor state: %w", err)
+	}
+	epochReward, err := rst.ThisEpochReward()
+	if err != nil {
+		return nil, fmt.Errorf("getting epoch reward: %w", err)
+	}
+	// the reward actor pays each winning ticket an equal share of the epoch reward
+	var winCount int64
+	if blk.ElectionProof != nil {
+		winCount = blk.ElectionProof.WinCount
+	}
+	blockReward := big.Div(big.Mul(epochReward, big.NewInt(winCount)), big.NewInt(int64(constants.ExpectedLeadersPerEpoch)))
+
+	return &types.MessageExecutionContext{
+		TipSetKey:       ts.Key(),
+		Epoch:           ts.Height(),
+		Block:           blk.Cid(),
+		Miner:           blk.Miner,
+		BlockReward:     blockReward,
+		ParentBaseFee:   blk.ParentBaseFee,
+		ParentStateRoot: blk.ParentStateRoot,
+	}, nil
+}
+
 // StateNetworkName returns the name of the network the node is synced to
 func (cia *chainInfoAPI) StateNetworkName(ctx context.Context) (types.NetworkName, error) {
 	networkName, err := cia.getNetworkName(ctx)
@@ -424,6 +517,30 @@ func (cia *chainInfoAPI) StateGetRandomnessFromBeacon(ctx context.Context, perso
 	return r.GetBeaconRandomnessV1(ctx, personalization, randEpoch, entropy)
 }
 
+// ChainGetRandomnessDigest returns the randomness drawn for the domain tag at the given epoch, from the
+// beacon or from the tickets depending on where the actors draw the randomness of the tag from.
+func (cia *chainInfoAPI) ChainGetRandomnessDigest(ctx context.Context, domainTag acrypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) ([]byte, error) {
+	if isBeaconDomainTag(domainTag) {
+		return cia.StateGetRandomnessFromBeacon(ctx, domainTag, epoch, entropy, tsk)
+	}
+	return cia.StateGetRandomnessFromTickets(ctx, domainTag, epoch, entropy, tsk)
+}
+
+// isBeaconDomainTag reports whether the randomness of the domain tag is drawn from the beacon,
+// the randomness of the other tags is drawn from the tickets
+func isBeaconDomainTag(domainTag acrypto.DomainSeparationTag) bool {
+	switch domainTag {
+	case acrypto.DomainSeparationTag_TicketProduction,
+		acrypto.DomainSeparationTag_ElectionProofProduction,
+		acrypto.DomainSeparationTag_WinningPoStChallengeSeed,
+		acrypto.DomainSeparationTag_WindowedPoStChallengeSeed,
+		acrypto.DomainSeparationTag_InteractiveSealChallengeSeed:
+		return true
+	default:
+		return false
+	}
+}
+
 // StateNetworkVersion returns the network version at the given tipset
 func (cia *chainInfoAPI) StateNetworkVersion(ctx context.Context, tsk types.TipSetKey) (network.Version, error) {
 	ts, err := cia.chain.ChainReader.GetTipSet(ctx, tsk)
@@ -463,7 +580,7 @@ func (cia *chainInfoAPI) StateVerifierStatus(ctx context.Context, addr address.A
 
 	aid, err := view.LookupID(ctx, addr)
 	if err != nil {
-		log.Warnf("lookup failure %v", err)
+		reqid.Logger(ctx, log).Warnf("lookup failure %v", err)
 		return nil, err
 	}
 
@@ -576,6 +693,112 @@ func (cia *chainInfoAPI) StateWaitMsg(ctx context.Context, mCid cid.Cid, confide
 	return nil, nil
 }
 
+const stateWaitMsgBatchLimit = 1000
+
+// StateWaitMsgBatch waits for the messages concurrently and returns the lookups in the order of cids,
+// on error the lookups of the leading messages found so far are returned with it.
+func (cia *chainInfoAPI) StateWaitMsgBatch(ctx context.Context, cids []cid.Cid, confidence uint64, lookbackLimit abi.ChainEpoch, allowReplaced bool) ([]*types.MsgLookup, error) {
+	if len(cids) > stateWaitMsgBatchLimit {
+		return nil, fmt.Errorf("too many messages %d, at most %d", len(cids), stateWaitMsgBatchLimit)
+	}
+
+	lookups := make([]*types.MsgLookup, len(cids))
+	done := make([]bool, len(cids))
+	group, gctx := errgroup.WithContext(ctx)
+	for i, c := range cids {
+		i, c := i, c
+		group.Go(func() error {
+			lookup, err := cia.StateWaitMsg(gctx, c, confidence, lookbackLimit, allowReplaced)
+			if err != nil {
+				return fmt.Errorf("waiting for message %s: %w", c, err)
+			}
+			lookups[i], done[i] = lookup, true
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		n := 0
+		for n < len(done) && done[n] {
+			n++
+		}
+		return lookups[:n], err
+	}
+
+	return lookups, nil
+}
+
+// chainCheckConsistencyLimit is the number of epochs a ChainCheckConsistency call walks at most
+const chainCheckConsistencyLimit = builtin.EpochsInDay
+
+// ChainCheckConsistency walks the canonical chain between the epochs from and to and reports the parent state roots
+// and message bundles referenced by its blocks that are missing from the blockstore. The range is limited to
+// chainCheckConsistencyLimit epochs, longer ones have to be checked in several calls.
+func (cia *chainInfoAPI) ChainCheckConsistency(ctx context.Context, from, to abi.ChainEpoch) (*types.ConsistencyReport, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid epoch range: from (%d) must not be after to (%d)", from, to)
+	}
+
+	head := cia.chain.ChainReader.GetHead()
+	if to > head.Height() {
+		to = head.Height()
+	}
+	if from < 0 {
+		from = 0
+	}
+	if to-from >= chainCheckConsistencyLimit {
+		return nil, fmt.Errorf("epoch range %d to %d too long, at most %d epochs are checked at once", from, to, chainCheckConsistencyLimit)
+	}
+	ts, err := cia.chain.ChainReader.GetTipSetByHeight(ctx, head, to, true)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset at %d: %w", to, err)
+	}
+
+	bs := cia.chain.ChainReader.Blockstore()
+	report := &types.ConsistencyReport{}
+	seen := cid.NewSet()
+	for ts.Height() >= from {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		has, err := bs.Has(ctx, ts.ParentState())
+		if err != nil {
+			return nil, fmt.Errorf("checking parent state root of tipset at %d: %w", ts.Height(), err)
+		}
+		if !has {
+			report.MissingStateRoots = append(report.MissingStateRoots, ts.Height())
+		}
+
+		for _, blk := range ts.Blocks() {
+			if !seen.Visit(blk.Messages) {
+				continue
+			}
+			has, err := bs.Has(ctx, blk.Messages)
+			if err != nil {
+				return nil, fmt.Errorf("checking messages of block %s: %w", blk.Cid(), err)
+			}
+			if !has {
+				report.MissingMessageBundles = append(report.MissingMessageBundles, blk.Messages)
+			}
+		}
+
+		if ts.Height() == 0 {
+			break
+		}
+		parent, err := cia.chain.ChainReader.GetTipSet(ctx, ts.Parents())
+		if err != nil {
+			return nil, fmt.Errorf("loading parent of tipset at %d: %w", ts.Height(), err)
+		}
+		ts = parent
+	}
+
+	// report in ascending epochs
+	sort.Slice(report.MissingStateRoots, func(i, j int) bool {
+		return report.MissingStateRoots[i] < report.MissingStateRoots[j]
+	})
+	return report, nil
+}
+
 func (cia *chainInfoAPI) ChainExport(ctx context.Context, nroots abi.ChainEpoch, skipoldmsgs bool, tsk types.TipSetKey) (<-chan []byte, error) {
 	ts, err := cia.chain.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
@@ -591,20 +814,21 @@ func (cia *chainInfoAPI) ChainExport(ctx context.Context, nroots abi.ChainEpoch,
 		w.CloseWithError(err) //nolint:errcheck // it is a pipe
 	}()
 
+	reqLog := reqid.Logger(ctx, log)
 	go func() {
 		defer close(out)
 		for {
 			buf := make([]byte, 1<<20)
 			n, err := r.Read(buf)
 			if err != nil && err != io.EOF {
-				log.Errorf("chain export pipe read failed: %s", err)
+				reqLog.Errorf("chain export pipe read failed: %s", err)
 				return
 			}
 			if n > 0 {
 				select {
 				case out <- buf[:n]:
 				case <-ctx.Done():
-					log.Warnf("export writer failed: %s", ctx.Err())
+					reqLog.Warnf("export writer failed: %s", ctx.Err())
 					return
 				}
 			}
@@ -613,7 +837,7 @@ func (cia *chainInfoAPI) ChainExport(ctx context.Context, nroots abi.ChainEpoch,
 				select {
 				case out <- []byte{}:
 				case <-ctx.Done():
-					log.Warnf("export writer failed: %s", ctx.Err())
+					reqLog.Warnf("export writer failed: %s", ctx.Err())
 					return
 				}
 
@@ -675,13 +899,18 @@ func (cia *chainInfoAPI) StateGetNetworkParams(ctx context.Context) (*types.Netw
 	if err != nil {
 		return nil, err
 	}
+	// the actor policy holds the effective values, the network params only override some of them
+	minPower, err := policy.GetConsensusMinerMinPower()
+	if err != nil {
+		return nil, fmt.Errorf("getting consensus miner min power: %w", err)
+	}
 	cfg := cia.chain.config.Repo().Config()
 	params := &types.NetworkParams{
 		NetworkName:             types.NetworkName(networkName),
 		BlockDelaySecs:          cfg.NetworkParams.BlockDelay,
-		ConsensusMinerMinPower:  abi.NewStoragePower(int64(cfg.NetworkParams.ConsensusMinerMinPower)),
-		SupportedProofTypes:     cfg.NetworkParams.ReplaceProofTypes,
-		PreCommitChallengeDelay: cfg.NetworkParams.PreCommitChallengeDelay,
+		ConsensusMinerMinPower:  minPower,
+		SupportedProofTypes:     policy.GetSupportedProofTypes(),
+		PreCommitChallengeDelay: policy.GetPreCommitChallengeDelay(),
 		ForkUpgradeParams: types.ForkUpgradeParams{
 			UpgradeSmokeHeight:       cfg.NetworkParams.ForkUpgradeParam.UpgradeSmokeHeight,
 			UpgradeBreezeHeight:      cfg.NetworkParams.ForkUpgradeParam.UpgradeBreezeHeight,
@@ -708,6 +937,7 @@ func (cia *chainInfoAPI) StateGetNetworkParams(ctx context.Context) (*types.Netw
 			UpgradeLightningHeight:   cfg.NetworkParams.ForkUpgradeParam.UpgradeLightningHeight,
 			UpgradeThunderHeight:     cfg.NetworkParams.ForkUpgradeParam.UpgradeThunderHeight,
 		},
+		Eip155ChainID: cfg.NetworkParams.Eip155ChainID,
 	}
 
 	return params, nil
@@ -795,6 +1025,9 @@ func (cia *chainInfoAPI) StateCall(ctx context.Context, msg *types.Message, tsk
 // A replacing message is a message with a different CID, any of Gas values, and
 // different signature, but with all other parameters matching (source/destination,
 // nonce, params, etc.)
+//
+// The implicit messages applied by the tipset (block rewards and cron) can be replayed
+// too, they are not on chain so the tipset key has to be provided.
 func (cia *chainInfoAPI) StateReplay(ctx context.Context, tsk types.TipSetKey, mc cid.Cid) (*types.InvocResult, error) {
 	msgToReplay := mc
 	var ts *types.TipSet
@@ -805,7 +1038,8 @@ func (cia *chainInfoAPI) StateReplay(ctx context.Context, tsk types.TipSetKey, m
 			return nil, fmt.Errorf("searching for msg %s: %w", mc, err)
 		}
 		if mlkp == nil {
-			return nil, fmt.Errorf("didn't find msg %s", mc)
+			// implicit messages are not on chain, the tipset applying them has to be given
+			return nil, fmt.Errorf("didn't find msg %s, replaying a block reward or cron message needs the key of the tipset applying it", mc)
 		}
 
 		msgToReplay = mlkp.Message
@@ -836,15 +1070,19 @@ func (cia *chainInfoAPI) StateReplay(ctx context.Context, tsk types.TipSetKey, m
 		errstr = r.ActorErr.Error()
 	}
 
-	return &types.InvocResult{
+	ir := &types.InvocResult{
 		MsgCid:         msgToReplay,
 		Msg:            m,
 		MsgRct:         &r.Receipt,
-		GasCost:        statemanger.MakeMsgGasCost(m, r),
 		ExecutionTrace: r.GasTracker.ExecutionTrace,
 		Error:          errstr,
 		Duration:       r.Duration,
-	}, nil
+	}
+	// implicit messages pay no gas, they have no refund to compute the cost from
+	if !r.OutPuts.Refund.Nil() {
+		ir.GasCost = statemanger.MakeMsgGasCost(m, r)
+	}
+	return ir, nil
 }
 
 // ChainGetEvents returns the events under an event AMT root CID.
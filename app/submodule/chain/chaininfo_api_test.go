@@ -0,0 +1,97 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	acrypto "github.com/filecoin-project/go-state-types/crypto"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestIsBeaconDomainTag(t *testing.T) {
+	tf.UnitTest(t)
+
+	// the interactive seal challenge comes from the beacon, the seal randomness from the tickets
+	assert.True(t, isBeaconDomainTag(acrypto.DomainSeparationTag_InteractiveSealChallengeSeed))
+	assert.False(t, isBeaconDomainTag(acrypto.DomainSeparationTag_SealRandomness))
+
+	assert.True(t, isBeaconDomainTag(acrypto.DomainSeparationTag_WinningPoStChallengeSeed))
+	assert.True(t, isBeaconDomainTag(acrypto.DomainSeparationTag_WindowedPoStChallengeSeed))
+	assert.False(t, isBeaconDomainTag(acrypto.DomainSeparationTag_PoStChainCommit))
+}
+
+// storedStateBuilder is a chain.FakeStateBuilder whose state roots are blocks of its store.
+type storedStateBuilder struct {
+	chain.FakeStateBuilder
+	bs blockstoreutil.Blockstore
+}
+
+func (sb *storedStateBuilder) ComputeState(prev cid.Cid, blockmsg []types.BlockMessagesInfo) (cid.Cid, []types.MessageReceipt, error) {
+	root, receipts, err := sb.FakeStateBuilder.ComputeState(prev, blockmsg)
+	if err != nil {
+		return cid.Undef, nil, err
+	}
+	// the genesis state is computed before the store is set
+	if sb.bs == nil {
+		return root, receipts, nil
+	}
+	stored, err := cbor.NewCborStore(sb.bs).Put(context.Background(), []cid.Cid{prev, root})
+	return stored, receipts, err
+}
+
+func TestChainCheckConsistency(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	sb := &storedStateBuilder{}
+	builder := chain.NewBuilderWithDeps(t, address.Undef, sb, &chain.ZeroTimestamper{})
+	sb.bs = builder.BlockStore()
+	head := builder.AppendManyOn(ctx, 10, builder.AppendOn(ctx, builder.Genesis(), 1))
+	require.NoError(t, builder.Store().SetHead(ctx, head))
+	cia := &chainInfoAPI{chain: &ChainSubmodule{ChainReader: builder.Store()}}
+
+	report, err := cia.ChainCheckConsistency(ctx, 2, 100)
+	require.NoError(t, err)
+	assert.Empty(t, report.MissingStateRoots)
+	assert.Empty(t, report.MissingMessageBundles)
+
+	_, err = cia.ChainCheckConsistency(ctx, 5, 4)
+	assert.Error(t, err)
+	// the range is bounded once clamped to the chain
+	head = builder.AppendManyOn(ctx, chainCheckConsistencyLimit, head)
+	require.NoError(t, builder.Store().SetHead(ctx, head))
+	_, err = cia.ChainCheckConsistency(ctx, head.Height()-chainCheckConsistencyLimit+1, head.Height()+100)
+	assert.NoError(t, err)
+	_, err = cia.ChainCheckConsistency(ctx, head.Height()-chainCheckConsistencyLimit, head.Height()+100)
+	assert.Error(t, err)
+
+	// remove the parent state of the tipset at 5 and the messages of the one at 7
+	ts5, err := builder.Store().GetTipSetByHeight(ctx, head, 5, true)
+	require.NoError(t, err)
+	ts7, err := builder.Store().GetTipSetByHeight(ctx, head, 7, true)
+	require.NoError(t, err)
+	msgs := ts7.Blocks()[0].Messages
+	require.NoError(t, builder.BlockStore().DeleteBlock(ctx, ts5.ParentState()))
+	require.NoError(t, builder.BlockStore().DeleteBlock(ctx, msgs))
+
+	report, err = cia.ChainCheckConsistency(ctx, 2, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []abi.ChainEpoch{5}, report.MissingStateRoots)
+	// the blocks share the empty message bundle
+	assert.Equal(t, []cid.Cid{msgs}, report.MissingMessageBundles)
+
+	report, err = cia.ChainCheckConsistency(ctx, -5, 4)
+	require.NoError(t, err)
+	assert.Empty(t, report.MissingStateRoots)
+	assert.Equal(t, []cid.Cid{msgs}, report.MissingMessageBundles)
+}
@@ -0,0 +1,97 @@
+package chain
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const evmWordSize = 32
+
+type evmMethod struct {
+	signature string
+	inputs    []string
+}
+
+// knownEVMMethods are the methods ChainGetBlockMessagesDecoded decodes the calldata of, keyed by selector.
+// Only static arguments are supported.
+var knownEVMMethods = map[[4]byte]evmMethod{}
+
+func init() {
+	for _, sig := range []string{
+		// ERC-20
+		"totalSupply()",
+		"balanceOf(address)",
+		"transfer(address,uint256)",
+		"transferFrom(address,address,uint256)",
+		"approve(address,uint256)",
+		"allowance(address,address)",
+	} {
+		args := strings.TrimSuffix(sig[strings.Index(sig, "(")+1:], ")")
+		var inputs []string
+		if args != "" {
+			inputs = strings.Split(args, ",")
+		}
+		knownEVMMethods[evmSelector(sig)] = evmMethod{signature: sig, inputs: inputs}
+	}
+}
+
+func evmSelector(signature string) [4]byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(signature)) // nolint: errcheck
+	var selector [4]byte
+	copy(selector[:], hasher.Sum(nil))
+	return selector
+}
+
+// decodeEVMParams decodes the params of a message invoking an EVM contract if the method called is known,
+// it returns nil otherwise.
+func decodeEVMParams(msg *types.Message) interface{} {
+	if msg.To.Protocol() != address.Delegated || msg.Method != builtintypes.MethodsEVM.InvokeContract || len(msg.Params) == 0 {
+		return nil
+	}
+
+	calldata, err := cbg.ReadByteArray(bytes.NewReader(msg.Params), uint64(len(msg.Params)))
+	if err != nil || len(calldata) < 4 {
+		return nil
+	}
+
+	var selector [4]byte
+	copy(selector[:], calldata)
+	method, ok := knownEVMMethods[selector]
+	if !ok {
+		return nil
+	}
+
+	data := calldata[4:]
+	if len(data) != len(method.inputs)*evmWordSize {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(method.inputs))
+	for i, input := range method.inputs {
+		word := data[i*evmWordSize : (i+1)*evmWordSize]
+		switch input {
+		case "address":
+			if !bytes.Equal(word[:evmWordSize-types.EthAddressLength], make([]byte, evmWordSize-types.EthAddressLength)) {
+				return nil
+			}
+			var addr types.EthAddress
+			copy(addr[:], word[evmWordSize-types.EthAddressLength:])
+			args = append(args, addr)
+		case "uint256":
+			args = append(args, types.EthBigInt(big.PositiveFromUnsignedBytes(word)))
+		default:
+			return nil
+		}
+	}
+
+	return &types.EthCallDecoded{Method: method.signature, Args: args}
+}
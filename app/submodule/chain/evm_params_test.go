@@ -0,0 +1,58 @@
+package chain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestDecodeEVMParams(t *testing.T) {
+	tf.UnitTest(t)
+
+	to, err := types.ParseEthAddress("0xd4c5fb16488Aa48081296299d54b0c648C9333dA")
+	require.NoError(t, err)
+	contract, err := to.ToFilecoinAddress()
+	require.NoError(t, err)
+
+	params := func(calldata []byte) []byte {
+		buf := new(bytes.Buffer)
+		require.NoError(t, cbg.WriteByteArray(buf, calldata))
+		return buf.Bytes()
+	}
+	word := func(b []byte) []byte {
+		return append(make([]byte, 32-len(b)), b...)
+	}
+
+	// transfer(address,uint256)
+	selector := []byte{0xa9, 0x05, 0x9c, 0xbb}
+	calldata := append(append(append([]byte{}, selector...), word(to[:])...), word([]byte{0x03, 0xe8})...)
+	msg := &types.Message{To: contract, Method: builtintypes.MethodsEVM.InvokeContract, Params: params(calldata)}
+
+	decoded, ok := decodeEVMParams(msg).(*types.EthCallDecoded)
+	require.True(t, ok)
+	assert.Equal(t, "transfer(address,uint256)", decoded.Method)
+	assert.Equal(t, []interface{}{to, types.EthBigInt(big.NewInt(1000))}, decoded.Args)
+
+	// unknown selector
+	msg.Params = params(append([]byte{0x01, 0x02, 0x03, 0x04}, word(to[:])...))
+	assert.Nil(t, decodeEVMParams(msg))
+
+	// truncated arguments
+	msg.Params = params(calldata[:len(calldata)-1])
+	assert.Nil(t, decodeEVMParams(msg))
+
+	// not a call to an EVM contract
+	msg.Params = params(calldata)
+	msg.To, err = address.NewIDAddress(1000)
+	require.NoError(t, err)
+	assert.Nil(t, decodeEVMParams(msg))
+}
@@ -7,12 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-bitfield"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/cbor"
+	acrypto "github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/dline"
 	"github.com/filecoin-project/go-state-types/network"
 	"github.com/ipfs/go-cid"
@@ -21,8 +23,11 @@ import (
 	cbg "github.com/whyrusleeping/cbor-gen"
 
 	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	miner11 "github.com/filecoin-project/go-state-types/builtin/v11/miner"
+	smoothing11 "github.com/filecoin-project/go-state-types/builtin/v11/util/smoothing"
 	market2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/market"
 	market5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/market"
+	proof7 "github.com/filecoin-project/specs-actors/v7/actors/runtime/proof"
 	"github.com/filecoin-project/venus/pkg/state/tree"
 	"github.com/filecoin-project/venus/pkg/vm/register"
 	"github.com/filecoin-project/venus/venus-shared/actors"
@@ -33,6 +38,7 @@ import (
 	lminer "github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/power"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/reward"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/verifreg"
 	"github.com/filecoin-project/venus/venus-shared/actors/policy"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
@@ -218,6 +224,239 @@ func (msa *minerStateAPI) StateMinerProvingDeadline(ctx context.Context, maddr a
 	return di.NextNotElapsed(), nil
 }
 
+// MinerGetProvingDeadlineForEpoch calculates the deadline open at the given epoch from the proving period start
+// of the miner at tsk, the state at epoch itself is not looked at. Use IsOpen on the result to tell whether
+// the deadline's challenge window is open at epoch.
+func (msa *minerStateAPI) MinerGetProvingDeadlineForEpoch(ctx context.Context, maddr address.Address, epoch abi.ChainEpoch, tsk types.TipSetKey) (*dline.Info, error) {
+	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+	}
+	mas, err := view.LoadMinerState(ctx, maddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load miner actor state: %v", err)
+	}
+
+	// DeadlineInfo of the miner state is bound to the recorded current deadline, only its period start
+	// and the proving parameters of the actors version are kept
+	recorded, err := mas.DeadlineInfo(epoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deadline info: %v", err)
+	}
+
+	return deadlineInfoForEpoch(recorded, epoch), nil
+}
+
+// deadlineInfoForEpoch returns the deadline open at epoch, with the proving parameters of recorded and the
+// proving periods starting at recorded.PeriodStart plus a multiple of the proving period.
+func deadlineInfoForEpoch(recorded *dline.Info, epoch abi.ChainEpoch) *dline.Info {
+	offset := (epoch - recorded.PeriodStart) % recorded.WPoStProvingPeriod
+	if offset < 0 {
+		offset += recorded.WPoStProvingPeriod
+	}
+	periodStart := epoch - offset
+	return dline.NewInfo(periodStart, uint64(offset/recorded.WPoStChallengeWindow), epoch, recorded.WPoStPeriodDeadlines,
+		recorded.WPoStProvingPeriod, recorded.WPoStChallengeWindow, recorded.WPoStChallengeLookback, recorded.FaultDeclarationCutoff)
+}
+
+// StateMinerVerifyWindowPoSt checks proofs as a SubmitWindowedPoSt of the partitions of deadline would, without
+// sending the message. The proofs are verified against the latest challenge of the deadline at tsk.
+func (msa *minerStateAPI) StateMinerVerifyWindowPoSt(ctx context.Context, maddr address.Address, deadline uint64, partitions []types.PoStPartition, proofs []types.PoStProof, tsk types.TipSetKey) (bool, error) {
+	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return false, fmt.Errorf("loading tipset %s: %v", tsk, err)
+	}
+	_, view, err := msa.Stmgr.ParentStateView(ctx, ts)
+	if err != nil {
+		return false, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+	}
+	idAddr, err := view.InitResolveAddress(ctx, maddr)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve miner address %s: %v", maddr, err)
+	}
+	minerID, err := address.IDFromAddress(idAddr)
+	if err != nil {
+		return false, err
+	}
+	mas, err := view.LoadMinerState(ctx, idAddr)
+	if err != nil {
+		return false, fmt.Errorf("failed to load miner actor state: %v", err)
+	}
+
+	// bound the work as the actor does, a SubmitWindowedPoSt carries a single proof of at most the partitions
+	// fitting in a message
+	if len(proofs) != 1 {
+		return false, fmt.Errorf("expected exactly one proof, got %d", len(proofs))
+	}
+	info, err := mas.Info()
+	if err != nil {
+		return false, fmt.Errorf("failed to get miner info: %v", err)
+	}
+	maxPartitions, err := policy.GetMaxPoStPartitions(msa.Fork.GetNetworkVersion(ctx, ts.Height()), info.WindowPoStProofType)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the max partitions per proof: %v", err)
+	}
+	if len(partitions) > maxPartitions {
+		return false, fmt.Errorf("too many partitions %d, limit %d", len(partitions), maxPartitions)
+	}
+
+	di, err := mas.DeadlineInfo(ts.Height())
+	if err != nil {
+		return false, fmt.Errorf("failed to get deadline info: %v", err)
+	}
+	if deadline >= di.WPoStPeriodDeadlines {
+		return false, fmt.Errorf("invalid deadline %d, must be < %d", deadline, di.WPoStPeriodDeadlines)
+	}
+	// the deadline was last challenged in the current proving period, or in the previous one if it is ahead
+	di = dline.NewInfo(di.PeriodStart, deadline, ts.Height(), di.WPoStPeriodDeadlines, di.WPoStProvingPeriod, di.WPoStChallengeWindow, di.WPoStChallengeLookback, di.FaultDeclarationCutoff)
+	if di.Challenge > ts.Height() {
+		di = dline.NewInfo(di.PeriodStart-di.WPoStProvingPeriod, deadline, ts.Height(), di.WPoStPeriodDeadlines, di.WPoStProvingPeriod, di.WPoStChallengeWindow, di.WPoStChallengeLookback, di.FaultDeclarationCutoff)
+	}
+	if di.Challenge < 0 {
+		return false, fmt.Errorf("deadline %d has not been challenged yet", deadline)
+	}
+
+	dl, err := mas.LoadDeadline(deadline)
+	if err != nil {
+		return false, fmt.Errorf("failed to load deadline %d: %v", deadline, err)
+	}
+	sectors, ignored, err := provenSectors(dl, partitions)
+	if err != nil {
+		return false, err
+	}
+	challenged, err := challengedSectors(sectors, ignored, mas.LoadSectors)
+	if err != nil {
+		return false, err
+	}
+	if len(challenged) == 0 {
+		return false, fmt.Errorf("no sectors to prove in the partitions of deadline %d", deadline)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := idAddr.MarshalCBOR(buf); err != nil {
+		return false, fmt.Errorf("failed to marshal miner address: %v", err)
+	}
+	rand, err := NewChainInfoAPI(msa.ChainSubmodule).StateGetRandomnessFromBeacon(ctx, acrypto.DomainSeparationTag_WindowedPoStChallengeSeed, di.Challenge, buf.Bytes(), ts.Key())
+	if err != nil {
+		return false, fmt.Errorf("failed to get randomness for the challenge at %d: %v", di.Challenge, err)
+	}
+
+	return msa.config.Verifier().VerifyWindowPoSt(ctx, proof7.WindowPoStVerifyInfo{
+		Randomness:        abi.PoStRandomness(rand),
+		Proofs:            proofs,
+		ChallengedSectors: challenged,
+		Prover:            abi.ActorID(minerID),
+	})
+}
+
+// provenSectors returns the sectors of partitions of dl and those a proof of them does not cover, i.e. the
+// terminated sectors and the faulty ones once the skipped sectors and the declared recoveries are accounted.
+func provenSectors(dl lminer.Deadline, partitions []types.PoStPartition) (bitfield.BitField, bitfield.BitField, error) {
+	var all, ignored []bitfield.BitField
+	for _, post := range partitions {
+		part, err := dl.LoadPartition(post.Index)
+		if err != nil {
+			return bitfield.BitField{}, bitfield.BitField{}, fmt.Errorf("failed to load partition %d: %v", post.Index, err)
+		}
+		sectors, err := part.AllSectors()
+		if err != nil {
+			return bitfield.BitField{}, bitfield.BitField{}, err
+		}
+		live, err := part.LiveSectors()
+		if err != nil {
+			return bitfield.BitField{}, bitfield.BitField{}, err
+		}
+		faults, err := part.FaultySectors()
+		if err != nil {
+			return bitfield.BitField{}, bitfield.BitField{}, err
+		}
+		recoveries, err := part.RecoveringSectors()
+		if err != nil {
+			return bitfield.BitField{}, bitfield.BitField{}, err
+		}
+
+		terminated, err := bitfield.SubtractBitField(sectors, live)
+		if err != nil {
+			return bitfield.BitField{}, bitfield.BitField{}, err
+		}
+		recovered, err := bitfield.SubtractBitField(recoveries, post.Skipped)
+		if err != nil {
+			return bitfield.BitField{}, bitfield.BitField{}, err
+		}
+		faults, err = bitfield.MergeBitFields(faults, post.Skipped)
+		if err != nil {
+			return bitfield.BitField{}, bitfield.BitField{}, err
+		}
+		faults, err = bitfield.SubtractBitField(faults, recovered)
+		if err != nil {
+			return bitfield.BitField{}, bitfield.BitField{}, err
+		}
+		all = append(all, sectors)
+		ignored = append(ignored, terminated, faults)
+	}
+
+	sectors, err := bitfield.MultiMerge(all...)
+	if err != nil {
+		return bitfield.BitField{}, bitfield.BitField{}, err
+	}
+	ignoredSectors, err := bitfield.MultiMerge(ignored...)
+	if err != nil {
+		return bitfield.BitField{}, bitfield.BitField{}, err
+	}
+	return sectors, ignoredSectors, nil
+}
+
+// challengedSectors returns the infos of sectors the proof is checked against in the order of sectors, the
+// actor substitutes the ignored sectors with the first proven one. It is empty when all sectors are ignored.
+func challengedSectors(sectors, ignored bitfield.BitField, load func(*bitfield.BitField) ([]*lminer.SectorOnChainInfo, error)) ([]proof7.SectorInfo, error) {
+	proven, err := bitfield.SubtractBitField(sectors, ignored)
+	if err != nil {
+		return nil, err
+	}
+	if empty, err := proven.IsEmpty(); err != nil || empty {
+		return nil, err
+	}
+
+	infos, err := load(&proven)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sectors: %v", err)
+	}
+	byNumber := make(map[uint64]*lminer.SectorOnChainInfo, len(infos))
+	for _, info := range infos {
+		byNumber[uint64(info.SectorNumber)] = info
+	}
+	good, err := proven.First()
+	if err != nil {
+		return nil, err
+	}
+	substitute, ok := byNumber[good]
+	if !ok {
+		return nil, fmt.Errorf("sector %d not found", good)
+	}
+
+	var out []proof7.SectorInfo
+	err = sectors.ForEach(func(sno uint64) error {
+		info, ok := byNumber[sno]
+		if !ok {
+			isIgnored, err := ignored.IsSet(sno)
+			if err != nil {
+				return err
+			}
+			if !isIgnored {
+				return fmt.Errorf("sector %d not found", sno)
+			}
+			info = substitute
+		}
+		out = append(out, proof7.SectorInfo{
+			SealProof:    info.SealProof,
+			SectorNumber: info.SectorNumber,
+			SealedCID:    info.SealedCID,
+		})
+		return nil
+	})
+	return out, err
+}
+
 // StateMinerPartitions returns all partitions in the specified deadline
 func (msa *minerStateAPI) StateMinerPartitions(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error) {
 	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
@@ -304,9 +543,40 @@ func (msa *minerStateAPI) StateMinerDeadlines(ctx context.Context, maddr address
 			return err
 		}
 
+		live, err := dl.LiveSectors()
+		if err != nil {
+			return err
+		}
+
+		total, err := dl.TotalSectors()
+		if err != nil {
+			return err
+		}
+
+		faultyPower, err := dl.FaultyPower()
+		if err != nil {
+			return err
+		}
+
+		livePower := types.NewPowerPairZero()
+		if err := dl.ForEachPartition(func(_ uint64, part lminer.Partition) error {
+			pp, err := part.LivePower()
+			if err != nil {
+				return err
+			}
+			livePower = livePower.Add(pp)
+			return nil
+		}); err != nil {
+			return err
+		}
+
 		out[i] = types.Deadline{
 			PostSubmissions:      ps,
 			DisputableProofCount: l,
+			LiveSectors:          live,
+			TotalSectors:         total,
+			FaultyPower:          faultyPower,
+			LivePower:            livePower,
 		}
 		return nil
 	}); err != nil {
@@ -405,6 +675,83 @@ func (msa *minerStateAPI) StateGetAllocationForPendingDeal(ctx context.Context,
 	return msa.StateGetAllocation(ctx, dealState.Proposal.Client, allocationID, tsk)
 }
 
+// StateGetAllocationsForSector returns the IDs of the verified allocations the deals of a pre-committed sector
+// will claim once the sector is prove-committed.
+func (msa *minerStateAPI) StateGetAllocationsForSector(ctx context.Context, maddr address.Address, sectorNum abi.SectorNumber, tsk types.TipSetKey) ([]types.AllocationId, error) {
+	ts, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+	}
+
+	pci, err := view.SectorPreCommitInfo(ctx, maddr, sectorNum)
+	if err != nil {
+		return nil, fmt.Errorf("getting precommit info: %w", err)
+	}
+
+	marketState, err := view.LoadMarketState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load market actor state: %v", err)
+	}
+	verifregState, err := view.LoadVerifregActor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load verifreg actor state: %v", err)
+	}
+
+	allocationIDs, err := sectorAllocations(ctx, pci, ts.Height(), marketState, verifregState, view.LookupID)
+	if err != nil {
+		return nil, fmt.Errorf("sector %d of miner %s: %w", sectorNum, maddr, err)
+	}
+	return allocationIDs, nil
+}
+
+// sectorAllocations returns the IDs of the allocations of the deals of the pre-committed sector pci that can still
+// be claimed at epoch, deals without an allocation and allocations that expired or were removed are skipped.
+func sectorAllocations(ctx context.Context, pci *types.SectorPreCommitOnChainInfo, epoch abi.ChainEpoch, marketState market.State, verifregState verifreg.State, lookupID func(context.Context, address.Address) (address.Address, error)) ([]types.AllocationId, error) {
+	if pci == nil {
+		return nil, fmt.Errorf("sector is not pre-committed")
+	}
+
+	proposals, err := marketState.Proposals()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load deal proposals: %v", err)
+	}
+
+	allocationIDs := make([]types.AllocationId, 0, len(pci.Info.DealIDs))
+	for _, dealID := range pci.Info.DealIDs {
+		allocationID, err := marketState.GetAllocationIdForPendingDeal(dealID)
+		if err != nil {
+			return nil, fmt.Errorf("getting allocation id of deal %d: %w", dealID, err)
+		}
+		if allocationID == types.NoAllocationID {
+			continue
+		}
+
+		proposal, found, err := proposals.Get(dealID)
+		if err != nil {
+			return nil, fmt.Errorf("getting proposal of deal %d: %w", dealID, err)
+		}
+		if !found {
+			return nil, fmt.Errorf("proposal of deal %d not found", dealID)
+		}
+		clientID, err := lookupID(ctx, proposal.Client)
+		if err != nil {
+			return nil, fmt.Errorf("looking up client %s of deal %d: %w", proposal.Client, dealID, err)
+		}
+
+		allocation, found, err := verifregState.GetAllocation(clientID, allocationID)
+		if err != nil {
+			return nil, fmt.Errorf("getting allocation %d: %w", allocationID, err)
+		}
+		// an expired allocation can no longer be claimed, it stays in the state until it is removed
+		if !found || epoch > allocation.Expiration {
+			continue
+		}
+		allocationIDs = append(allocationIDs, allocationID)
+	}
+
+	return allocationIDs, nil
+}
+
 // StateGetAllocation returns the allocation for a given address and allocation ID.
 func (msa *minerStateAPI) StateGetAllocation(ctx context.Context, clientAddr address.Address, allocationID types.AllocationId, tsk types.TipSetKey) (*types.Allocation, error) {
 	idAddr, err := msa.ChainSubmodule.API().StateLookupID(ctx, clientAddr, tsk)
@@ -458,6 +805,98 @@ func (msa *minerStateAPI) StateGetAllocations(ctx context.Context, clientAddr ad
 	return allocations, nil
 }
 
+const (
+	defaultAllocationPageSize = 100
+	maxAllocationPageSize     = 1000
+)
+
+// StateListVerifiedDatacapAllocations returns a page of the pending allocations in the order of the allocations table
+// of the verified registry, which groups them by client, only those of clientAddr unless it is address.Undef.
+func (msa *minerStateAPI) StateListVerifiedDatacapAllocations(ctx context.Context, clientAddr address.Address, tsk types.TipSetKey, pageToken *types.AllocationPageToken, pageSize int) (*types.AllocationPage, error) {
+	if pageSize < 0 {
+		return nil, fmt.Errorf("invalid page size %d", pageSize)
+	}
+
+	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+	}
+
+	st, err := view.LoadVerifregActor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load verified registry actor state: %v", err)
+	}
+
+	var idAddr address.Address
+	if clientAddr != address.Undef {
+		idAddr, err = msa.ChainSubmodule.API().StateLookupID(ctx, clientAddr, tsk)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	page, err := pageAllocations(st, idAddr, pageToken, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("getting allocations: %w", err)
+	}
+	return page, nil
+}
+
+var errAllocationPageFull = errors.New("allocation page full")
+
+// pageAllocations walks the allocations table from pageToken on and stops as soon as the page is full, only the
+// allocations of clientIdAddr are listed unless it is address.Undef.
+func pageAllocations(st verifreg.State, clientIdAddr address.Address, pageToken *types.AllocationPageToken, pageSize int) (*types.AllocationPage, error) {
+	if pageSize == 0 {
+		pageSize = defaultAllocationPageSize
+	}
+	if pageSize > maxAllocationPageSize {
+		pageSize = maxAllocationPageSize
+	}
+	if pageToken != nil && clientIdAddr != address.Undef && pageToken.Client != clientIdAddr {
+		return nil, fmt.Errorf("page token of client %s used to list the allocations of %s", pageToken.Client, clientIdAddr)
+	}
+
+	page := &types.AllocationPage{Allocations: make([]types.AllocationListItem, 0, pageSize)}
+	addClient := func(client address.Address, after *types.AllocationId) error {
+		return st.ForEachAllocationAfter(client, after, func(id types.AllocationId, allocation types.Allocation) error {
+			if len(page.Allocations) == pageSize {
+				page.NextPageToken = &types.AllocationPageToken{Client: client, AfterID: page.Allocations[pageSize-1].ID}
+				return errAllocationPageFull
+			}
+			page.Allocations = append(page.Allocations, types.AllocationListItem{ID: id, Allocation: allocation})
+			return nil
+		})
+	}
+
+	var err error
+	switch {
+	case clientIdAddr != address.Undef:
+		var after *types.AllocationId
+		if pageToken != nil {
+			after = &pageToken.AfterID
+		}
+		err = addClient(clientIdAddr, after)
+	case pageToken != nil:
+		// finish the client the previous page stopped in, then continue with the clients after it
+		err = addClient(pageToken.Client, &pageToken.AfterID)
+		if err == nil {
+			err = st.ForEachAllocationClientAfter(pageToken.Client, func(client address.Address) error {
+				return addClient(client, nil)
+			})
+		}
+	default:
+		err = st.ForEachAllocationClientAfter(address.Undef, func(client address.Address) error {
+			return addClient(client, nil)
+		})
+	}
+	if err != nil && !errors.Is(err, errAllocationPageFull) {
+		return nil, err
+	}
+
+	return page, nil
+}
+
 // StateGetClaim returns the claim for a given address and claim ID.
 func (msa *minerStateAPI) StateGetClaim(ctx context.Context, providerAddr address.Address, claimID types.ClaimId, tsk types.TipSetKey) (*types.Claim, error) {
 	idAddr, err := msa.ChainSubmodule.API().StateLookupID(ctx, providerAddr, tsk)
@@ -511,6 +950,22 @@ func (msa *minerStateAPI) StateGetClaims(ctx context.Context, providerAddr addre
 	return claims, nil
 }
 
+// StateListDatacapClaims returns the claims made by a provider ordered by allocation id.
+func (msa *minerStateAPI) StateListDatacapClaims(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) ([]types.DatacapClaim, error) {
+	claims, err := msa.StateGetClaims(ctx, providerAddr, tsk)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]types.DatacapClaim, 0, len(claims))
+	for id, claim := range claims {
+		out = append(out, types.DatacapClaim{AllocationID: id, Claim: claim})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].AllocationID < out[j].AllocationID })
+
+	return out, nil
+}
+
 // StateComputeDataCID computes DataCID from a set of on-chain deals
 func (msa *minerStateAPI) StateComputeDataCID(ctx context.Context, maddr address.Address, sectorType abi.RegisteredSealProof, deals []abi.DealID, tsk types.TipSetKey) (cid.Cid, error) {
 	nv, err := msa.API().StateNetworkVersion(ctx, tsk)
@@ -580,8 +1035,20 @@ var (
 	initialPledgeDen = big.NewInt(100)
 )
 
-// StateMinerInitialPledgeCollateral returns the precommit deposit for the specified miner's sector
+// StateMinerPreCommitDepositForPower returns the precommit deposit for the specified miner's sector,
+// with a margin over the deposit the miner actor will require
 func (msa *minerStateAPI) StateMinerPreCommitDepositForPower(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error) {
+	deposit, err := msa.StateMinerPreCommitDeposit(ctx, maddr, pci, tsk)
+	if err != nil {
+		return big.Zero(), err
+	}
+
+	return big.Div(big.Mul(deposit, initialPledgeNum), initialPledgeDen), nil
+}
+
+// StateMinerPreCommitDeposit returns the precommit deposit the miner actor requires for the specified
+// miner's sector at tsk, so that the balance of the miner can be checked before sending the message
+func (msa *minerStateAPI) StateMinerPreCommitDeposit(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error) {
 	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
 		return big.Int{}, err
@@ -642,34 +1109,46 @@ func (msa *minerStateAPI) StateMinerPreCommitDepositForPower(ctx context.Context
 		return big.Zero(), fmt.Errorf("calculating precommit deposit: %v", err)
 	}
 
-	return big.Div(big.Mul(deposit, initialPledgeNum), initialPledgeDen), nil
+	return deposit, nil
 }
 
 // StateMinerInitialPledgeCollateral returns the initial pledge collateral for the specified miner's sector
 func (msa *minerStateAPI) StateMinerInitialPledgeCollateral(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error) {
+	pledge, err := msa.StateMinerInitialPledge(ctx, maddr, pci, tsk)
+	if err != nil {
+		return big.Int{}, err
+	}
+
+	initialPledge := big.Add(pledge.StoragePledge, pledge.ConsensusPledge)
+	return big.Div(big.Mul(initialPledge, initialPledgeNum), initialPledgeDen), nil
+}
+
+// StateMinerInitialPledge returns the initial pledge the miner actor requires for the specified miner's sector at tsk,
+// split into the storage pledge and the consensus pledge
+func (msa *minerStateAPI) StateMinerInitialPledge(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (*types.InitialPledge, error) {
 	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
-		return big.Int{}, fmt.Errorf("loading tipset %s: %v", tsk, err)
+		return nil, fmt.Errorf("loading tipset %s: %v", tsk, err)
 	}
 
 	_, state, err := msa.Stmgr.ParentState(ctx, ts)
 	if err != nil {
-		return big.Int{}, fmt.Errorf("loading tipset(%s) parent state failed: %v", tsk, err)
+		return nil, fmt.Errorf("loading tipset(%s) parent state failed: %v", tsk, err)
 	}
 
 	ssize, err := pci.SealProof.SectorSize()
 	if err != nil {
-		return big.Int{}, fmt.Errorf("failed to get resolve size: %v", err)
+		return nil, fmt.Errorf("failed to get resolve size: %v", err)
 	}
 
 	store := msa.ChainReader.Store(ctx)
 	var sectorWeight abi.StoragePower
 	if act, found, err := state.GetActor(ctx, market.Address); err != nil || !found {
-		return big.Int{}, fmt.Errorf("loading miner actor %s: %v", maddr, err)
+		return nil, fmt.Errorf("loading miner actor %s: %v", maddr, err)
 	} else if s, err := market.Load(store, act); err != nil {
-		return big.Int{}, fmt.Errorf("loading market actor state %s: %v", maddr, err)
+		return nil, fmt.Errorf("loading market actor state %s: %v", maddr, err)
 	} else if w, vw, err := s.VerifyDealsForActivation(maddr, pci.DealIDs, ts.Height(), pci.Expiration); err != nil {
-		return big.Int{}, fmt.Errorf("verifying deals for activation: %v", err)
+		return nil, fmt.Errorf("verifying deals for activation: %v", err)
 	} else {
 		// NB: not exactly accurate, but should always lead us to *over* estimate, not under
 		duration := pci.Expiration - ts.Height()
@@ -681,13 +1160,13 @@ func (msa *minerStateAPI) StateMinerInitialPledgeCollateral(ctx context.Context,
 		pledgeCollateral abi.TokenAmount
 	)
 	if act, found, err := state.GetActor(ctx, power.Address); err != nil || !found {
-		return big.Int{}, fmt.Errorf("loading miner actor: %v", err)
+		return nil, fmt.Errorf("loading miner actor: %v", err)
 	} else if s, err := power.Load(store, act); err != nil {
-		return big.Int{}, fmt.Errorf("loading power actor state: %v", err)
+		return nil, fmt.Errorf("loading power actor state: %v", err)
 	} else if p, err := s.TotalPowerSmoothed(); err != nil {
-		return big.Int{}, fmt.Errorf("failed to determine total power: %v", err)
+		return nil, fmt.Errorf("failed to determine total power: %v", err)
 	} else if c, err := s.TotalLocked(); err != nil {
-		return big.Int{}, fmt.Errorf("failed to determine pledge collateral: %v", err)
+		return nil, fmt.Errorf("failed to determine pledge collateral: %v", err)
 	} else {
 		powerSmoothed = p
 		pledgeCollateral = c
@@ -695,17 +1174,17 @@ func (msa *minerStateAPI) StateMinerInitialPledgeCollateral(ctx context.Context,
 
 	rewardActor, found, err := state.GetActor(ctx, reward.Address)
 	if err != nil || !found {
-		return big.Int{}, fmt.Errorf("loading miner actor: %v", err)
+		return nil, fmt.Errorf("loading miner actor: %v", err)
 	}
 
 	rewardState, err := reward.Load(store, rewardActor)
 	if err != nil {
-		return big.Int{}, fmt.Errorf("loading reward actor state: %v", err)
+		return nil, fmt.Errorf("loading reward actor state: %v", err)
 	}
 
 	circSupply, err := msa.StateVMCirculatingSupplyInternal(ctx, ts.Key())
 	if err != nil {
-		return big.Zero(), fmt.Errorf("getting circulating supply: %v", err)
+		return nil, fmt.Errorf("getting circulating supply: %v", err)
 	}
 
 	initialPledge, err := rewardState.InitialPledgeForPower(
@@ -715,10 +1194,44 @@ func (msa *minerStateAPI) StateMinerInitialPledgeCollateral(ctx context.Context,
 		circSupply.FilCirculating,
 	)
 	if err != nil {
-		return big.Zero(), fmt.Errorf("calculating initial pledge: %v", err)
+		return nil, fmt.Errorf("calculating initial pledge: %v", err)
 	}
 
-	return big.Div(big.Mul(initialPledge, initialPledgeNum), initialPledgeDen), nil
+	rewardSmoothed, err := rewardState.ThisEpochRewardSmoothed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine reward: %v", err)
+	}
+
+	return splitInitialPledge(initialPledge, storagePledgeForPower(rewardSmoothed, powerSmoothed, sectorWeight)), nil
+}
+
+// storagePledgeForPower returns the storage pledge of a sector, the block reward the sector is expected to earn
+// over the projection period of the initial pledge, which is computed the same way by all the actor versions
+func storagePledgeForPower(rewardSmoothed, networkQAPower builtin.FilterEstimate, qaPower abi.StoragePower) abi.TokenAmount {
+	return miner11.ExpectedRewardForPowerClampedAtAttoFIL(
+		smoothing11.FilterEstimate{
+			PositionEstimate: rewardSmoothed.PositionEstimate,
+			VelocityEstimate: rewardSmoothed.VelocityEstimate,
+		},
+		smoothing11.FilterEstimate{
+			PositionEstimate: networkQAPower.PositionEstimate,
+			VelocityEstimate: networkQAPower.VelocityEstimate,
+		},
+		qaPower,
+		miner11.InitialPledgeProjectionPeriod,
+	)
+}
+
+// splitInitialPledge splits the initial pledge computed by the actors into the storage pledge and the consensus
+// pledge, the initial pledge is capped per byte so the consensus pledge is what remains under the cap
+func splitInitialPledge(initialPledge, storagePledge abi.TokenAmount) *types.InitialPledge {
+	if storagePledge.GreaterThan(initialPledge) {
+		storagePledge = initialPledge
+	}
+	return &types.InitialPledge{
+		StoragePledge:   storagePledge,
+		ConsensusPledge: big.Sub(initialPledge, storagePledge),
+	}
 }
 
 // StateVMCirculatingSupplyInternal returns an approximation of the circulating supply of Filecoin at the given tipset.
@@ -729,12 +1242,24 @@ func (msa *minerStateAPI) StateVMCirculatingSupplyInternal(ctx context.Context,
 		return types.CirculatingSupply{}, err
 	}
 
+	key := ts.Key().String()
+	if cs, ok := msa.circSupplyCache.Get(key); ok {
+		return cs.(types.CirculatingSupply), nil
+	}
+
 	_, sTree, err := msa.Stmgr.ParentState(ctx, ts)
 	if err != nil {
 		return types.CirculatingSupply{}, err
 	}
 
-	return msa.ChainReader.GetCirculatingSupplyDetailed(ctx, ts.Height(), sTree)
+	// same calculator as the CircSupplyCalculator of the vm options, with the detailed breakdown
+	cs, err := msa.ChainReader.GetCirculatingSupplyDetailed(ctx, ts.Height(), sTree)
+	if err != nil {
+		return types.CirculatingSupply{}, err
+	}
+	msa.circSupplyCache.SetDefault(key, cs)
+
+	return cs, nil
 }
 
 // StateCirculatingSupply returns the exact circulating supply of Filecoin at the given tipset.
@@ -833,6 +1358,68 @@ func (msa *minerStateAPI) StateListMiners(ctx context.Context, tsk types.TipSetK
 	return view.StateListMiners(ctx, tsk)
 }
 
+const (
+	defaultMinerPageSize = 1000
+	maxMinerPageSize     = 10000
+)
+
+// StateListMinersPaginated returns a page of the miners that have claimed power in the Power Actor, so they
+// can be iterated without holding all of them in memory.
+func (msa *minerStateAPI) StateListMinersPaginated(ctx context.Context, tsk types.TipSetKey, pageToken *types.MinerPageToken, pageSize int) (*types.MinerPage, error) {
+	if pageSize < 0 {
+		return nil, fmt.Errorf("invalid page size %d", pageSize)
+	}
+
+	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+	}
+
+	powState, err := view.LoadPowerActor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load power actor state: %v", err)
+	}
+
+	return pageMiners(powState, pageToken, pageSize)
+}
+
+var errMinerPageFull = errors.New("miner page full")
+
+// pageMiners returns the page of the miners with a claim in st following pageToken. The page token is the key
+// of the last miner of the previous page, the claims table is walked from its position on, which is kept even
+// if that miner was removed meanwhile.
+func pageMiners(st power.State, pageToken *types.MinerPageToken, pageSize int) (*types.MinerPage, error) {
+	if pageSize == 0 {
+		pageSize = defaultMinerPageSize
+	}
+	if pageSize > maxMinerPageSize {
+		pageSize = maxMinerPageSize
+	}
+
+	after := address.Undef
+	if pageToken != nil {
+		var err error
+		if after, err = address.NewFromBytes(pageToken.Cursor); err != nil {
+			return nil, fmt.Errorf("invalid page token: %w", err)
+		}
+	}
+
+	page := &types.MinerPage{Miners: make([]address.Address, 0, pageSize)}
+	err := st.ForEachClaimAfter(after, func(miner address.Address, _ power.Claim) error {
+		if len(page.Miners) == pageSize {
+			page.NextPageToken = &types.MinerPageToken{Cursor: page.Miners[pageSize-1].Bytes()}
+			return errMinerPageFull
+		}
+		page.Miners = append(page.Miners, miner)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errMinerPageFull) {
+		return nil, err
+	}
+
+	return page, nil
+}
+
 // StateListActors returns the addresses of every actor in the state
 func (msa *minerStateAPI) StateListActors(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error) {
 	_, stat, err := msa.Stmgr.TipsetStateTsk(ctx, tsk)
@@ -870,6 +1457,43 @@ func (msa *minerStateAPI) StateMinerPower(ctx context.Context, addr address.Addr
 	}, nil
 }
 
+// MinerGetQualityAdjustedPower sums the power of the active sectors of a miner from its own state, the
+// claims table of the power actor is not loaded.
+func (msa *minerStateAPI) MinerGetQualityAdjustedPower(ctx context.Context, minerAddr address.Address, tsk types.TipSetKey) (*types.MinerSectorPower, error) {
+	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+	}
+	mas, err := view.LoadMinerState(ctx, minerAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load miner actor state: %v", err)
+	}
+	info, err := mas.Info()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load miner info: %v", err)
+	}
+	activeSectors, err := lminer.AllPartSectors(mas, lminer.Partition.ActiveSectors)
+	if err != nil {
+		return nil, fmt.Errorf("merge partition active sets: %v", err)
+	}
+	sectors, err := mas.LoadSectors(&activeSectors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load active sectors: %v", err)
+	}
+
+	sectorSize := abi.NewStoragePower(int64(info.SectorSize))
+	out := &types.MinerSectorPower{
+		RawBytePower:    big.Mul(sectorSize, big.NewInt(int64(len(sectors)))),
+		QualityAdjPower: big.Zero(),
+	}
+	for _, sector := range sectors {
+		duration := sector.Expiration - sector.Activation
+		out.QualityAdjPower = big.Add(out.QualityAdjPower, builtin.QAPowerForWeight(info.SectorSize, duration, sector.DealWeight, sector.VerifiedDealWeight))
+	}
+
+	return out, nil
+}
+
 // StateMinerAvailableBalance returns the portion of a miner's balance that can be withdrawn or spent
 func (msa *minerStateAPI) StateMinerAvailableBalance(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error) {
 	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
@@ -884,6 +1508,58 @@ func (msa *minerStateAPI) StateMinerAvailableBalance(ctx context.Context, maddr
 	return view.StateMinerAvailableBalance(ctx, maddr, ts)
 }
 
+// StateMinerWithdrawableAmount returns the amount the miner can withdraw at tsk: the balance minus the initial
+// pledge, the precommit deposits, the fee debt and the funds that are not vested yet. The miner actor refuses
+// to withdraw from a balance that does not cover them, so the amount is never negative.
+func (msa *minerStateAPI) StateMinerWithdrawableAmount(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error) {
+	available, err := msa.StateMinerAvailableBalance(ctx, maddr, tsk)
+	if err != nil {
+		return big.Int{}, err
+	}
+
+	return big.Max(available, big.Zero()), nil
+}
+
+// StateGetRewardSmoothingEstimate returns the smoothed estimate of the block reward per epoch of the reward actor at tsk
+func (msa *minerStateAPI) StateGetRewardSmoothingEstimate(ctx context.Context, tsk types.TipSetKey) (*builtin.FilterEstimate, error) {
+	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+	}
+
+	rewardState, err := view.LoadRewardState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading reward actor state: %v", err)
+	}
+
+	rewardSmoothed, err := rewardState.ThisEpochRewardSmoothed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine reward: %v", err)
+	}
+
+	return &rewardSmoothed, nil
+}
+
+// StateGetPowerSmoothingEstimate returns the smoothed estimate of the network quality adjusted power of the power actor at tsk
+func (msa *minerStateAPI) StateGetPowerSmoothingEstimate(ctx context.Context, tsk types.TipSetKey) (*builtin.FilterEstimate, error) {
+	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+	}
+
+	powerState, err := view.LoadPowerState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading power actor state: %v", err)
+	}
+
+	powerSmoothed, err := powerState.TotalPowerSmoothed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine total power: %v", err)
+	}
+
+	return &powerSmoothed, nil
+}
+
 // StateSectorExpiration returns epoch at which given sector will expire
 func (msa *minerStateAPI) StateSectorExpiration(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorExpiration, error) {
 	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
@@ -894,7 +1570,19 @@ func (msa *minerStateAPI) StateSectorExpiration(ctx context.Context, maddr addre
 	return view.StateSectorExpiration(ctx, maddr, sectorNumber, tsk)
 }
 
-// StateMinerSectorCount returns the number of sectors in a miner's sector set and proving set
+// StateGetSectorExpirationBatch returns the expirations of the given sectors, the sectors that are not
+// in the sector set of the miner are absent from the result
+func (msa *minerStateAPI) StateGetSectorExpirationBatch(ctx context.Context, maddr address.Address, sectorNums []abi.SectorNumber, tsk types.TipSetKey) (map[abi.SectorNumber]*lminer.SectorExpiration, error) {
+	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+	}
+
+	return view.StateSectorExpirationBatch(ctx, maddr, sectorNums)
+}
+
+// StateMinerSectorCount returns the number of sectors in a miner's sector set and proving set, the counts
+// come from the partition bitfields so the sectors themselves are not loaded.
 func (msa *minerStateAPI) StateMinerSectorCount(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MinerSectors, error) {
 	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
 	if err != nil {
@@ -906,36 +1594,39 @@ func (msa *minerStateAPI) StateMinerSectorCount(ctx context.Context, addr addres
 		return types.MinerSectors{}, err
 	}
 
-	var activeCount, liveCount, faultyCount uint64
+	var out types.MinerSectors
 	if err := mas.ForEachDeadline(func(_ uint64, dl lminer.Deadline) error {
 		return dl.ForEachPartition(func(_ uint64, part lminer.Partition) error {
-			if active, err := part.ActiveSectors(); err != nil {
-				return err
-			} else if count, err := active.Count(); err != nil {
-				return err
-			} else {
-				activeCount += count
-			}
-			if live, err := part.LiveSectors(); err != nil {
-				return err
-			} else if count, err := live.Count(); err != nil {
-				return err
-			} else {
-				liveCount += count
-			}
-			if faulty, err := part.FaultySectors(); err != nil {
-				return err
-			} else if count, err := faulty.Count(); err != nil {
-				return err
-			} else {
-				faultyCount += count
-			}
-			return nil
+			return addPartitionSectorCounts(&out, part)
 		})
 	}); err != nil {
 		return types.MinerSectors{}, err
 	}
-	return types.MinerSectors{Live: liveCount, Active: activeCount, Faulty: faultyCount}, nil
+	return out, nil
+}
+
+// addPartitionSectorCounts adds the number of live, active, faulty and recovering sectors of part to counts.
+func addPartitionSectorCounts(counts *types.MinerSectors, part lminer.Partition) error {
+	for _, set := range []struct {
+		sectors func() (bitfield.BitField, error)
+		count   *uint64
+	}{
+		{part.LiveSectors, &counts.Live},
+		{part.ActiveSectors, &counts.Active},
+		{part.FaultySectors, &counts.Faulty},
+		{part.RecoveringSectors, &counts.Recovering},
+	} {
+		sectors, err := set.sectors()
+		if err != nil {
+			return err
+		}
+		count, err := sectors.Count()
+		if err != nil {
+			return err
+		}
+		*set.count += count
+	}
+	return nil
 }
 
 // StateMarketBalance looks up the Escrow and Locked balances of the given address in the Storage Market
@@ -1156,6 +1847,75 @@ func (msa *minerStateAPI) StateDecodeParams(ctx context.Context, toAddr address.
 	return paramType, nil
 }
 
+func (msa *minerStateAPI) StateDecodeReturnValue(ctx context.Context, msgCID cid.Cid, tsk types.TipSetKey) (interface{}, error) {
+	chainMsg, err := msa.MessageStore.LoadMessage(ctx, msgCID)
+	if err != nil {
+		return nil, err
+	}
+	head, err := msa.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
+	}
+	msgResult, found, err := msa.Waiter.Find(ctx, chainMsg, decodeReturnValueLookback, head, true)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("message %s not found from tipset %s", msgCID, tsk)
+	}
+	if msgResult.Receipt.ExitCode.IsError() {
+		return nil, fmt.Errorf("message %s failed with exit code %d", msgCID, msgResult.Receipt.ExitCode)
+	}
+	if len(msgResult.Receipt.Return) == 0 {
+		return nil, nil
+	}
+
+	msg := msgResult.Message.VMMessage()
+	act, err := receiverAt(ctx, msg.To, msgResult.TS, msa.Stmgr.GetActorAt, func(ctx context.Context, addr address.Address, ts *types.TipSet) (*types.Actor, error) {
+		st, err := msa.Stmgr.TipsetState(ctx, ts)
+		if err != nil {
+			return nil, err
+		}
+		act, found, err := st.GetActor(ctx, addr)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, types.ErrActorNotFound
+		}
+		return act, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading receiver %s: %w", msg.To, err)
+	}
+
+	methodMeta, found := utils.MethodsMap[act.Code][msg.Method]
+	if !found {
+		return nil, fmt.Errorf("method %d not found on actor %s", msg.Method, act.Code)
+	}
+
+	retType := reflect.New(methodMeta.Ret.Elem()).Interface().(cbg.CBORUnmarshaler)
+	if err = retType.UnmarshalCBOR(bytes.NewReader(msgResult.Receipt.Return)); err != nil {
+		return nil, fmt.Errorf("decoding return value of method %s: %w", methodMeta.Name, err)
+	}
+
+	return retType, nil
+}
+
+// decodeReturnValueLookback bounds how far back StateDecodeReturnValue searches for the message
+const decodeReturnValueLookback = 7 * builtin.EpochsInDay
+
+// receiverAt returns the receiver of a message included in ts. preExec loads the actor from the
+// parent state of ts, the state the message was executed on, and postExec from the state computed
+// by executing ts, where the receivers created by the messages of ts are found.
+func receiverAt(ctx context.Context, to address.Address, ts *types.TipSet, preExec, postExec func(context.Context, address.Address, *types.TipSet) (*types.Actor, error)) (*types.Actor, error) {
+	act, err := preExec(ctx, to, ts)
+	if errors.Is(err, types.ErrActorNotFound) {
+		return postExec(ctx, to, ts)
+	}
+	return act, err
+}
+
 func (msa *minerStateAPI) StateEncodeParams(ctx context.Context, toActCode cid.Cid, method abi.MethodNum, params json.RawMessage) ([]byte, error) {
 	methodMeta, found := utils.MethodsMap[toActCode][method]
 	if !found {
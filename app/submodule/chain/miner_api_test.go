@@ -0,0 +1,509 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/dline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/market"
+	lminer "github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/power"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/verifreg"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestReceiverAt(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	to, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	ts := &types.TipSet{}
+
+	preActor := &types.Actor{Nonce: 1}
+	postActor := &types.Actor{Nonce: 2}
+	lookup := func(act *types.Actor, err error, calls *int) func(context.Context, address.Address, *types.TipSet) (*types.Actor, error) {
+		return func(_ context.Context, addr address.Address, at *types.TipSet) (*types.Actor, error) {
+			*calls++
+			assert.Equal(t, to, addr)
+			// both states are looked up at the inclusion tipset
+			assert.Same(t, ts, at)
+			return act, err
+		}
+	}
+
+	// the receiver exists before the execution of the tipset
+	var pre, post int
+	act, err := receiverAt(ctx, to, ts, lookup(preActor, nil, &pre), lookup(postActor, nil, &post))
+	require.NoError(t, err)
+	assert.Equal(t, preActor, act)
+	assert.Equal(t, 1, pre)
+	assert.Equal(t, 0, post)
+
+	// the receiver is created by a message of the tipset
+	pre, post = 0, 0
+	act, err = receiverAt(ctx, to, ts, lookup(nil, types.ErrActorNotFound, &pre), lookup(postActor, nil, &post))
+	require.NoError(t, err)
+	assert.Equal(t, postActor, act)
+	assert.Equal(t, 1, post)
+
+	// other failures are not retried on the post execution state
+	pre, post = 0, 0
+	loadErr := errors.New("load failed")
+	_, err = receiverAt(ctx, to, ts, lookup(nil, loadErr, &pre), lookup(postActor, nil, &post))
+	assert.ErrorIs(t, err, loadErr)
+	assert.Equal(t, 0, post)
+}
+
+// fakeAllocations serves the allocations table of the verified registry, clients and allocations are kept in
+// table order
+type fakeAllocations struct {
+	verifreg.State
+	clients     []address.Address
+	allocations map[address.Address][]types.AllocationId
+}
+
+func (f *fakeAllocations) ForEachAllocationClientAfter(after address.Address, cb func(address.Address) error) error {
+	found := after == address.Undef
+	for _, client := range f.clients {
+		if !found {
+			found = client == after
+			continue
+		}
+		if err := cb(client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeAllocations) ForEachAllocationAfter(client address.Address, after *types.AllocationId, cb func(types.AllocationId, types.Allocation) error) error {
+	for _, id := range f.allocations[client] {
+		if after != nil && id <= *after {
+			continue
+		}
+		clientID, err := address.IDFromAddress(client)
+		if err != nil {
+			return err
+		}
+		if err := cb(id, types.Allocation{Client: abi.ActorID(clientID)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestPageAllocations(t *testing.T) {
+	tf.UnitTest(t)
+
+	st := &fakeAllocations{allocations: map[address.Address][]types.AllocationId{}}
+	for i, ids := range [][]types.AllocationId{{1, 2, 3}, {4}, {5, 6}} {
+		client, err := address.NewIDAddress(uint64(1000 + i))
+		require.NoError(t, err)
+		st.clients = append(st.clients, client)
+		st.allocations[client] = ids
+	}
+	ids := func(page *types.AllocationPage) []types.AllocationId {
+		var out []types.AllocationId
+		for _, item := range page.Allocations {
+			out = append(out, item.ID)
+		}
+		return out
+	}
+
+	// pages stop in the middle of a client and continue with the next clients
+	page, err := pageAllocations(st, address.Undef, nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []types.AllocationId{1, 2}, ids(page))
+	assert.Equal(t, abi.ActorID(1000), page.Allocations[0].Client)
+	require.NotNil(t, page.NextPageToken)
+	assert.Equal(t, st.clients[0], page.NextPageToken.Client)
+
+	page, err = pageAllocations(st, address.Undef, page.NextPageToken, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []types.AllocationId{3, 4}, ids(page))
+	require.NotNil(t, page.NextPageToken)
+
+	page, err = pageAllocations(st, address.Undef, page.NextPageToken, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []types.AllocationId{5, 6}, ids(page))
+	assert.Nil(t, page.NextPageToken)
+
+	// a page that exactly drains the allocations is the last one
+	page, err = pageAllocations(st, address.Undef, nil, 6)
+	require.NoError(t, err)
+	assert.Len(t, page.Allocations, 6)
+	assert.Nil(t, page.NextPageToken)
+
+	page, err = pageAllocations(st, address.Undef, nil, 0)
+	require.NoError(t, err)
+	assert.Len(t, page.Allocations, 6)
+
+	// only the allocations of the client are listed
+	page, err = pageAllocations(st, st.clients[0], nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []types.AllocationId{1, 2}, ids(page))
+	page, err = pageAllocations(st, st.clients[0], page.NextPageToken, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []types.AllocationId{3}, ids(page))
+	assert.Nil(t, page.NextPageToken)
+
+	_, err = pageAllocations(st, st.clients[2], &types.AllocationPageToken{Client: st.clients[0], AfterID: 2}, 2)
+	assert.Error(t, err)
+}
+
+// fakePendingDeals serves the pending deals of the market actor, with the client and the allocation of each deal
+type fakePendingDeals struct {
+	market.State
+	clients     map[abi.DealID]address.Address
+	allocations map[abi.DealID]types.AllocationId
+}
+
+func (f *fakePendingDeals) GetAllocationIdForPendingDeal(dealID abi.DealID) (types.AllocationId, error) {
+	if id, ok := f.allocations[dealID]; ok {
+		return id, nil
+	}
+	return types.NoAllocationID, nil
+}
+
+func (f *fakePendingDeals) Proposals() (market.DealProposals, error) {
+	return fakeProposals{clients: f.clients}, nil
+}
+
+type fakeProposals struct {
+	market.DealProposals
+	clients map[abi.DealID]address.Address
+}
+
+func (f fakeProposals) Get(dealID abi.DealID) (*types.DealProposal, bool, error) {
+	client, ok := f.clients[dealID]
+	if !ok {
+		return nil, false, nil
+	}
+	return &types.DealProposal{Client: client}, true, nil
+}
+
+// fakeVerifiedAllocations serves allocations of the verified registry by ID
+type fakeVerifiedAllocations struct {
+	verifreg.State
+	allocations map[types.AllocationId]types.Allocation
+}
+
+func (f *fakeVerifiedAllocations) GetAllocation(clientIDAddr address.Address, allocationID types.AllocationId) (*types.Allocation, bool, error) {
+	allocation, ok := f.allocations[allocationID]
+	if !ok {
+		return nil, false, nil
+	}
+	clientID, err := address.IDFromAddress(clientIDAddr)
+	if err != nil {
+		return nil, false, err
+	}
+	if abi.ActorID(clientID) != allocation.Client {
+		return nil, false, nil
+	}
+	return &allocation, true, nil
+}
+
+func TestSectorAllocations(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	clientID, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	client := testhelpers.NewForTestGetter()()
+	lookupID := func(_ context.Context, addr address.Address) (address.Address, error) {
+		if addr != client {
+			return address.Undef, fmt.Errorf("actor %s not found", addr)
+		}
+		return clientID, nil
+	}
+
+	epoch := abi.ChainEpoch(100)
+	marketState := &fakePendingDeals{
+		clients: map[abi.DealID]address.Address{1: client, 2: client, 3: client, 4: client, 5: client},
+		// deal 1 is not verified
+		allocations: map[abi.DealID]types.AllocationId{2: 10, 3: 11, 4: 12, 5: 13},
+	}
+	verifregState := &fakeVerifiedAllocations{allocations: map[types.AllocationId]types.Allocation{
+		10: {Client: 1000, Expiration: epoch + 1},
+		// allocation 11 expired, allocation 12 was removed
+		11: {Client: 1000, Expiration: epoch - 1},
+		// an allocation can still be claimed at its expiration
+		13: {Client: 1000, Expiration: epoch},
+	}}
+	pci := &types.SectorPreCommitOnChainInfo{}
+	pci.Info.DealIDs = []abi.DealID{1, 2, 3, 4, 5}
+
+	ids, err := sectorAllocations(ctx, pci, epoch, marketState, verifregState, lookupID)
+	require.NoError(t, err)
+	assert.Equal(t, []types.AllocationId{10, 13}, ids)
+
+	// a sector without deals claims nothing
+	ids, err = sectorAllocations(ctx, &types.SectorPreCommitOnChainInfo{}, epoch, marketState, verifregState, lookupID)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	_, err = sectorAllocations(ctx, nil, epoch, marketState, verifregState, lookupID)
+	assert.ErrorContains(t, err, "not pre-committed")
+
+	// the proposal of a deal with an allocation is required
+	pci.Info.DealIDs = []abi.DealID{6}
+	marketState.allocations[6] = 14
+	_, err = sectorAllocations(ctx, pci, epoch, marketState, verifregState, lookupID)
+	assert.Error(t, err)
+}
+
+// fakeClaims serves the claims table of the power actor, the miners are kept in table order, which is the order
+// of their keys
+type fakeClaims struct {
+	power.State
+	miners []address.Address
+	err    error
+}
+
+func (f *fakeClaims) ForEachClaimAfter(after address.Address, cb func(address.Address, power.Claim) error) error {
+	if f.err != nil {
+		return f.err
+	}
+	for _, miner := range f.miners {
+		if after != address.Undef && bytes.Compare(miner.Bytes(), after.Bytes()) <= 0 {
+			continue
+		}
+		if err := cb(miner, power.Claim{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestPageMiners(t *testing.T) {
+	tf.UnitTest(t)
+
+	st := &fakeClaims{}
+	for id := uint64(1000); id < 1005; id++ {
+		miner, err := address.NewIDAddress(id)
+		require.NoError(t, err)
+		st.miners = append(st.miners, miner)
+	}
+	miners := st.miners
+
+	page, err := pageMiners(st, nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, miners[:2], page.Miners)
+	require.NotNil(t, page.NextPageToken)
+
+	page, err = pageMiners(st, page.NextPageToken, 2)
+	require.NoError(t, err)
+	assert.Equal(t, miners[2:4], page.Miners)
+	require.NotNil(t, page.NextPageToken)
+
+	page, err = pageMiners(st, page.NextPageToken, 2)
+	require.NoError(t, err)
+	assert.Equal(t, miners[4:], page.Miners)
+	assert.Nil(t, page.NextPageToken)
+
+	// a page that exactly drains the miners is the last one
+	page, err = pageMiners(st, nil, 5)
+	require.NoError(t, err)
+	assert.Len(t, page.Miners, 5)
+	assert.Nil(t, page.NextPageToken)
+
+	page, err = pageMiners(st, nil, 0)
+	require.NoError(t, err)
+	assert.Len(t, page.Miners, 5)
+
+	// the last miner of a page is removed before the next page is fetched
+	page, err = pageMiners(st, nil, 2)
+	require.NoError(t, err)
+	st.miners = append(append([]address.Address{}, miners[:1]...), miners[2:]...)
+	page, err = pageMiners(st, page.NextPageToken, 2)
+	require.NoError(t, err)
+	assert.Equal(t, miners[2:4], page.Miners)
+
+	_, err = pageMiners(st, &types.MinerPageToken{Cursor: []byte("unknown")}, 2)
+	assert.Error(t, err)
+
+	iterErr := errors.New("iteration failed")
+	_, err = pageMiners(&fakeClaims{err: iterErr}, nil, 2)
+	assert.ErrorIs(t, err, iterErr)
+}
+
+type fakePartition struct {
+	lminer.Partition
+	all, live, active, faulty, recovering bitfield.BitField
+}
+
+func (p fakePartition) AllSectors() (bitfield.BitField, error)        { return p.all, nil }
+func (p fakePartition) LiveSectors() (bitfield.BitField, error)       { return p.live, nil }
+func (p fakePartition) ActiveSectors() (bitfield.BitField, error)     { return p.active, nil }
+func (p fakePartition) FaultySectors() (bitfield.BitField, error)     { return p.faulty, nil }
+func (p fakePartition) RecoveringSectors() (bitfield.BitField, error) { return p.recovering, nil }
+
+func TestAddPartitionSectorCounts(t *testing.T) {
+	tf.UnitTest(t)
+
+	var counts types.MinerSectors
+	require.NoError(t, addPartitionSectorCounts(&counts, fakePartition{
+		live:       bitfield.NewFromSet([]uint64{1, 2, 3, 4}),
+		active:     bitfield.NewFromSet([]uint64{1, 2}),
+		faulty:     bitfield.NewFromSet([]uint64{3, 4}),
+		recovering: bitfield.NewFromSet([]uint64{4}),
+	}))
+	require.NoError(t, addPartitionSectorCounts(&counts, fakePartition{
+		live:       bitfield.NewFromSet([]uint64{10}),
+		active:     bitfield.NewFromSet([]uint64{10}),
+		faulty:     bitfield.New(),
+		recovering: bitfield.New(),
+	}))
+	assert.Equal(t, types.MinerSectors{Live: 5, Active: 3, Faulty: 2, Recovering: 1}, counts)
+}
+
+type fakeDeadline struct {
+	lminer.Deadline
+	partitions []fakePartition
+}
+
+func (d fakeDeadline) LoadPartition(idx uint64) (lminer.Partition, error) {
+	if idx >= uint64(len(d.partitions)) {
+		return nil, fmt.Errorf("no partition %d", idx)
+	}
+	return d.partitions[idx], nil
+}
+
+func TestProvenSectors(t *testing.T) {
+	tf.UnitTest(t)
+
+	dl := fakeDeadline{partitions: []fakePartition{
+		{
+			// 3 is terminated, 4 faulty and 5 faulty but declared recovering
+			all:        bitfield.NewFromSet([]uint64{1, 2, 3, 4, 5}),
+			live:       bitfield.NewFromSet([]uint64{1, 2, 4, 5}),
+			faulty:     bitfield.NewFromSet([]uint64{4, 5}),
+			recovering: bitfield.NewFromSet([]uint64{5}),
+		},
+		{
+			all:        bitfield.NewFromSet([]uint64{10, 11}),
+			live:       bitfield.NewFromSet([]uint64{10, 11}),
+			faulty:     bitfield.New(),
+			recovering: bitfield.New(),
+		},
+	}}
+	toSlice := func(bf bitfield.BitField) []uint64 {
+		out, err := bf.All(100)
+		require.NoError(t, err)
+		return out
+	}
+
+	sectors, ignored, err := provenSectors(dl, []types.PoStPartition{{Index: 0, Skipped: bitfield.New()}})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, toSlice(sectors))
+	assert.Equal(t, []uint64{3, 4}, toSlice(ignored))
+
+	// skipping a recovering sector keeps it faulty, skipping a healthy one makes it faulty
+	sectors, ignored, err = provenSectors(dl, []types.PoStPartition{
+		{Index: 0, Skipped: bitfield.NewFromSet([]uint64{5})},
+		{Index: 1, Skipped: bitfield.NewFromSet([]uint64{11})},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 10, 11}, toSlice(sectors))
+	assert.Equal(t, []uint64{3, 4, 5, 11}, toSlice(ignored))
+
+	sectors, ignored, err = provenSectors(dl, nil)
+	require.NoError(t, err)
+	assert.Empty(t, toSlice(sectors))
+	assert.Empty(t, toSlice(ignored))
+
+	_, _, err = provenSectors(dl, []types.PoStPartition{{Index: 2, Skipped: bitfield.New()}})
+	assert.Error(t, err)
+}
+
+func TestChallengedSectors(t *testing.T) {
+	tf.UnitTest(t)
+
+	newCid := testhelpers.NewCidForTestGetter()
+	infos := make(map[abi.SectorNumber]*lminer.SectorOnChainInfo)
+	for sno := abi.SectorNumber(1); sno <= 5; sno++ {
+		infos[sno] = &lminer.SectorOnChainInfo{
+			SectorNumber: sno,
+			SealProof:    abi.RegisteredSealProof_StackedDrg2KiBV1_1,
+			SealedCID:    newCid(),
+		}
+	}
+	load := func(sectorNos *bitfield.BitField) ([]*lminer.SectorOnChainInfo, error) {
+		var out []*lminer.SectorOnChainInfo
+		return out, sectorNos.ForEach(func(sno uint64) error {
+			if info, ok := infos[abi.SectorNumber(sno)]; ok {
+				out = append(out, info)
+			}
+			return nil
+		})
+	}
+
+	sectors := bitfield.NewFromSet([]uint64{1, 2, 3, 4, 5})
+	challenged, err := challengedSectors(sectors, bitfield.NewFromSet([]uint64{1, 4}), load)
+	require.NoError(t, err)
+	require.Len(t, challenged, 5)
+	// the ignored sectors are replaced by the first proven one
+	for i, sno := range []abi.SectorNumber{2, 2, 3, 2, 5} {
+		assert.Equal(t, sno, challenged[i].SectorNumber)
+		assert.Equal(t, infos[sno].SealedCID, challenged[i].SealedCID)
+	}
+
+	challenged, err = challengedSectors(sectors, sectors, load)
+	require.NoError(t, err)
+	assert.Empty(t, challenged)
+
+	// a proven sector missing from the state
+	_, err = challengedSectors(bitfield.NewFromSet([]uint64{1, 6}), bitfield.New(), load)
+	assert.Error(t, err)
+}
+
+func TestSplitInitialPledge(t *testing.T) {
+	tf.UnitTest(t)
+
+	pledge := splitInitialPledge(big.NewInt(100), big.NewInt(30))
+	assert.Equal(t, big.NewInt(30), pledge.StoragePledge)
+	assert.Equal(t, big.NewInt(70), pledge.ConsensusPledge)
+
+	// under the cap per byte, the storage pledge is all that is required
+	pledge = splitInitialPledge(big.NewInt(20), big.NewInt(30))
+	assert.Equal(t, big.NewInt(20), pledge.StoragePledge)
+	assert.True(t, pledge.ConsensusPledge.IsZero())
+}
+
+func TestDeadlineInfoForEpoch(t *testing.T) {
+	tf.UnitTest(t)
+
+	// the proving parameters come from the recorded deadline of the miner state
+	deadlines := uint64(4)
+	window := abi.ChainEpoch(10)
+	period := window * abi.ChainEpoch(deadlines)
+	pps := abi.ChainEpoch(100)
+	recorded := dline.NewInfo(pps, 1, pps+window, deadlines, period, window, 5, 2)
+
+	di := deadlineInfoForEpoch(recorded, pps+2*period+3*window+1)
+	assert.Equal(t, pps+2*period, di.PeriodStart)
+	assert.Equal(t, uint64(3), di.Index)
+	assert.Equal(t, pps+2*period+3*window, di.Open)
+	assert.Equal(t, di.Open-5, di.Challenge)
+	assert.Equal(t, period, di.WPoStProvingPeriod)
+	assert.True(t, di.IsOpen())
+
+	// the proving period of a new miner may start after the epoch
+	di = deadlineInfoForEpoch(recorded, pps-window)
+	assert.Equal(t, pps-period, di.PeriodStart)
+	assert.Equal(t, deadlines-1, di.Index)
+	assert.Equal(t, pps, di.Close)
+}
@@ -2,13 +2,19 @@ package common
 
 import (
 	"context"
+	"fmt"
+	"runtime"
+	"strings"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 
+	"github.com/filecoin-project/go-state-types/abi"
 	chain2 "github.com/filecoin-project/venus/app/submodule/chain"
 	apiwrapper "github.com/filecoin-project/venus/app/submodule/common/v0api"
+	"github.com/filecoin-project/venus/app/submodule/mpool"
 	"github.com/filecoin-project/venus/app/submodule/network"
+	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/net"
 	"github.com/filecoin-project/venus/venus-shared/api/chain"
@@ -22,15 +28,24 @@ var _ v1api.ICommon = (*CommonModule)(nil)
 type CommonModule struct { // nolint
 	chainModule    *chain2.ChainSubmodule
 	netModule      *network.NetworkSubmodule
+	mpoolModule    *mpool.MessagePoolSubmodule
 	blockDelaySecs uint64
+	healthCfg      *config.HealthConfig
 	start          time.Time
 }
 
-func NewCommonModule(chainModule *chain2.ChainSubmodule, netModule *network.NetworkSubmodule, blockDelaySecs uint64) *CommonModule {
+func NewCommonModule(chainModule *chain2.ChainSubmodule,
+	netModule *network.NetworkSubmodule,
+	mpoolModule *mpool.MessagePoolSubmodule,
+	blockDelaySecs uint64,
+	healthCfg *config.HealthConfig,
+) *CommonModule {
 	return &CommonModule{
 		chainModule:    chainModule,
 		netModule:      netModule,
+		mpoolModule:    mpoolModule,
 		blockDelaySecs: blockDelaySecs,
+		healthCfg:      healthCfg,
 		start:          time.Now(),
 	}
 }
@@ -119,6 +134,73 @@ func (cm *CommonModule) StartTime(ctx context.Context) (time.Time, error) {
 	return cm.start, nil
 }
 
+// NodeVersion returns the build metadata of the node, the current network version and the supported api versions
+func (cm *CommonModule) NodeVersion(ctx context.Context) (*types.NodeVersion, error) {
+	head := cm.chainModule.ChainReader.GetHead()
+	gitCommit := constants.GitCommit
+	if gitCommit == "" {
+		// builds not made by the Makefile only carry the abbreviated commit, if any
+		gitCommit = strings.TrimPrefix(constants.CurrentCommit, "+git.")
+	}
+	return &types.NodeVersion{
+		Build:                constants.BuildVersion,
+		GitCommit:            gitCommit,
+		GoVersion:            runtime.Version(),
+		NetworkVersion:       cm.chainModule.Fork.GetNetworkVersion(ctx, head.Height()),
+		SupportedAPIVersions: []int{0, 1},
+		ChainName:            cm.netModule.NetworkName,
+	}, nil
+}
+
+// NodeHealthScore returns the health of the node as a score between 0 and 100, see config.HealthConfig
+func (cm *CommonModule) NodeHealthScore(ctx context.Context) (*types.HealthScore, error) {
+	start := time.Now()
+
+	head := cm.chainModule.ChainReader.GetHead()
+	behind := abi.ChainEpoch(0)
+	if delta := time.Since(time.Unix(int64(head.MinTimestamp()), 0)); delta > 0 {
+		behind = abi.ChainEpoch(delta.Seconds() / float64(cm.blockDelaySecs))
+	}
+
+	score := &types.HealthScore{
+		BehindHead:   behind,
+		MpoolSize:    cm.mpoolModule.MPool.PendingCount(),
+		PeerCount:    len(cm.netModule.Host.Network().Peers()),
+		APILatencyMs: int(time.Since(start).Milliseconds()),
+	}
+	computeHealthScore(cm.healthCfg, score)
+
+	return score, nil
+}
+
+// computeHealthScore fills in the score, synced flag and warnings of s from its measurements
+func computeHealthScore(cfg *config.HealthConfig, s *types.HealthScore) {
+	s.Score = 100
+	s.Synced = s.BehindHead <= cfg.SyncedThreshold
+	s.Warnings = []string{}
+
+	if !s.Synced {
+		s.Score -= int(s.BehindHead-cfg.SyncedThreshold) * cfg.BehindPenalty
+		s.Warnings = append(s.Warnings, fmt.Sprintf("head is %d epochs behind", s.BehindHead))
+	}
+	if s.PeerCount < cfg.MinPeers {
+		s.Score -= cfg.PeerPenalty
+		s.Warnings = append(s.Warnings, fmt.Sprintf("only %d peers connected, want at least %d", s.PeerCount, cfg.MinPeers))
+	}
+	if s.MpoolSize > cfg.MaxMpoolSize {
+		s.Score -= cfg.MpoolPenalty
+		s.Warnings = append(s.Warnings, fmt.Sprintf("%d messages pending in mpool, more than %d", s.MpoolSize, cfg.MaxMpoolSize))
+	}
+	if maxLatency := time.Duration(cfg.MaxAPILatency); time.Duration(s.APILatencyMs)*time.Millisecond > maxLatency {
+		s.Score -= cfg.LatencyPenalty
+		s.Warnings = append(s.Warnings, fmt.Sprintf("api latency %dms exceeds %s", s.APILatencyMs, maxLatency))
+	}
+
+	if s.Score < 0 {
+		s.Score = 0
+	}
+}
+
 func (cm *CommonModule) API() v1api.ICommon {
 	return cm
 }
@@ -0,0 +1,40 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestComputeHealthScore(t *testing.T) {
+	tf.UnitTest(t)
+
+	cfg := config.NewDefaultConfig().Health
+
+	healthy := &types.HealthScore{BehindHead: 1, MpoolSize: 10, PeerCount: 50, APILatencyMs: 5}
+	computeHealthScore(cfg, healthy)
+	require.Equal(t, 100, healthy.Score)
+	require.True(t, healthy.Synced)
+	require.Empty(t, healthy.Warnings)
+
+	lagging := &types.HealthScore{BehindHead: cfg.SyncedThreshold + 4, PeerCount: 1, APILatencyMs: 5}
+	computeHealthScore(cfg, lagging)
+	require.Equal(t, 100-4*cfg.BehindPenalty-cfg.PeerPenalty, lagging.Score)
+	require.False(t, lagging.Synced)
+	require.Len(t, lagging.Warnings, 2)
+
+	// the score does not go below zero
+	down := &types.HealthScore{
+		BehindHead:   1000,
+		MpoolSize:    cfg.MaxMpoolSize + 1,
+		APILatencyMs: int((time.Duration(cfg.MaxAPILatency) + time.Second).Milliseconds()),
+	}
+	computeHealthScore(cfg, down)
+	require.Equal(t, 0, down.Score)
+	require.Len(t, down.Warnings, 4)
+}
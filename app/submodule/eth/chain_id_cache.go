@@ -0,0 +1,49 @@
+package eth
+
+import (
+	"context"
+	"sync"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	types2 "github.com/filecoin-project/venus/venus-shared/actors/types"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// chainIDRevalidateEpochs is the number of epochs a cached chain id is served before it is checked
+// against the network params again, so a network upgrade changing it is picked up.
+const chainIDRevalidateEpochs = 100
+
+// chainIDCache holds the eip155 chain id together with the epoch it was loaded at.
+type chainIDCache struct {
+	lk     sync.Mutex
+	id     types.EthUint64
+	epoch  abi.ChainEpoch
+	loaded bool
+}
+
+// get returns the cached chain id, reloading it with load when it was loaded more than
+// chainIDRevalidateEpochs before height or after height, e.g. after a reorg.
+func (c *chainIDCache) get(ctx context.Context, height abi.ChainEpoch, load func(context.Context) (types.EthUint64, error)) (types.EthUint64, error) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	if c.loaded && height >= c.epoch && height-c.epoch < chainIDRevalidateEpochs {
+		return c.id, nil
+	}
+
+	id, err := load(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if c.loaded && id != c.id {
+		log.Warnw("eip155 chain id changed", "old", c.id, "new", id, "height", height)
+	}
+	c.id, c.epoch, c.loaded = id, height, true
+	return id, nil
+}
+
+// ethChainID returns the eip155 chain id the eth transactions are signed and validated with, every eth value built
+// by this package and EthChainId take it from here. It is set once from the network params at startup.
+func ethChainID() types.EthUint64 {
+	return types.EthUint64(types2.Eip155ChainID)
+}
@@ -68,12 +68,16 @@ func (e *ethAPIDummy) EthGetTransactionReceipt(ctx context.Context, txHash types
 	return nil, ErrModuleDisabled
 }
 
-func (e *ethAPIDummy) EthGetTransactionByBlockHashAndIndex(ctx context.Context, blkHash types.EthHash, txIndex types.EthUint64) (types.EthTx, error) {
-	return types.EthTx{}, ErrModuleDisabled
+func (e *ethAPIDummy) EthGetBlockReceipts(ctx context.Context, blkParam string) ([]*types.EthTxReceipt, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthGetTransactionByBlockHashAndIndex(ctx context.Context, blkHash types.EthHash, txIndex types.EthUint64) (*types.EthTx, error) {
+	return nil, ErrModuleDisabled
 }
 
-func (e *ethAPIDummy) EthGetTransactionByBlockNumberAndIndex(ctx context.Context, blkNum types.EthUint64, txIndex types.EthUint64) (types.EthTx, error) {
-	return types.EthTx{}, ErrModuleDisabled
+func (e *ethAPIDummy) EthGetTransactionByBlockNumberAndIndex(ctx context.Context, blkParam string, txIndex types.EthUint64) (*types.EthTx, error) {
+	return nil, ErrModuleDisabled
 }
 
 func (e *ethAPIDummy) EthGetCode(ctx context.Context, address types.EthAddress, blkOpt string) (types.EthBytes, error) {
@@ -104,6 +108,14 @@ func (e *ethAPIDummy) NetListening(ctx context.Context) (bool, error) {
 	return false, ErrModuleDisabled
 }
 
+func (e *ethAPIDummy) EthNetPeerCount(ctx context.Context) (types.EthUint64, error) {
+	return 0, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthNetListening(ctx context.Context) (bool, error) {
+	return false, ErrModuleDisabled
+}
+
 func (e *ethAPIDummy) EthProtocolVersion(ctx context.Context) (types.EthUint64, error) {
 	return 0, ErrModuleDisabled
 }
@@ -132,6 +144,22 @@ func (e *ethAPIDummy) Web3ClientVersion(ctx context.Context) (string, error) {
 	return "", ErrModuleDisabled
 }
 
+func (e *ethAPIDummy) EthDebugGetBadBlocks(ctx context.Context) ([]types.EthBlock, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthGetUncleByBlockHashAndIndex(ctx context.Context, blkHash types.EthHash, index types.EthUint64) (*types.EthBlock, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthGetUncleCountByBlockHash(ctx context.Context, blkHash types.EthHash) (types.EthUint64, error) {
+	return 0, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthTxpoolContent(ctx context.Context) (*types.EthTxpoolContentResult, error) {
+	return nil, ErrModuleDisabled
+}
+
 func (e *ethAPIDummy) start(_ context.Context) error {
 	return nil
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/filecoin-project/go-state-types/builtin/v10/eam"
 	"github.com/filecoin-project/go-state-types/builtin/v10/evm"
 	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/network"
 	"github.com/filecoin-project/specs-actors/actors/builtin"
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/config"
@@ -29,10 +30,10 @@ import (
 	"github.com/filecoin-project/venus/pkg/fork"
 	"github.com/filecoin-project/venus/pkg/messagepool"
 	"github.com/filecoin-project/venus/pkg/statemanger"
+	"github.com/filecoin-project/venus/pkg/util/reqid"
 	"github.com/filecoin-project/venus/venus-shared/actors"
 	builtinactors "github.com/filecoin-project/venus/venus-shared/actors/builtin"
 	builtinevm "github.com/filecoin-project/venus/venus-shared/actors/builtin/evm"
-	types2 "github.com/filecoin-project/venus/venus-shared/actors/types"
 	v1 "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/ipfs/go-cid"
@@ -44,11 +45,14 @@ var log = logging.Logger("eth_api")
 
 var ErrNullRound = errors.New("requested epoch was a null round")
 
+var ErrHistoricalStateNotAvailable = errors.New("historical state not available")
+
 func newEthAPI(em *EthSubModule) (*ethAPI, error) {
 	a := &ethAPI{
-		em:    em,
-		chain: em.chainModule.API(),
-		mpool: em.mpoolModule.API(),
+		em:      em,
+		chain:   em.chainModule.API(),
+		mpool:   em.mpoolModule.API(),
+		network: em.networkModule.API(),
 	}
 
 	dbPath := filepath.Join(a.em.sqlitePath, "txhash.db")
@@ -83,7 +87,9 @@ type ethAPI struct {
 	em               *EthSubModule
 	chain            v1.IChain
 	mpool            v1.IMessagePool
+	network          v1.INetwork
 	ethTxHashManager *ethTxHashManager
+	chainID          chainIDCache
 }
 
 func (a *ethAPI) start(ctx context.Context) error {
@@ -213,6 +219,8 @@ func (a *ethAPI) parseBlkParam(ctx context.Context, blkParam string, strict bool
 		if abi.ChainEpoch(num) > head.Height()-1 {
 			return nil, fmt.Errorf("requested a future epoch (beyond 'latest')")
 		}
+		// block range scans query the heights one by one, let the chain index prefetch the scanned range
+		a.em.chainModule.ChainReader.PrefetchTipSetsByHeight(head, []abi.ChainEpoch{abi.ChainEpoch(num)})
 		ts, err := a.chain.ChainGetTipSetByHeight(ctx, abi.ChainEpoch(num), head.Key())
 		if err != nil {
 			return nil, fmt.Errorf("cannot get tipset at height: %v", num)
@@ -240,7 +248,7 @@ func (a *ethAPI) EthGetTransactionByHash(ctx context.Context, txHash *types.EthH
 
 	c, err := a.ethTxHashManager.TransactionHashLookup.GetCidFromHash(*txHash)
 	if err != nil {
-		log.Debug("could not find transaction hash %s in lookup table", txHash.String())
+		reqid.Logger(ctx, log).Debugf("could not find transaction hash %s in lookup table", txHash.String())
 	}
 
 	// This isn't an eth transaction we have the mapping for, so let's look it up as a filecoin message
@@ -287,7 +295,7 @@ func (a *ethAPI) EthGetMessageCidByTransactionHash(ctx context.Context, txHash *
 	c, err := a.ethTxHashManager.TransactionHashLookup.GetCidFromHash(*txHash)
 	// We fall out of the first condition and continue
 	if errors.Is(err, ethhashlookup.ErrNotFound) {
-		log.Debug("could not find transaction hash %s in lookup table", txHash.String())
+		reqid.Logger(ctx, log).Debugf("could not find transaction hash %s in lookup table", txHash.String())
 	} else if err != nil {
 		return nil, fmt.Errorf("database error: %w", err)
 	} else {
@@ -365,7 +373,7 @@ func (a *ethAPI) EthGetTransactionCount(ctx context.Context, sender types.EthAdd
 func (a *ethAPI) EthGetTransactionReceipt(ctx context.Context, txHash types.EthHash) (*types.EthTxReceipt, error) {
 	c, err := a.ethTxHashManager.TransactionHashLookup.GetCidFromHash(txHash)
 	if err != nil {
-		log.Debug("could not find transaction hash %s in lookup table", txHash.String())
+		reqid.Logger(ctx, log).Debugf("could not find transaction hash %s in lookup table", txHash.String())
 	}
 
 	// This isn't an eth transaction we have the mapping for, so let's look it up as a filecoin message
@@ -404,12 +412,33 @@ func (a *ethAPI) EthGetTransactionReceipt(ctx context.Context, txHash types.EthH
 	return &receipt, nil
 }
 
-func (a *ethAPI) EthGetTransactionByBlockHashAndIndex(ctx context.Context, blkHash types.EthHash, txIndex types.EthUint64) (types.EthTx, error) {
-	return types.EthTx{}, nil
+// EthGetBlockReceipts returns the receipts of all the transactions of a block, in transaction order
+func (a *ethAPI) EthGetBlockReceipts(ctx context.Context, blkParam string) ([]*types.EthTxReceipt, error) {
+	ts, err := a.parseBlkNumberOrHash(ctx, blkParam)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse block param %s: %w", blkParam, err)
+	}
+	return newEthTxReceiptsFromFilecoinTipSet(ctx, ts, a.em.chainModule.MessageStore, a.chain)
 }
 
-func (a *ethAPI) EthGetTransactionByBlockNumberAndIndex(ctx context.Context, blkNum types.EthUint64, txIndex types.EthUint64) (types.EthTx, error) {
-	return types.EthTx{}, nil
+func (a *ethAPI) EthGetTransactionByBlockHashAndIndex(ctx context.Context, blkHash types.EthHash, txIndex types.EthUint64) (*types.EthTx, error) {
+	ts, err := a.em.chainModule.ChainReader.GetTipSetByCid(ctx, blkHash.ToCid())
+	if err != nil {
+		return nil, fmt.Errorf("error loading tipset %s: %w", blkHash, err)
+	}
+	return newEthTxFromTipSetIndex(ctx, ts, txIndex, a.em.chainModule.MessageStore, a.chain)
+}
+
+func (a *ethAPI) EthGetTransactionByBlockNumberAndIndex(ctx context.Context, blkParam string, txIndex types.EthUint64) (*types.EthTx, error) {
+	ts, err := a.parseBlkParam(ctx, blkParam, true)
+	if err != nil {
+		if errors.Is(err, ErrNullRound) {
+			// there is no block at a null round, like an out of bounds index
+			return nil, nil
+		}
+		return nil, err
+	}
+	return newEthTxFromTipSetIndex(ctx, ts, txIndex, a.em.chainModule.MessageStore, a.chain)
 }
 
 // EthGetCode returns string value of the compiled bytecode
@@ -616,7 +645,25 @@ func (a *ethAPI) EthGetBalance(ctx context.Context, address types.EthAddress, bl
 }
 
 func (a *ethAPI) EthChainId(ctx context.Context) (types.EthUint64, error) {
-	return types.EthUint64(types2.Eip155ChainID), nil
+	head, err := a.chain.ChainHead(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := a.chainID.get(ctx, head.Height(), func(ctx context.Context) (types.EthUint64, error) {
+		params, err := a.chain.StateGetNetworkParams(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("getting network params: %w", err)
+		}
+		return types.EthUint64(params.Eip155ChainID), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if id != ethChainID() {
+		log.Errorw("eip155 chain id of the network params differs from the one set at startup, restart the node to use it",
+			"params", id, "running", ethChainID())
+	}
+	return ethChainID(), nil
 }
 
 func (a *ethAPI) EthFeeHistory(ctx context.Context, p jsonrpc.RawParams) (types.EthFeeHistory, error) {
@@ -736,13 +783,40 @@ func (a *ethAPI) NetListening(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
+// EthNetPeerCount returns the number of peers the node is connected to
+func (a *ethAPI) EthNetPeerCount(ctx context.Context) (types.EthUint64, error) {
+	peers, err := a.network.NetPeers(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list peers: %v", err)
+	}
+	return types.EthUint64(len(peers)), nil
+}
+
+// EthNetListening returns true once the node is connected to a peer
+func (a *ethAPI) EthNetListening(ctx context.Context) (bool, error) {
+	count, err := a.EthNetPeerCount(ctx)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 func (a *ethAPI) EthProtocolVersion(ctx context.Context) (types.EthUint64, error) {
-	head, err := a.chain.ChainHead(ctx)
+	nv, err := a.chain.StateNetworkVersion(ctx, types.EmptyTSK)
 	if err != nil {
 		return types.EthUint64(0), err
 	}
 
-	return types.EthUint64(a.em.chainModule.Fork.GetNetworkVersion(ctx, head.Height())), nil
+	return ethProtocolVersion(nv, a.em.cfg.FevmConfig.ProtocolVersionMap), nil
+}
+
+// ethProtocolVersion looks the network version up in the configured table, the network version
+// is used as the protocol version when the table has no entry for it
+func ethProtocolVersion(nv network.Version, versionMap map[network.Version]uint64) types.EthUint64 {
+	if v, ok := versionMap[nv]; ok {
+		return types.EthUint64(v)
+	}
+	return types.EthUint64(nv)
 }
 
 func (a *ethAPI) EthMaxPriorityFeePerGas(ctx context.Context) (types.EthBigInt, error) {
@@ -785,6 +859,7 @@ func (a *ethAPI) EthSendRawTransaction(ctx context.Context, rawTx types.EthBytes
 
 	_, err = a.mpool.MpoolPush(ctx, smsg)
 	if err != nil {
+		reqid.Logger(ctx, log).Debugw("failed to push raw transaction", "from", smsg.Message.From, "nonce", smsg.Message.Nonce, "error", err)
 		return types.EmptyEthHash, err
 	}
 	return types.EthHashFromTxBytes(rawTx), nil
@@ -848,6 +923,45 @@ func (a *ethAPI) ethCallToFilecoinMessage(ctx context.Context, tx types.EthCall)
 	}, nil
 }
 
+// parseBlkNumberOrHash resolves the block param to a tipset, the param is either accepted by parseBlkParam
+// or the hash of a block
+func (a *ethAPI) parseBlkNumberOrHash(ctx context.Context, blkParam string) (*types.TipSet, error) {
+	blkHash, ok := parseBlkHashParam(blkParam)
+	if !ok {
+		return a.parseBlkParam(ctx, blkParam, false)
+	}
+	ts, err := a.em.chainModule.ChainReader.GetTipSetByCid(ctx, blkHash.ToCid())
+	if err != nil {
+		return nil, fmt.Errorf("cannot get tipset of block hash %s: %v", blkHash, err)
+	}
+	return ts, nil
+}
+
+// parseBlkHashParam tells apart a block hash from a block number, both are hex encoded
+func parseBlkHashParam(blkParam string) (types.EthHash, bool) {
+	if len(blkParam) != 2+2*types.EthHashLength {
+		return types.EthHash{}, false
+	}
+	blkHash, err := types.ParseEthHash(blkParam)
+	if err != nil {
+		return types.EthHash{}, false
+	}
+	return blkHash, true
+}
+
+// checkStateAvailable makes sure the state a call at the tipset executes on was not garbage collected
+func (a *ethAPI) checkStateAvailable(ctx context.Context, ts *types.TipSet) error {
+	has, err := a.em.chainModule.ChainReader.Blockstore().Has(ctx, ts.ParentState())
+	if err != nil {
+		return fmt.Errorf("failed to check the state root of epoch %d: %v", ts.Height(), err)
+	}
+	if !has {
+		return fmt.Errorf("%w: the state root %s of epoch %d was pruned, query a more recent block or a node keeping the full history",
+			ErrHistoricalStateNotAvailable, ts.ParentState(), ts.Height())
+	}
+	return nil
+}
+
 func (a *ethAPI) applyMessage(ctx context.Context, msg *types.Message, tsk types.TipSetKey) (*types.InvocResult, error) {
 	ts, err := a.chain.ChainGetTipSet(ctx, tsk)
 	if err != nil {
@@ -893,7 +1007,7 @@ func (a *ethAPI) EthEstimateGas(ctx context.Context, tx types.EthCall) (types.Et
 	if err != nil {
 		return types.EthUint64(0), err
 	}
-	gassedMsg, err := a.mpool.GasEstimateMessageGas(ctx, msg, nil, ts.Key())
+	gassedMsg, err := a.em.mpoolModule.MPool.GasEstimateMessageGas(ctx, &types.EstimateMessage{Msg: msg, MessageType: types.MessageTypeEVM}, ts.Key())
 	if err != nil {
 		return types.EthUint64(0), fmt.Errorf("failed to estimate gas: %w", err)
 	}
@@ -1029,7 +1143,7 @@ func ethGasSearch(
 			return -1, fmt.Errorf("gas estimation search failed: %w", err)
 		}
 
-		ret = int64(float64(ret) * mpool.GetConfig().GasLimitOverestimation)
+		ret = int64(float64(ret) * mpool.GetConfig().EVMGasOverestimation)
 		return ret, nil
 	}
 
@@ -1041,10 +1155,13 @@ func (a *ethAPI) EthCall(ctx context.Context, tx types.EthCall, blkParam string)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert ethcall to filecoin message: %w", err)
 	}
-	ts, err := a.parseBlkParam(ctx, blkParam, false)
+	ts, err := a.parseBlkNumberOrHash(ctx, blkParam)
 	if err != nil {
 		return nil, fmt.Errorf("cannot parse block param: %s", blkParam)
 	}
+	if err := a.checkStateAvailable(ctx, ts); err != nil {
+		return nil, err
+	}
 
 	invokeResult, err := a.applyMessage(ctx, msg, ts.Key())
 	if err != nil {
@@ -1065,6 +1182,104 @@ func (a *ethAPI) Web3ClientVersion(ctx context.Context) (string, error) {
 	return constants.UserVersion(), nil
 }
 
+// EthDebugGetBadBlocks always returns an empty list, blocks failing validation are not kept
+func (a *ethAPI) EthDebugGetBadBlocks(ctx context.Context) ([]types.EthBlock, error) {
+	return []types.EthBlock{}, nil
+}
+
+// EthGetUncleByBlockHashAndIndex returns null like Ethereum does for a block without uncles,
+// tipsets take the place of uncles in Filecoin
+func (a *ethAPI) EthGetUncleByBlockHashAndIndex(ctx context.Context, blkHash types.EthHash, index types.EthUint64) (*types.EthBlock, error) {
+	return nil, nil
+}
+
+// EthGetUncleCountByBlockHash returns 0, tipsets take the place of uncles in Filecoin
+func (a *ethAPI) EthGetUncleCountByBlockHash(ctx context.Context, blkHash types.EthHash) (types.EthUint64, error) {
+	return 0, nil
+}
+
+// EthTxpoolContent returns the eth transactions of the message pool by sender and nonce,
+// the transactions following a nonce gap are reported as queued
+func (a *ethAPI) EthTxpoolContent(ctx context.Context) (*types.EthTxpoolContentResult, error) {
+	pending, err := a.mpool.MpoolPending(ctx, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get pending txs from mpool: %v", err)
+	}
+
+	bySender := make(map[address.Address][]*types.SignedMessage)
+	for _, smsg := range pending {
+		if smsg.Signature.Type != crypto.SigTypeDelegated {
+			continue
+		}
+		bySender[smsg.Message.From] = append(bySender[smsg.Message.From], smsg)
+	}
+
+	head := a.em.chainModule.ChainReader.GetHead()
+	res := &types.EthTxpoolContentResult{
+		Pending: make(map[types.EthAddress]map[string]*types.EthTx),
+		Queued:  make(map[types.EthAddress]map[string]*types.EthTx),
+	}
+	for from, msgs := range bySender {
+		var stateNonce uint64
+		act, err := a.em.chainModule.Stmgr.GetActorAt(ctx, from, head)
+		if err == nil {
+			stateNonce = act.Nonce
+		} else if !errors.Is(err, types.ErrActorNotFound) {
+			return nil, fmt.Errorf("failed to load actor %s: %v", from, err)
+		}
+
+		ethFrom, err := lookupEthAddress(ctx, from, a.chain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve Ethereum address of %s: %v", from, err)
+		}
+
+		executable, queued := splitTxpoolMessages(stateNonce, msgs)
+		if len(executable) > 0 {
+			if res.Pending[ethFrom], err = a.newEthTxsByNonce(ctx, executable); err != nil {
+				return nil, err
+			}
+		}
+		if len(queued) > 0 {
+			if res.Queued[ethFrom], err = a.newEthTxsByNonce(ctx, queued); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (a *ethAPI) newEthTxsByNonce(ctx context.Context, msgs []*types.SignedMessage) (map[string]*types.EthTx, error) {
+	txs := make(map[string]*types.EthTx, len(msgs))
+	for _, smsg := range msgs {
+		tx, err := newEthTxFromSignedMessage(ctx, smsg, a.chain)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert Filecoin message into tx: %v", err)
+		}
+		txs[strconv.FormatUint(smsg.Message.Nonce, 10)] = &tx
+	}
+	return txs, nil
+}
+
+// splitTxpoolMessages sorts the messages of a sender by nonce, the messages continuing the state nonce
+// can be executed, the ones after the first nonce gap are queued
+func splitTxpoolMessages(stateNonce uint64, msgs []*types.SignedMessage) ([]*types.SignedMessage, []*types.SignedMessage) {
+	sorted := make([]*types.SignedMessage, len(msgs))
+	copy(sorted, msgs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Message.Nonce < sorted[j].Message.Nonce
+	})
+
+	next := stateNonce
+	for i, smsg := range sorted {
+		if smsg.Message.Nonce != next {
+			return sorted[:i], sorted[i:]
+		}
+		next++
+	}
+	return sorted, nil
+}
+
 func newEthBlockFromFilecoinTipSet(ctx context.Context, ts *types.TipSet, fullTxInfo bool, ms *chain.MessageStore, ca v1.IChain) (types.EthBlock, error) {
 	parentKeyCid, err := ts.Parents().Cid()
 	if err != nil {
@@ -1086,44 +1301,15 @@ func newEthBlockFromFilecoinTipSet(ctx context.Context, ts *types.TipSet, fullTx
 		return types.EthBlock{}, err
 	}
 
-	msgs, err := ms.MessagesForTipset(ts)
+	txs, traces, err := newEthTxsFromTipSet(ctx, ts, ms, ca)
 	if err != nil {
-		return types.EthBlock{}, fmt.Errorf("error loading messages for tipset: %v: %w", ts, err)
+		return types.EthBlock{}, err
 	}
 
-	block := types.NewEthBlock(len(msgs) > 0)
-
+	block := types.NewEthBlock(len(txs) > 0)
 	gasUsed := int64(0)
-	compOutput, err := ca.StateCompute(ctx, ts.Height(), nil, ts.Key())
-	if err != nil {
-		return types.EthBlock{}, fmt.Errorf("failed to compute state: %w", err)
-	}
-
-	txIdx := 0
-	for _, msg := range compOutput.Trace {
-		// skip system messages like reward application and cron
-		if msg.Msg.From == builtintypes.SystemActorAddr {
-			continue
-		}
-
-		ti := types.EthUint64(txIdx)
-		txIdx++
-
-		gasUsed += msg.MsgRct.GasUsed
-		smsgCid, err := getSignedMessage(ctx, ms, msg.MsgCid)
-		if err != nil {
-			return types.EthBlock{}, fmt.Errorf("failed to get signed msg %s: %w", msg.MsgCid, err)
-		}
-		tx, err := newEthTxFromSignedMessage(ctx, smsgCid, ca)
-		if err != nil {
-			return types.EthBlock{}, fmt.Errorf("failed to convert msg to ethTx: %w", err)
-		}
-
-		tx.ChainID = types.EthUint64(types2.Eip155ChainID)
-		tx.BlockHash = &blkHash
-		tx.BlockNumber = &bn
-		tx.TransactionIndex = &ti
-
+	for i, tx := range txs {
+		gasUsed += traces[i].MsgRct.GasUsed
 		if fullTxInfo {
 			block.Transactions = append(block.Transactions, tx)
 		} else {
@@ -1244,6 +1430,65 @@ func newEthTxFromSignedMessage(ctx context.Context, smsg *types.SignedMessage, c
 	return tx, nil
 }
 
+// newEthTxsFromTipSet computes the tipset and returns its transactions in execution order, skipping the
+// system messages, along with the traces of the messages they come from
+func newEthTxsFromTipSet(ctx context.Context, ts *types.TipSet, ms *chain.MessageStore, ca v1.IChain) ([]types.EthTx, []*types.InvocResult, error) {
+	blkCid, err := ts.Key().Cid()
+	if err != nil {
+		return nil, nil, err
+	}
+	blkHash, err := types.EthHashFromCid(blkCid)
+	if err != nil {
+		return nil, nil, err
+	}
+	bn := types.EthUint64(ts.Height())
+
+	compOutput, err := ca.StateCompute(ctx, ts.Height(), nil, ts.Key())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compute state: %w", err)
+	}
+
+	txs := make([]types.EthTx, 0, len(compOutput.Trace))
+	traces := make([]*types.InvocResult, 0, len(compOutput.Trace))
+	for _, msg := range compOutput.Trace {
+		// skip system messages like reward application and cron
+		if msg.Msg.From == builtintypes.SystemActorAddr {
+			continue
+		}
+
+		smsg, err := getSignedMessage(ctx, ms, msg.MsgCid)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get signed msg %s: %w", msg.MsgCid, err)
+		}
+		tx, err := newEthTxFromSignedMessage(ctx, smsg, ca)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert msg to ethTx: %w", err)
+		}
+
+		ti := types.EthUint64(len(txs))
+		tx.ChainID = ethChainID()
+		tx.BlockHash = &blkHash
+		tx.BlockNumber = &bn
+		tx.TransactionIndex = &ti
+		txs = append(txs, tx)
+		traces = append(traces, msg)
+	}
+	return txs, traces, nil
+}
+
+// newEthTxFromTipSetIndex returns the transaction at the index of the transactions of the block built by
+// newEthBlockFromFilecoinTipSet. It returns nil when the index is out of bounds.
+func newEthTxFromTipSetIndex(ctx context.Context, ts *types.TipSet, txIndex types.EthUint64, ms *chain.MessageStore, ca v1.IChain) (*types.EthTx, error) {
+	txs, _, err := newEthTxsFromTipSet(ctx, ts, ms, ca)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(txIndex) >= uint64(len(txs)) {
+		return nil, nil
+	}
+	return &txs[txIndex], nil
+}
+
 // ethTxFromNativeMessage does NOT populate:
 // - BlockHash
 // - BlockNumber
@@ -1257,7 +1502,7 @@ func ethTxFromNativeMessage(ctx context.Context, msg *types.Message, ca v1.IChai
 		To:                   &to,
 		From:                 from,
 		Nonce:                types.EthUint64(msg.Nonce),
-		ChainID:              types.EthUint64(types2.Eip155ChainID),
+		ChainID:              ethChainID(),
 		Value:                types.EthBigInt(msg.Value),
 		Type:                 types.Eip1559TxType,
 		Gas:                  types.EthUint64(msg.GasLimit),
@@ -1328,7 +1573,7 @@ func newEthTxFromMessageLookup(ctx context.Context, msgLookup *types.MsgLookup,
 		ti = types.EthUint64(txIdx)
 	)
 
-	tx.ChainID = types.EthUint64(types2.Eip155ChainID)
+	tx.ChainID = ethChainID()
 	tx.BlockHash = &blkHash
 	tx.BlockNumber = &bn
 	tx.TransactionIndex = &ti
@@ -1430,6 +1675,41 @@ func newEthTxReceipt(ctx context.Context, tx types.EthTx, lookup *types.MsgLooku
 	return receipt, nil
 }
 
+// newEthTxReceiptsFromFilecoinTipSet computes the tipset once and builds the receipts of its transactions,
+// in the order of the transactions of the block built by newEthBlockFromFilecoinTipSet
+func newEthTxReceiptsFromFilecoinTipSet(ctx context.Context, ts *types.TipSet, ms *chain.MessageStore, ca v1.IChain) ([]*types.EthTxReceipt, error) {
+	txs, traces, err := newEthTxsFromTipSet(ctx, ts, ms, ca)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := make([]*types.EthTxReceipt, 0, len(txs))
+	for i, tx := range txs {
+		msg := traces[i]
+		var events []types.Event
+		if rct := msg.MsgRct; rct.EventsRoot != nil {
+			events, err = ca.ChainGetEvents(ctx, *rct.EventsRoot)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load events of msg %s: %w", msg.MsgCid, err)
+			}
+		}
+
+		lookup := &types.MsgLookup{
+			Message: msg.MsgCid,
+			Receipt: *msg.MsgRct,
+			TipSet:  ts.Key(),
+			Height:  ts.Height(),
+		}
+		receipt, err := newEthTxReceipt(ctx, tx, lookup, msg, events, ca)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the receipt of msg %s: %w", msg.MsgCid, err)
+		}
+		receipts = append(receipts, &receipt)
+	}
+
+	return receipts, nil
+}
+
 type ethTxHashManager struct {
 	chainAPI              v1.IChain
 	messageStore          *chain.MessageStore
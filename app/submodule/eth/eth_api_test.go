@@ -0,0 +1,285 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+
+	chain2 "github.com/filecoin-project/venus/app/submodule/chain"
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/ethhashlookup"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	v1 "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// fakeEthChain serves the tipsets of a builder. Its StateCompute traces a cron message first and
+// the messages of the tipset, in reverse order when reversed is set.
+type fakeEthChain struct {
+	v1.IChain
+	builder  *chain.Builder
+	head     *types.TipSet
+	reversed bool
+}
+
+func (c *fakeEthChain) ChainHead(context.Context) (*types.TipSet, error) {
+	return c.head, nil
+}
+
+func (c *fakeEthChain) ChainGetTipSet(ctx context.Context, tsk types.TipSetKey) (*types.TipSet, error) {
+	return c.builder.Store().GetTipSet(ctx, tsk)
+}
+
+func (c *fakeEthChain) ChainGetTipSetByHeight(ctx context.Context, h abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error) {
+	ts, err := c.builder.Store().GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, err
+	}
+	return c.builder.Store().GetTipSetByHeight(ctx, ts, h, true)
+}
+
+func (c *fakeEthChain) StateCompute(ctx context.Context, _ abi.ChainEpoch, _ []*types.Message, tsk types.TipSetKey) (*types.ComputeStateOutput, error) {
+	ts, err := c.builder.Store().GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := c.builder.MessageStore().MessagesForTipset(ts)
+	if err != nil {
+		return nil, err
+	}
+	if c.reversed {
+		for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+			msgs[i], msgs[j] = msgs[j], msgs[i]
+		}
+	}
+
+	cron := &types.Message{From: builtintypes.SystemActorAddr, To: builtintypes.CronActorAddr}
+	out := &types.ComputeStateOutput{Root: ts.ParentState()}
+	out.Trace = append(out.Trace, &types.InvocResult{MsgCid: cron.Cid(), Msg: cron, MsgRct: &types.MessageReceipt{}})
+	for i, msg := range msgs {
+		gasUsed := int64(100 * (i + 1))
+		out.Trace = append(out.Trace, &types.InvocResult{
+			MsgCid:  msg.Cid(),
+			Msg:     msg.VMMessage(),
+			MsgRct:  &types.MessageReceipt{ExitCode: exitcode.ExitCode(i % 2), GasUsed: gasUsed},
+			GasCost: types.MsgGasCost{Message: msg.Cid(), TotalCost: big.NewInt(3 * gasUsed)},
+		})
+	}
+	return out, nil
+}
+
+// trace finds the tipset executing the message and its trace.
+func (c *fakeEthChain) trace(ctx context.Context, msg cid.Cid) (*types.TipSet, *types.InvocResult, error) {
+	for child := c.head; child.Height() > 0; {
+		parent, err := c.ChainGetTipSet(ctx, child.Parents())
+		if err != nil {
+			return nil, nil, err
+		}
+		out, err := c.StateCompute(ctx, parent.Height(), nil, parent.Key())
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, res := range out.Trace {
+			if res.MsgCid == msg {
+				return child, res, nil
+			}
+		}
+		child = parent
+	}
+	return nil, nil, fmt.Errorf("message %s not found", msg)
+}
+
+func (c *fakeEthChain) StateSearchMsg(ctx context.Context, _ types.TipSetKey, msg cid.Cid, _ abi.ChainEpoch, _ bool) (*types.MsgLookup, error) {
+	ts, res, err := c.trace(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	return &types.MsgLookup{Message: msg, Receipt: *res.MsgRct, TipSet: ts.Key(), Height: ts.Height()}, nil
+}
+
+func (c *fakeEthChain) StateReplay(ctx context.Context, _ types.TipSetKey, msg cid.Cid) (*types.InvocResult, error) {
+	_, res, err := c.trace(ctx, msg)
+	return res, err
+}
+
+func (c *fakeEthChain) StateGetActor(context.Context, address.Address, types.TipSetKey) (*types.Actor, error) {
+	return nil, types.ErrActorNotFound
+}
+
+// keepStateBuilder keeps the state of the genesis, which MessagesForTipset can load
+type keepStateBuilder struct {
+	chain.FakeStateBuilder
+}
+
+func (keepStateBuilder) ComputeState(prev cid.Cid, _ []types.BlockMessagesInfo) (cid.Cid, []types.MessageReceipt, error) {
+	return prev, nil, nil
+}
+
+func newTestEthAPI(t *testing.T) (*ethAPI, *chain.Builder) {
+	builder := chain.NewBuilderWithDeps(t, address.Undef, &keepStateBuilder{}, &chain.ZeroTimestamper{})
+	txHashLookup, err := ethhashlookup.NewTransactionHashLookup(filepath.Join(t.TempDir(), "txhash.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, txHashLookup.Close()) })
+
+	return &ethAPI{
+		em: &EthSubModule{chainModule: &chain2.ChainSubmodule{
+			ChainReader:  builder.Store(),
+			MessageStore: builder.MessageStore(),
+		}},
+		chain:            &fakeEthChain{builder: builder, reversed: true},
+		ethTxHashManager: &ethTxHashManager{TransactionHashLookup: txHashLookup},
+	}, builder
+}
+
+// buildTipSetWithMessages appends a tipset holding secp and bls messages on parent, after nullRounds null rounds.
+func buildTipSetWithMessages(ctx context.Context, t *testing.T, builder *chain.Builder, parent *types.TipSet, nullRounds abi.ChainEpoch) *types.TipSet {
+	newAddr := testhelpers.NewForTestGetter()
+	from, to := newAddr(), newAddr()
+	var secpMsgs []*types.SignedMessage
+	var blsMsgs []*types.Message
+	for i := uint64(0); i < 2; i++ {
+		secpMsgs = append(secpMsgs, &types.SignedMessage{
+			Message:   types.Message{From: from, To: to, Nonce: i, Value: abi.NewTokenAmount(int64(parent.Height()) + 1)},
+			Signature: crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: []byte("signature")},
+		})
+		blsMsgs = append(blsMsgs, &types.Message{From: to, To: from, Nonce: i, Value: abi.NewTokenAmount(int64(parent.Height()) + 2)})
+	}
+
+	ts := builder.BuildOneOn(ctx, parent, func(b *chain.BlockBuilder) {
+		b.IncHeight(nullRounds)
+		b.AddMessages(secpMsgs, blsMsgs)
+	})
+	builder.Store().PersistTipSetKey(ctx, ts.Key())
+	return ts
+}
+
+func ethHashOfTipSet(t *testing.T, ts *types.TipSet) types.EthHash {
+	c, err := ts.Key().Cid()
+	require.NoError(t, err)
+	hash, err := types.EthHashFromCid(c)
+	require.NoError(t, err)
+	return hash
+}
+
+func TestEthGetTransactionByBlockHashAndIndex(t *testing.T) {
+	ctx := context.Background()
+	api, builder := newTestEthAPI(t)
+	ts := buildTipSetWithMessages(ctx, t, builder, builder.Genesis(), 0)
+	blkHash := ethHashOfTipSet(t, ts)
+
+	block, err := api.EthGetBlockByHash(ctx, blkHash, true)
+	require.NoError(t, err)
+	require.Len(t, block.Transactions, 4)
+
+	// the transactions are indexed as in the block, in execution order without the cron message
+	compOutput, err := api.chain.StateCompute(ctx, ts.Height(), nil, ts.Key())
+	require.NoError(t, err)
+	for i, blockTx := range block.Transactions {
+		tx, err := api.EthGetTransactionByBlockHashAndIndex(ctx, blkHash, types.EthUint64(i))
+		require.NoError(t, err)
+		require.NotNil(t, tx)
+		require.Equal(t, blockTx, *tx)
+		require.Equal(t, types.EthUint64(i), *tx.TransactionIndex)
+
+		hash, err := types.EthHashFromCid(compOutput.Trace[i+1].MsgCid)
+		require.NoError(t, err)
+		require.Equal(t, hash, tx.Hash)
+	}
+
+	tx, err := api.EthGetTransactionByBlockHashAndIndex(ctx, blkHash, types.EthUint64(len(block.Transactions)))
+	require.NoError(t, err)
+	require.Nil(t, tx)
+
+	unknown := ethHashOfTipSet(t, builder.Genesis())
+	unknown[0]++
+	_, err = api.EthGetTransactionByBlockHashAndIndex(ctx, unknown, 0)
+	require.ErrorContains(t, err, unknown.String())
+}
+
+func TestEthGetTransactionByBlockNumberAndIndex(t *testing.T) {
+	ctx := context.Background()
+	api, builder := newTestEthAPI(t)
+	// 1 and 3 hold messages, 2 is a null round and 4 is the head
+	ts1 := buildTipSetWithMessages(ctx, t, builder, builder.Genesis(), 0)
+	ts3 := buildTipSetWithMessages(ctx, t, builder, ts1, 1)
+	require.Equal(t, abi.ChainEpoch(3), ts3.Height())
+	api.chain.(*fakeEthChain).head = builder.AppendOn(ctx, ts3, 1)
+
+	for _, ts := range []*types.TipSet{ts1, ts3} {
+		blkParam := types.EthUint64(ts.Height()).Hex()
+		block, err := api.EthGetBlockByNumber(ctx, blkParam, true)
+		require.NoError(t, err)
+		require.Len(t, block.Transactions, 4)
+
+		for i, blockTx := range block.Transactions {
+			tx, err := api.EthGetTransactionByBlockNumberAndIndex(ctx, blkParam, types.EthUint64(i))
+			require.NoError(t, err)
+			require.NotNil(t, tx)
+			require.Equal(t, blockTx, *tx)
+			require.Equal(t, ethHashOfTipSet(t, ts), *tx.BlockHash)
+		}
+
+		tx, err := api.EthGetTransactionByBlockNumberAndIndex(ctx, blkParam, types.EthUint64(len(block.Transactions)))
+		require.NoError(t, err)
+		require.Nil(t, tx)
+	}
+
+	// there is no block at a null round
+	tx, err := api.EthGetTransactionByBlockNumberAndIndex(ctx, types.EthUint64(2).Hex(), 0)
+	require.NoError(t, err)
+	require.Nil(t, tx)
+
+	// the transactions of different blocks differ
+	tx1, err := api.EthGetTransactionByBlockNumberAndIndex(ctx, types.EthUint64(1).Hex(), 0)
+	require.NoError(t, err)
+	tx3, err := api.EthGetTransactionByBlockNumberAndIndex(ctx, "latest", 0)
+	require.NoError(t, err)
+	require.NotEqual(t, tx1.Hash, tx3.Hash)
+	require.Equal(t, types.EthUint64(3), *tx3.BlockNumber)
+
+	_, err = api.EthGetTransactionByBlockNumberAndIndex(ctx, types.EthUint64(4).Hex(), 0)
+	require.Error(t, err)
+}
+
+func TestEthGetBlockReceipts(t *testing.T) {
+	ctx := context.Background()
+	api, builder := newTestEthAPI(t)
+	// the receipts of a single transaction index the messages as MessagesForTipset does
+	api.chain.(*fakeEthChain).reversed = false
+	ts1 := buildTipSetWithMessages(ctx, t, builder, builder.Genesis(), 0)
+	api.chain.(*fakeEthChain).head = builder.AppendOn(ctx, ts1, 1)
+
+	blkHash := ethHashOfTipSet(t, ts1)
+	for _, blkParam := range []string{blkHash.String(), types.EthUint64(1).Hex(), "latest"} {
+		receipts, err := api.EthGetBlockReceipts(ctx, blkParam)
+		require.NoError(t, err)
+
+		block, err := api.EthGetBlockByHash(ctx, blkHash, false)
+		require.NoError(t, err)
+		require.Len(t, receipts, len(block.Transactions))
+
+		for i, receipt := range receipts {
+			require.Equal(t, block.Transactions[i], receipt.TransactionHash.String())
+			expect, err := api.EthGetTransactionReceipt(ctx, receipt.TransactionHash)
+			require.NoError(t, err)
+			require.NotNil(t, expect)
+			require.Equal(t, *expect, *receipt)
+		}
+		// both successful and failed transactions are included
+		require.Equal(t, types.EthUint64(1), receipts[0].Status)
+		require.Equal(t, types.EthUint64(0), receipts[1].Status)
+	}
+
+	_, err := api.EthGetBlockReceipts(ctx, "0xinvalid")
+	require.ErrorContains(t, err, "cannot parse block number")
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/filecoin-project/go-address"
@@ -48,6 +49,7 @@ func newEthEventAPI(ctx context.Context, em *EthSubModule) (*ethEventAPI, error)
 	ee.SubManager = &EthSubscriptionManager{
 		ChainAPI:     chainAPI,
 		messageStore: ee.em.chainModule.MessageStore,
+		sendTimeout:  time.Duration(cfg.WSKeepaliveTimeout),
 	}
 	ee.FilterStore = filter.NewMemFilterStore(cfg.Event.MaxFilters)
 
@@ -69,8 +71,10 @@ func newEthEventAPI(ctx context.Context, em *EthSubModule) (*ethEventAPI, error)
 	}
 
 	ee.EventFilterManager = &filter.EventFilterManager{
-		ChainStore: bsstore,
-		EventIndex: eventIndex, // will be nil unless EnableHistoricFilterAPI is true
+		MessageStore:  em.chainModule.MessageStore,
+		ChainStore:    bsstore,
+		EventIndex:    eventIndex,               // will be nil unless EnableHistoricFilterAPI is true
+		FVMEventStore: em.chainModule.FVMEvents, // will be nil unless EnableFVMEventStore is true
 		AddressResolver: func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) (address.Address, bool) {
 			// we only want to match using f4 addresses
 			idAddr, err := address.NewIDAddress(uint64(emitter))
@@ -93,6 +97,9 @@ func newEthEventAPI(ctx context.Context, em *EthSubModule) (*ethEventAPI, error)
 			}
 			return *actor.Address, true
 		},
+		// native events are matched by the f4 address of their emitter when it has one
+		// and by its id address otherwise
+		NativeAddressResolver: em.chainModule.ResolveEventEmitter,
 
 		MaxFilterResults: cfg.Event.MaxFilterResults,
 	}
@@ -437,7 +444,9 @@ func (e *ethEventAPI) EthSubscribe(ctx context.Context, p jsonrpc.RawParams) (ty
 		return types.EthSubscriptionID{}, fmt.Errorf("connection doesn't support callbacks")
 	}
 
-	sub, err := e.SubManager.StartSubscription(e.SubscribtionCtx, ethCb.EthSubscription)
+	sub, err := e.SubManager.StartSubscription(e.SubscribtionCtx, ethCb.EthSubscription, func(id types.EthSubscriptionID) {
+		_, _ = e.EthUnsubscribe(e.SubscribtionCtx, id)
+	})
 	if err != nil {
 		return types.EthSubscriptionID{}, err
 	}
@@ -519,6 +528,157 @@ func (e *ethEventAPI) EthUnsubscribe(ctx context.Context, id types.EthSubscripti
 	return true, nil
 }
 
+func (e *ethEventAPI) GetActorEventsRaw(ctx context.Context, evtFilter *types.ActorEventFilter) (<-chan *types.ActorEvent, error) {
+	if e.EventFilterManager == nil {
+		return nil, api.ErrNotSupported
+	}
+	if evtFilter == nil {
+		return nil, fmt.Errorf("missing actor event filter")
+	}
+
+	head, err := e.ChainAPI.ChainHead(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to got head %v", err)
+	}
+	if evtFilter.FromEpoch >= 0 && evtFilter.ToEpoch >= 0 && evtFilter.FromEpoch > evtFilter.ToEpoch {
+		return nil, fmt.Errorf("invalid epoch range: to epoch (%d) must be after from epoch (%d)", evtFilter.ToEpoch, evtFilter.FromEpoch)
+	}
+	if evtFilter.FromEpoch >= 0 && head.Height()-evtFilter.FromEpoch > e.MaxFilterHeightRange {
+		return nil, fmt.Errorf("invalid epoch range: from epoch is too far in the past (maximum: %d)", e.MaxFilterHeightRange)
+	}
+
+	// topics are laid out like the topics of eth logs
+	keys := map[string][][]byte{}
+	for idx, topic := range evtFilter.Topics {
+		if topic == nil {
+			continue
+		}
+		key := fmt.Sprintf("t%d", idx+1)
+		keys[key] = append(keys[key], topic)
+	}
+
+	f, err := e.EventFilterManager.InstallNative(ctx, evtFilter.FromEpoch, evtFilter.ToEpoch, evtFilter.Addresses, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	// nothing is left to follow once the range is behind the head
+	follow := evtFilter.ToEpoch < 0 || evtFilter.ToEpoch >= head.Height()
+
+	in := make(chan interface{}, 256)
+	var historic []*filter.CollectedEvent
+	if follow {
+		// events collected after the prefill are taken together with setting the channel so none is lost
+		historic = f.Subscribe(in)
+	} else {
+		historic = f.TakeCollectedEvents(ctx)
+	}
+
+	out := make(chan *types.ActorEvent, 256)
+	go func() {
+		defer func() {
+			if err := e.EventFilterManager.Remove(context.Background(), f.ID()); err != nil && !errors.Is(err, filter.ErrFilterNotFound) {
+				log.Warnf("failed to remove actor event filter: %v", err)
+			}
+			// the filter may be blocked pushing an event while holding its lock, keep draining
+			// until the channel is detached
+			cleared := make(chan struct{})
+			go func() {
+				f.ClearSubChannel()
+				close(cleared)
+			}()
+			for {
+				select {
+				case <-in:
+				case <-cleared:
+					close(out)
+					return
+				}
+			}
+		}()
+
+		for _, ev := range historic {
+			select {
+			case out <- actorEventFromCollected(ev):
+			case <-ctx.Done():
+				return
+			}
+		}
+		if !follow {
+			return
+		}
+
+		for {
+			select {
+			case v := <-in:
+				ev, ok := v.(*filter.CollectedEvent)
+				if !ok {
+					log.Warnf("unexpected actor event value type: %T", v)
+					continue
+				}
+				select {
+				case out <- actorEventFromCollected(ev):
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (e *ethEventAPI) GetFVMEvents(ctx context.Context, evtFilter *types.ActorEventFilter) ([]*types.ActorEvent, error) {
+	// the store is kept by the chain module, it does not depend on the eth event filters
+	store := e.em.chainModule.FVMEvents
+	if store == nil {
+		return nil, api.ErrNotSupported
+	}
+	if evtFilter == nil {
+		return nil, fmt.Errorf("missing actor event filter")
+	}
+
+	if evtFilter.FromEpoch >= 0 && evtFilter.ToEpoch >= 0 && evtFilter.FromEpoch > evtFilter.ToEpoch {
+		return nil, fmt.Errorf("invalid epoch range: to epoch (%d) must be after from epoch (%d)", evtFilter.ToEpoch, evtFilter.FromEpoch)
+	}
+
+	head, err := e.ChainAPI.ChainHead(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to got head %v", err)
+	}
+
+	// the store only keeps the events of the last MaxFilterHeightRange epochs, an open from epoch
+	// starts there
+	f := *evtFilter
+	oldest := head.Height() - e.MaxFilterHeightRange
+	if oldest < 0 {
+		oldest = 0
+	}
+	if f.FromEpoch < 0 {
+		f.FromEpoch = oldest
+	} else if f.FromEpoch < oldest {
+		return nil, fmt.Errorf("invalid epoch range: from epoch is too far in the past (maximum: %d)", e.MaxFilterHeightRange)
+	}
+	if f.ToEpoch >= 0 && f.ToEpoch < f.FromEpoch {
+		return nil, fmt.Errorf("invalid epoch range: to epoch (%d) is older than the oldest stored epoch (%d)", f.ToEpoch, f.FromEpoch)
+	}
+
+	return store.GetEvents(ctx, &f)
+}
+
+func actorEventFromCollected(ev *filter.CollectedEvent) *types.ActorEvent {
+	return &types.ActorEvent{
+		Entries:   ev.Entries,
+		Emitter:   ev.EmitterAddr,
+		Reverted:  ev.Reverted,
+		Height:    ev.Height,
+		TipSetKey: ev.TipSetKey,
+		MsgCid:    ev.MsgCid,
+	}
+}
+
 // GC runs a garbage collection loop, deleting filters that have not been used within the ttl window
 func (e *ethEventAPI) GC(ctx context.Context, ttl time.Duration) {
 	if e.FilterStore == nil {
@@ -698,11 +858,14 @@ func ethFilterResultFromMessages(cs []*types.SignedMessage, ca v1.IChain) (*type
 type EthSubscriptionManager struct { // nolint
 	ChainAPI     v1.IChain
 	messageStore *chain.MessageStore
+	sendTimeout  time.Duration
 	mu           sync.Mutex
 	subs         map[types.EthSubscriptionID]*ethSubscription
 }
 
-func (e *EthSubscriptionManager) StartSubscription(ctx context.Context, out ethSubscriptionCallback) (*ethSubscription, error) { // nolint
+// StartSubscription starts a subscription delivering notifications through out, drop is called
+// when the subscriber stops accepting notifications and the subscription should be torn down
+func (e *EthSubscriptionManager) StartSubscription(ctx context.Context, out ethSubscriptionCallback, drop func(types.EthSubscriptionID)) (*ethSubscription, error) { // nolint
 	rawid, err := uuid.NewRandom()
 	if err != nil {
 		return nil, fmt.Errorf("new uuid: %w", err)
@@ -718,7 +881,10 @@ func (e *EthSubscriptionManager) StartSubscription(ctx context.Context, out ethS
 		id:           id,
 		in:           make(chan interface{}, 200),
 		out:          out,
+		drop:         drop,
+		sendTimeout:  e.sendTimeout,
 		quit:         quit,
+		released:     make(chan struct{}),
 	}
 
 	e.mu.Lock()
@@ -742,6 +908,11 @@ func (e *EthSubscriptionManager) StopSubscription(ctx context.Context, id types.
 		return nil, fmt.Errorf("subscription not found")
 	}
 	sub.stop()
+	// the filters may be blocked delivering to the subscription, which is drained until they are detached
+	for _, f := range sub.filters {
+		f.ClearSubChannel()
+	}
+	sub.release()
 	delete(e.subs, id)
 
 	return sub.filters, nil
@@ -755,10 +926,16 @@ type ethSubscription struct {
 	id           types.EthSubscriptionID
 	in           chan interface{}
 	out          ethSubscriptionCallback
+	drop         func(types.EthSubscriptionID)
+	sendTimeout  time.Duration
 
-	mu      sync.Mutex
-	filters []filter.Filter
-	quit    func()
+	// dropping is set once the subscriber stalled and the subscription is being dropped
+	dropping int32
+
+	mu       sync.Mutex
+	filters  []filter.Filter
+	quit     func()
+	released chan struct{}
 }
 
 func (e *ethSubscription) addFilter(ctx context.Context, f filter.Filter) {
@@ -770,6 +947,14 @@ func (e *ethSubscription) addFilter(ctx context.Context, f filter.Filter) {
 }
 
 func (e *ethSubscription) send(ctx context.Context, v interface{}) {
+	if ctx.Err() != nil {
+		// subscription was stopped
+		return
+	}
+	if atomic.LoadInt32(&e.dropping) == 1 {
+		return
+	}
+
 	resp := types.EthSubscriptionResponse{
 		SubscriptionID: e.id,
 		Result:         v,
@@ -781,7 +966,24 @@ func (e *ethSubscription) send(ctx context.Context, v interface{}) {
 		return
 	}
 
-	if err := e.out(ctx, outParam); err != nil {
+	sendCtx := ctx
+	if e.sendTimeout > 0 {
+		var cancel context.CancelFunc
+		sendCtx, cancel = context.WithTimeout(ctx, e.sendTimeout)
+		defer cancel()
+	}
+
+	if err := e.out(sendCtx, outParam); err != nil {
+		if ctx.Err() == nil && errors.Is(sendCtx.Err(), context.DeadlineExceeded) {
+			// nothing reads from a closed websocket connection, so a stalled send means the subscriber is gone
+			log.Warnw("subscriber did not respond within keepalive timeout, dropping subscription", "sub", e.id, "timeout", e.sendTimeout)
+			// dropping detaches the filters, which waits for the deliveries in flight, so it runs while
+			// this goroutine keeps draining e.in
+			if atomic.CompareAndSwapInt32(&e.dropping, 0, 1) && e.drop != nil {
+				go e.drop(e.id)
+			}
+			return
+		}
 		log.Warnw("sending subscription response", "sub", e.id, "error", err)
 		return
 	}
@@ -791,6 +993,7 @@ func (e *ethSubscription) start(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
+			e.drain()
 			return
 		case v := <-e.in:
 			switch vt := v.(type) {
@@ -826,6 +1029,29 @@ func (e *ethSubscription) start(ctx context.Context) {
 	}
 }
 
+// drain discards the values delivered by the filters until they are detached from the subscription
+func (e *ethSubscription) drain() {
+	for {
+		select {
+		case <-e.in:
+		case <-e.released:
+			return
+		}
+	}
+}
+
+// release stops draining the subscription once no filter delivers to it anymore
+func (e *ethSubscription) release() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	select {
+	case <-e.released:
+	default:
+		close(e.released)
+	}
+}
+
 func (e *ethSubscription) stop() {
 	e.mu.Lock()
 	defer e.mu.Unlock()
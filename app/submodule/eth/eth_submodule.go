@@ -6,6 +6,7 @@ import (
 
 	"github.com/filecoin-project/venus/app/submodule/chain"
 	"github.com/filecoin-project/venus/app/submodule/mpool"
+	"github.com/filecoin-project/venus/app/submodule/network"
 	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/constants"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
@@ -15,16 +16,18 @@ func NewEthSubModule(ctx context.Context,
 	cfg *config.Config,
 	chainModule *chain.ChainSubmodule,
 	mpoolModule *mpool.MessagePoolSubmodule,
+	networkModule *network.NetworkSubmodule,
 	sqlitePath string,
 ) (*EthSubModule, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	em := &EthSubModule{
-		cfg:         cfg,
-		chainModule: chainModule,
-		mpoolModule: mpoolModule,
-		sqlitePath:  sqlitePath,
-		ctx:         ctx,
-		cancel:      cancel,
+		cfg:           cfg,
+		chainModule:   chainModule,
+		mpoolModule:   mpoolModule,
+		networkModule: networkModule,
+		sqlitePath:    sqlitePath,
+		ctx:           ctx,
+		cancel:        cancel,
 	}
 	ee, err := newEthEventAPI(ctx, em)
 	if err != nil {
@@ -45,10 +48,11 @@ func NewEthSubModule(ctx context.Context,
 }
 
 type EthSubModule struct { // nolint
-	cfg         *config.Config
-	chainModule *chain.ChainSubmodule
-	mpoolModule *mpool.MessagePoolSubmodule
-	sqlitePath  string
+	cfg           *config.Config
+	chainModule   *chain.ChainSubmodule
+	mpoolModule   *mpool.MessagePoolSubmodule
+	networkModule *network.NetworkSubmodule
+	sqlitePath    string
 
 	ethEventAPI   *ethEventAPI
 	ethAPIAdapter ethAPIAdapter
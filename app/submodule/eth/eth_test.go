@@ -1,12 +1,20 @@
 package eth
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/filecoin-project/venus/pkg/events/filter"
 	"github.com/filecoin-project/venus/pkg/messagepool"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
@@ -163,3 +171,158 @@ func TestRewardPercentiles(t *testing.T) {
 		require.Equal(t, ans, rewards)
 	}
 }
+
+func TestEthSubscriptionDropOnKeepaliveTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	dropped := make(chan types.EthSubscriptionID, 1)
+	mgr := &EthSubscriptionManager{sendTimeout: 10 * time.Millisecond}
+	// a closed connection never accepts the notification
+	stalled := func(ctx context.Context, _ jsonrpc.RawParams) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	sub, err := mgr.StartSubscription(ctx, stalled, func(id types.EthSubscriptionID) {
+		dropped <- id
+	})
+	require.NoError(t, err)
+	defer sub.stop()
+
+	sub.send(ctx, "head")
+	select {
+	case id := <-dropped:
+		require.Equal(t, sub.id, id)
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not dropped")
+	}
+
+	// a subscriber that keeps up is never dropped
+	sub, err = mgr.StartSubscription(ctx, func(context.Context, jsonrpc.RawParams) error { return nil }, func(types.EthSubscriptionID) {
+		t.Fatal("unexpected drop")
+	})
+	require.NoError(t, err)
+	defer sub.stop()
+	sub.send(ctx, "head")
+}
+
+func TestEthSubscriptionDropStalledSubscriberWhileDelivering(t *testing.T) {
+	ctx := context.Background()
+
+	from, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	mgr := &EthSubscriptionManager{sendTimeout: 10 * time.Millisecond}
+	stalled := func(ctx context.Context, _ jsonrpc.RawParams) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	// like the filter managers, the delivery holds a lock that removing the filter takes
+	var managerLk sync.Mutex
+	dropped := make(chan struct{})
+	sub, err := mgr.StartSubscription(ctx, stalled, func(id types.EthSubscriptionID) {
+		_, _ = mgr.StopSubscription(ctx, id)
+		managerLk.Lock()
+		managerLk.Unlock() // nolint:staticcheck
+		close(dropped)
+	})
+	require.NoError(t, err)
+	f := &filter.MemPoolFilter{}
+	sub.addFilter(ctx, f)
+
+	delivered := make(chan struct{})
+	go func() {
+		managerLk.Lock()
+		defer managerLk.Unlock()
+		// more messages than the subscription buffers, the subscriber is dropped in the meantime
+		for i := 0; i < 2*cap(sub.in); i++ {
+			f.CollectMessage(ctx, &types.SignedMessage{
+				Message:   types.Message{From: from, To: from, Nonce: uint64(i)},
+				Signature: crypto.Signature{Type: crypto.SigTypeBLS},
+			})
+		}
+		close(delivered)
+	}()
+
+	for _, done := range []chan struct{}{delivered, dropped} {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("dropping the stalled subscription deadlocked with the delivery")
+		}
+	}
+}
+
+func TestChainIDCacheRevalidation(t *testing.T) {
+	ctx := context.Background()
+
+	var loads int
+	id := types.EthUint64(314)
+	load := func(context.Context) (types.EthUint64, error) {
+		loads++
+		return id, nil
+	}
+
+	var c chainIDCache
+	got, err := c.get(ctx, 1000, load)
+	require.NoError(t, err)
+	require.Equal(t, types.EthUint64(314), got)
+
+	// served from the cache within the revalidation window
+	id = 314159
+	got, err = c.get(ctx, 1000+chainIDRevalidateEpochs-1, load)
+	require.NoError(t, err)
+	require.Equal(t, types.EthUint64(314), got)
+	require.Equal(t, 1, loads)
+
+	// reloaded once the window has passed
+	got, err = c.get(ctx, 1000+chainIDRevalidateEpochs, load)
+	require.NoError(t, err)
+	require.Equal(t, types.EthUint64(314159), got)
+	require.Equal(t, 2, loads)
+
+	// and after the chain went back below the load epoch
+	_, err = c.get(ctx, 900, load)
+	require.NoError(t, err)
+	require.Equal(t, 3, loads)
+}
+
+func TestSplitTxpoolMessages(t *testing.T) {
+	msg := func(nonce uint64) *types.SignedMessage {
+		return &types.SignedMessage{Message: types.Message{Nonce: nonce}}
+	}
+	nonces := func(msgs []*types.SignedMessage) []uint64 {
+		out := make([]uint64, 0, len(msgs))
+		for _, m := range msgs {
+			out = append(out, m.Message.Nonce)
+		}
+		return out
+	}
+
+	executable, queued := splitTxpoolMessages(3, []*types.SignedMessage{msg(4), msg(3), msg(7), msg(5)})
+	require.Equal(t, []uint64{3, 4, 5}, nonces(executable))
+	require.Equal(t, []uint64{7}, nonces(queued))
+
+	// nothing can be executed while the state nonce is missing
+	executable, queued = splitTxpoolMessages(2, []*types.SignedMessage{msg(3), msg(4)})
+	require.Empty(t, executable)
+	require.Equal(t, []uint64{3, 4}, nonces(queued))
+}
+
+func TestParseBlkHashParam(t *testing.T) {
+	blkHash, ok := parseBlkHashParam("0x37690cfec6c1bf4c3b9288c7a5d783e98731e90b0a4c177c2a374c7a9427355e")
+	require.True(t, ok)
+	require.Equal(t, "0x37690cfec6c1bf4c3b9288c7a5d783e98731e90b0a4c177c2a374c7a9427355e", blkHash.String())
+
+	for _, blkParam := range []string{"latest", "pending", "0x1f", "0x37690cfec6c1bf4c3b9288c7a5d783e98731e90b0a4c177c2a374c7a9427355z"} {
+		_, ok := parseBlkHashParam(blkParam)
+		require.False(t, ok, blkParam)
+	}
+}
+
+func TestEthProtocolVersion(t *testing.T) {
+	versionMap := map[network.Version]uint64{network.Version18: 66}
+
+	require.Equal(t, types.EthUint64(66), ethProtocolVersion(network.Version18, versionMap))
+	require.Equal(t, types.EthUint64(19), ethProtocolVersion(network.Version19, versionMap))
+	require.Equal(t, types.EthUint64(18), ethProtocolVersion(network.Version18, nil))
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/venus/pkg/messagepool"
+	"github.com/filecoin-project/venus/pkg/util/reqid"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/ipfs/go-cid"
@@ -38,7 +40,11 @@ func (a *MessagePoolAPI) MpoolPublishMessage(ctx context.Context, smsg *types.Si
 
 // MpoolPush pushes a signed message to mempool.
 func (a *MessagePoolAPI) MpoolPush(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error) {
-	return a.mp.MPool.Push(ctx, smsg)
+	c, err := a.mp.MPool.Push(ctx, smsg)
+	if err != nil {
+		reqid.Logger(ctx, log).Debugw("failed to push message", "from", smsg.Message.From, "nonce", smsg.Message.Nonce, "error", err)
+	}
+	return c, err
 }
 
 // MpoolGetConfig returns (a copy of) the current mpool config
@@ -51,6 +57,11 @@ func (a *MessagePoolAPI) MpoolGetConfig(context.Context) (*types.MpoolConfig, er
 		ReplaceByFeeRatio:      cfg.ReplaceByFeeRatio,
 		PruneCooldown:          cfg.PruneCooldown,
 		GasLimitOverestimation: cfg.GasLimitOverestimation,
+		EVMGasOverestimation:   cfg.EVMGasOverestimation,
+
+		GasEstimateAdaptiveOverestimation: cfg.GasEstimateAdaptiveOverestimation,
+		OverestimationTarget:              cfg.OverestimationTarget,
+		MethodMaxFees:                     cfg.MethodMaxFees,
 	}, nil
 }
 
@@ -63,6 +74,11 @@ func (a *MessagePoolAPI) MpoolSetConfig(ctx context.Context, cfg *types.MpoolCon
 		ReplaceByFeeRatio:      cfg.ReplaceByFeeRatio,
 		PruneCooldown:          cfg.PruneCooldown,
 		GasLimitOverestimation: cfg.GasLimitOverestimation,
+		EVMGasOverestimation:   cfg.EVMGasOverestimation,
+
+		GasEstimateAdaptiveOverestimation: cfg.GasEstimateAdaptiveOverestimation,
+		OverestimationTarget:              cfg.OverestimationTarget,
+		MethodMaxFees:                     cfg.MethodMaxFees,
 	})
 }
 
@@ -86,6 +102,11 @@ func (a *MessagePoolAPI) MpoolSelects(ctx context.Context, tsk types.TipSetKey,
 	return a.mp.MPool.MultipleSelectMessages(ctx, ts, ticketQualitys)
 }
 
+// MpoolGetBestGasLimit returns the gas left in the next block after the messages the pool would select for it
+func (a *MessagePoolAPI) MpoolGetBestGasLimit(ctx context.Context) (int64, error) {
+	return a.mp.MPool.BestGasLimit(ctx)
+}
+
 // MpoolPending returns pending mempool messages.
 func (a *MessagePoolAPI) MpoolPending(ctx context.Context, tsk types.TipSetKey) ([]*types.SignedMessage, error) {
 	var ts *types.TipSet
@@ -168,6 +189,28 @@ func (a *MessagePoolAPI) MpoolClear(ctx context.Context, local bool) error {
 	return nil
 }
 
+// MpoolClearWithSafetyCheck clears pending messages like MpoolClear. When safetyCheckHeight is positive only the
+// messages whose nonce was already used on chain at that height are cleared, the ones still needed are kept.
+func (a *MessagePoolAPI) MpoolClearWithSafetyCheck(ctx context.Context, local bool, safetyCheckHeight abi.ChainEpoch) error {
+	if safetyCheckHeight <= 0 {
+		a.mp.MPool.Clear(ctx, local)
+		return nil
+	}
+
+	ts, err := a.mp.chain.API().ChainGetTipSetByHeight(ctx, safetyCheckHeight, types.EmptyTSK)
+	if err != nil {
+		return fmt.Errorf("loading tipset at %d: %w", safetyCheckHeight, err)
+	}
+	_, err = a.mp.MPool.ClearStale(ctx, local, ts)
+	return err
+}
+
+// MpoolClearRange clears the pending messages of addr with nonce in [fromNonce, toNonce] from the mpool,
+// local messages are only cleared when local is true.
+func (a *MessagePoolAPI) MpoolClearRange(ctx context.Context, addr address.Address, fromNonce, toNonce uint64, local bool) (int, error) {
+	return a.mp.MPool.ClearRange(ctx, addr, fromNonce, toNonce, local)
+}
+
 // MpoolPushUntrusted pushes a signed message to mempool from untrusted sources.
 func (a *MessagePoolAPI) MpoolPushUntrusted(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error) {
 	return a.mp.MPool.PushUntrusted(ctx, smsg)
@@ -218,7 +261,7 @@ func (a *MessagePoolAPI) MpoolPushMessage(ctx context.Context, msg *types.Messag
 	}
 
 	if msg.From.Protocol() == address.ID {
-		log.Warnf("Push from ID address (%s), adjusting to %s", msg.From, fromA)
+		reqid.Logger(ctx, log).Warnf("Push from ID address (%s), adjusting to %s", msg.From, fromA)
 		msg.From = fromA
 	}
 
@@ -241,6 +284,62 @@ func (a *MessagePoolAPI) MpoolPushMessage(ctx context.Context, msg *types.Messag
 	})
 }
 
+// MpoolPushWithKey signs msg with the wallet key of key and pushes it, msg.From defaults to key and otherwise
+// has to resolve to it. Like MpoolPushMessage the nonce is assigned, the zero gas fields are estimated, and the
+// message is signed and pushed while holding the lock of the sender.
+func (a *MessagePoolAPI) MpoolPushWithKey(ctx context.Context, msg *types.Message, key address.Address) (*types.SignedMessage, error) {
+	cp := *msg
+	if cp.From == address.Undef {
+		cp.From = key
+	} else {
+		fromA, err := a.mp.chain.API().StateAccountKey(ctx, cp.From, types.EmptyTSK)
+		if err != nil {
+			return nil, fmt.Errorf("getting key address of from: %w", err)
+		}
+		keyA, err := a.mp.chain.API().StateAccountKey(ctx, key, types.EmptyTSK)
+		if err != nil {
+			return nil, fmt.Errorf("getting key address of key: %w", err)
+		}
+		if fromA != keyA {
+			return nil, fmt.Errorf("message from %s can not be signed by %s", cp.From, key)
+		}
+	}
+
+	return a.MpoolPushMessage(ctx, &cp, nil)
+}
+
+// MpoolReplace replaces the pending message of from with the given nonce by a copy whose
+// GasPremium and GasFeeCap are bumped by the configured ReplaceByFeeRatio, signs it and pushes it to mempool.
+// The fees are capped according to spec.
+func (a *MessagePoolAPI) MpoolReplace(ctx context.Context, from address.Address, nonce uint64, spec *types.MessageSendSpec) (*types.SignedMessage, error) {
+	fromA, err := a.mp.chain.API().StateAccountKey(ctx, from, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("getting key address: %w", err)
+	}
+	{
+		done, err := a.pushLocks.TakeLock(ctx, fromA)
+		if err != nil {
+			return nil, fmt.Errorf("taking lock: %w", err)
+		}
+		defer done()
+	}
+
+	replace, err := a.mp.MPool.ReplaceMessage(ctx, fromA, nonce, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	smsg, err := a.mp.walletAPI.WalletSignMessage(ctx, replace.From, replace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+	if _, err := a.MpoolPush(ctx, smsg); err != nil {
+		return nil, fmt.Errorf("mpool push: failed to push message: %w", err)
+	}
+
+	return smsg, nil
+}
+
 // MpoolBatchPush batch pushes a unsigned message to mempool.
 func (a *MessagePoolAPI) MpoolBatchPush(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error) {
 	var messageCids []cid.Cid
@@ -286,6 +385,12 @@ func (a *MessagePoolAPI) MpoolGetNonce(ctx context.Context, addr address.Address
 	return a.mp.MPool.GetNonce(ctx, addr, types.EmptyTSK)
 }
 
+// MpoolGetPendingNonce returns the nonce the next message of the specified sender should use,
+// taking all of its pending messages into account.
+func (a *MessagePoolAPI) MpoolGetPendingNonce(ctx context.Context, addr address.Address) (uint64, error) {
+	return a.mp.MPool.GetPendingNonce(ctx, addr)
+}
+
 func (a *MessagePoolAPI) MpoolSub(ctx context.Context) (<-chan types.MpoolUpdate, error) {
 	return a.mp.MPool.Updates(ctx)
 }
@@ -308,12 +413,32 @@ func (a *MessagePoolAPI) GasEstimateGasLimit(ctx context.Context, msgIn *types.M
 	return a.mp.MPool.GasEstimateGasLimit(ctx, msgIn, tsk)
 }
 
+// GasEstimateGasLimitWithTrace estimates the gas limit of msgIn and breaks the gas used down by the calls it makes
+func (a *MessagePoolAPI) GasEstimateGasLimitWithTrace(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (*types.GasLimitBreakdown, error) {
+	return a.mp.MPool.GasEstimateGasLimitWithTrace(ctx, msgIn, tsk)
+}
+
+// GasEstimateGasLimitWithPriors estimates the gas limit of msgIn assuming priors are applied first
+func (a *MessagePoolAPI) GasEstimateGasLimitWithPriors(ctx context.Context, msgIn *types.Message, priors []*types.Message, tsk types.TipSetKey) (int64, error) {
+	priorMsgs := make([]types.ChainMsg, 0, len(priors))
+	for _, m := range priors {
+		priorMsgs = append(priorMsgs, m)
+	}
+	return a.mp.MPool.GasEstimateGasLimitWithPriors(ctx, msgIn, priorMsgs, tsk)
+}
+
 // GasEstimateGasPremium estimates what gas price should be used for a
 // message to have high likelihood of inclusion in `nblocksincl` epochs.
 func (a *MessagePoolAPI) GasEstimateGasPremium(ctx context.Context, nblocksincl uint64, sender address.Address, gaslimit int64, tsk types.TipSetKey) (big.Int, error) {
 	return a.mp.MPool.GasEstimateGasPremium(ctx, nblocksincl, sender, gaslimit, tsk, a.mp.MPool.PriceCache)
 }
 
+// GasEstimateGasPremiumWithHistory is GasEstimateGasPremium looking back historyBlocks epochs for the premiums
+// paid instead of nblocksincl*2, 0 keeps the default. The history is capped at messagepool.MaxPremiumHistoryEpochs.
+func (a *MessagePoolAPI) GasEstimateGasPremiumWithHistory(ctx context.Context, nblocksincl uint64, historyBlocks uint64, sender address.Address, gaslimit int64, tsk types.TipSetKey) (big.Int, error) {
+	return a.mp.MPool.GasEstimateGasPremiumWithHistory(ctx, nblocksincl, historyBlocks, sender, gaslimit, tsk, a.mp.MPool.PriceCache)
+}
+
 func (a *MessagePoolAPI) MpoolCheckMessages(ctx context.Context, protos []*types.MessagePrototype) ([][]types.MessageCheckStatus, error) {
 	return a.mp.MPool.CheckMessages(ctx, protos)
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/filecoin-project/venus/app/submodule/network"
 	"github.com/filecoin-project/venus/app/submodule/wallet"
 	chainpkg "github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/clock"
 	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/messagepool"
@@ -47,6 +48,7 @@ var log = logging.Logger("mpool")
 
 type messagepoolConfig interface {
 	Repo() repo.Repo
+	ChainClock() clock.ChainEpochClock
 }
 
 // MessagingSubmodule enhances the `Node` with internal message capabilities.
@@ -87,6 +89,7 @@ func NewMpoolSubmodule(ctx context.Context, cfg messagepoolConfig,
 	if err != nil {
 		return nil, fmt.Errorf("constructing mpool: %s", err)
 	}
+	mp.SetChainClock(cfg.ChainClock())
 
 	return &MessagePoolSubmodule{
 		MPool:      mp,
@@ -102,6 +105,10 @@ func (mp *MessagePoolSubmodule) handleIncomingMessage(ctx context.Context) {
 	for {
 		_, err := mp.MessageSub.Next(ctx)
 		if err != nil {
+			if errors.Is(err, pubsub.ErrSubscriptionCancelled) {
+				log.Warn("message subscription cancelled, quitting HandleIncomingMessages loop")
+				return
+			}
 			log.Warn("error from message subscription: ", err)
 			if ctx.Err() != nil {
 				log.Warn("quitting HandleIncomingMessages loop")
@@ -137,6 +144,8 @@ func (mp *MessagePoolSubmodule) Validate(ctx context.Context, pid peer.ID, msg *
 		case errors.Is(err, messagepool.ErrNonceGap):
 			fallthrough
 		case errors.Is(err, messagepool.ErrNonceTooLow):
+			fallthrough
+		case errors.Is(err, messagepool.ErrSyncLag):
 			return pubsub.ValidationIgnore
 		default:
 			return pubsub.ValidationReject
@@ -194,6 +203,7 @@ func (mp *MessagePoolSubmodule) Start(ctx context.Context) error {
 			if mp.MessageSub, err = msgTopic.Subscribe(); err != nil {
 				panic(err)
 			}
+			mp.network.TrackSubscription(topicName, mp.MessageSub)
 			go mp.handleIncomingMessage(ctx)
 		})
 	}
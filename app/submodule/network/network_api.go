@@ -120,6 +120,58 @@ func (na *networkAPI) NetProtectList(ctx context.Context) ([]peer.ID, error) {
 	return na.network.Network.ProtectList()
 }
 
+// NetBlockAdd blocks the given peers, IP addresses and subnets
+func (na *networkAPI) NetBlockAdd(ctx context.Context, acl types.NetBlockList) error {
+	return na.network.Network.BlockAdd(acl)
+}
+
+// NetBlockRemove unblocks the given peers, IP addresses and subnets
+func (na *networkAPI) NetBlockRemove(ctx context.Context, acl types.NetBlockList) error {
+	return na.network.Network.BlockRemove(acl)
+}
+
+// NetBlockList returns the blocked peers, IP addresses and subnets
+func (na *networkAPI) NetBlockList(ctx context.Context) (types.NetBlockList, error) {
+	return na.network.Network.BlockList()
+}
+
+// NetPeerStats returns the bytes sent to and received from each connected peer since it connected
+func (na *networkAPI) NetPeerStats(ctx context.Context) ([]types.PeerStats, error) {
+	return na.network.Network.PeerStats()
+}
+
+// NetBootstrapHealth returns the health of the configured bootstrap peers
+func (na *networkAPI) NetBootstrapHealth(ctx context.Context) ([]types.BootstrapStatus, error) {
+	return na.network.PeerMgr.BootstrapHealth(), nil
+}
+
+// NetTagPeer tags the peer in the connection manager, scaled by the weight configured for the tag
+func (na *networkAPI) NetTagPeer(ctx context.Context, p peer.ID, tag string, value int) error {
+	return na.network.Network.TagPeer(p, tag, value)
+}
+
+// NetUntagPeer removes the tag from the peer in the connection manager
+func (na *networkAPI) NetUntagPeer(ctx context.Context, p peer.ID, tag string) error {
+	return na.network.Network.UntagPeer(p, tag)
+}
+
+// PubsubListTopics returns the pubsub topics the node is subscribed to
+func (na *networkAPI) PubsubListTopics(ctx context.Context) ([]string, error) {
+	topics := na.network.Pubsub.GetTopics()
+	sort.Strings(topics)
+	return topics, nil
+}
+
+// PubsubLeaveTopic cancels the subscriptions of the node to the topic
+func (na *networkAPI) PubsubLeaveTopic(ctx context.Context, topic string) error {
+	return na.network.LeaveTopic(topic)
+}
+
+// NetNATStatus returns the reachability, public addresses and NAT type of the node
+func (na *networkAPI) NetNATStatus(ctx context.Context) (*types.NATStatus, error) {
+	return na.network.Network.NATStatus()
+}
+
 // NetConnectedness returns a state signaling connection capabilities
 func (na *networkAPI) NetConnectedness(ctx context.Context, p peer.ID) (network.Connectedness, error) {
 	return na.network.Network.Connectedness(p)
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/filecoin-project/venus/pkg/net/helloprotocol"
@@ -86,9 +87,17 @@ type NetworkSubmodule struct { //nolint
 
 	ScoreKeeper *net.ScoreKeeper
 
+	subsLk sync.Mutex
+	subs   map[string][]subscription
+
 	cfg networkConfig
 }
 
+// subscription is a pubsub subscription that can be left through PubsubLeaveTopic
+type subscription interface {
+	Cancel()
+}
+
 // API create a new network implement
 func (networkSubmodule *NetworkSubmodule) API() v1api.INetwork {
 	return &networkAPI{network: networkSubmodule}
@@ -99,6 +108,9 @@ func (networkSubmodule *NetworkSubmodule) V0API() v0api.INetwork {
 }
 
 func (networkSubmodule *NetworkSubmodule) Stop(ctx context.Context) {
+	if err := networkSubmodule.Network.Close(); err != nil {
+		networkLogger.Errorf("error closing network: %s", err.Error())
+	}
 	networkLogger.Infof("closing bitswap")
 	if err := networkSubmodule.Bitswap.Close(); err != nil {
 		networkLogger.Errorf("error closing bitswap: %s", err.Error())
@@ -125,9 +137,13 @@ func NewNetworkSubmodule(ctx context.Context, chainStore *chain.Store,
 	messageStore *chain.MessageStore, config networkConfig,
 ) (*NetworkSubmodule, error) {
 	bandwidthTracker := p2pmetrics.NewBandwidthCounter()
-	libP2pOpts := append(config.Libp2pOpts(), libp2p.BandwidthReporter(bandwidthTracker), makeSmuxTransportOption())
+	// the block list is kept in a file of the repo so that it survives restarts
+	gater, err := net.NewBlockListGater(config.Repo().NetBlockListPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load net block list: %w", err)
+	}
+	libP2pOpts := append(config.Libp2pOpts(), libp2p.BandwidthReporter(bandwidthTracker), libp2p.ConnectionGater(gater), makeSmuxTransportOption())
 	var networkName string
-	var err error
 	if !config.Repo().Config().NetworkParams.DevNet {
 		networkName = "testnetnet"
 	} else {
@@ -161,13 +177,19 @@ func NewNetworkSubmodule(ctx context.Context, chainStore *chain.Store,
 		return nil, err
 	}
 
-	peerMgr, err := peermgr.NewPeerMgr(peerHost, router.(*dht.IpfsDHT), period, bootNodes)
+	healthInterval, err := time.ParseDuration(config.Repo().Config().Bootstrap.HealthInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	peerMgr, err := peermgr.NewPeerMgr(peerHost, router.(*dht.IpfsDHT), period, bootNodes, healthInterval, config.Repo().Config().Bootstrap.MaxFailures)
 	if err != nil {
 		return nil, err
 	}
 
 	sk := net.NewScoreKeeper()
-	gsub, err := net.NewGossipSub(ctx, peerHost, sk, networkName, config.Repo().Config().NetworkParams.DrandSchedule, bootNodes)
+	gsub, err := net.NewGossipSub(ctx, peerHost, sk, networkName, config.Repo().Config().NetworkParams.DrandSchedule, bootNodes,
+		time.Duration(config.Repo().Config().Swarm.PubsubSeenMessagesTTL))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to set up network")
 	}
@@ -198,7 +220,7 @@ func NewNetworkSubmodule(ctx context.Context, chainStore *chain.Store,
 		return nil, err
 	}
 	// build network
-	network := net.New(peerHost, rawHost, net.NewRouter(router), bandwidthTracker)
+	network := net.New(peerHost, rawHost, net.NewRouter(router), bandwidthTracker, gater, config.Repo().NetBlockListPath(), config.Repo().Config().Swarm.HighPriorityPeerTags)
 	exchangeClient := filexchange.NewClient(peerHost, peerMgr)
 	helloHandler := helloprotocol.NewHelloProtocolHandler(peerHost, peerMgr, exchangeClient, chainStore, messageStore, config.GenesisCid(), time.Duration(config.Repo().Config().NetworkParams.BlockDelay)*time.Second)
 	// build the network submdule
@@ -218,6 +240,7 @@ func NewNetworkSubmodule(ctx context.Context, chainStore *chain.Store,
 		HelloHandler:     helloHandler,
 		cfg:              config,
 		ScoreKeeper:      sk,
+		subs:             make(map[string][]subscription),
 	}, nil
 }
 
@@ -229,6 +252,32 @@ func (networkSubmodule *NetworkSubmodule) Start(ctx context.Context) error {
 	return nil
 }
 
+// TrackSubscription records sub as a subscription to topic, so that it can be cancelled by LeaveTopic
+func (networkSubmodule *NetworkSubmodule) TrackSubscription(topic string, sub subscription) {
+	networkSubmodule.subsLk.Lock()
+	defer networkSubmodule.subsLk.Unlock()
+
+	networkSubmodule.subs[topic] = append(networkSubmodule.subs[topic], sub)
+}
+
+// LeaveTopic cancels the tracked subscriptions to topic
+func (networkSubmodule *NetworkSubmodule) LeaveTopic(topic string) error {
+	networkSubmodule.subsLk.Lock()
+	subs, ok := networkSubmodule.subs[topic]
+	delete(networkSubmodule.subs, topic)
+	networkSubmodule.subsLk.Unlock()
+
+	if !ok {
+		return fmt.Errorf("not subscribed to topic %s", topic)
+	}
+	for _, sub := range subs {
+		sub.Cancel()
+	}
+	networkLogger.Infof("left pubsub topic %s", topic)
+
+	return nil
+}
+
 func (networkSubmodule *NetworkSubmodule) FetchMessagesByCids(
 	ctx context.Context,
 	service bserv.BlockService,
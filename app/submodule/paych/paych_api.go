@@ -13,6 +13,7 @@ import (
 	"github.com/filecoin-project/go-state-types/builtin/v8/paych"
 
 	"github.com/filecoin-project/venus/pkg/paychmgr"
+	pchTypes "github.com/filecoin-project/venus/venus-shared/types/market"
 )
 
 type PaychAPI struct { //nolint
@@ -69,6 +70,14 @@ func (a *PaychAPI) PaychAllocateLane(ctx context.Context, ch address.Address) (u
 	return a.paychMgr.AllocateLane(ctx, ch)
 }
 
+func (a *PaychAPI) PaychAllocateLaneWithLabel(ctx context.Context, ch address.Address, label string) (uint64, error) {
+	return a.paychMgr.AllocateLaneWithLabel(ctx, ch, label)
+}
+
+func (a *PaychAPI) PaychGetLaneLabels(ctx context.Context, ch address.Address) (map[uint64]string, error) {
+	return a.paychMgr.LaneLabels(ctx, ch)
+}
+
 func (a *PaychAPI) PaychNewPayment(ctx context.Context, from, to address.Address, vouchers []types.VoucherSpec) (*types.PaymentInfo, error) {
 	amount := vouchers[len(vouchers)-1].Amount
 
@@ -122,10 +131,21 @@ func (a *PaychAPI) PaychStatus(ctx context.Context, pch address.Address) (*types
 	if err != nil {
 		return nil, err
 	}
-	return &types.Status{
-		ControlAddr: ci.Control,
-		Direction:   types.PCHDir(ci.Direction),
-	}, nil
+	status := &types.Status{
+		ControlAddr:     ci.Control,
+		Direction:       types.PCHDir(ci.Direction),
+		PendingIncoming: big.Zero(),
+		PendingOutgoing: big.Zero(),
+	}
+	if ci.Direction == pchTypes.DirInbound {
+		status.PendingIncoming = ci.PendingVoucherAmount()
+	} else {
+		// the recipient redeems the vouchers of an outbound channel, only the channel state tells what is left
+		if status.PendingOutgoing, err = a.paychMgr.PendingOutgoing(ctx, pch); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
 }
 
 func (a *PaychAPI) PaychSettle(ctx context.Context, addr address.Address) (cid.Cid, error) {
@@ -159,6 +179,21 @@ func (a *PaychAPI) PaychVoucherCreate(ctx context.Context, pch address.Address,
 	return a.paychMgr.CreateVoucher(ctx, pch, paych.SignedVoucher{Amount: amt, Lane: lane})
 }
 
+// PaychVoucherCreateSigned creates a new voucher like PaychVoucherCreate and returns the voucher
+// signed by the From address of the channel. If there are insufficient funds in the channel, it
+// returns an error with the shortfall instead of a nil voucher.
+func (a *PaychAPI) PaychVoucherCreateSigned(ctx context.Context, pch address.Address, amt big.Int, lane uint64) (*paych.SignedVoucher, error) {
+	res, err := a.PaychVoucherCreate(ctx, pch, amt, lane)
+	if err != nil {
+		return nil, err
+	}
+	if res.Voucher == nil {
+		return nil, fmt.Errorf("not enough funds in channel %s to create voucher, shortfall: %s", pch, res.Shortfall)
+	}
+
+	return res.Voucher, nil
+}
+
 func (a *PaychAPI) PaychVoucherList(ctx context.Context, pch address.Address) ([]*paych.SignedVoucher, error) {
 	vi, err := a.paychMgr.ListVouchers(ctx, pch)
 	if err != nil {
@@ -86,6 +86,13 @@ func (sa *syncerAPI) ChainTipSetWeight(ctx context.Context, tsk types.TipSetKey)
 	return sa.syncer.ChainSelector.Weight(ctx, ts)
 }
 
+// ChainValidateBlock runs the block validation of the syncer against blk without adding it to the chain,
+// all failed checks are reported. The messages of blk are loaded from the local store, so they have to
+// be fetched or received with the block beforehand.
+func (sa *syncerAPI) ChainValidateBlock(ctx context.Context, blk *types.BlockHeader) error {
+	return sa.syncer.BlockValidator.ValidateBlock(ctx, blk)
+}
+
 // ChainSyncHandleNewTipSet submits a chain head to the syncer for processing.
 func (sa *syncerAPI) ChainSyncHandleNewTipSet(ctx context.Context, ci *types.ChainInfo) error {
 	return sa.syncer.SyncProvider.HandleNewTipSet(ci)
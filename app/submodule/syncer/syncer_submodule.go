@@ -18,6 +18,7 @@ import (
 	fbig "github.com/filecoin-project/go-state-types/big"
 	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log/v2"
+	libp2pps "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/net/pubsub"
 	"github.com/filecoin-project/venus/pkg/repo"
 	"github.com/filecoin-project/venus/pkg/state"
+	"github.com/filecoin-project/venus/pkg/vm"
 	"github.com/filecoin-project/venus/pkg/vm/gas"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/ipfs/go-blockservice"
@@ -110,6 +112,11 @@ func NewSyncerSubmodule(ctx context.Context,
 		return nil, errors.Wrap(err, "failed to register block validator")
 	}
 
+	var traceFilter *vm.TraceFilter
+	if fevmCfg := config.Repo().Config().FevmConfig; len(fevmCfg.TraceAddresses) > 0 || len(fevmCfg.TraceMethods) > 0 {
+		traceFilter = &vm.TraceFilter{Addresses: fevmCfg.TraceAddresses, Methods: fevmCfg.TraceMethods}
+	}
+
 	rnd := chn.API()
 	nodeConsensus := consensus.NewExpected(cborStore,
 		blockstore.Blockstore,
@@ -123,6 +130,7 @@ func NewSyncerSubmodule(ctx context.Context,
 		circulatingSupplyCalculator,
 		config.Repo().Config().NetworkParams,
 		config.Repo().Config().FevmConfig.EnableEthRPC,
+		traceFilter,
 	)
 
 	stmgr := statemanger.NewStateManger(chn.ChainReader, chn.MessageStore, nodeConsensus, rnd,
@@ -245,13 +253,16 @@ func (syncer *SyncerSubmodule) Start(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrapf(err, "failed to subscribe block topic")
 	}
+	syncer.NetworkModule.TrackSubscription(syncer.BlockSub.Topic(), syncer.BlockSub)
 
 	// process incoming blocks
 	go func() {
 		for {
 			received, err := syncer.BlockSub.Next(ctx)
 			if err != nil {
-				if ctx.Err() != context.Canceled {
+				if errors.Is(err, libp2pps.ErrSubscriptionCancelled) {
+					log.Warnf("subscription to topic %s cancelled", syncer.BlockSub.Topic())
+				} else if ctx.Err() != context.Canceled {
 					log.Errorf("error reading message from topic %s: %s", syncer.BlockSub.Topic(), err)
 				}
 				return
@@ -271,6 +282,8 @@ func (syncer *SyncerSubmodule) Start(ctx context.Context) error {
 		return err
 	}
 
+	go syncer.Stmgr.RunHeadStateCache(ctx)
+
 	return syncer.ChainSyncManager.Start(ctx)
 }
 
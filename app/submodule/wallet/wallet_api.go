@@ -37,6 +37,43 @@ func (walletAPI *WalletAPI) WalletBalance(ctx context.Context, addr address.Addr
 	return actor.Balance, nil
 }
 
+// walletBalanceBatchLimit is the maximum number of addresses of a WalletBalanceBatch call
+const walletBalanceBatchLimit = 1000
+
+// WalletBalanceBatch returns the balances of addrs at tsk in the order of addrs, looked up in a single state tree.
+// address.Undef and addresses without an actor have a zero balance.
+func (walletAPI *WalletAPI) WalletBalanceBatch(ctx context.Context, addrs []address.Address, tsk types.TipSetKey) ([]types.AddressBalance, error) {
+	if len(addrs) > walletBalanceBatchLimit {
+		return nil, fmt.Errorf("too many addresses: %d, the limit is %d", len(addrs), walletBalanceBatchLimit)
+	}
+
+	ts, err := walletAPI.walletModule.Chain.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
+	}
+	_, st, err := walletAPI.walletModule.Chain.Stmgr.ParentState(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("loading state of tipset %s: %w", ts.Key(), err)
+	}
+
+	balances := make([]types.AddressBalance, 0, len(addrs))
+	for _, addr := range addrs {
+		balance := abi.NewTokenAmount(0)
+		if addr != address.Undef {
+			actor, found, err := st.GetActor(ctx, addr)
+			if err != nil {
+				return nil, fmt.Errorf("loading actor %s: %w", addr, err)
+			}
+			if found {
+				balance = actor.Balance
+			}
+		}
+		balances = append(balances, types.AddressBalance{Address: addr, Balance: balance})
+	}
+
+	return balances, nil
+}
+
 // WalletHas indicates whether the given address is in the wallet.
 func (walletAPI *WalletAPI) WalletHas(ctx context.Context, addr address.Address) (bool, error) {
 	return walletAPI.adapter.HasAddress(ctx, addr), nil
@@ -167,3 +204,8 @@ func (walletAPI *WalletAPI) HasPassword(ctx context.Context) bool {
 func (walletAPI *WalletAPI) WalletState(ctx context.Context) int {
 	return walletAPI.walletModule.Wallet.WalletState(ctx)
 }
+
+// WalletValidateAddress parses s as an address, the protocol and checksum are checked without reading the chain
+func (walletAPI *WalletAPI) WalletValidateAddress(ctx context.Context, s string) (address.Address, error) {
+	return address.NewFromString(s)
+}
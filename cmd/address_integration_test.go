@@ -25,7 +25,7 @@ func TestAddressNewAndList(t *testing.T) {
 	tf.IntegrationTest(t)
 
 	ctx := context.Background()
-	builder := test.NewNodeBuilder(t)
+	builder := test.NewSimulationNodeBuilder(t)
 
 	n, cmdClient, done := builder.BuildAndStartAPI(ctx)
 	defer done()
@@ -47,7 +47,7 @@ func TestWalletBalance(t *testing.T) {
 	tf.IntegrationTest(t)
 	ctx := context.Background()
 
-	builder := test.NewNodeBuilder(t)
+	builder := test.NewSimulationNodeBuilder(t)
 	cs := test.FixtureChainSeed(t)
 	builder.WithGenesisInit(cs.GenesisInitFunc)
 
@@ -76,7 +76,7 @@ func TestWalletLoadFromFile(t *testing.T) {
 	tf.IntegrationTest(t)
 	ctx := context.Background()
 
-	builder := test.NewNodeBuilder(t)
+	builder := test.NewSimulationNodeBuilder(t)
 	cs := test.FixtureChainSeed(t)
 	builder.WithGenesisInit(cs.GenesisInitFunc)
 
@@ -102,7 +102,7 @@ func TestWalletExportImportRoundTrip(t *testing.T) {
 	tf.IntegrationTest(t)
 
 	ctx := context.Background()
-	builder := test.NewNodeBuilder(t)
+	builder := test.NewSimulationNodeBuilder(t)
 
 	n, cmdClient, done := builder.BuildAndStartAPI(ctx)
 	defer done()
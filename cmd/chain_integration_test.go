@@ -18,7 +18,7 @@ func TestChainHead(t *testing.T) {
 	tf.IntegrationTest(t)
 
 	ctx := context.Background()
-	builder := test.NewNodeBuilder(t)
+	builder := test.NewSimulationNodeBuilder(t)
 
 	_, cmdClient, done := builder.BuildAndStartAPI(ctx)
 	defer done()
@@ -10,8 +10,10 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/filecoin-project/venus/app/node/test"
 	"github.com/filecoin-project/venus/cmd"
 
+	ma "github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
 
 	th "github.com/filecoin-project/venus/pkg/testhelpers"
@@ -72,71 +74,31 @@ func TestDaemonApiFile(t *testing.T) {
 
 func TestDaemonCORS(t *testing.T) {
 	tf.IntegrationTest(t)
+	ctx := context.Background()
 
 	t.Run("default allowed origins work", func(t *testing.T) {
-		td := th.NewDaemon(t).Start()
-		defer td.ShutdownSuccess()
-
-		maddr, err := td.CmdAddr()
-		assert.NoError(t, err)
-
-		_, host, err := manet.DialArgs(maddr) //nolint
-		assert.NoError(t, err)
-
-		url := fmt.Sprintf("http://%s/api/swarm/id", host)
-
-		token, err := td.CmdToken()
-		assert.NoError(t, err)
-
-		req, err := http.NewRequest("POST", url, nil)
-		assert.NoError(t, err)
-		req.Header.Set("Authorization", "Bearer "+token)
-		req.Header.Add("Origin", "http://localhost:8080")
-		res, err := http.DefaultClient.Do(req)
-		assert.NoError(t, err)
-		assert.Equal(t, http.StatusOK, res.StatusCode)
-
-		req, err = http.NewRequest("POST", url, nil)
-		assert.NoError(t, err)
-		req.Header.Set("Authorization", "Bearer "+token)
-		req.Header.Add("Origin", "https://localhost:8080")
-		res, err = http.DefaultClient.Do(req)
-		assert.NoError(t, err)
-		assert.Equal(t, http.StatusOK, res.StatusCode)
-
-		req, err = http.NewRequest("POST", url, nil)
-		assert.NoError(t, err)
-		req.Header.Set("Authorization", "Bearer "+token)
-		req.Header.Add("Origin", "http://127.0.0.1:8080")
-		res, err = http.DefaultClient.Do(req)
-		assert.NoError(t, err)
-		assert.Equal(t, http.StatusOK, res.StatusCode)
-
-		req, err = http.NewRequest("POST", url, nil)
-		assert.NoError(t, err)
-		req.Header.Set("Authorization", "Bearer "+token)
-		req.Header.Add("Origin", "https://127.0.0.1:8080")
-		res, err = http.DefaultClient.Do(req)
-		assert.NoError(t, err)
-		assert.Equal(t, http.StatusOK, res.StatusCode)
+		_, client, done := test.NewSimulationNodeBuilder(t).BuildAndStartAPI(ctx)
+		defer done()
+
+		url := apiURL(t, client, "/api/swarm/id")
+		for _, origin := range []string{"http://localhost:8080", "https://localhost:8080", "http://127.0.0.1:8080", "https://127.0.0.1:8080"} {
+			req, err := http.NewRequest("POST", url, nil)
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+client.Token())
+			req.Header.Add("Origin", origin)
+			res, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, res.StatusCode, origin)
+		}
 	})
 
 	t.Run("non-configured origin fails", func(t *testing.T) {
-		td := th.NewDaemon(t).Start()
-		defer td.ShutdownSuccess()
-
-		maddr, err := td.CmdAddr()
-		assert.NoError(t, err)
-
-		_, host, err := manet.DialArgs(maddr) //nolint
-		assert.NoError(t, err)
-		token, err := td.CmdToken()
-		assert.NoError(t, err)
+		_, client, done := test.NewSimulationNodeBuilder(t).BuildAndStartAPI(ctx)
+		defer done()
 
-		url := fmt.Sprintf("http://%s/api/swarm/id", host)
-		req, err := http.NewRequest("POST", url, nil)
+		req, err := http.NewRequest("POST", apiURL(t, client, "/api/swarm/id"), nil)
 		assert.NoError(t, err)
-		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Authorization", "Bearer "+client.Token())
 		req.Header.Add("Origin", "http://disallowed.origin")
 		res, err := http.DefaultClient.Do(req)
 		assert.NoError(t, err)
@@ -146,25 +108,26 @@ func TestDaemonCORS(t *testing.T) {
 
 func TestDaemonOverHttp(t *testing.T) {
 	tf.IntegrationTest(t)
+	ctx := context.Background()
 
-	td := th.NewDaemon(t).Start()
-	defer td.ShutdownSuccess()
+	_, client, done := test.NewSimulationNodeBuilder(t).BuildAndStartAPI(ctx)
+	defer done()
 
-	maddr, err := td.CmdAddr()
+	req, err := http.NewRequest("POST", apiURL(t, client, "/api/daemon"), nil)
 	require.NoError(t, err)
-
-	_, host, err := manet.DialArgs(maddr) //nolint
+	req.Header.Set("Authorization", "Bearer "+client.Token())
+	res, err := http.DefaultClient.Do(req)
 	require.NoError(t, err)
-	token, err := td.CmdToken()
-	assert.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+}
 
-	url := fmt.Sprintf("http://%s/api/daemon", host)
-	req, err := http.NewRequest("POST", url, nil)
+// apiURL returns the url of path on the API server of client
+func apiURL(t *testing.T, client *test.Client, path string) string {
+	maddr, err := ma.NewMultiaddr(client.Address())
 	require.NoError(t, err)
-	req.Header.Set("Authorization", "Bearer "+token)
-	res, err := http.DefaultClient.Do(req)
+	_, host, err := manet.DialArgs(maddr) //nolint
 	require.NoError(t, err)
-	require.Equal(t, http.StatusNotFound, res.StatusCode)
+	return fmt.Sprintf("http://%s%s", host, path)
 }
 
 func Test_MergePeers(t *testing.T) {
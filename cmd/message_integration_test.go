@@ -19,7 +19,7 @@ func TestMessageSend(t *testing.T) {
 	t.Skip("This can be unskipped with fake proofs")
 	tf.IntegrationTest(t)
 	ctx := context.Background()
-	builder := test.NewNodeBuilder(t)
+	builder := test.NewSimulationNodeBuilder(t)
 	defaultAddr := fortest.TestAddresses[0]
 
 	cs := test.FixtureChainSeed(t)
@@ -82,7 +82,7 @@ func TestMessageSendBlockGasLimit(t *testing.T) {
 	t.Skip("Unskip using fake proofs")
 
 	ctx := context.Background()
-	builder := test.NewNodeBuilder(t)
+	builder := test.NewSimulationNodeBuilder(t)
 	defaultAddr := fortest.TestAddresses[0]
 
 	cs := test.FixtureChainSeed(t)
@@ -19,7 +19,7 @@ func TestActorDaemon(t *testing.T) {
 	tf.IntegrationTest(t)
 	ctx := context.Background()
 	t.Run("state ls --enc json returns NDJSON containing all actors in the state tree", func(t *testing.T) {
-		builder := test.NewNodeBuilder(t)
+		builder := test.NewSimulationNodeBuilder(t)
 
 		_, cmdClient, done := builder.BuildAndStartAPI(ctx)
 		defer done()
@@ -29,7 +29,7 @@ func TestSwarmConnectPeersInvalid(t *testing.T) {
 	tf.IntegrationTest(t)
 
 	ctx := context.Background()
-	builder := test.NewNodeBuilder(t)
+	builder := test.NewSimulationNodeBuilder(t)
 
 	_, cmdClient, done := builder.BuildAndStartAPI(ctx)
 	defer done()
@@ -105,7 +105,7 @@ func TestDhtFindPeer(t *testing.T) {
 func TestStatsBandwidth(t *testing.T) {
 	tf.IntegrationTest(t)
 	ctx := context.Background()
-	builder := test.NewNodeBuilder(t)
+	builder := test.NewSimulationNodeBuilder(t)
 
 	_, cmdClient, done := builder.BuildAndStartAPI(ctx)
 	defer done()
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/wallet/key"
+	"github.com/filecoin-project/venus/venus-shared/api"
+	v1 "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type mpoolBenchConfig struct {
+	apiInfo       string
+	from          string
+	senders       int
+	msgsPerSender int
+	rate          float64
+	fund          string
+	gasLimit      int64
+	gasFeeCap     string
+	gasPremium    string
+	batchInterval time.Duration
+	timeout       time.Duration
+}
+
+func benchMpool(args []string) error {
+	var cfg mpoolBenchConfig
+	fs := flag.NewFlagSet("bench-mpool", flag.ExitOnError)
+	fs.StringVar(&cfg.apiInfo, "api", os.Getenv("FULLNODE_API_INFO"), "api info of the node, token:multiaddr, defaults to $FULLNODE_API_INFO")
+	fs.StringVar(&cfg.from, "from", "", "wallet address funding the senders, defaults to the default wallet address of the node")
+	fs.IntVar(&cfg.senders, "senders", 10, "number of sending accounts, created for the run")
+	fs.IntVar(&cfg.msgsPerSender, "msgs-per-sender", 100, "number of messages each sender submits")
+	fs.Float64Var(&cfg.rate, "rate", 10, "submission rate in messages per second")
+	fs.StringVar(&cfg.fund, "fund", "1", "FIL transferred to each sender to pay for its messages")
+	fs.Int64Var(&cfg.gasLimit, "gas-limit", 0, "gas limit of the messages, estimated when zero")
+	fs.StringVar(&cfg.gasFeeCap, "gas-feecap", "", "gas fee cap of the messages in attoFIL, estimated when empty")
+	fs.StringVar(&cfg.gasPremium, "gas-premium", "", "gas premium of the messages in attoFIL, estimated when empty")
+	fs.DurationVar(&cfg.batchInterval, "batch-interval", 100*time.Millisecond, "interval between two MpoolBatchPush calls")
+	fs.DurationVar(&cfg.timeout, "timeout", 30*time.Minute, "time to wait for the messages to be executed once all are submitted")
+	_ = fs.Parse(args)
+
+	if cfg.senders <= 0 || cfg.msgsPerSender <= 0 {
+		return errors.New("senders and msgs-per-sender must be positive")
+	}
+	if cfg.rate <= 0 {
+		return errors.New("rate must be positive")
+	}
+	if cfg.batchInterval <= 0 {
+		return errors.New("batch-interval must be positive")
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	node, closer, err := dialFullNode(ctx, cfg.apiInfo)
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	b := &mpoolBench{cfg: cfg, node: node, tracker: newInclusionTracker()}
+	report, err := b.run(ctx)
+	if err != nil {
+		return err
+	}
+	report.print(os.Stdout)
+	return nil
+}
+
+func dialFullNode(ctx context.Context, apiInfo string) (v1.FullNode, jsonrpc.ClientCloser, error) {
+	if apiInfo == "" {
+		return nil, nil, errors.New("no api info, set -api or $FULLNODE_API_INFO")
+	}
+	ai := api.ParseApiInfo(apiInfo)
+	addr, err := ai.DialArgs("v1")
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing api info: %w", err)
+	}
+	return v1.NewFullNodeRPC(ctx, addr, ai.AuthHeader())
+}
+
+type benchSender struct {
+	addr address.Address
+	key  key.KeyInfo
+}
+
+type mpoolBench struct {
+	cfg     mpoolBenchConfig
+	node    v1.FullNode
+	tracker *inclusionTracker
+}
+
+func (b *mpoolBench) run(ctx context.Context) (benchReport, error) {
+	from, err := b.fromAddress(ctx)
+	if err != nil {
+		return benchReport{}, err
+	}
+
+	senders, err := b.fundSenders(ctx, from)
+	if err != nil {
+		return benchReport{}, err
+	}
+
+	msgs, err := b.signMessages(ctx, senders, from)
+	if err != nil {
+		return benchReport{}, err
+	}
+
+	notifs, err := b.node.ChainNotify(ctx)
+	if err != nil {
+		return benchReport{}, fmt.Errorf("subscribing to head changes: %w", err)
+	}
+	go b.watchReceipts(ctx, notifs)
+
+	log.Infof("submitting %d messages at %.2f msg/s", len(msgs), b.cfg.rate)
+	start := time.Now()
+	failed := b.submit(ctx, msgs)
+	submitElapsed := time.Since(start)
+
+	log.Infof("submitted %d messages in %s, waiting for their receipts", len(msgs)-failed, submitElapsed.Round(time.Millisecond))
+	b.waitReceipts(ctx)
+
+	report := b.tracker.report()
+	report.Submitted = len(msgs) - failed
+	report.Failed = failed
+	if submitElapsed > 0 {
+		report.SubmitRate = float64(report.Submitted) / submitElapsed.Seconds()
+	}
+	return report, nil
+}
+
+func (b *mpoolBench) fromAddress(ctx context.Context) (address.Address, error) {
+	if b.cfg.from != "" {
+		return address.NewFromString(b.cfg.from)
+	}
+	from, err := b.node.WalletDefaultAddress(ctx)
+	if err != nil {
+		return address.Undef, fmt.Errorf("getting default wallet address: %w", err)
+	}
+	if from.Empty() {
+		return address.Undef, errors.New("the node has no default wallet address, set -from")
+	}
+	return from, nil
+}
+
+// fundSenders creates the sending accounts and waits for the transfers funding them to be executed.
+func (b *mpoolBench) fundSenders(ctx context.Context, from address.Address) ([]benchSender, error) {
+	fund, err := types.ParseFIL(b.cfg.fund)
+	if err != nil {
+		return nil, fmt.Errorf("parsing fund: %w", err)
+	}
+
+	senders := make([]benchSender, b.cfg.senders)
+	fundMsgs := make([]cid.Cid, b.cfg.senders)
+	for i := range senders {
+		ki, err := key.NewSecpKeyFromSeed(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := ki.Address()
+		if err != nil {
+			return nil, err
+		}
+		senders[i] = benchSender{addr: addr, key: ki}
+
+		smsg, err := b.node.MpoolPushMessage(ctx, &types.Message{
+			From:   from,
+			To:     addr,
+			Value:  abi.TokenAmount(fund),
+			Method: builtin.MethodSend,
+		}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("funding sender %s: %w", addr, err)
+		}
+		fundMsgs[i] = smsg.Cid()
+	}
+
+	log.Infof("funding %d senders with %s each", len(senders), fund)
+	for i, c := range fundMsgs {
+		lookup, err := b.node.StateWaitMsg(ctx, c, constants.MessageConfidence, constants.LookbackNoLimit, true)
+		if err != nil {
+			return nil, fmt.Errorf("waiting for funding message %s: %w", c, err)
+		}
+		if lookup.Receipt.ExitCode.IsError() {
+			return nil, fmt.Errorf("funding sender %s failed: exit code %d", senders[i].addr, lookup.Receipt.ExitCode)
+		}
+	}
+	return senders, nil
+}
+
+// signMessages signs the messages of every sender ahead of the run, so signing doesn't limit the rate.
+// The messages of the senders are interleaved, each sender's in nonce order.
+func (b *mpoolBench) signMessages(ctx context.Context, senders []benchSender, to address.Address) ([]*types.SignedMessage, error) {
+	tmpl, err := b.messageTemplate(ctx, senders[0].addr, to)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*types.SignedMessage, 0, len(senders)*b.cfg.msgsPerSender)
+	for nonce := 0; nonce < b.cfg.msgsPerSender; nonce++ {
+		for _, s := range senders {
+			msg := *tmpl
+			msg.From = s.addr
+			msg.Nonce = uint64(nonce)
+
+			sig, err := crypto.Sign(msg.Cid().Bytes(), s.key.Key(), s.key.SigType)
+			if err != nil {
+				return nil, fmt.Errorf("signing message: %w", err)
+			}
+			msgs = append(msgs, &types.SignedMessage{Message: msg, Signature: *sig})
+		}
+	}
+	return msgs, nil
+}
+
+// messageTemplate returns the message every sender submits, gas settings not configured are estimated.
+func (b *mpoolBench) messageTemplate(ctx context.Context, from, to address.Address) (*types.Message, error) {
+	msg := &types.Message{
+		From:       from,
+		To:         to,
+		Value:      big.Zero(),
+		Method:     builtin.MethodSend,
+		GasLimit:   b.cfg.gasLimit,
+		GasFeeCap:  big.Zero(),
+		GasPremium: big.Zero(),
+	}
+	var err error
+	if b.cfg.gasFeeCap != "" {
+		if msg.GasFeeCap, err = types.BigFromString(b.cfg.gasFeeCap); err != nil {
+			return nil, fmt.Errorf("parsing gas-feecap: %w", err)
+		}
+	}
+	if b.cfg.gasPremium != "" {
+		if msg.GasPremium, err = types.BigFromString(b.cfg.gasPremium); err != nil {
+			return nil, fmt.Errorf("parsing gas-premium: %w", err)
+		}
+	}
+	if msg.GasLimit != 0 && b.cfg.gasFeeCap != "" && b.cfg.gasPremium != "" {
+		return msg, nil
+	}
+
+	estimated, err := b.node.GasEstimateMessageGas(ctx, msg, nil, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("estimating gas: %w", err)
+	}
+	log.Infof("estimated gas: limit %d, fee cap %s, premium %s", estimated.GasLimit, estimated.GasFeeCap, estimated.GasPremium)
+	return estimated, nil
+}
+
+// submit pushes msgs in batches, every batchInterval the messages due at the configured rate are pushed.
+// It returns the number of messages which failed to be pushed.
+func (b *mpoolBench) submit(ctx context.Context, msgs []*types.SignedMessage) int {
+	ticker := time.NewTicker(b.cfg.batchInterval)
+	defer ticker.Stop()
+
+	start := time.Now()
+	sent, failed := 0, 0
+	for sent < len(msgs) {
+		select {
+		case <-ctx.Done():
+			return failed + len(msgs) - sent
+		case <-ticker.C:
+		}
+
+		due := int(time.Since(start).Seconds() * b.cfg.rate)
+		if due > len(msgs) {
+			due = len(msgs)
+		}
+		if due <= sent {
+			continue
+		}
+
+		batch := msgs[sent:due]
+		now := time.Now()
+		for _, msg := range batch {
+			b.tracker.submitted(msg.Cid(), now)
+		}
+		if _, err := b.node.MpoolBatchPush(ctx, batch); err != nil {
+			log.Warnf("pushing %d messages: %v", len(batch), err)
+			for _, msg := range batch {
+				b.tracker.failed(msg.Cid())
+			}
+			failed += len(batch)
+		}
+		sent = due
+	}
+	return failed
+}
+
+// watchReceipts marks the messages executed by every applied tipset as done.
+func (b *mpoolBench) watchReceipts(ctx context.Context, notifs <-chan []*types.HeadChange) {
+	for changes := range notifs {
+		now := time.Now()
+		for _, change := range changes {
+			if change.Type != types.HCApply {
+				continue
+			}
+			// the receipts in a tipset are the ones of the messages of its parent
+			msgs, err := b.node.ChainGetParentMessages(ctx, change.Val.Blocks()[0].Cid())
+			if err != nil {
+				log.Warnf("getting parent messages of %s: %v", change.Val.Key(), err)
+				continue
+			}
+			cids := make([]cid.Cid, len(msgs))
+			for i, msg := range msgs {
+				cids[i] = msg.Cid
+			}
+			b.tracker.executed(cids, now)
+		}
+	}
+}
+
+// waitReceipts waits until every submitted message is executed or the timeout expires.
+func (b *mpoolBench) waitReceipts(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, b.cfg.timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for b.tracker.pendingCount() > 0 {
+		select {
+		case <-ctx.Done():
+			log.Warnf("stopped waiting with %d messages not executed", b.tracker.pendingCount())
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// venus-bench benchmarks a running venus node through its API.
+//
+// Usage:
+//
+//	venus-bench <command> [flags]
+//
+// Commands:
+//
+//	bench-mpool  submits signed messages at a fixed rate and reports their inclusion latency and throughput
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	logging "github.com/ipfs/go-log/v2"
+
+	_ "github.com/filecoin-project/venus/pkg/crypto/secp" // enable secp signatures
+)
+
+var log = logging.Logger("venus-bench")
+
+var commands = map[string]func(args []string) error{
+	"bench-mpool": benchMpool,
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(os.Stderr, "usage: %s <command> [flags]\n\ncommands:\n", os.Args[0]) // nolint: errcheck
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", name) // nolint: errcheck
+	}
+	fmt.Fprintf(os.Stderr, "\nrun '%s <command> -h' for the flags of a command\n", os.Args[0]) // nolint: errcheck
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", os.Args[1]) // nolint: errcheck
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[1], err) // nolint: errcheck
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+)
+
+// inclusionTracker measures the time between the submission of messages and the chain producing their receipts.
+type inclusionTracker struct {
+	lk        sync.Mutex
+	pending   map[cid.Cid]time.Time
+	latencies []time.Duration
+	first     time.Time
+	last      time.Time
+}
+
+func newInclusionTracker() *inclusionTracker {
+	return &inclusionTracker{pending: make(map[cid.Cid]time.Time)}
+}
+
+// submitted records msg was submitted at t, it has to be called before the message can be observed.
+func (it *inclusionTracker) submitted(msg cid.Cid, t time.Time) {
+	it.lk.Lock()
+	defer it.lk.Unlock()
+
+	if it.first.IsZero() {
+		it.first = t
+	}
+	it.pending[msg] = t
+}
+
+// failed forgets msg, its submission failed.
+func (it *inclusionTracker) failed(msg cid.Cid) {
+	it.lk.Lock()
+	defer it.lk.Unlock()
+
+	delete(it.pending, msg)
+}
+
+// executed records the receipts of msgs were produced at t, unknown messages are ignored.
+func (it *inclusionTracker) executed(msgs []cid.Cid, t time.Time) {
+	it.lk.Lock()
+	defer it.lk.Unlock()
+
+	for _, msg := range msgs {
+		submitted, ok := it.pending[msg]
+		if !ok {
+			continue
+		}
+		delete(it.pending, msg)
+		it.latencies = append(it.latencies, t.Sub(submitted))
+		it.last = t
+	}
+}
+
+func (it *inclusionTracker) pendingCount() int {
+	it.lk.Lock()
+	defer it.lk.Unlock()
+
+	return len(it.pending)
+}
+
+// report summarizes the latencies of the executed messages.
+func (it *inclusionTracker) report() benchReport {
+	it.lk.Lock()
+	defer it.lk.Unlock()
+
+	latencies := append([]time.Duration(nil), it.latencies...)
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	r := benchReport{
+		Executed: len(latencies),
+		Pending:  len(it.pending),
+		P50:      percentile(latencies, 50),
+		P95:      percentile(latencies, 95),
+		P99:      percentile(latencies, 99),
+	}
+	if len(latencies) > 0 {
+		r.Max = latencies[len(latencies)-1]
+		if elapsed := it.last.Sub(it.first); elapsed > 0 {
+			r.Throughput = float64(len(latencies)) / elapsed.Seconds()
+		}
+	}
+	return r
+}
+
+// benchReport is the result of a benchmark run.
+type benchReport struct {
+	Submitted  int
+	Failed     int
+	Executed   int
+	Pending    int
+	SubmitRate float64
+	// Throughput is the number of executed messages per second between the first submission and the last execution
+	Throughput float64
+
+	P50, P95, P99, Max time.Duration
+}
+
+func (r benchReport) print(w io.Writer) {
+	fmt.Fprintf(w, "submitted:   %d (%.2f msg/s), failed: %d\n", r.Submitted, r.SubmitRate, r.Failed) // nolint: errcheck
+	fmt.Fprintf(w, "executed:    %d, pending: %d\n", r.Executed, r.Pending)                           // nolint: errcheck
+	fmt.Fprintf(w, "throughput:  %.2f msg/s\n", r.Throughput)                                         // nolint: errcheck
+	fmt.Fprintf(w, "latency:     p50 %s, p95 %s, p99 %s, max %s\n",                                   // nolint: errcheck
+		r.P50.Round(time.Millisecond), r.P95.Round(time.Millisecond), r.P99.Round(time.Millisecond), r.Max.Round(time.Millisecond))
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted, zero if it is empty.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestPercentile(t *testing.T) {
+	tf.UnitTest(t)
+
+	assert.Equal(t, time.Duration(0), percentile(nil, 50))
+
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+	assert.Equal(t, 50*time.Millisecond, percentile(sorted, 50))
+	assert.Equal(t, 95*time.Millisecond, percentile(sorted, 95))
+	assert.Equal(t, 99*time.Millisecond, percentile(sorted, 99))
+	assert.Equal(t, 100*time.Millisecond, percentile(sorted, 100))
+	assert.Equal(t, time.Millisecond, percentile(sorted, 0))
+
+	assert.Equal(t, 3*time.Second, percentile([]time.Duration{time.Second, 2 * time.Second, 3 * time.Second}, 99))
+}
+
+func TestInclusionTracker(t *testing.T) {
+	tf.UnitTest(t)
+
+	newCid := testhelpers.NewCidForTestGetter()
+	a, b, c, unknown := newCid(), newCid(), newCid(), newCid()
+
+	start := time.Now()
+	it := newInclusionTracker()
+	it.submitted(a, start)
+	it.submitted(b, start.Add(time.Second))
+	it.submitted(c, start.Add(time.Second))
+	it.failed(c)
+	assert.Equal(t, 2, it.pendingCount())
+
+	it.executed([]cid.Cid{a, unknown}, start.Add(2*time.Second))
+	assert.Equal(t, 1, it.pendingCount())
+	it.executed([]cid.Cid{b}, start.Add(5*time.Second))
+	assert.Equal(t, 0, it.pendingCount())
+
+	r := it.report()
+	assert.Equal(t, 2, r.Executed)
+	assert.Equal(t, 0, r.Pending)
+	assert.Equal(t, 2*time.Second, r.P50)
+	assert.Equal(t, 4*time.Second, r.P99)
+	assert.Equal(t, 4*time.Second, r.Max)
+	assert.InDelta(t, 0.4, r.Throughput, 1e-9)
+}
@@ -16,18 +16,29 @@ import (
 // Mock beacon assumes that filecoin rounds are 1:1 mapped with the beacon rounds
 type mockBeacon struct {
 	interval time.Duration
+	seed     int64
 }
 
 func NewMockBeacon(interval time.Duration) RandomBeacon {
-	mb := &mockBeacon{interval: interval}
+	return NewSeededMockBeacon(interval, 0)
+}
+
+// NewSeededMockBeacon returns a mock beacon whose entries are derived from seed as well,
+// mock beacons with different seeds produce different randomness.
+func NewSeededMockBeacon(interval time.Duration, seed int64) RandomBeacon {
+	mb := &mockBeacon{interval: interval, seed: seed}
 
 	return mb
 }
 
 func NewMockSchedule(interval time.Duration) Schedule {
+	return NewSeededMockSchedule(interval, 0)
+}
+
+func NewSeededMockSchedule(interval time.Duration, seed int64) Schedule {
 	return []BeaconPoint{{
 		Start:  abi.ChainEpoch(0),
-		Beacon: NewMockBeacon(interval),
+		Beacon: NewSeededMockBeacon(interval, seed),
 	}}
 }
 
@@ -36,8 +47,13 @@ func (mb *mockBeacon) RoundTime() time.Duration {
 }
 
 func (mb *mockBeacon) entryForIndex(index uint64) types.BeaconEntry {
-	buf := make([]byte, 8)
+	buf := make([]byte, 8, 16)
 	binary.BigEndian.PutUint64(buf, index)
+	if mb.seed != 0 {
+		// the unseeded mock keeps the entries it always had
+		buf = buf[:16]
+		binary.BigEndian.PutUint64(buf[8:], uint64(mb.seed))
+	}
 	rval := blake2b.Sum256(buf)
 	return types.BeaconEntry{
 		Round: index,
@@ -0,0 +1,28 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestSeededMockBeacon(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	entry := func(b RandomBeacon, round uint64) []byte {
+		resp := <-b.Entry(ctx, round)
+		require.NoError(t, resp.Err)
+		require.NoError(t, b.VerifyEntry(resp.Entry, resp.Entry))
+		return resp.Entry.Data
+	}
+
+	// the same seed produces the same entries, another seed other ones
+	require.Equal(t, entry(NewSeededMockBeacon(time.Second, 42), 7), entry(NewSeededMockBeacon(time.Second, 42), 7))
+	require.NotEqual(t, entry(NewSeededMockBeacon(time.Second, 42), 7), entry(NewSeededMockBeacon(time.Second, 43), 7))
+	require.Equal(t, entry(NewMockBeacon(time.Second), 7), entry(NewSeededMockBeacon(time.Second, 0), 7))
+}
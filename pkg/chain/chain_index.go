@@ -14,32 +14,52 @@ import (
 
 var DefaultChainIndexCacheSize = 32 << 15
 
+const (
+	// prefetchWindowSize is the number of recently queried heights tracked by PrefetchRange
+	prefetchWindowSize = 256
+	// prefetchMinHits is how often a skip interval has to be queried within the window to be prefetched
+	prefetchMinHits = 2
+	// prefetchConcurrency bounds the prefetches running at once, extra ones are dropped
+	prefetchConcurrency = 4
+)
+
 func init() {
 	if s := os.Getenv("CHAIN_INDEX_CACHE"); s != "" {
 		lcic, err := strconv.Atoi(s)
 		if err != nil {
 			log.Errorf("failed to parse 'CHAIN_INDEX_CACHE' env var: %s", err)
+		} else {
+			DefaultChainIndexCacheSize = lcic
 		}
-		DefaultChainIndexCacheSize = lcic
 	}
 }
 
 // ChainIndex tipset height index, used to getting tipset by height quickly
 type ChainIndex struct { //nolint
-	indexCacheLk sync.Mutex
+	indexCacheLk sync.RWMutex
 	indexCache   map[types.TipSetKey]*lbEntry
+	// cacheSize bounds the entries of indexCache, a size of 0 or less leaves it unbounded
+	cacheSize int
 
 	loadTipSet loadTipSetFunc
 
 	skipLength abi.ChainEpoch
+
+	prefetchLk   sync.Mutex
+	recent       []abi.ChainEpoch
+	recentHits   map[abi.ChainEpoch]int
+	prefetchSema chan struct{}
 }
 
-// NewChainIndex return a new chain index with arc cache
+// NewChainIndex return a new chain index with a cache of DefaultChainIndexCacheSize entries
 func NewChainIndex(lts loadTipSetFunc) *ChainIndex {
 	return &ChainIndex{
-		indexCache: make(map[types.TipSetKey]*lbEntry, DefaultChainIndexCacheSize),
-		loadTipSet: lts,
-		skipLength: 20,
+		indexCache:   make(map[types.TipSetKey]*lbEntry),
+		cacheSize:    DefaultChainIndexCacheSize,
+		loadTipSet:   lts,
+		skipLength:   20,
+		recentHits:   make(map[abi.ChainEpoch]int),
+		prefetchSema: make(chan struct{}, prefetchConcurrency),
 	}
 }
 
@@ -62,11 +82,11 @@ func (ci *ChainIndex) GetTipSetByHeight(ctx context.Context, from *types.TipSet,
 		return nil, fmt.Errorf("failed to round down: %w", err)
 	}
 
-	ci.indexCacheLk.Lock()
-	defer ci.indexCacheLk.Unlock()
 	cur := rounded.Key()
 	for {
+		ci.indexCacheLk.RLock()
 		lbe, ok := ci.indexCache[cur]
+		ci.indexCacheLk.RUnlock()
 		if !ok {
 			fc, err := ci.fillCache(ctx, cur)
 			if err != nil {
@@ -95,12 +115,55 @@ func (ci *ChainIndex) GetTipSetByHeight(ctx context.Context, from *types.TipSet,
 	}
 }
 
+// PrefetchRange records heights as queried from the tipset from and asynchronously fills the cache for the
+// skip intervals queried at least prefetchMinHits times among the recent queries, so that the following
+// GetTipSetByHeight calls are served from the cache. The prefetches stop when ctx is cancelled.
+func (ci *ChainIndex) PrefetchRange(ctx context.Context, from *types.TipSet, heights []abi.ChainEpoch) {
+	var targets []abi.ChainEpoch
+	ci.prefetchLk.Lock()
+	for _, h := range heights {
+		// heights close to from are walked back directly without the cache
+		if h < 0 || from.Height()-h <= ci.skipLength {
+			continue
+		}
+		bucket := ci.roundHeight(h)
+		if len(ci.recent) == prefetchWindowSize {
+			old := ci.recent[0]
+			ci.recent = ci.recent[1:]
+			if ci.recentHits[old]--; ci.recentHits[old] == 0 {
+				delete(ci.recentHits, old)
+			}
+		}
+		ci.recent = append(ci.recent, bucket)
+		ci.recentHits[bucket]++
+		if ci.recentHits[bucket] == prefetchMinHits {
+			targets = append(targets, bucket)
+		}
+	}
+	ci.prefetchLk.Unlock()
+
+	for _, h := range targets {
+		select {
+		case ci.prefetchSema <- struct{}{}:
+		default:
+			log.Debugf("chain index prefetch of height %d dropped, too many running", h)
+			continue
+		}
+		go func(h abi.ChainEpoch) {
+			defer func() { <-ci.prefetchSema }()
+			if _, err := ci.GetTipSetByHeight(ctx, from, h); err != nil {
+				log.Debugf("chain index prefetch of height %d failed: %s", h, err)
+			}
+		}(h)
+	}
+}
+
 // GetTipsetByHeightWithoutCache get the tipset of specific height by reading the database directly
 func (ci *ChainIndex) GetTipsetByHeightWithoutCache(ctx context.Context, from *types.TipSet, to abi.ChainEpoch) (*types.TipSet, error) {
 	return ci.walkBack(ctx, from, to)
 }
 
-// Caller must hold indexCacheLk
+// fillCache computes the skip entry of tsk and caches it, concurrent fills of the same key store the same entry.
 func (ci *ChainIndex) fillCache(ctx context.Context, tsk types.TipSetKey) (*lbEntry, error) {
 	ts, err := ci.loadTipSet(ctx, tsk)
 	if err != nil {
@@ -141,7 +204,16 @@ func (ci *ChainIndex) fillCache(ctx context.Context, tsk types.TipSetKey) (*lbEn
 		targetHeight: skipTarget.Height(),
 		target:       skipTarget.Key(),
 	}
+	ci.indexCacheLk.Lock()
+	if _, ok := ci.indexCache[tsk]; !ok && ci.cacheSize > 0 && len(ci.indexCache) >= ci.cacheSize {
+		// evict an arbitrary entry, it is filled again by the next lookup going through it
+		for k := range ci.indexCache {
+			delete(ci.indexCache, k)
+			break
+		}
+	}
 	ci.indexCache[tsk] = lbe
+	ci.indexCacheLk.Unlock()
 
 	return lbe, nil
 }
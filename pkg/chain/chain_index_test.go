@@ -7,9 +7,12 @@ import (
 	"math/rand"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
 	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/stretchr/testify/require"
@@ -56,3 +59,97 @@ func TestChainIndex(t *testing.T) {
 	_, err = chainIndex.GetTipSetByHeight(ctx, head, head.Height()/2)
 	require.Error(t, err)
 }
+
+func TestChainIndexPrefetchRange(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+
+	builder := NewBuilder(t, address.Undef)
+	head := builder.AppendManyOn(ctx, 60, builder.Genesis())
+
+	chainIndex := NewChainIndex(builder.GetTipSet)
+	chainIndex.skipLength = 10
+	cacheLen := func() int {
+		chainIndex.indexCacheLk.RLock()
+		defer chainIndex.indexCacheLk.RUnlock()
+		return len(chainIndex.indexCache)
+	}
+
+	// a single query of a skip interval is not prefetched, nor are heights close to the head
+	chainIndex.PrefetchRange(ctx, head, []abi.ChainEpoch{5, 55, 56})
+	require.Equal(t, 0, cacheLen())
+
+	chainIndex.PrefetchRange(ctx, head, []abi.ChainEpoch{6, 7})
+	require.Eventually(t, func() bool { return cacheLen() > 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestChainIndexCacheSize(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+
+	builder := NewBuilder(t, address.Undef)
+	head := builder.AppendManyOn(ctx, 100, builder.Genesis())
+
+	chainIndex := NewChainIndex(builder.GetTipSet)
+	chainIndex.skipLength = 10
+	chainIndex.cacheSize = 3
+
+	for h := abi.ChainEpoch(0); h < head.Height(); h++ {
+		ts, err := chainIndex.GetTipSetByHeight(ctx, head, h)
+		require.NoError(t, err)
+		require.Equal(t, h, ts.Height())
+		require.LessOrEqual(t, len(chainIndex.indexCache), chainIndex.cacheSize)
+	}
+	require.Len(t, chainIndex.indexCache, chainIndex.cacheSize)
+}
+
+func BenchmarkChainIndexGetTipSetByHeightParallel(b *testing.B) {
+	tf.BenchUnitTest(b)
+
+	ctx := context.Background()
+	miner, err := address.NewIDAddress(1000)
+	require.NoError(b, err)
+
+	tipsets := make(map[types.TipSetKey]*types.TipSet)
+	var head *types.TipSet
+	for h := abi.ChainEpoch(0); h < 2000; h++ {
+		blk := &types.BlockHeader{
+			Miner:                 miner,
+			Height:                h,
+			ParentWeight:          big.Zero(),
+			ParentBaseFee:         big.Zero(),
+			ParentStateRoot:       testhelpers.EmptyTxMetaCID,
+			ParentMessageReceipts: testhelpers.EmptyReceiptsCID,
+			Messages:              testhelpers.EmptyTxMetaCID,
+		}
+		if head != nil {
+			blk.Parents = head.Cids()
+		}
+		head, err = types.NewTipSet([]*types.BlockHeader{blk})
+		require.NoError(b, err)
+		tipsets[head.Key()] = head
+	}
+
+	chainIndex := NewChainIndex(func(_ context.Context, tsk types.TipSetKey) (*types.TipSet, error) {
+		return tipsets[tsk], nil
+	})
+	// warm the cache, the benchmark measures concurrent lookups served from it
+	for h := abi.ChainEpoch(0); h < head.Height(); h += chainIndex.skipLength {
+		_, err := chainIndex.GetTipSetByHeight(ctx, head, h)
+		require.NoError(b, err)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		h := abi.ChainEpoch(0)
+		for pb.Next() {
+			if _, err := chainIndex.GetTipSetByHeight(ctx, head, h); err != nil {
+				b.Error(err)
+				return
+			}
+			h = (h + chainIndex.skipLength) % (head.Height() - chainIndex.skipLength)
+		}
+	})
+}
@@ -111,6 +111,25 @@ func (ms *MessageStore) ReadMsgMetaCids(ctx context.Context, mmc cid.Cid) ([]cid
 	return blsCids, secpCids, nil
 }
 
+// ErrMessageNotInTipSet is returned by FindMessageBlock when no block of the tipset includes the message
+var ErrMessageNotInTipSet = errors.New("message not found in tipset")
+
+// FindMessageBlock returns the first block of ts including the message mid
+func (ms *MessageStore) FindMessageBlock(ctx context.Context, ts *types.TipSet, mid cid.Cid) (*types.BlockHeader, error) {
+	for _, blk := range ts.Blocks() {
+		blsCids, secpCids, err := ms.ReadMsgMetaCids(ctx, blk.Messages)
+		if err != nil {
+			return nil, fmt.Errorf("loading messages of block %s: %w", blk.Cid(), err)
+		}
+		for _, c := range append(blsCids, secpCids...) {
+			if c == mid {
+				return blk, nil
+			}
+		}
+	}
+	return nil, ErrMessageNotInTipSet
+}
+
 // LoadMessage load message of specify message cid
 // First get the unsigned message. If it is not found, then get the signed message. If still not found, an error will be returned
 func (ms *MessageStore) LoadMessage(ctx context.Context, mid cid.Cid) (types.ChainMsg, error) {
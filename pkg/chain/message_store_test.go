@@ -10,6 +10,8 @@ import (
 	"github.com/ipfs/go-cid"
 	cbg "github.com/whyrusleeping/cbor-gen"
 
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/specs-actors/actors/util/adt"
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/config"
@@ -229,3 +231,54 @@ func TestMessageStoreLoadMessage(t *testing.T) {
 	_, err = ms.LoadUnsignedMessagesFromCids(ctx, []cid.Cid{badMsgCID})
 	assert.Error(t, err)
 }
+
+func TestMessageStoreFindMessageBlock(t *testing.T) {
+	testflags.UnitTest(t)
+	ctx := context.Background()
+	keys := testhelpers.MustGenerateKeyInfo(2, 42)
+	mm := testhelpers.NewMessageMaker(t, keys)
+
+	alice := mm.Addresses()[0]
+	bob := mm.Addresses()[1]
+
+	bs := blockstoreutil.Adapt(blockstore.NewBlockstore(datastore.NewMapDatastore()))
+	ms := chain.NewMessageStore(bs, config.DefaultForkUpgradeParam)
+
+	aliceMsg := mm.NewSignedMessage(alice, 0)
+	bobMsg := mm.NewUnsignedMessage(bob, 0)
+	missing := mm.NewSignedMessage(alice, 1)
+
+	aliceMeta, err := ms.StoreMessages(ctx, []*types.SignedMessage{aliceMsg}, nil)
+	require.NoError(t, err)
+	bobMeta, err := ms.StoreMessages(ctx, nil, []*types.Message{bobMsg})
+	require.NoError(t, err)
+
+	mkBlock := func(miner uint64, meta cid.Cid) *types.BlockHeader {
+		minerAddr, err := address.NewIDAddress(miner)
+		require.NoError(t, err)
+		return &types.BlockHeader{
+			Miner:                 minerAddr,
+			Ticket:                &types.Ticket{VRFProof: []byte{byte(miner)}},
+			Height:                10,
+			ParentWeight:          big.Zero(),
+			ParentBaseFee:         big.Zero(),
+			ParentStateRoot:       meta,
+			ParentMessageReceipts: meta,
+			Messages:              meta,
+		}
+	}
+	aliceBlk := mkBlock(1000, aliceMeta)
+	bobBlk := mkBlock(1001, bobMeta)
+	ts := testhelpers.RequireNewTipSet(t, aliceBlk, bobBlk)
+
+	blk, err := ms.FindMessageBlock(ctx, ts, aliceMsg.Cid())
+	require.NoError(t, err)
+	assert.Equal(t, aliceBlk.Cid(), blk.Cid())
+
+	blk, err = ms.FindMessageBlock(ctx, ts, bobMsg.Cid())
+	require.NoError(t, err)
+	assert.Equal(t, bobBlk.Cid(), blk.Cid())
+
+	_, err = ms.FindMessageBlock(ctx, ts, missing.Cid())
+	assert.ErrorIs(t, err, chain.ErrMessageNotInTipSet)
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"runtime/debug"
+	"strconv"
 	"sync"
 
 	lru "github.com/hashicorp/golang-lru/v2"
@@ -17,6 +18,7 @@ import (
 	mh "github.com/multiformats/go-multihash"
 	"github.com/pkg/errors"
 	cbg "github.com/whyrusleeping/cbor-gen"
+	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 
 	"github.com/ipfs/go-cid"
@@ -34,11 +36,13 @@ import (
 	blockadt "github.com/filecoin-project/specs-actors/actors/util/adt"
 
 	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/metrics"
 	"github.com/filecoin-project/venus/pkg/metrics/tracing"
 	"github.com/filecoin-project/venus/pkg/repo"
 	"github.com/filecoin-project/venus/pkg/state"
 	"github.com/filecoin-project/venus/pkg/state/tree"
 	"github.com/filecoin-project/venus/pkg/util"
+	"github.com/filecoin-project/venus/pkg/util/reqid"
 
 	"github.com/filecoin-project/venus/venus-shared/actors/adt"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
@@ -71,6 +75,11 @@ type ReorgNotifee func(rev, app []*types.TipSet) error
 
 var DefaultTipsetLruCacheSize = 10000
 
+var (
+	cacheHitKey     = tag.MustNewKey("cache_hit")
+	loadTipSetTimer = metrics.NewTimerUs("chain/load_tipset", "Duration of loading a tipset in microseconds", cacheHitKey)
+)
+
 type reorg struct {
 	old []*types.TipSet
 	new []*types.TipSet
@@ -124,11 +133,19 @@ type Store struct {
 	circulatingSupplyCalculator ICirculatingSupplyCalcualtor
 
 	chainIndex *ChainIndex
+	// prefetchCtx bounds the lifetime of the chain index prefetches, it is cancelled by Stop
+	prefetchCtx    context.Context
+	prefetchCancel context.CancelFunc
 
 	reorgCh        chan reorg
 	reorgNotifeeCh chan ReorgNotifee
 
 	tsCache *lru.ARCCache[types.TipSetKey, *types.TipSet]
+
+	// latestBeacon caches the latest beacon entry found from the head it was computed for
+	latestBeaconLk  sync.Mutex
+	latestBeaconKey types.TipSetKey
+	latestBeacon    *types.BeaconEntry
 }
 
 // NewStore constructs a new default store.
@@ -152,6 +169,7 @@ func NewStore(chainDs repo.Datastore,
 	// todo cycle reference , may think a better idea
 	store.tipIndex = NewTipStateCache(store)
 	store.chainIndex = NewChainIndex(store.GetTipSet)
+	store.prefetchCtx, store.prefetchCancel = context.WithCancel(context.Background())
 	store.circulatingSupplyCalculator = circulatiingSupplyCalculator
 
 	val, err := store.ds.Get(context.TODO(), CheckPoint)
@@ -319,7 +337,9 @@ func (store *Store) GetTipSet(ctx context.Context, key types.TipSetKey) (*types.
 		return store.GetHead(), nil
 	}
 
+	stopwatch := loadTipSetTimer.Start(ctx)
 	if val, has := store.tsCache.Get(key); has {
+		recordLoadTipSet(ctx, stopwatch, true)
 		return val, nil
 	}
 
@@ -339,10 +359,16 @@ func (store *Store) GetTipSet(ctx context.Context, key types.TipSetKey) (*types.
 		return nil, err
 	}
 	store.tsCache.Add(key, ts)
+	recordLoadTipSet(ctx, stopwatch, false)
 
 	return ts, nil
 }
 
+func recordLoadTipSet(ctx context.Context, stopwatch *metrics.Stopwatch, cacheHit bool) {
+	ctx, _ = tag.New(ctx, tag.Upsert(cacheHitKey, strconv.FormatBool(cacheHit)))
+	stopwatch.Stop(ctx)
+}
+
 // GetTipSetByHeight looks back for a tipset at the specified epoch.
 // If there are no blocks at the specified epoch, a tipset at an earlier epoch
 // will be returned.
@@ -365,7 +391,7 @@ func (store *Store) GetTipSetByHeight(ctx context.Context, ts *types.TipSet, h a
 	}
 
 	if lbts.Height() < h {
-		log.Warnf("chain index returned the wrong tipset at height %d, using slow retrieval", h)
+		reqid.Logger(ctx, log).Warnf("chain index returned the wrong tipset at height %d, using slow retrieval", h)
 		lbts, err = store.chainIndex.GetTipsetByHeightWithoutCache(ctx, ts, h)
 		if err != nil {
 			return nil, err
@@ -379,6 +405,12 @@ func (store *Store) GetTipSetByHeight(ctx context.Context, ts *types.TipSet, h a
 	return store.GetTipSet(ctx, lbts.Parents())
 }
 
+// PrefetchTipSetsByHeight hints that the tipsets at heights are about to be looked up from ts, the chain index
+// prefetches the ranges queried repeatedly in the background until the store is stopped.
+func (store *Store) PrefetchTipSetsByHeight(ts *types.TipSet, heights []abi.ChainEpoch) {
+	store.chainIndex.PrefetchRange(store.prefetchCtx, ts, heights)
+}
+
 func (store *Store) GetTipSetByCid(ctx context.Context, c cid.Cid) (*types.TipSet, error) {
 	blk, err := store.bsstore.Get(ctx, c)
 	if err != nil {
@@ -502,6 +534,45 @@ func (store *Store) GetLatestBeaconEntry(ctx context.Context, ts *types.TipSet)
 	return nil, fmt.Errorf("found NO beacon entries in the 20 blocks prior to given tipset")
 }
 
+// GetLatestBeaconEntryFromHead walks back from the chain head until it finds a tipset carrying
+// beacon entries and returns the one with the highest round. The result is cached for the current head.
+func (store *Store) GetLatestBeaconEntryFromHead(ctx context.Context) (*types.BeaconEntry, error) {
+	head := store.GetHead()
+
+	store.latestBeaconLk.Lock()
+	defer store.latestBeaconLk.Unlock()
+	if store.latestBeacon != nil && store.latestBeaconKey.Equals(head.Key()) {
+		return store.latestBeacon, nil
+	}
+
+	cur := head
+	for {
+		var latest *types.BeaconEntry
+		for _, blk := range cur.Blocks() {
+			for i := range blk.BeaconEntries {
+				if latest == nil || blk.BeaconEntries[i].Round > latest.Round {
+					latest = &blk.BeaconEntries[i]
+				}
+			}
+		}
+		if latest != nil {
+			store.latestBeaconKey = head.Key()
+			store.latestBeacon = latest
+			return latest, nil
+		}
+
+		if cur.Height() == 0 {
+			return nil, fmt.Errorf("made it back to genesis block without finding beacon entry")
+		}
+
+		next, err := store.GetTipSet(ctx, cur.Parents())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load parents when searching back for latest beacon entry: %w", err)
+		}
+		cur = next
+	}
+}
+
 // nolint
 func (store *Store) walkBack(ctx context.Context, from *types.TipSet, to abi.ChainEpoch) (*types.TipSet, error) {
 	if to > from.Height() {
@@ -1220,6 +1291,7 @@ func (store *Store) GetCheckPoint() types.TipSetKey {
 
 // Stop stops all activities and cleans up.
 func (store *Store) Stop() {
+	store.prefetchCancel()
 	store.headEvents.Shutdown()
 }
 
@@ -519,6 +519,14 @@ func TestLoadTipsetMeta(t *testing.T) {
 		_, err = cs.GetLatestBeaconEntry(ctx, head)
 		assert.Error(t, err)
 
+		// walking back from head is not limited in depth, and the result is cached for the head
+		latest, err := cs.GetLatestBeaconEntryFromHead(ctx)
+		assert.NoError(t, err)
+		assert.Greater(t, len(latest.Data), 0)
+		cached, err := cs.GetLatestBeaconEntryFromHead(ctx)
+		assert.NoError(t, err)
+		assert.True(t, latest == cached)
+
 		deletedCid := ts.Parents().Cids()[0]
 		block, err := bs.Get(ctx, deletedCid)
 		assert.NoError(t, err)
@@ -40,6 +40,7 @@ type Config struct {
 	SlashFilterDs *SlashFilterDsConfig `json:"slashFilter"`
 	RateLimitCfg  *RateLimitCfg        `json:"rateLimit"`
 	FevmConfig    *FevmConfig          `json:"fevm"`
+	Health        *HealthConfig        `json:"health"`
 }
 
 // APIConfig holds all configuration options related to the api.
@@ -51,6 +52,14 @@ type APIConfig struct {
 	AccessControlAllowOrigin      []string `json:"accessControlAllowOrigin"`
 	AccessControlAllowCredentials bool     `json:"accessControlAllowCredentials"`
 	AccessControlAllowMethods     []string `json:"accessControlAllowMethods"`
+	// MaxConnectionsPerIP limits the open api connections from a single remote ip, 0 means no limit
+	MaxConnectionsPerIP int `json:"maxConnectionsPerIP"`
+	// ConnectionLimitAllowlist are the ips and cidrs not limited by MaxConnectionsPerIP, loopback addresses never are
+	ConnectionLimitAllowlist []string `json:"connectionLimitAllowlist"`
+	// EnableCompression gzips responses of clients accepting it
+	EnableCompression bool `json:"enableCompression"`
+	// CompressionThreshold is the minimum response size in bytes that gets compressed
+	CompressionThreshold int `json:"compressionThreshold"`
 }
 
 type RateLimitCfg struct {
@@ -70,6 +79,8 @@ func newDefaultAPIConfig() *APIConfig {
 			"https://127.0.0.1:8080",
 		},
 		AccessControlAllowMethods: []string{"GET", "POST", "PUT"},
+		EnableCompression:         true,
+		CompressionThreshold:      1024,
 	}
 }
 
@@ -100,11 +111,18 @@ func newDefaultDatastoreConfig() *DatastoreConfig {
 type SwarmConfig struct {
 	Address            string `json:"address"`
 	PublicRelayAddress string `json:"public_relay_address,omitempty"`
+	// PubsubSeenMessagesTTL is how long gossipsub remembers the ids of the messages it has seen,
+	// a message seen again within it is dropped as a duplicate
+	PubsubSeenMessagesTTL Duration `json:"pubsubSeenMessagesTTL"`
+	// HighPriorityPeerTags maps connection manager tags to weights, the value of a tag set through
+	// NetTagPeer is multiplied by its weight so that e.g. deal protocol peers are trimmed last
+	HighPriorityPeerTags map[string]int `json:"highPriorityPeerTags,omitempty"`
 }
 
 func newDefaultSwarmConfig() *SwarmConfig {
 	return &SwarmConfig{
-		Address: "/ip4/0.0.0.0/tcp/0",
+		Address:               "/ip4/0.0.0.0/tcp/0",
+		PubsubSeenMessagesTTL: Duration(2 * time.Minute),
 	}
 }
 
@@ -113,6 +131,11 @@ type BootstrapConfig struct {
 	Addresses        []string `json:"addresses"`
 	MinPeerThreshold int      `json:"minPeerThreshold"`
 	Period           string   `json:"period,omitempty"`
+	// HealthInterval is how often each bootstrap peer is dialed to check it is responsive
+	HealthInterval string `json:"healthInterval,omitempty"`
+	// MaxFailures is the number of consecutive failed dials after which a bootstrap peer
+	// is no longer used, until it responds again
+	MaxFailures int `json:"maxFailures"`
 }
 
 // TODO: provide bootstrap node addresses
@@ -121,6 +144,8 @@ func newDefaultBootstrapConfig() *BootstrapConfig {
 		Addresses:        []string{},
 		MinPeerThreshold: 3, // TODO: we don't actually have an bootstrap peers yet.
 		Period:           "1m",
+		HealthInterval:   "10m",
+		MaxFailures:      3,
 	}
 }
 
@@ -236,17 +261,26 @@ type MessagePoolConfig struct {
 	MaxNonceGap uint64 `json:"maxNonceGap"`
 	// MaxFee
 	MaxFee types.FIL `json:"maxFee"`
+	// SyncLagLimit is the maximum number of epochs the chain head may be behind the network head
+	// before messages from the network are rejected, zero or less disables the check
+	SyncLagLimit abi.ChainEpoch `json:"syncLagLimit"`
+	// RebroadcastMinDelay is the number of epochs a local message has to be pending before it is republished
+	RebroadcastMinDelay abi.ChainEpoch `json:"rebroadcastMinDelay"`
 }
 
 var DefaultMessagePoolParam = &MessagePoolConfig{
-	MaxNonceGap: 100,
-	MaxFee:      DefaultDefaultMaxFee,
+	MaxNonceGap:         100,
+	MaxFee:              DefaultDefaultMaxFee,
+	SyncLagLimit:        5,
+	RebroadcastMinDelay: 2,
 }
 
 func newDefaultMessagePoolConfig() *MessagePoolConfig {
 	return &MessagePoolConfig{
-		MaxNonceGap: 100,
-		MaxFee:      DefaultDefaultMaxFee,
+		MaxNonceGap:         100,
+		MaxFee:              DefaultDefaultMaxFee,
+		SyncLagLimit:        5,
+		RebroadcastMinDelay: 2,
 	}
 }
 
@@ -405,6 +439,11 @@ type EventConfig struct {
 	// relative to the CWD (current working directory).
 	DatabasePath string `json:"databasePath"`
 
+	// EnableFVMEventStore persists the native actor events of every applied tipset next to the other sqlite
+	// databases of the repo, whether or not the eth RPC is enabled. They are queried by GetFVMEvents, events
+	// older than MaxFilterHeightRange epochs are pruned.
+	EnableFVMEventStore bool `json:"enableFVMEventStore"`
+
 	// Others, not implemented yet:
 	// Set a limit on the number of active websocket subscriptions (may be zero)
 	// Set a timeout for subscription clients
@@ -418,6 +457,24 @@ type FevmConfig struct {
 	// Set to 0 to keep all mappings
 	EthTxHashMappingLifetimeDays int `json:"ethTxHashMappingLifetimeDays"`
 
+	// WSKeepaliveInterval is the interval at which websocket connections are expected to be pinged
+	WSKeepaliveInterval Duration `json:"wsKeepaliveInterval"`
+	// WSKeepaliveTimeout is how long a websocket connection may go without answering a ping, or
+	// a subscriber may take to accept a notification, before the connection is considered dead
+	WSKeepaliveTimeout Duration `json:"wsKeepaliveTimeout"`
+
+	// ProtocolVersionMap maps a network version to the protocol version reported by eth_protocolVersion,
+	// the network version itself is reported when it is missing from the map
+	ProtocolVersionMap map[network.Version]uint64 `json:"protocolVersionMap,omitempty"`
+
+	// TraceAddresses and TraceMethods keep the execution traces of the messages sent from or to one of the addresses
+	// and calling one of the methods only, when a tipset is executed with tracing for the trace apis or StateCompute.
+	// StateReplay returns the trace of its message either way. An empty list does not restrict the messages.
+	// The ffi FVM traces either every message of the tipset or none, so the filter does not reduce the cost of
+	// tracing: the traces of the other messages are still produced and are only not decoded or returned.
+	TraceAddresses []address.Address `json:"traceAddresses,omitempty"`
+	TraceMethods   []abi.MethodNum   `json:"traceMethods,omitempty"`
+
 	Event EventConfig `json:"event"`
 }
 
@@ -425,6 +482,8 @@ func newFevmConfig() *FevmConfig {
 	return &FevmConfig{
 		EnableEthRPC:                 false,
 		EthTxHashMappingLifetimeDays: 0,
+		WSKeepaliveInterval:          Duration(30 * time.Second),
+		WSKeepaliveTimeout:           Duration(10 * time.Second),
 		Event: EventConfig{
 			EnableRealTimeFilterAPI: false,
 			EnableHistoricFilterAPI: false,
@@ -432,10 +491,45 @@ func newFevmConfig() *FevmConfig {
 			MaxFilters:              100,
 			MaxFilterResults:        10000,
 			MaxFilterHeightRange:    2880, // conservative limit of one day
+			EnableFVMEventStore:     false,
 		},
 	}
 }
 
+// HealthConfig configures the health score reported by NodeHealthScore and GET /health.
+// The score starts at 100 and every failed check subtracts its penalty, down to 0.
+type HealthConfig struct {
+	// SyncedThreshold is the number of epochs the head may lag behind the wall clock while the node is still synced
+	SyncedThreshold abi.ChainEpoch `json:"syncedThreshold"`
+	// BehindPenalty is subtracted for every epoch the head lags behind beyond SyncedThreshold
+	BehindPenalty int `json:"behindPenalty"`
+	// MinPeers is the number of connected peers below which PeerPenalty is subtracted
+	MinPeers    int `json:"minPeers"`
+	PeerPenalty int `json:"peerPenalty"`
+	// MaxMpoolSize is the number of pending messages above which MpoolPenalty is subtracted
+	MaxMpoolSize int `json:"maxMpoolSize"`
+	MpoolPenalty int `json:"mpoolPenalty"`
+	// MaxAPILatency is the time gathering the health data may take before LatencyPenalty is subtracted
+	MaxAPILatency  Duration `json:"maxAPILatency"`
+	LatencyPenalty int      `json:"latencyPenalty"`
+	// MinHealthyScore is the lowest score GET /health answers 200 for, lower scores answer 503
+	MinHealthyScore int `json:"minHealthyScore"`
+}
+
+func newDefaultHealthConfig() *HealthConfig {
+	return &HealthConfig{
+		SyncedThreshold: 3,
+		BehindPenalty:   5,
+		MinPeers:        5,
+		PeerPenalty:     20,
+		MaxMpoolSize:    10000,
+		MpoolPenalty:    10,
+		MaxAPILatency:   Duration(time.Second),
+		LatencyPenalty:  10,
+		MinHealthyScore: 50,
+	}
+}
+
 // NewDefaultConfig returns a config object with all the fields filled out to
 // their default values
 func NewDefaultConfig() *Config {
@@ -451,6 +545,7 @@ func NewDefaultConfig() *Config {
 		SlashFilterDs: newDefaultSlashFilterDsConfig(),
 		RateLimitCfg:  newRateLimitConfig(),
 		FevmConfig:    newFevmConfig(),
+		Health:        newDefaultHealthConfig(),
 	}
 }
 
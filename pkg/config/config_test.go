@@ -23,6 +23,7 @@ func TestDefaults(t *testing.T) {
 	bs := []string{}
 	assert.Equal(t, "/ip4/127.0.0.1/tcp/3453", cfg.API.APIAddress)
 	assert.Equal(t, "/ip4/0.0.0.0/tcp/0", cfg.Swarm.Address)
+	assert.Equal(t, Duration(2*time.Minute), cfg.Swarm.PubsubSeenMessagesTTL)
 	assert.Equal(t, bs, cfg.Bootstrap.Addresses)
 }
 
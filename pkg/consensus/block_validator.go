@@ -133,7 +133,7 @@ func (bv *BlockValidator) ValidateFullBlock(ctx context.Context, blk *types.Bloc
 	if _, ok := bv.validateBlockCache.Get(blk.Cid()); ok {
 		return nil
 	}
-	err := bv.validateBlock(ctx, blk)
+	err := bv.validateBlock(ctx, blk, false)
 	if err != nil {
 		return err
 	}
@@ -144,7 +144,53 @@ func (bv *BlockValidator) ValidateFullBlock(ctx context.Context, blk *types.Bloc
 	return nil
 }
 
-func (bv *BlockValidator) validateBlock(ctx context.Context, blk *types.BlockHeader) error {
+// ValidateBlock runs the same checks as ValidateFullBlock, but neither consults nor fills the
+// validation cache, and keeps going after a failed header check so that every failure is reported.
+func (bv *BlockValidator) ValidateBlock(ctx context.Context, blk *types.BlockHeader) error {
+	return bv.validateBlock(ctx, blk, true)
+}
+
+// checkBlockHeader checks the timestamp and the parent weight of blk against its parent, it returns
+// the first failure, or all of them when collectAll is set.
+func checkBlockHeader(blk *types.BlockHeader, parent *types.TipSet, parentWeight big.Int, blockDelay, allowableClockDrift, now uint64, collectAll bool) error {
+	var merr error
+	// fail records err and reports whether the checks should stop
+	fail := func(err error) bool {
+		if !collectAll {
+			merr = err
+			return true
+		}
+		merr = multierror.Append(merr, err)
+		return false
+	}
+
+	nulls := blk.Height - (parent.Height() + 1)
+	if tgtTS := parent.MinTimestamp() + blockDelay*uint64(nulls+1); blk.Timestamp != tgtTS {
+		if fail(fmt.Errorf("block has wrong timestamp: %d != %d", blk.Timestamp, tgtTS)) {
+			return merr
+		}
+	}
+
+	if blk.Timestamp > now+allowableClockDrift {
+		if fail(fmt.Errorf("block was from the future (now=%d, blk=%d): %v", now, blk.Timestamp, ErrTemporal)) {
+			return merr
+		}
+	} else if blk.Timestamp > now {
+		logExpect.Warn("Got block from the future, but within threshold", blk.Timestamp, now)
+	}
+
+	if !parentWeight.Equals(blk.ParentWeight) {
+		fail(fmt.Errorf("block %s has invalid parent weight %d expected %d", blk.Cid().String(), blk.ParentWeight, parentWeight))
+	}
+
+	return merr
+}
+
+// validateBlock returns at the first failed header check unless collectAll is set, in which case
+// header failures are accumulated with the failures of the remaining checks.
+func (bv *BlockValidator) validateBlock(ctx context.Context, blk *types.BlockHeader, collectAll bool) error {
+	var merr error
+
 	parent, err := bv.chainState.GetTipSet(ctx, types.NewTipSetKey(blk.Parents...))
 	if err != nil {
 		return fmt.Errorf("load parent tipset failed %w", err)
@@ -155,21 +201,16 @@ func (bv *BlockValidator) validateBlock(ctx context.Context, blk *types.BlockHea
 	}
 
 	if err := blockSanityChecks(blk); err != nil {
+		// the remaining checks dereference the fields checked here
 		return fmt.Errorf("incoming header failed basic sanity checks: %w", err)
 	}
 
-	baseHeight := parent.Height()
-	nulls := blk.Height - (baseHeight + 1)
-	if tgtTS := parent.MinTimestamp() + bv.config.BlockDelay*uint64(nulls+1); blk.Timestamp != tgtTS {
-		return fmt.Errorf("block has wrong timestamp: %d != %d", blk.Timestamp, tgtTS)
-	}
-
 	now := uint64(time.Now().Unix())
-	if blk.Timestamp > now+bv.config.AllowableClockDriftSecs {
-		return fmt.Errorf("block was from the future (now=%d, blk=%d): %v", now, blk.Timestamp, ErrTemporal)
-	}
-	if blk.Timestamp > now {
-		logExpect.Warn("Got block from the future, but within threshold", blk.Timestamp, time.Now().Unix())
+	if err := checkBlockHeader(blk, parent, parentWeight, bv.config.BlockDelay, bv.config.AllowableClockDriftSecs, now, collectAll); err != nil {
+		if !collectAll {
+			return err
+		}
+		merr = multierror.Append(merr, err)
 	}
 
 	// get parent beacon
@@ -178,10 +219,6 @@ func (bv *BlockValidator) validateBlock(ctx context.Context, blk *types.BlockHea
 		return fmt.Errorf("failed to get latest beacon entry: %w", err)
 	}
 
-	if !parentWeight.Equals(blk.ParentWeight) {
-		return fmt.Errorf("block %s has invalid parent weight %d expected %d", blk.Cid().String(), blk.ParentWeight, parentWeight)
-	}
-
 	// get worker address
 	version := bv.fork.GetNetworkVersion(ctx, blk.Height)
 	lbTS, lbStateRoot, err := bv.chainState.GetLookbackTipSetForRound(ctx, parent, blk.Height, version)
@@ -254,7 +291,7 @@ func (bv *BlockValidator) validateBlock(ctx context.Context, blk *types.BlockHea
 		return bv.ValidateBlockWinner(ctx, workerAddr, lbTS, lbStateRoot, parent, parent.At(0).ParentStateRoot, blk, prevBeacon)
 	})
 
-	winPoStNv := bv.fork.GetNetworkVersion(ctx, baseHeight)
+	winPoStNv := bv.fork.GetNetworkVersion(ctx, parent.Height())
 	wproofCheck := async.Err(func() error {
 		if err := bv.VerifyWinningPoStProof(ctx, winPoStNv, blk, prevBeacon, lbStateRoot); err != nil {
 			return fmt.Errorf("invalid election post: %w", err)
@@ -306,7 +343,6 @@ func (bv *BlockValidator) validateBlock(ctx context.Context, blk *types.BlockHea
 		stateRootCheck,
 	}
 
-	var merr error
 	for _, fut := range await {
 		if err := fut.AwaitContext(ctx); err != nil {
 			merr = multierror.Append(merr, err)
@@ -0,0 +1,78 @@
+// stm: #unit
+package consensus
+
+import (
+	"testing"
+
+	fbig "github.com/filecoin-project/go-state-types/big"
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestCheckBlockHeader(t *testing.T) {
+	tf.UnitTest(t)
+
+	minerAddr := testhelpers.NewForTestGetter()()
+	parent := testhelpers.RequireNewTipSet(t, &types.BlockHeader{
+		Miner:                 minerAddr,
+		Height:                10,
+		Timestamp:             1000,
+		ParentWeight:          fbig.Zero(),
+		Ticket:                MakeFakeTicketForTest(),
+		ElectionProof:         &types.ElectionProof{WinCount: 1},
+		ParentStateRoot:       testhelpers.EmptyReceiptsCID,
+		Messages:              testhelpers.EmptyMessagesCID,
+		ParentMessageReceipts: testhelpers.EmptyReceiptsCID,
+	})
+
+	const (
+		blockDelay = 30
+		clockDrift = 6
+		now        = 2000
+	)
+	parentWeight := fbig.NewInt(100)
+
+	blk := &types.BlockHeader{
+		Miner:                 minerAddr,
+		Parents:               parent.Key().Cids(),
+		Height:                11,
+		Timestamp:             1030,
+		ParentWeight:          parentWeight,
+		ParentStateRoot:       testhelpers.EmptyReceiptsCID,
+		Messages:              testhelpers.EmptyMessagesCID,
+		ParentMessageReceipts: testhelpers.EmptyReceiptsCID,
+	}
+	assert.NoError(t, checkBlockHeader(blk, parent, parentWeight, blockDelay, clockDrift, now, false))
+	assert.NoError(t, checkBlockHeader(blk, parent, parentWeight, blockDelay, clockDrift, now, true))
+
+	// wrong timestamp, from the future and a wrong parent weight
+	bad := *blk
+	bad.Timestamp = 3000
+	bad.ParentWeight = fbig.NewInt(99)
+
+	err := checkBlockHeader(&bad, parent, parentWeight, blockDelay, clockDrift, now, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong timestamp")
+	assert.NotContains(t, err.Error(), "parent weight")
+
+	err = checkBlockHeader(&bad, parent, parentWeight, blockDelay, clockDrift, now, true)
+	require.Error(t, err)
+	merr, ok := err.(*multierror.Error)
+	require.True(t, ok)
+	require.Len(t, merr.Errors, 3)
+	assert.Contains(t, merr.Errors[0].Error(), "wrong timestamp")
+	assert.Contains(t, merr.Errors[1].Error(), "from the future")
+	assert.Contains(t, merr.Errors[2].Error(), "invalid parent weight")
+
+	// a single failure is reported on its own when checks stop at the first one
+	bad = *blk
+	bad.ParentWeight = fbig.NewInt(99)
+	err = checkBlockHeader(&bad, parent, parentWeight, blockDelay, clockDrift, now, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid parent weight")
+}
@@ -129,6 +129,8 @@ type Expected struct {
 
 	netParamCfg  *config.NetworkParamsConfig
 	returnEvents bool
+	// traceFilter narrows the execution traces of the tipsets run with tracing
+	traceFilter *vm.TraceFilter
 }
 
 // NewExpected is the constructor for the Expected consenus.Protocol module.
@@ -144,6 +146,7 @@ func NewExpected(cs cbor.IpldStore,
 	circulatingSupplyCalculator chain.ICirculatingSupplyCalcualtor,
 	netParamCfg *config.NetworkParamsConfig,
 	returnEvents bool,
+	traceFilter *vm.TraceFilter,
 ) *Expected {
 	processor := NewDefaultProcessor(syscalls, circulatingSupplyCalculator, chainState, netParamCfg)
 	return &Expected{
@@ -159,9 +162,24 @@ func NewExpected(cs cbor.IpldStore,
 		blockValidator:   blockValidator,
 		netParamCfg:      netParamCfg,
 		returnEvents:     returnEvents,
+		traceFilter:      traceFilter,
 	}
 }
 
+type unfilteredTracesKey struct{}
+
+// WithUnfilteredTraces returns a context whose tipset executions keep the execution traces of every
+// message, ignoring the trace filter. Replaying a single message always wants its trace.
+func WithUnfilteredTraces(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unfilteredTracesKey{}, true)
+}
+
+// UnfilteredTraces tells whether ctx was returned by WithUnfilteredTraces.
+func UnfilteredTraces(ctx context.Context) bool {
+	unfiltered, _ := ctx.Value(unfilteredTracesKey{}).(bool)
+	return unfiltered
+}
+
 // RunStateTransition applies the messages in a tipset to a state, and persists that new state.
 // It errors if the tipset was not mined according to the EC rules, or if any of the messages
 // in the tipset results in an error.
@@ -219,6 +237,9 @@ func (c *Expected) RunStateTransition(ctx context.Context, ts *types.TipSet, cb
 		ActorDebugging:      c.netParamCfg.ActorDebugging,
 		ReturnEvents:        c.returnEvents,
 	}
+	if vmTracing && !UnfilteredTraces(ctx) {
+		vmOption.TraceFilter = c.traceFilter
+	}
 
 	var parentEpoch abi.ChainEpoch
 	if pts.Defined() {
@@ -111,6 +111,8 @@ func (p *DefaultProcessor) ApplyBlocks(ctx context.Context,
 			TipSetGetter:         vmOpts.TipSetGetter,
 			Tracing:              vmOpts.Tracing,
 			ActorDebugging:       vmOpts.ActorDebugging,
+			MinBaseFee:           vmOpts.MinBaseFee,
+			TraceFilter:          vmOpts.TraceFilter,
 		}
 
 		return fvm.NewVM(ctx, vmOpt)
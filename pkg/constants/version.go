@@ -9,6 +9,9 @@ const BuildVersion = "1.11.0-rc1"
 
 var CurrentCommit string
 
+// GitCommit is the full hash of the commit the binary was built from, set by build system
+var GitCommit string
+
 // software version
 func UserVersion() string {
 	if os.Getenv("VENUS_VERSION_IGNORE_COMMIT") == "1" {
@@ -0,0 +1,179 @@
+package events
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// event field types known by the decoder
+const (
+	// FieldTypeInt values are decoded as int64
+	FieldTypeInt     = "int"
+	FieldTypeBigInt  = "bigint"
+	FieldTypeString  = "string"
+	FieldTypeCid     = "cid"
+	FieldTypeAddress = "address"
+	FieldTypeBytes   = "bytes"
+	// FieldTypeRaw is used for entries that are not described by the schema
+	FieldTypeRaw = "raw"
+)
+
+// evmEventType is the type given to EVM logs, which are not tagged with an event type
+const evmEventType = "evm-log"
+
+// EventSchema describes the entries of an event type
+type EventSchema map[string]string
+
+// ActorEventSchemas maps a builtin actor name and an event type to its schema
+var ActorEventSchemas = map[string]map[string]EventSchema{
+	manifest.VerifregKey: {
+		"verifier-balance":   {"verifier": FieldTypeInt, "balance": FieldTypeBigInt},
+		"allocation":         {"id": FieldTypeInt, "client": FieldTypeInt, "provider": FieldTypeInt},
+		"allocation-removed": {"id": FieldTypeInt, "client": FieldTypeInt, "provider": FieldTypeInt},
+		"claim":              {"id": FieldTypeInt, "client": FieldTypeInt, "provider": FieldTypeInt},
+		"claim-updated":      {"id": FieldTypeInt, "client": FieldTypeInt, "provider": FieldTypeInt},
+		"claim-removed":      {"id": FieldTypeInt, "client": FieldTypeInt, "provider": FieldTypeInt},
+	},
+	manifest.MinerKey: {
+		"sector-precommitted": {"sector": FieldTypeInt},
+		"sector-activated":    {"sector": FieldTypeInt, "unsealed-cid": FieldTypeCid, "piece-cid": FieldTypeCid, "piece-size": FieldTypeInt},
+		"sector-updated":      {"sector": FieldTypeInt, "unsealed-cid": FieldTypeCid, "piece-cid": FieldTypeCid, "piece-size": FieldTypeInt},
+		"sector-terminated":   {"sector": FieldTypeInt},
+	},
+	manifest.EvmKey: {
+		evmEventType: {"t1": FieldTypeBytes, "t2": FieldTypeBytes, "t3": FieldTypeBytes, "t4": FieldTypeBytes, "d": FieldTypeBytes},
+	},
+}
+
+// ActorCodeLookup returns the code cid of the actor at addr
+type ActorCodeLookup func(addr address.Address) (cid.Cid, error)
+
+// ActorEventDecoder decodes events emitted by builtin actors with ActorEventSchemas
+type ActorEventDecoder struct {
+	lookup ActorCodeLookup
+}
+
+func NewActorEventDecoder(lookup ActorCodeLookup) *ActorEventDecoder {
+	return &ActorEventDecoder{lookup: lookup}
+}
+
+// DecodeActorEvent resolves the actor kind of the emitter and decodes the entries of event
+// according to the schema of its event type.
+func (d *ActorEventDecoder) DecodeActorEvent(event *types.ActorEvent) (*types.DecodedActorEvent, error) {
+	code, err := d.lookup(event.Emitter)
+	if err != nil {
+		return nil, fmt.Errorf("looking up code of emitter %s: %w", event.Emitter, err)
+	}
+	name, _, ok := actors.GetActorMetaByCode(code)
+	if !ok {
+		return nil, fmt.Errorf("emitter %s is not a builtin actor: %s", event.Emitter, code)
+	}
+	schemas, ok := ActorEventSchemas[name]
+	if !ok {
+		return nil, fmt.Errorf("no event schema for actor %s", name)
+	}
+
+	evtType := evmEventType
+	if name != manifest.EvmKey {
+		evtType, err = types.ActorEventType(event.Entries)
+		if err != nil {
+			return nil, err
+		}
+	}
+	schema, ok := schemas[evtType]
+	if !ok {
+		return nil, fmt.Errorf("unknown event %s of actor %s", evtType, name)
+	}
+
+	out := &types.DecodedActorEvent{
+		Emitter: event.Emitter,
+		Actor:   name,
+		Type:    evtType,
+		Fields:  make([]types.DecodedEventField, 0, len(event.Entries)),
+	}
+	for _, entry := range event.Entries {
+		if entry.Key == types.EventTypeKey {
+			continue
+		}
+		typ, ok := schema[entry.Key]
+		if !ok {
+			out.Fields = append(out.Fields, types.DecodedEventField{Name: entry.Key, Type: FieldTypeRaw, Value: entry.Value})
+			continue
+		}
+		val, err := decodeEntryValue(entry, typ)
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s of event %s: %w", entry.Key, evtType, err)
+		}
+		out.Fields = append(out.Fields, types.DecodedEventField{Name: entry.Key, Type: typ, Value: val})
+	}
+	return out, nil
+}
+
+func decodeEntryValue(entry types.EventEntry, typ string) (interface{}, error) {
+	if entry.Codec == cid.Raw {
+		if typ != FieldTypeBytes {
+			return nil, fmt.Errorf("raw value cannot be decoded as %s", typ)
+		}
+		return entry.Value, nil
+	}
+	if entry.Codec != types.EventCodecCbor {
+		return nil, fmt.Errorf("unsupported codec %d", entry.Codec)
+	}
+
+	r := bytes.NewReader(entry.Value)
+	switch typ {
+	case FieldTypeInt:
+		maj, extra, err := cbg.NewCborReader(r).ReadHeader()
+		if err != nil {
+			return nil, err
+		}
+		if extra > math.MaxInt64 {
+			return nil, fmt.Errorf("integer %d overflows int64", extra)
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			return int64(extra), nil
+		case cbg.MajNegativeInt:
+			return -int64(extra) - 1, nil
+		default:
+			return nil, fmt.Errorf("expected an integer, was major type %d", maj)
+		}
+	case FieldTypeBigInt:
+		var v big.Int
+		if err := v.UnmarshalCBOR(r); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case FieldTypeString:
+		return cbg.ReadString(r)
+	case FieldTypeCid:
+		if bytes.Equal(entry.Value, cbg.CborNull) {
+			return nil, nil
+		}
+		var v cbg.CborCid
+		if err := v.UnmarshalCBOR(r); err != nil {
+			return nil, err
+		}
+		return cid.Cid(v), nil
+	case FieldTypeAddress:
+		var v address.Address
+		if err := v.UnmarshalCBOR(r); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case FieldTypeBytes:
+		return cbg.ReadByteArray(r, cbg.ByteArrayMaxLen)
+	default:
+		return nil, fmt.Errorf("unknown field type %s", typ)
+	}
+}
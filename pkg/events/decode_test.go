@@ -0,0 +1,120 @@
+package events
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-address"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/manifest"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func cborEntry(t *testing.T, key string, write func(w *cbg.CborWriter) error) types.EventEntry {
+	buf := new(bytes.Buffer)
+	require.NoError(t, write(cbg.NewCborWriter(buf)))
+	return types.EventEntry{Flags: types.EventFlagIndexedValue, Key: key, Codec: types.EventCodecCbor, Value: buf.Bytes()}
+}
+
+func TestDecodeActorEvent(t *testing.T) {
+	tf.UnitTest(t)
+
+	minerAddr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	evmAddr, err := address.NewIDAddress(1001)
+	require.NoError(t, err)
+	minerCode, ok := actors.GetActorCodeID(actorstypes.Version10, manifest.MinerKey)
+	require.True(t, ok)
+	evmCode, ok := actors.GetActorCodeID(actorstypes.Version10, manifest.EvmKey)
+	require.True(t, ok)
+
+	decoder := NewActorEventDecoder(func(addr address.Address) (cid.Cid, error) {
+		if addr == minerAddr {
+			return minerCode, nil
+		}
+		return evmCode, nil
+	})
+
+	typeEntry := cborEntry(t, types.EventTypeKey, func(w *cbg.CborWriter) error {
+		if err := w.WriteMajorTypeHeader(cbg.MajTextString, uint64(len("sector-activated"))); err != nil {
+			return err
+		}
+		_, err := w.Write([]byte("sector-activated"))
+		return err
+	})
+	sectorEntry := cborEntry(t, "sector", func(w *cbg.CborWriter) error {
+		return w.WriteMajorTypeHeader(cbg.MajUnsignedInt, 7)
+	})
+	cidEntry := types.EventEntry{Key: "unsealed-cid", Codec: types.EventCodecCbor, Value: cbg.CborNull}
+	pieceCid, err := cid.Decode("baga6ea4seaqao7s73y24kcutaosvacpdjgfe5pw76ooefnyqw4ynr3d2y6x2mpq")
+	require.NoError(t, err)
+	pieceCidEntry := cborEntry(t, "piece-cid", func(w *cbg.CborWriter) error {
+		return cbg.WriteCid(w, pieceCid)
+	})
+	pieceSizeEntry := cborEntry(t, "piece-size", func(w *cbg.CborWriter) error {
+		return w.WriteMajorTypeHeader(cbg.MajUnsignedInt, 2048)
+	})
+	extraEntry := types.EventEntry{Key: "unknown", Codec: types.EventCodecCbor, Value: []byte{0x01}}
+
+	decoded, err := decoder.DecodeActorEvent(&types.ActorEvent{
+		Emitter: minerAddr,
+		Entries: []types.EventEntry{typeEntry, sectorEntry, cidEntry, pieceCidEntry, pieceSizeEntry, extraEntry},
+	})
+	require.NoError(t, err)
+	require.Equal(t, manifest.MinerKey, decoded.Actor)
+	require.Equal(t, "sector-activated", decoded.Type)
+	require.Equal(t, []types.DecodedEventField{
+		{Name: "sector", Type: FieldTypeInt, Value: int64(7)},
+		{Name: "unsealed-cid", Type: FieldTypeCid, Value: nil},
+		{Name: "piece-cid", Type: FieldTypeCid, Value: pieceCid},
+		{Name: "piece-size", Type: FieldTypeInt, Value: int64(2048)},
+		{Name: "unknown", Type: FieldTypeRaw, Value: []byte{0x01}},
+	}, decoded.Fields)
+
+	// the emitter is not part of the miner events
+	minerEntry := cborEntry(t, "miner", func(w *cbg.CborWriter) error {
+		return w.WriteMajorTypeHeader(cbg.MajUnsignedInt, 1000)
+	})
+	decoded, err = decoder.DecodeActorEvent(&types.ActorEvent{
+		Emitter: minerAddr,
+		Entries: []types.EventEntry{typeEntry, minerEntry},
+	})
+	require.NoError(t, err)
+	require.Equal(t, FieldTypeRaw, decoded.Fields[0].Type)
+
+	// ints beyond int64 are rejected
+	hugeEntry := cborEntry(t, "sector", func(w *cbg.CborWriter) error {
+		return w.WriteMajorTypeHeader(cbg.MajUnsignedInt, 1<<63)
+	})
+	_, err = decoder.DecodeActorEvent(&types.ActorEvent{
+		Emitter: minerAddr,
+		Entries: []types.EventEntry{typeEntry, hugeEntry},
+	})
+	require.Error(t, err)
+
+	// events of builtin actors must carry their type
+	_, err = decoder.DecodeActorEvent(&types.ActorEvent{Emitter: minerAddr, Entries: []types.EventEntry{sectorEntry}})
+	require.Error(t, err)
+
+	// evm logs are raw topics and data
+	decoded, err = decoder.DecodeActorEvent(&types.ActorEvent{
+		Emitter: evmAddr,
+		Entries: []types.EventEntry{
+			{Key: "t1", Codec: cid.Raw, Value: []byte{0xaa}},
+			{Key: "d", Codec: cid.Raw, Value: []byte{0xbb}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, evmEventType, decoded.Type)
+	require.Equal(t, []types.DecodedEventField{
+		{Name: "t1", Type: FieldTypeBytes, Value: []byte{0xaa}},
+		{Name: "d", Type: FieldTypeBytes, Value: []byte{0xbb}},
+	}, decoded.Fields)
+}
@@ -38,6 +38,7 @@ type EventFilter struct {
 	addresses  []address.Address   // list of f4 actor addresses that are extpected to emit the event
 	keys       map[string][][]byte // map of key names to a list of alternate values that may match
 	maxResults int                 // maximum number of results to collect, 0 is unlimited
+	native     bool                // emitters are resolved with the native address resolver
 
 	mu        sync.Mutex
 	collected []*CollectedEvent
@@ -69,6 +70,18 @@ func (f *EventFilter) SetSubChannel(ch chan<- interface{}) {
 	f.collected = nil
 }
 
+// Subscribe sets the subscription channel and returns the events collected before it was set,
+// no event is lost or delivered twice between the two.
+func (f *EventFilter) Subscribe(ch chan<- interface{}) []*CollectedEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	collected := f.collected
+	f.ch = ch
+	f.collected = nil
+	f.lastTaken = time.Now().UTC()
+	return collected
+}
+
 func (f *EventFilter) ClearSubChannel() {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -297,6 +310,11 @@ type EventFilterManager struct {
 	AddressResolver  func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) (address.Address, bool)
 	MaxFilterResults int
 	EventIndex       *EventIndex
+	FVMEventStore    *FVMEventStore
+
+	// NativeAddressResolver resolves the emitters of the events kept in FVMEventStore and of the filters
+	// installed by InstallNative, unlike AddressResolver it does not drop actors without an f4 address.
+	NativeAddressResolver func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) (address.Address, bool)
 
 	mu            sync.Mutex // guards mutations to filters
 	filters       map[types.FilterID]*EventFilter
@@ -326,7 +344,7 @@ func (m *EventFilterManager) Apply(ctx context.Context, from, to *types.TipSet)
 
 	// TODO: could run this loop in parallel with errgroup if there are many filters
 	for _, f := range m.filters {
-		if err := f.CollectEvents(ctx, tse, false, m.AddressResolver); err != nil {
+		if err := f.CollectEvents(ctx, tse, false, m.addressResolver(f)); err != nil {
 			return err
 		}
 	}
@@ -357,7 +375,7 @@ func (m *EventFilterManager) Revert(ctx context.Context, from, to *types.TipSet)
 
 	// TODO: could run this loop in parallel with errgroup if there are many filters
 	for _, f := range m.filters {
-		if err := f.CollectEvents(ctx, tse, true, m.AddressResolver); err != nil {
+		if err := f.CollectEvents(ctx, tse, true, m.addressResolver(f)); err != nil {
 			return err
 		}
 	}
@@ -366,13 +384,28 @@ func (m *EventFilterManager) Revert(ctx context.Context, from, to *types.TipSet)
 }
 
 func (m *EventFilterManager) Install(ctx context.Context, minHeight, maxHeight abi.ChainEpoch, tipsetCid cid.Cid, addresses []address.Address, keys map[string][][]byte) (*EventFilter, error) {
+	return m.install(ctx, minHeight, maxHeight, tipsetCid, addresses, keys, false)
+}
+
+// InstallNative installs a filter whose emitters are resolved with NativeAddressResolver, so it
+// also matches the events of actors without an f4 address by their id address. Historic events
+// are read from FVMEventStore.
+func (m *EventFilterManager) InstallNative(ctx context.Context, minHeight, maxHeight abi.ChainEpoch, addresses []address.Address, keys map[string][][]byte) (*EventFilter, error) {
+	return m.install(ctx, minHeight, maxHeight, cid.Undef, addresses, keys, true)
+}
+
+func (m *EventFilterManager) install(ctx context.Context, minHeight, maxHeight abi.ChainEpoch, tipsetCid cid.Cid, addresses []address.Address, keys map[string][][]byte, native bool) (*EventFilter, error) {
 	m.mu.Lock()
 	currentHeight := m.currentHeight
 	m.mu.Unlock()
 
-	if m.EventIndex == nil && minHeight != -1 && minHeight < currentHeight {
+	historic := minHeight != -1 && minHeight < currentHeight
+	if historic && !native && m.EventIndex == nil {
 		return nil, xerrors.Errorf("historic event index disabled")
 	}
+	if historic && native && m.FVMEventStore == nil {
+		return nil, xerrors.Errorf("historic fvm event store disabled")
+	}
 
 	id, err := newFilterID()
 	if err != nil {
@@ -387,11 +420,17 @@ func (m *EventFilterManager) Install(ctx context.Context, minHeight, maxHeight a
 		addresses:  addresses,
 		keys:       keys,
 		maxResults: m.MaxFilterResults,
+		native:     native,
 	}
 
-	if m.EventIndex != nil && minHeight != -1 && minHeight < currentHeight {
+	if historic {
 		// Filter needs historic events
-		if err := m.EventIndex.PrefillFilter(ctx, f); err != nil {
+		if native {
+			err = m.FVMEventStore.PrefillFilter(ctx, f)
+		} else {
+			err = m.EventIndex.PrefillFilter(ctx, f)
+		}
+		if err != nil {
 			return nil, err
 		}
 	}
@@ -406,6 +445,20 @@ func (m *EventFilterManager) Install(ctx context.Context, minHeight, maxHeight a
 	return f, nil
 }
 
+func (m *EventFilterManager) addressResolver(f *EventFilter) func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) (address.Address, bool) {
+	if f.native {
+		return m.nativeAddressResolver()
+	}
+	return m.AddressResolver
+}
+
+func (m *EventFilterManager) nativeAddressResolver() func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) (address.Address, bool) {
+	if m.NativeAddressResolver != nil {
+		return m.NativeAddressResolver
+	}
+	return m.AddressResolver
+}
+
 func (m *EventFilterManager) Remove(ctx context.Context, id types.FilterID) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -417,12 +470,18 @@ func (m *EventFilterManager) Remove(ctx context.Context, id types.FilterID) erro
 }
 
 func (m *EventFilterManager) loadExecutedMessages(ctx context.Context, msgTS, rctTS *types.TipSet) ([]executedMessage, error) {
-	msgs, err := m.MessageStore.MessagesForTipset(msgTS)
+	return loadExecutedMessages(ctx, m.MessageStore, m.ChainStore, msgTS, rctTS)
+}
+
+// loadExecutedMessages returns the messages of msgTS along with their receipts and events, read from
+// the receipts of rctTS.
+func loadExecutedMessages(ctx context.Context, ms *chain.MessageStore, bs blockstore.Blockstore, msgTS, rctTS *types.TipSet) ([]executedMessage, error) {
+	msgs, err := ms.MessagesForTipset(msgTS)
 	if err != nil {
 		return nil, xerrors.Errorf("read messages: %w", err)
 	}
 
-	st := adt.WrapStore(ctx, cbor.NewCborStore(m.ChainStore))
+	st := adt.WrapStore(ctx, cbor.NewCborStore(bs))
 
 	arr, err := blockadt.AsArray(st, rctTS.Blocks()[0].ParentMessageReceipts)
 	if err != nil {
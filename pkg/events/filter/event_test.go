@@ -272,6 +272,81 @@ func TestEventFilterCollectEvents(t *testing.T) {
 	}
 }
 
+func TestEventFilterSubscribe(t *testing.T) {
+	ctx := context.Background()
+	rng := pseudo.New(pseudo.NewSource(299792458))
+
+	a1ID := abi.ActorID(1)
+	addrMap := addressMap{}
+	addrMap.add(a1ID, randomF4Addr(t, rng))
+
+	ev := fakeEvent(a1ID, []kv{{k: "type", v: []byte("approval")}}, nil)
+	events := []*types.Event{ev}
+	em := executedMessage{
+		msg: fakeMessage(randomF4Addr(t, rng), randomF4Addr(t, rng)),
+		rct: fakeReceipt(t, rng, newStore(), events),
+		evs: events,
+	}
+
+	f := &EventFilter{minHeight: -1, maxHeight: -1}
+	require.NoError(t, f.CollectEvents(ctx, buildTipSetEvents(t, rng, 14000, em), false, addrMap.ResolveAddress))
+
+	// the events collected so far are handed back once and later ones go to the channel
+	ch := make(chan interface{}, 1)
+	collected := f.Subscribe(ch)
+	require.Len(t, collected, 1)
+	require.Equal(t, abi.ChainEpoch(14000), collected[0].Height)
+	require.Empty(t, f.TakeCollectedEvents(ctx))
+
+	require.NoError(t, f.CollectEvents(ctx, buildTipSetEvents(t, rng, 14001, em), false, addrMap.ResolveAddress))
+	require.Empty(t, f.TakeCollectedEvents(ctx))
+	v := <-ch
+	require.Equal(t, abi.ChainEpoch(14001), v.(*CollectedEvent).Height)
+}
+
+func TestEventFilterManagerAddressResolver(t *testing.T) {
+	ctx := context.Background()
+	rng := pseudo.New(pseudo.NewSource(299792458))
+
+	f4ID := abi.ActorID(1)
+	f4Addr := randomF4Addr(t, rng)
+	minerID := abi.ActorID(1000)
+	minerAddr, err := address.NewIDAddress(uint64(minerID))
+	require.NoError(t, err)
+
+	ethResolver := addressMap{}
+	ethResolver.add(f4ID, f4Addr)
+	nativeResolver := addressMap{}
+	nativeResolver.add(f4ID, f4Addr)
+	nativeResolver.add(minerID, minerAddr)
+
+	m := &EventFilterManager{
+		AddressResolver:       ethResolver.ResolveAddress,
+		NativeAddressResolver: nativeResolver.ResolveAddress,
+	}
+
+	ethFilter, err := m.Install(ctx, -1, -1, cid.Undef, nil, nil)
+	require.NoError(t, err)
+	nativeFilter, err := m.InstallNative(ctx, -1, -1, nil, nil)
+	require.NoError(t, err)
+
+	ev := fakeEvent(minerID, []kv{{k: "t1", v: []byte("sector-activated")}}, nil)
+	events := []*types.Event{ev}
+	te := buildTipSetEvents(t, rng, 14000, executedMessage{
+		msg: fakeMessage(randomF4Addr(t, rng), randomF4Addr(t, rng)),
+		rct: fakeReceipt(t, rng, newStore(), events),
+		evs: events,
+	})
+
+	// eth filters only see emitters with an f4 address, native filters see every emitter
+	require.NoError(t, ethFilter.CollectEvents(ctx, te, false, m.addressResolver(ethFilter)))
+	require.Empty(t, ethFilter.TakeCollectedEvents(ctx))
+	require.NoError(t, nativeFilter.CollectEvents(ctx, te, false, m.addressResolver(nativeFilter)))
+	collected := nativeFilter.TakeCollectedEvents(ctx)
+	require.Len(t, collected, 1)
+	require.Equal(t, minerAddr, collected[0].EmitterAddr)
+}
+
 type kv struct {
 	k string
 	v []byte
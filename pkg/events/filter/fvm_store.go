@@ -0,0 +1,392 @@
+package filter
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// fvmEventDdls creates the schema of the FVMEventStore, each row is one entry of an event,
+// the entries of an event share tipset_key, message_index and event_index.
+// Entry keys are stored as plain text rather than JSON documents, so they are indexed with regular
+// indexes on key and (key, value) instead of a JSON expression index.
+var fvmEventDdls = []string{
+	`CREATE TABLE IF NOT EXISTS fvm_event_entry (
+		epoch INTEGER NOT NULL,
+		tipset_key BLOB NOT NULL,
+		message_cid BLOB NOT NULL,
+		message_index INTEGER NOT NULL,
+		event_index INTEGER NOT NULL,
+		emitter_addr BLOB NOT NULL,
+		flags INTEGER NOT NULL,
+		key TEXT NOT NULL,
+		value_codec INTEGER NOT NULL,
+		value BLOB NOT NULL,
+		reverted INTEGER NOT NULL
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS fvm_event_entry_epoch ON fvm_event_entry (epoch)`,
+	`CREATE INDEX IF NOT EXISTS fvm_event_entry_key ON fvm_event_entry (key)`,
+	`CREATE INDEX IF NOT EXISTS fvm_event_entry_key_value ON fvm_event_entry (key, value)`,
+	`CREATE INDEX IF NOT EXISTS fvm_event_entry_tipset ON fvm_event_entry (tipset_key)`,
+}
+
+const (
+	insertFVMEventEntry = `INSERT INTO fvm_event_entry
+	(epoch, tipset_key, message_cid, message_index, event_index, emitter_addr, flags, key, value_codec, value, reverted)
+	VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 0)`
+
+	deleteFVMEventEntries = `DELETE FROM fvm_event_entry WHERE tipset_key=?`
+
+	revertFVMEventEntries = `UPDATE fvm_event_entry SET reverted=1 WHERE tipset_key=?`
+
+	pruneFVMEventEntries = `DELETE FROM fvm_event_entry WHERE epoch<?`
+)
+
+// FVMEventStore persists the native events of every executed tipset, unlike EventIndex it is not
+// limited to the events of eth contracts. Only the events of the last retention epochs are kept,
+// older ones are pruned as new tipsets are applied.
+type FVMEventStore struct {
+	db        *sql.DB
+	retention abi.ChainEpoch
+}
+
+// NewFVMEventStore opens the store at path, a retention of 0 or less keeps every event.
+func NewFVMEventStore(path string, retention abi.ChainEpoch) (*FVMEventStore, error) {
+	db, err := sql.Open("sqlite3", path+"?mode=rwc")
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite3 database: %w", err)
+	}
+
+	for _, pragma := range pragmas {
+		if _, err := db.Exec(pragma); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("exec pragma %q: %w", pragma, err)
+		}
+	}
+	for _, ddl := range fvmEventDdls {
+		if _, err := db.Exec(ddl); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("exec ddl %q: %w", ddl, err)
+		}
+	}
+
+	return &FVMEventStore{
+		db:        db,
+		retention: retention,
+	}, nil
+}
+
+func (s *FVMEventStore) Close() error {
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// CollectEvents stores the events of the messages executed by te, a revert flags them as reverted.
+// Applying a tipset again replaces the events stored for it and prunes the events that fell out of
+// the retention window.
+func (s *FVMEventStore) CollectEvents(ctx context.Context, te *TipSetEvents, revert bool, resolver func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) (address.Address, bool)) error {
+	tsKey := te.msgTS.Key().Bytes()
+	if revert {
+		if _, err := s.db.ExecContext(ctx, revertFVMEventEntries, tsKey); err != nil {
+			return fmt.Errorf("exec revert events: %w", err)
+		}
+		return nil
+	}
+
+	ems, err := te.messages(ctx)
+	if err != nil {
+		return fmt.Errorf("load executed messages: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, deleteFVMEventEntries, tsKey); err != nil {
+		return fmt.Errorf("exec delete events: %w", err)
+	}
+	if s.retention > 0 {
+		if _, err := tx.ExecContext(ctx, pruneFVMEventEntries, te.msgTS.Height()-s.retention); err != nil {
+			return fmt.Errorf("exec prune events: %w", err)
+		}
+	}
+	stmt, err := tx.Prepare(insertFVMEventEntry)
+	if err != nil {
+		return fmt.Errorf("prepare insert entry: %w", err)
+	}
+
+	addressLookups := make(map[abi.ActorID]address.Address)
+	for msgIdx, em := range ems {
+		for evIdx, ev := range em.Events() {
+			addr, found := addressLookups[ev.Emitter]
+			if !found {
+				var ok bool
+				addr, ok = resolver(ctx, ev.Emitter, te.rctTS)
+				if !ok {
+					continue
+				}
+				addressLookups[ev.Emitter] = addr
+			}
+
+			for _, entry := range ev.Entries {
+				_, err := stmt.Exec(
+					te.msgTS.Height(),          // epoch
+					tsKey,                      // tipset_key
+					em.Message().Cid().Bytes(), // message_cid
+					msgIdx,                     // message_index
+					evIdx,                      // event_index
+					addr.Bytes(),               // emitter_addr
+					entry.Flags,                // flags
+					entry.Key,                  // key
+					entry.Codec,                // value_codec
+					entry.Value,                // value
+				)
+				if err != nil {
+					return fmt.Errorf("exec insert entry: %w", err)
+				}
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FVMEventObserver persists the events of the tipsets applied to the chain into an FVMEventStore. It
+// observes the chain on its own, so events are stored whether or not the eth event filters are enabled.
+type FVMEventObserver struct {
+	Store           *FVMEventStore
+	MessageStore    *chain.MessageStore
+	ChainStore      blockstore.Blockstore
+	AddressResolver func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) (address.Address, bool)
+}
+
+func (o *FVMEventObserver) Apply(ctx context.Context, from, to *types.TipSet) error {
+	return o.Store.CollectEvents(ctx, o.tipSetEvents(from, to), false, o.AddressResolver)
+}
+
+func (o *FVMEventObserver) Revert(ctx context.Context, from, to *types.TipSet) error {
+	return o.Store.CollectEvents(ctx, o.tipSetEvents(to, from), true, o.AddressResolver)
+}
+
+func (o *FVMEventObserver) tipSetEvents(msgTS, rctTS *types.TipSet) *TipSetEvents {
+	return &TipSetEvents{
+		msgTS: msgTS,
+		rctTS: rctTS,
+		load: func(ctx context.Context, msgTS, rctTS *types.TipSet) ([]executedMessage, error) {
+			return loadExecutedMessages(ctx, o.MessageStore, o.ChainStore, msgTS, rctTS)
+		},
+	}
+}
+
+// GetEvents returns the stored events matching f in execution order, a negative epoch leaves
+// that end of the range open.
+func (s *FVMEventStore) GetEvents(ctx context.Context, f *types.ActorEventFilter) ([]*types.ActorEvent, error) {
+	// topics are laid out like the topics of eth logs, an event matches when it has every topic
+	keys := map[string][][]byte{}
+	for idx, topic := range f.Topics {
+		if topic == nil {
+			continue
+		}
+		key := fmt.Sprintf("t%d", idx+1)
+		keys[key] = append(keys[key], topic)
+	}
+
+	ces, err := s.query(ctx, f.FromEpoch, f.ToEpoch, f.Addresses, keys, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*types.ActorEvent, 0, len(ces))
+	for _, ce := range ces {
+		out = append(out, &types.ActorEvent{
+			Entries:   ce.Entries,
+			Emitter:   ce.EmitterAddr,
+			Reverted:  ce.Reverted,
+			Height:    ce.Height,
+			TipSetKey: ce.TipSetKey,
+			MsgCid:    ce.MsgCid,
+		})
+	}
+	return out, nil
+}
+
+// PrefillFilter fills a native filter's collection of events from the store, keeping the most
+// recent events when the filter limits its results.
+func (s *FVMEventStore) PrefillFilter(ctx context.Context, f *EventFilter) error {
+	ces, err := s.query(ctx, f.minHeight, f.maxHeight, f.addresses, f.keys, f.maxResults)
+	if err != nil {
+		return err
+	}
+	if len(ces) > 0 {
+		f.setCollectedEvents(ces)
+	}
+	return nil
+}
+
+// query returns the events between minHeight and maxHeight emitted by one of addresses that carry, for
+// every key of keys, one of its values. A negative height leaves that end of the range open and a
+// positive limit keeps only the most recent events.
+func (s *FVMEventStore) query(ctx context.Context, minHeight, maxHeight abi.ChainEpoch, addresses []address.Address, keys map[string][][]byte, limit int) ([]*CollectedEvent, error) {
+	clauses := []string{}
+	values := []any{}
+
+	if minHeight >= 0 {
+		clauses = append(clauses, "e.epoch>=?")
+		values = append(values, minHeight)
+	}
+	if maxHeight >= 0 {
+		clauses = append(clauses, "e.epoch<=?")
+		values = append(values, maxHeight)
+	}
+
+	if len(addresses) > 0 {
+		subclauses := []string{}
+		for _, addr := range addresses {
+			subclauses = append(subclauses, "e.emitter_addr=?")
+			values = append(values, addr.Bytes())
+		}
+		clauses = append(clauses, "("+strings.Join(subclauses, " OR ")+")")
+	}
+
+	for key, vals := range keys {
+		if len(vals) == 0 {
+			continue
+		}
+		subclauses := []string{}
+		values = append(values, key)
+		for _, val := range vals {
+			subclauses = append(subclauses, "t.value=?")
+			values = append(values, val)
+		}
+		clauses = append(clauses, `EXISTS (SELECT 1 FROM fvm_event_entry t
+			WHERE t.tipset_key=e.tipset_key AND t.message_index=e.message_index AND t.event_index=e.event_index
+			AND t.key=? AND (`+strings.Join(subclauses, " OR ")+`))`)
+	}
+
+	q := `SELECT
+			e.epoch,
+			e.tipset_key,
+			e.message_cid,
+			e.message_index,
+			e.event_index,
+			e.emitter_addr,
+			e.flags,
+			e.key,
+			e.value_codec,
+			e.value,
+			e.reverted
+		FROM fvm_event_entry e`
+	if len(clauses) > 0 {
+		q += " WHERE " + strings.Join(clauses, " AND ")
+	}
+	if limit > 0 {
+		// the most recent events first, they are put back in execution order below
+		q += " ORDER BY e.epoch DESC, e.tipset_key DESC, e.message_index DESC, e.event_index DESC, e.rowid"
+	} else {
+		q += " ORDER BY e.epoch, e.tipset_key, e.message_index, e.event_index, e.rowid"
+	}
+
+	rows, err := s.db.QueryContext(ctx, q, values...)
+	if err != nil {
+		return nil, fmt.Errorf("exec events query: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var (
+		out     []*CollectedEvent
+		current *CollectedEvent
+		lastKey string
+	)
+	for rows.Next() {
+		var row struct {
+			epoch        int64
+			tipsetKey    []byte
+			messageCid   []byte
+			messageIndex int
+			eventIndex   int
+			emitterAddr  []byte
+			flags        uint8
+			key          string
+			codec        uint64
+			value        []byte
+			reverted     bool
+		}
+		if err := rows.Scan(
+			&row.epoch,
+			&row.tipsetKey,
+			&row.messageCid,
+			&row.messageIndex,
+			&row.eventIndex,
+			&row.emitterAddr,
+			&row.flags,
+			&row.key,
+			&row.codec,
+			&row.value,
+			&row.reverted,
+		); err != nil {
+			return nil, fmt.Errorf("read event row: %w", err)
+		}
+
+		eventKey := fmt.Sprintf("%x/%d/%d", row.tipsetKey, row.messageIndex, row.eventIndex)
+		if current == nil || eventKey != lastKey {
+			if limit > 0 && len(out) == limit {
+				break
+			}
+			lastKey = eventKey
+			current = &CollectedEvent{
+				EventIdx: row.eventIndex,
+				Reverted: row.reverted,
+				Height:   abi.ChainEpoch(row.epoch),
+				MsgIdx:   row.messageIndex,
+			}
+			if current.EmitterAddr, err = address.NewFromBytes(row.emitterAddr); err != nil {
+				return nil, fmt.Errorf("parse emitter addr: %w", err)
+			}
+			if current.TipSetKey, err = types.TipSetKeyFromBytes(row.tipsetKey); err != nil {
+				return nil, fmt.Errorf("parse tipsetkey: %w", err)
+			}
+			if current.MsgCid, err = cid.Cast(row.messageCid); err != nil {
+				return nil, fmt.Errorf("parse message cid: %w", err)
+			}
+			out = append(out, current)
+		}
+
+		current.Entries = append(current.Entries, types.EventEntry{
+			Flags: row.flags,
+			Key:   row.key,
+			Codec: row.codec,
+			Value: row.value,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read event rows: %w", err)
+	}
+
+	if limit > 0 {
+		for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+			out[i], out[j] = out[j], out[i]
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,254 @@
+package filter
+
+import (
+	"context"
+	pseudo "math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+	amt4 "github.com/filecoin-project/go-amt-ipld/v4"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestFVMEventStore(t *testing.T) {
+	ctx := context.Background()
+	rng := pseudo.New(pseudo.NewSource(299792458))
+
+	minerID := abi.ActorID(1000)
+	minerAddr, err := address.NewIDAddress(uint64(minerID))
+	require.NoError(t, err)
+
+	addrMap := addressMap{}
+	addrMap.add(minerID, minerAddr)
+
+	ev := fakeEvent(
+		minerID,
+		[]kv{
+			{k: "t1", v: []byte("sector-activated")},
+		},
+		[]kv{
+			{k: "sector", v: []byte("7")},
+		},
+	)
+	events := []*types.Event{ev}
+	em := executedMessage{
+		msg: fakeMessage(randomF4Addr(t, rng), randomF4Addr(t, rng)),
+		rct: fakeReceipt(t, rng, newStore(), events),
+		evs: events,
+	}
+	te := buildTipSetEvents(t, rng, 14000, em)
+
+	store, err := NewFVMEventStore(filepath.Join(t.TempDir(), "fvm_events.db"), 0)
+	require.NoError(t, err)
+	defer store.Close() //nolint:errcheck
+
+	require.NoError(t, store.CollectEvents(ctx, te, false, addrMap.ResolveAddress))
+	// applying the tipset again does not duplicate its events
+	require.NoError(t, store.CollectEvents(ctx, te, false, addrMap.ResolveAddress))
+
+	want := &types.ActorEvent{
+		Entries:   ev.Entries,
+		Emitter:   minerAddr,
+		Height:    14000,
+		TipSetKey: te.msgTS.Key(),
+		MsgCid:    em.msg.Cid(),
+	}
+
+	got, err := store.GetEvents(ctx, &types.ActorEventFilter{
+		Addresses: []address.Address{minerAddr},
+		Topics:    [][]byte{[]byte("sector-activated")},
+		FromEpoch: 14000,
+		ToEpoch:   14000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*types.ActorEvent{want}, got)
+
+	got, err = store.GetEvents(ctx, &types.ActorEventFilter{FromEpoch: 14001, ToEpoch: -1})
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	got, err = store.GetEvents(ctx, &types.ActorEventFilter{FromEpoch: -1, ToEpoch: -1, Topics: [][]byte{[]byte("sector-updated")}})
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	require.NoError(t, store.CollectEvents(ctx, te, true, addrMap.ResolveAddress))
+	got, err = store.GetEvents(ctx, &types.ActorEventFilter{FromEpoch: -1, ToEpoch: -1})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.True(t, got[0].Reverted)
+}
+
+func TestFVMEventStorePrefillFilter(t *testing.T) {
+	ctx := context.Background()
+	rng := pseudo.New(pseudo.NewSource(299792458))
+
+	minerID := abi.ActorID(1000)
+	minerAddr, err := address.NewIDAddress(uint64(minerID))
+	require.NoError(t, err)
+
+	addrMap := addressMap{}
+	addrMap.add(minerID, minerAddr)
+
+	store, err := NewFVMEventStore(filepath.Join(t.TempDir(), "fvm_events.db"), 0)
+	require.NoError(t, err)
+	defer store.Close() //nolint:errcheck
+
+	for _, h := range []abi.ChainEpoch{14000, 14001, 14002} {
+		ev := fakeEvent(minerID, []kv{{k: "t1", v: []byte("sector-activated")}}, nil)
+		events := []*types.Event{ev}
+		te := buildTipSetEvents(t, rng, h, executedMessage{
+			msg: fakeMessage(randomF4Addr(t, rng), randomF4Addr(t, rng)),
+			rct: fakeReceipt(t, rng, newStore(), events),
+			evs: events,
+		})
+		require.NoError(t, store.CollectEvents(ctx, te, false, addrMap.ResolveAddress))
+	}
+
+	f := &EventFilter{
+		minHeight:  14000,
+		maxHeight:  -1,
+		keys:       map[string][][]byte{"t1": {[]byte("sector-updated"), []byte("sector-activated")}},
+		maxResults: 2,
+	}
+	require.NoError(t, store.PrefillFilter(ctx, f))
+
+	// the most recent events are kept, in execution order
+	collected := f.TakeCollectedEvents(ctx)
+	require.Len(t, collected, 2)
+	require.Equal(t, abi.ChainEpoch(14001), collected[0].Height)
+	require.Equal(t, abi.ChainEpoch(14002), collected[1].Height)
+	require.Equal(t, minerAddr, collected[0].EmitterAddr)
+
+	f = &EventFilter{
+		minHeight: -1,
+		maxHeight: -1,
+		keys:      map[string][][]byte{"t1": {[]byte("sector-updated")}},
+	}
+	require.NoError(t, store.PrefillFilter(ctx, f))
+	require.Empty(t, f.TakeCollectedEvents(ctx))
+}
+
+func TestFVMEventStoreRetention(t *testing.T) {
+	ctx := context.Background()
+	rng := pseudo.New(pseudo.NewSource(299792458))
+
+	minerID := abi.ActorID(1000)
+	minerAddr, err := address.NewIDAddress(uint64(minerID))
+	require.NoError(t, err)
+
+	addrMap := addressMap{}
+	addrMap.add(minerID, minerAddr)
+
+	store, err := NewFVMEventStore(filepath.Join(t.TempDir(), "fvm_events.db"), 10)
+	require.NoError(t, err)
+	defer store.Close() //nolint:errcheck
+
+	for _, h := range []abi.ChainEpoch{14000, 14005, 14011} {
+		ev := fakeEvent(minerID, []kv{{k: "t1", v: []byte("sector-activated")}}, nil)
+		events := []*types.Event{ev}
+		te := buildTipSetEvents(t, rng, h, executedMessage{
+			msg: fakeMessage(randomF4Addr(t, rng), randomF4Addr(t, rng)),
+			rct: fakeReceipt(t, rng, newStore(), events),
+			evs: events,
+		})
+		require.NoError(t, store.CollectEvents(ctx, te, false, addrMap.ResolveAddress))
+	}
+
+	// applying 14011 pruned the events older than 14001
+	got, err := store.GetEvents(ctx, &types.ActorEventFilter{FromEpoch: -1, ToEpoch: -1})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, abi.ChainEpoch(14005), got[0].Height)
+	require.Equal(t, abi.ChainEpoch(14011), got[1].Height)
+}
+
+func TestFVMEventObserver(t *testing.T) {
+	ctx := context.Background()
+	rng := pseudo.New(pseudo.NewSource(299792458))
+
+	minerID := abi.ActorID(1000)
+	minerAddr, err := address.NewIDAddress(uint64(minerID))
+	require.NoError(t, err)
+
+	addrMap := addressMap{}
+	addrMap.add(minerID, minerAddr)
+
+	// the messages of msgTS are executed, their receipts are referenced by rctTS
+	bs := blockstore.NewTemporarySync()
+	ms := chain.NewMessageStore(bs, config.DefaultForkUpgradeParam)
+	msg := fakeMessage(randomF4Addr(t, rng), randomF4Addr(t, rng))
+	msgsRoot, err := ms.StoreMessages(ctx, nil, []*types.Message{msg})
+	require.NoError(t, err)
+	ev := fakeEvent(minerID, []kv{{k: "t1", v: []byte("sector-activated")}}, nil)
+	evs, err := amt4.NewAMT(cbor.NewCborStore(bs), amt4.UseTreeBitWidth(types.EventAMTBitwidth))
+	require.NoError(t, err)
+	require.NoError(t, evs.Set(ctx, 0, ev))
+	eventsRoot, err := evs.Flush(ctx)
+	require.NoError(t, err)
+	rct := types.NewMessageReceiptV1(exitcode.Ok, nil, 1000, &eventsRoot)
+	rctsRoot, err := ms.StoreReceipts(ctx, []types.MessageReceipt{rct})
+	require.NoError(t, err)
+	// the messages are selected against the parent state
+	st, err := tree.NewState(cbor.NewCborStore(bs), tree.StateTreeVersion4)
+	require.NoError(t, err)
+	stateRoot, err := st.Flush(ctx)
+	require.NoError(t, err)
+
+	newTipSet := func(h abi.ChainEpoch, parents []cid.Cid, msgs, rcts cid.Cid) *types.TipSet {
+		ts, err := types.NewTipSet([]*types.BlockHeader{{
+			Height:                h,
+			Miner:                 randomIDAddr(t, rng),
+			Parents:               parents,
+			Ticket:                &types.Ticket{VRFProof: []byte{byte(h % 2)}},
+			ParentStateRoot:       stateRoot,
+			Messages:              msgs,
+			ParentMessageReceipts: rcts,
+			BlockSig:              &crypto.Signature{Type: crypto.SigTypeBLS},
+			BLSAggregate:          &crypto.Signature{Type: crypto.SigTypeBLS},
+		}})
+		require.NoError(t, err)
+		return ts
+	}
+	msgTS := newTipSet(14000, nil, msgsRoot, randomCid(t, rng))
+	rctTS := newTipSet(14001, msgTS.Cids(), randomCid(t, rng), rctsRoot)
+
+	store, err := NewFVMEventStore(filepath.Join(t.TempDir(), "fvm_events.db"), 0)
+	require.NoError(t, err)
+	defer store.Close() //nolint:errcheck
+
+	o := &FVMEventObserver{
+		Store:           store,
+		MessageStore:    ms,
+		ChainStore:      bs,
+		AddressResolver: addrMap.ResolveAddress,
+	}
+	require.NoError(t, o.Apply(ctx, msgTS, rctTS))
+
+	got, err := store.GetEvents(ctx, &types.ActorEventFilter{FromEpoch: -1, ToEpoch: -1})
+	require.NoError(t, err)
+	require.Equal(t, []*types.ActorEvent{{
+		Entries:   ev.Entries,
+		Emitter:   minerAddr,
+		Height:    14000,
+		TipSetKey: msgTS.Key(),
+		MsgCid:    msg.Cid(),
+	}}, got)
+
+	require.NoError(t, o.Revert(ctx, rctTS, msgTS))
+	got, err = store.GetEvents(ctx, &types.ActorEventFilter{FromEpoch: -1, ToEpoch: -1})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.True(t, got[0].Reverted)
+}
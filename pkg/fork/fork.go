@@ -155,6 +155,7 @@ type PreMigration struct {
 type Upgrade struct {
 	Height    abi.ChainEpoch
 	Network   network.Version
+	Name      string
 	Expensive bool
 	Migration MigrationFunc
 
@@ -187,67 +188,80 @@ func DefaultUpgradeSchedule(cf *ChainFork, upgradeHeight *config.ForkUpgradeConf
 	updates := []Upgrade{
 		{
 			Height:    upgradeHeight.UpgradeBreezeHeight,
+			Name:      "Breeze",
 			Network:   network.Version1,
 			Migration: cf.UpgradeFaucetBurnRecovery,
 		},
 		{
 			Height:    upgradeHeight.UpgradeSmokeHeight,
+			Name:      "Smoke",
 			Network:   network.Version2,
 			Migration: nil,
 		},
 		{
 			Height:    upgradeHeight.UpgradeIgnitionHeight,
+			Name:      "Ignition",
 			Network:   network.Version3,
 			Migration: cf.UpgradeIgnition,
 		},
 		{
 			Height:    upgradeHeight.UpgradeRefuelHeight,
+			Name:      "Refuel",
 			Network:   network.Version3,
 			Migration: cf.UpgradeRefuel,
 		},
 		{
 			Height:    upgradeHeight.UpgradeAssemblyHeight,
+			Name:      "Assembly",
 			Network:   network.Version4,
 			Expensive: true,
 			Migration: cf.UpgradeActorsV2,
 		},
 		{
 			Height:    upgradeHeight.UpgradeTapeHeight,
+			Name:      "Tape",
 			Network:   network.Version5,
 			Migration: nil,
 		},
 		{
 			Height:    upgradeHeight.UpgradeLiftoffHeight,
+			Name:      "Liftoff",
 			Network:   network.Version5,
 			Migration: cf.UpgradeLiftoff,
 		},
 		{
 			Height:    upgradeHeight.UpgradeKumquatHeight,
+			Name:      "Kumquat",
 			Network:   network.Version6,
 			Migration: nil,
 		},
 		//{
 		//		Height:    upgradeHeight.UpgradePriceListOopsHeight,
+		//		Name:      "PriceListOops",
 		//		Network:   network.Version6AndAHalf,
 		//		Migration: nil,
 		//},
 		{
 			Height:    upgradeHeight.UpgradeCalicoHeight,
+			Name:      "Calico",
 			Network:   network.Version7,
 			Migration: cf.UpgradeCalico,
 		},
 		{
 			Height:    upgradeHeight.UpgradePersianHeight,
+			Name:      "Persian",
 			Network:   network.Version8,
 			Migration: nil,
 		},
 		{
 			Height:    upgradeHeight.UpgradeOrangeHeight,
+			Name:      "Orange",
 			Network:   network.Version9,
 			Migration: nil,
 		},
 		{
 			Height:    upgradeHeight.UpgradeTrustHeight,
+			Name:      "Trust",
 			Network:   network.Version10,
 			Migration: cf.UpgradeActorsV3,
 			PreMigrations: []PreMigration{{
@@ -265,11 +279,13 @@ func DefaultUpgradeSchedule(cf *ChainFork, upgradeHeight *config.ForkUpgradeConf
 		},
 		{
 			Height:    upgradeHeight.UpgradeNorwegianHeight,
+			Name:      "Norwegian",
 			Network:   network.Version11,
 			Migration: nil,
 		},
 		{
 			Height:    upgradeHeight.UpgradeTurboHeight,
+			Name:      "Turbo",
 			Network:   network.Version12,
 			Migration: cf.UpgradeActorsV4,
 			PreMigrations: []PreMigration{{
@@ -287,6 +303,7 @@ func DefaultUpgradeSchedule(cf *ChainFork, upgradeHeight *config.ForkUpgradeConf
 		},
 		{
 			Height:    upgradeHeight.UpgradeHyperdriveHeight,
+			Name:      "Hyperdrive",
 			Network:   network.Version13,
 			Migration: cf.UpgradeActorsV5,
 			PreMigrations: []PreMigration{{
@@ -304,6 +321,7 @@ func DefaultUpgradeSchedule(cf *ChainFork, upgradeHeight *config.ForkUpgradeConf
 		},
 		{
 			Height:    upgradeHeight.UpgradeChocolateHeight,
+			Name:      "Chocolate",
 			Network:   network.Version14,
 			Migration: cf.UpgradeActorsV6,
 			PreMigrations: []PreMigration{{
@@ -321,6 +339,7 @@ func DefaultUpgradeSchedule(cf *ChainFork, upgradeHeight *config.ForkUpgradeConf
 		},
 		{
 			Height:    upgradeHeight.UpgradeOhSnapHeight,
+			Name:      "OhSnap",
 			Network:   network.Version15,
 			Migration: cf.UpgradeActorsV7,
 			PreMigrations: []PreMigration{{
@@ -333,6 +352,7 @@ func DefaultUpgradeSchedule(cf *ChainFork, upgradeHeight *config.ForkUpgradeConf
 		},
 		{
 			Height:    upgradeHeight.UpgradeSkyrHeight,
+			Name:      "Skyr",
 			Network:   network.Version16,
 			Migration: cf.UpgradeActorsV8,
 			PreMigrations: []PreMigration{{
@@ -345,6 +365,7 @@ func DefaultUpgradeSchedule(cf *ChainFork, upgradeHeight *config.ForkUpgradeConf
 		},
 		{
 			Height:    upgradeHeight.UpgradeSharkHeight,
+			Name:      "Shark",
 			Network:   network.Version17,
 			Migration: cf.UpgradeActorsV9,
 			PreMigrations: []PreMigration{{
@@ -361,6 +382,7 @@ func DefaultUpgradeSchedule(cf *ChainFork, upgradeHeight *config.ForkUpgradeConf
 			Expensive: true,
 		}, {
 			Height:    upgradeHeight.UpgradeHyggeHeight,
+			Name:      "Hygge",
 			Network:   network.Version18,
 			Migration: cf.UpgradeActorsV10,
 			PreMigrations: []PreMigration{{
@@ -372,6 +394,7 @@ func DefaultUpgradeSchedule(cf *ChainFork, upgradeHeight *config.ForkUpgradeConf
 			Expensive: true,
 		}, {
 			Height:    upgradeHeight.UpgradeLightningHeight,
+			Name:      "Lightning",
 			Network:   network.Version19,
 			Migration: cf.UpgradeActorsV11,
 			PreMigrations: []PreMigration{{
@@ -388,6 +411,7 @@ func DefaultUpgradeSchedule(cf *ChainFork, upgradeHeight *config.ForkUpgradeConf
 			Expensive: true,
 		}, {
 			Height:    upgradeHeight.UpgradeThunderHeight,
+			Name:      "Thunder",
 			Network:   network.Version20,
 			Migration: nil,
 		},
@@ -475,6 +499,7 @@ type IFork interface {
 	HasExpensiveFork(ctx context.Context, height abi.ChainEpoch) bool
 	HasExpensiveForkBetween(parent, height abi.ChainEpoch) bool
 	GetForkUpgrade() *config.ForkUpgradeConfig
+	GetUpgradeSchedule() UpgradeSchedule
 	Start(ctx context.Context) error
 }
 
@@ -508,8 +533,9 @@ type ChainFork struct {
 	expensiveUpgrades map[abi.ChainEpoch]struct{}
 
 	// upgrade param
-	networkType types.NetworkType
-	forkUpgrade *config.ForkUpgradeConfig
+	networkType     types.NetworkType
+	forkUpgrade     *config.ForkUpgradeConfig
+	upgradeSchedule UpgradeSchedule
 }
 
 func NewChainFork(ctx context.Context, cr chainReader, ipldstore cbor.IpldStore, bs blockstoreutil.Blockstore, networkParams *config.NetworkParamsConfig) (*ChainFork, error) {
@@ -553,6 +579,7 @@ func NewChainFork(ctx context.Context, cr chainReader, ipldstore cbor.IpldStore,
 		}
 	}
 
+	fork.upgradeSchedule = us
 	fork.networkVersions = networkVersions
 	fork.latestVersion = lastVersion
 	fork.stateMigrations = stateMigrations
@@ -2568,6 +2595,11 @@ func (c *ChainFork) GetForkUpgrade() *config.ForkUpgradeConfig {
 	return c.forkUpgrade
 }
 
+// GetUpgradeSchedule returns the enabled upgrades ordered by height
+func (c *ChainFork) GetUpgradeSchedule() UpgradeSchedule {
+	return c.upgradeSchedule
+}
+
 // Example upgrade function if upgrade requires only code changes
 // func (c *ChainFork) upgradeActorsV9Common(
 // 	ctx context.Context, cache MigrationCache,
@@ -54,6 +54,10 @@ func (mockFork *MockFork) GetForkUpgrade() *config.ForkUpgradeConfig {
 	}
 }
 
+func (mockFork *MockFork) GetUpgradeSchedule() UpgradeSchedule {
+	return nil
+}
+
 func (mockFork *MockFork) Start(ctx context.Context) error {
 	return nil
 }
@@ -0,0 +1,216 @@
+package fvm
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"testing"
+
+	ffi_cgo "github.com/filecoin-project/filecoin-ffi/cgo"
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/v11/miner"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/state"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/pkg/vm/gas"
+	"github.com/filecoin-project/venus/pkg/wallet/key"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/adt"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/account"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const faultEpoch = abi.ChainEpoch(100)
+
+// newFaultExtern creates an extern whose lookback state has testhelpers.FaultMiner with an id address
+// of workerKey as its worker.
+func newFaultExtern(t *testing.T, workerKey key.KeyInfo) *FvmExtern {
+	ctx := context.Background()
+	bs := blockstoreutil.NewBlockstore(datastore.NewMapDatastore())
+	cst := cbor.NewCborStore(bs)
+	store := adt.WrapStore(ctx, cst)
+
+	st, err := tree.NewState(cst, tree.StateTreeVersion5)
+	require.NoError(t, err)
+
+	workerKeyAddr, err := workerKey.Address()
+	require.NoError(t, err)
+	worker, err := address.NewIDAddress(1002)
+	require.NoError(t, err)
+
+	accountCode, ok := actors.GetActorCodeID(actorstypes.Version11, manifest.AccountKey)
+	require.True(t, ok)
+	accountState, err := account.MakeState(store, actorstypes.Version11, workerKeyAddr)
+	require.NoError(t, err)
+	accountHead, err := cst.Put(ctx, accountState.GetState())
+	require.NoError(t, err)
+	require.NoError(t, st.SetActor(ctx, worker, &types.Actor{Code: accountCode, Head: accountHead, Balance: big.Zero()}))
+
+	// only the info of the miner is read, the other fields just need to be defined
+	emptyCid, err := cst.Put(ctx, []cid.Cid{})
+	require.NoError(t, err)
+	infoCid, err := cst.Put(ctx, &miner.MinerInfo{
+		Owner:       worker,
+		Worker:      worker,
+		Beneficiary: worker,
+		PeerId:      []byte{},
+		SectorSize:  abi.SectorSize(2048),
+		BeneficiaryTerm: miner.BeneficiaryTerm{
+			Quota:     big.Zero(),
+			UsedQuota: big.Zero(),
+		},
+	})
+	require.NoError(t, err)
+	minerHead, err := cst.Put(ctx, &miner.State{
+		Info:                       infoCid,
+		PreCommitDeposits:          big.Zero(),
+		LockedFunds:                big.Zero(),
+		VestingFunds:               emptyCid,
+		FeeDebt:                    big.Zero(),
+		InitialPledge:              big.Zero(),
+		PreCommittedSectors:        emptyCid,
+		PreCommittedSectorsCleanUp: emptyCid,
+		AllocatedSectors:           emptyCid,
+		Sectors:                    emptyCid,
+		Deadlines:                  emptyCid,
+	})
+	require.NoError(t, err)
+	minerCode, ok := actors.GetActorCodeID(actorstypes.Version11, manifest.MinerKey)
+	require.True(t, ok)
+	require.NoError(t, st.SetActor(ctx, testhelpers.FaultMiner, &types.Actor{Code: minerCode, Head: minerHead, Balance: big.Zero()}))
+
+	root, err := st.Flush(ctx)
+	require.NoError(t, err)
+
+	return &FvmExtern{
+		Blockstore: bs,
+		epoch:      faultEpoch + 10,
+		lbState: func(ctx context.Context, epoch abi.ChainEpoch) (*state.View, error) {
+			return state.NewView(cst, root), nil
+		},
+		base:             root,
+		gasPriceSchedule: gas.NewPricesSchedule(config.DefaultForkUpgradeParam),
+	}
+}
+
+func newWorkerKey(t *testing.T) key.KeyInfo {
+	ki, err := key.NewSecpKeyFromSeed(rand.Reader)
+	require.NoError(t, err)
+	return ki
+}
+
+func decodeFaultBlock(t *testing.T, data []byte) *types.BlockHeader {
+	var blk types.BlockHeader
+	require.NoError(t, blk.UnmarshalCBOR(bytes.NewReader(data)))
+	return &blk
+}
+
+func TestVerifyConsensusFault(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	workerKey := newWorkerKey(t)
+	x := newFaultExtern(t, workerKey)
+
+	for _, tc := range []struct {
+		fault testhelpers.FaultType
+		want  ffi_cgo.ConsensusFaultType
+		epoch abi.ChainEpoch
+	}{
+		{testhelpers.DoubleForkMiningFault, ffi_cgo.ConsensusFaultDoubleForkMining, faultEpoch},
+		{testhelpers.TimeOffsetMiningFault, ffi_cgo.ConsensusFaultTimeOffsetMining, faultEpoch + 1},
+		{testhelpers.ParentGrindingFault, ffi_cgo.ConsensusFaultParentGrinding, faultEpoch + 1},
+	} {
+		t.Run(tc.fault.String(), func(t *testing.T) {
+			a, b, extra, err := testhelpers.GenerateConsensusFaultProof(ctx, workerKey, tc.fault, faultEpoch)
+			require.NoError(t, err)
+
+			fault, gasUsed := x.VerifyConsensusFault(ctx, a, b, extra)
+			assert.Equal(t, tc.want, fault.Type)
+			assert.Equal(t, testhelpers.FaultMiner, fault.Target)
+			assert.Equal(t, tc.epoch, fault.Epoch)
+			assert.True(t, gasUsed > 0)
+
+			// the same blocks signed by another key are no proof
+			a, b, extra, err = testhelpers.GenerateConsensusFaultProof(ctx, newWorkerKey(t), tc.fault, faultEpoch)
+			require.NoError(t, err)
+			fault, _ = x.VerifyConsensusFault(ctx, a, b, extra)
+			assert.Equal(t, ffi_cgo.ConsensusFaultNone, fault.Type)
+		})
+	}
+
+	t.Run("no fault", func(t *testing.T) {
+		a, b, _, err := testhelpers.GenerateConsensusFaultProof(ctx, workerKey, testhelpers.DoubleForkMiningFault, faultEpoch)
+		require.NoError(t, err)
+
+		// identical blocks
+		fault, _ := x.VerifyConsensusFault(ctx, a, a, nil)
+		assert.Equal(t, ffi_cgo.ConsensusFaultNone, fault.Type)
+
+		// parent grinding without the witness
+		a, b, _, err = testhelpers.GenerateConsensusFaultProof(ctx, workerKey, testhelpers.ParentGrindingFault, faultEpoch)
+		require.NoError(t, err)
+		fault, _ = x.VerifyConsensusFault(ctx, a, b, nil)
+		assert.Equal(t, ffi_cgo.ConsensusFaultNone, fault.Type)
+
+		// blocks in the wrong order
+		fault, _ = x.VerifyConsensusFault(ctx, b, a, nil)
+		assert.Equal(t, ffi_cgo.ConsensusFaultNone, fault.Type)
+
+		// undecodable blocks
+		fault, _ = x.VerifyConsensusFault(ctx, []byte{1}, b, nil)
+		assert.Equal(t, ffi_cgo.ConsensusFaultNone, fault.Type)
+	})
+}
+
+func TestVerifyBlockSig(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	workerKey := newWorkerKey(t)
+	x := newFaultExtern(t, workerKey)
+
+	a, _, _, err := testhelpers.GenerateConsensusFaultProof(ctx, workerKey, testhelpers.DoubleForkMiningFault, faultEpoch)
+	require.NoError(t, err)
+
+	blk := decodeFaultBlock(t, a)
+	_, err = x.verifyBlockSig(ctx, blk)
+	assert.NoError(t, err)
+
+	// signed by a key which is not the worker
+	other, _, _, err := testhelpers.GenerateConsensusFaultProof(ctx, newWorkerKey(t), testhelpers.DoubleForkMiningFault, faultEpoch)
+	require.NoError(t, err)
+	_, err = x.verifyBlockSig(ctx, decodeFaultBlock(t, other))
+	assert.Error(t, err)
+
+	// tampered block
+	tampered := decodeFaultBlock(t, a)
+	tampered.ParentBaseFee = abi.NewTokenAmount(101)
+	_, err = x.verifyBlockSig(ctx, tampered)
+	assert.Error(t, err)
+
+	// unsigned block
+	unsigned := decodeFaultBlock(t, a)
+	unsigned.BlockSig = nil
+	_, err = x.verifyBlockSig(ctx, unsigned)
+	assert.Error(t, err)
+
+	// the worker can't be looked up beyond finality
+	old := *x
+	old.epoch = blk.Height + 10000
+	_, err = old.verifyBlockSig(ctx, blk)
+	assert.Error(t, err)
+}
@@ -252,6 +252,11 @@ type FVM struct {
 
 	// returnEvents specifies whether to parse and return events when applying messages.
 	returnEvents bool
+	// traceFilter selects the messages whose execution trace is kept, all of them when nil.
+	traceFilter *vm.TraceFilter
+	// lookupID resolves the addresses compared by traceFilter in the base state of the vm.
+	lookupID func(address.Address) (address.Address, error)
+	ids      map[address.Address]address.Address
 }
 
 func defaultFVMOpts(ctx context.Context, opts *vm.VmOption) (*ffi.FVMOpts, error) {
@@ -278,7 +283,7 @@ func defaultFVMOpts(ctx context.Context, opts *vm.VmOption) (*ffi.FVMOpts, error
 		Epoch:          opts.Epoch,
 		Timestamp:      opts.Timestamp,
 		ChainID:        uint64(types2.Eip155ChainID),
-		BaseFee:        opts.BaseFee,
+		BaseFee:        floorBaseFee(opts.BaseFee, opts.MinBaseFee),
 		BaseCircSupply: circToReport,
 		NetworkVersion: opts.NetworkVersion,
 		StateBase:      opts.PRoot,
@@ -287,6 +292,18 @@ func defaultFVMOpts(ctx context.Context, opts *vm.VmOption) (*ffi.FVMOpts, error
 	}, nil
 }
 
+// floorBaseFee keeps the base fee above the floor, the base fee is zero when replaying from genesis
+// which breaks the fee calculations of the FVM
+func floorBaseFee(baseFee, minBaseFee abi.TokenAmount) abi.TokenAmount {
+	if minBaseFee.Nil() {
+		minBaseFee = types.NewInt(constants.MinimumBaseFee)
+	}
+	if baseFee.Nil() || baseFee.LessThan(minBaseFee) {
+		return minBaseFee
+	}
+	return baseFee
+}
+
 func NewFVM(ctx context.Context, opts *vm.VmOption) (*FVM, error) {
 	fvmOpts, err := defaultFVMOpts(ctx, opts)
 	if err != nil {
@@ -298,11 +315,47 @@ func NewFVM(ctx context.Context, opts *vm.VmOption) (*FVM, error) {
 		return nil, err
 	}
 
-	return &FVM{
+	return newFVM(ctx, fvm, opts)
+}
+
+func newFVM(ctx context.Context, fvm *ffi.FVM, opts *vm.VmOption) (*FVM, error) {
+	res := &FVM{
 		fvm:          fvm,
 		nv:           opts.NetworkVersion,
 		returnEvents: opts.ReturnEvents,
-	}, nil
+		traceFilter:  opts.TraceFilter,
+	}
+	if opts.TraceFilter != nil && len(opts.TraceFilter.Addresses) > 0 {
+		state, err := tree.LoadState(ctx, cbor.NewCborStore(opts.Bsstore), opts.PRoot)
+		if err != nil {
+			return nil, fmt.Errorf("loading state tree: %w", err)
+		}
+		res.lookupID = state.LookupID
+		res.ids = make(map[address.Address]address.Address)
+	}
+	return res, nil
+}
+
+// traced tells whether the execution trace of msg is kept
+func (fvm *FVM) traced(msg *types.Message) bool {
+	return fvm.traceFilter.Match(msg, fvm.idOf)
+}
+
+// idOf returns the ID address of addr in the base state of the vm, addr itself when it has none there, as for the
+// actors created since.
+func (fvm *FVM) idOf(addr address.Address) address.Address {
+	if addr.Protocol() == address.ID || fvm.lookupID == nil {
+		return addr
+	}
+	if id, ok := fvm.ids[addr]; ok {
+		return id
+	}
+	id, err := fvm.lookupID(addr)
+	if err != nil {
+		id = addr
+	}
+	fvm.ids[addr] = id
+	return id
 }
 
 func NewDebugFVM(ctx context.Context, opts *vm.VmOption) (*FVM, error) {
@@ -401,11 +454,7 @@ func NewDebugFVM(ctx context.Context, opts *vm.VmOption) (*FVM, error) {
 		return nil, err
 	}
 
-	return &FVM{
-		fvm:          fvm,
-		nv:           opts.NetworkVersion,
-		returnEvents: opts.ReturnEvents,
-	}, nil
+	return newFVM(ctx, fvm, opts)
 }
 
 func (fvm *FVM) ApplyMessage(ctx context.Context, cmsg types.ChainMsg) (*vm.Ret, error) {
@@ -422,8 +471,80 @@ func (fvm *FVM) ApplyMessage(ctx context.Context, cmsg types.ChainMsg) (*vm.Ret,
 		return nil, fmt.Errorf("applying msg: %w", err)
 	}
 
-	duration := time.Since(start)
+	return fvm.toApplyRet(ret, time.Since(start), fvm.traced(vmMsg))
+}
+
+// batchApplier is implemented by ffi FVM builds that are able to apply a batch of messages in a single call.
+// The returned results cover the messages applied before an error, the failed message has none.
+// The ffi FVM of this release does not implement it, ApplyMessages falls back to sequential calls.
+type batchApplier interface {
+	ApplyMessages(msgs [][]byte, chainLens []uint) ([]*ffi.ApplyRet, error)
+}
 
+// ApplyMessages applies msgs in order. When the underlying ffi FVM supports batch execution all messages
+// are passed through in one call, otherwise they are applied one by one.
+// On error, the results up to and including the failed message are returned, the entry of the failed
+// message is nil.
+func (fvm *FVM) ApplyMessages(ctx context.Context, msgs []types.ChainMsg) ([]*vm.Ret, error) {
+	if ba, ok := interface{}(fvm.fvm).(batchApplier); ok {
+		return fvm.applyBatch(ba, msgs)
+	}
+	return applySequential(ctx, msgs, fvm.ApplyMessage)
+}
+
+func applySequential(ctx context.Context, msgs []types.ChainMsg, apply func(context.Context, types.ChainMsg) (*vm.Ret, error)) ([]*vm.Ret, error) {
+	rets := make([]*vm.Ret, 0, len(msgs))
+	for i, msg := range msgs {
+		ret, err := apply(ctx, msg)
+		if err != nil {
+			return append(rets, nil), fmt.Errorf("applying msg %d: %w", i, err)
+		}
+		rets = append(rets, ret)
+	}
+	return rets, nil
+}
+
+func (fvm *FVM) applyBatch(ba batchApplier, msgs []types.ChainMsg) ([]*vm.Ret, error) {
+	start := constants.Clock.Now()
+	msgsBytes := make([][]byte, 0, len(msgs))
+	chainLens := make([]uint, 0, len(msgs))
+	for i, msg := range msgs {
+		msgBytes, err := msg.VMMessage().Serialize()
+		if err != nil {
+			return nil, fmt.Errorf("serializing msg %d: %w", i, err)
+		}
+		msgsBytes = append(msgsBytes, msgBytes)
+		chainLens = append(chainLens, uint(msg.ChainLength()))
+	}
+
+	ffiRets, applyErr := ba.ApplyMessages(msgsBytes, chainLens)
+	// every applied message has a result, a failed message has none and ends the batch
+	if (applyErr == nil && len(ffiRets) != len(msgs)) || (applyErr != nil && len(ffiRets) >= len(msgs)) {
+		return nil, fmt.Errorf("ffi returned %d results for %d messages", len(ffiRets), len(msgs))
+	}
+	atomic.AddUint64(&StatApplied, uint64(len(ffiRets)))
+
+	var duration time.Duration
+	if len(ffiRets) > 0 {
+		duration = time.Since(start) / time.Duration(len(ffiRets))
+	}
+	rets := make([]*vm.Ret, 0, len(ffiRets)+1)
+	for i, ffiRet := range ffiRets {
+		ret, err := fvm.toApplyRet(ffiRet, duration, fvm.traced(msgs[i].VMMessage()))
+		if err != nil {
+			return append(rets, nil), fmt.Errorf("applying msg %d: %w", i, err)
+		}
+		rets = append(rets, ret)
+	}
+	if applyErr != nil {
+		return append(rets, nil), fmt.Errorf("applying msg %d: %w", len(rets), applyErr)
+	}
+
+	return rets, nil
+}
+
+// toApplyRet converts the result of the ffi, the execution trace is dropped when the message is not traced.
+func (fvm *FVM) toApplyRet(ret *ffi.ApplyRet, duration time.Duration, traced bool) (*vm.Ret, error) {
 	var receipt types.MessageReceipt
 	if fvm.nv >= network.Version18 {
 		receipt = types.NewMessageReceiptV1(exitcode.ExitCode(ret.ExitCode), ret.Return, ret.GasUsed, ret.EventsRoot)
@@ -441,8 +562,8 @@ func (fvm *FVM) ApplyMessage(ctx context.Context, cmsg types.ChainMsg) (*vm.Ret,
 	}
 
 	var et types.ExecutionTrace
-	if len(ret.ExecTraceBytes) != 0 {
-		if err = et.UnmarshalCBOR(bytes.NewReader(ret.ExecTraceBytes)); err != nil {
+	if traced && len(ret.ExecTraceBytes) != 0 {
+		if err := et.UnmarshalCBOR(bytes.NewReader(ret.ExecTraceBytes)); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal exectrace: %w", err)
 		}
 	}
@@ -466,6 +587,7 @@ func (fvm *FVM) ApplyMessage(ctx context.Context, cmsg types.ChainMsg) (*vm.Ret,
 	}
 
 	if fvm.returnEvents && len(ret.EventsBytes) > 0 {
+		var err error
 		applyRet.Events, err = types.DecodeEvents(ret.EventsBytes)
 		if err != nil {
 			return nil, fmt.Errorf("failed to decode events returned by the FVM: %w", err)
@@ -509,15 +631,19 @@ func (fvm *FVM) ApplyImplicitMessage(ctx context.Context, cmsg types.ChainMsg) (
 	}
 
 	var et types.ExecutionTrace
-	if len(ret.ExecTraceBytes) != 0 {
+	if len(ret.ExecTraceBytes) != 0 && fvm.traced(vmMsg) {
 		if err = et.UnmarshalCBOR(bytes.NewReader(ret.ExecTraceBytes)); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal exectrace: %w", err)
 		}
 	}
 
 	applyRet := &vm.Ret{
-		Receipt:  receipt,
-		OutPuts:  gas.GasOutputs{},
+		Receipt: receipt,
+		// the rewards and cron are not charged gas, the fvm still reports the penalty and tip like for ApplyMessage
+		OutPuts: gas.GasOutputs{
+			MinerPenalty: ret.MinerPenalty,
+			MinerTip:     ret.MinerTip,
+		},
 		ActorErr: aerr,
 		GasTracker: &gas.GasTracker{
 			ExecutionTrace: et,
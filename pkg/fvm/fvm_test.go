@@ -0,0 +1,174 @@
+package fvm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	ffi "github.com/filecoin-project/filecoin-ffi"
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/pkg/vm"
+	"github.com/filecoin-project/venus/pkg/vm/vmcontext"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type fakeBatchApplier struct {
+	rets []*ffi.ApplyRet
+	err  error
+}
+
+func (f *fakeBatchApplier) ApplyMessages(msgs [][]byte, chainLens []uint) ([]*ffi.ApplyRet, error) {
+	return f.rets, f.err
+}
+
+func testMessages(t *testing.T, n int) []types.ChainMsg {
+	from, err := address.NewIDAddress(100)
+	require.NoError(t, err)
+	to, err := address.NewIDAddress(101)
+	require.NoError(t, err)
+
+	msgs := make([]types.ChainMsg, 0, n)
+	for i := 0; i < n; i++ {
+		msgs = append(msgs, &types.Message{
+			From:       from,
+			To:         to,
+			Nonce:      uint64(i),
+			Value:      big.Zero(),
+			GasFeeCap:  big.Zero(),
+			GasPremium: big.Zero(),
+		})
+	}
+	return msgs
+}
+
+func TestApplyMessagesPartialResults(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	msgs := testMessages(t, 3)
+	errApply := errors.New("apply failed")
+
+	// sequential: the second message fails
+	rets, err := applySequential(ctx, msgs, func(ctx context.Context, msg types.ChainMsg) (*vmcontext.Ret, error) {
+		if msg.VMMessage().Nonce == 1 {
+			return nil, errApply
+		}
+		return &vmcontext.Ret{}, nil
+	})
+	require.ErrorIs(t, err, errApply)
+	require.Len(t, rets, 2)
+	require.NotNil(t, rets[0])
+	require.Nil(t, rets[1])
+
+	rets, err = applySequential(ctx, msgs, func(ctx context.Context, msg types.ChainMsg) (*vmcontext.Ret, error) {
+		return &vmcontext.Ret{}, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, rets, 3)
+
+	// batch: the second message fails, the batch only returns the first result
+	fvm := &FVM{nv: network.Version18}
+	rets, err = fvm.applyBatch(&fakeBatchApplier{
+		rets: []*ffi.ApplyRet{{GasUsed: 10}},
+		err:  errApply,
+	}, msgs)
+	require.ErrorIs(t, err, errApply)
+	require.Len(t, rets, 2)
+	require.Equal(t, int64(10), rets[0].Receipt.GasUsed)
+	require.Nil(t, rets[1])
+
+	rets, err = fvm.applyBatch(&fakeBatchApplier{
+		rets: []*ffi.ApplyRet{{GasUsed: 10}, {GasUsed: 20}, {GasUsed: 30}},
+	}, msgs)
+	require.NoError(t, err)
+	require.Len(t, rets, 3)
+	require.Equal(t, int64(30), rets[2].Receipt.GasUsed)
+
+	// results that don't match the messages are rejected
+	for _, ba := range []*fakeBatchApplier{
+		{rets: []*ffi.ApplyRet{{}, {}, {}, {}}},
+		{rets: []*ffi.ApplyRet{{}, {}}},
+		{rets: []*ffi.ApplyRet{{}, {}, {}, {}}, err: errApply},
+		{rets: []*ffi.ApplyRet{{}, {}, {}}, err: errApply},
+	} {
+		rets, err = fvm.applyBatch(ba, msgs)
+		require.Error(t, err)
+		require.Nil(t, rets)
+	}
+}
+
+func TestFloorBaseFee(t *testing.T) {
+	tf.UnitTest(t)
+
+	// replaying from genesis, the base fee is zero
+	require.Equal(t, types.NewInt(constants.MinimumBaseFee), floorBaseFee(big.Zero(), abi.TokenAmount{}))
+	require.Equal(t, big.NewInt(500), floorBaseFee(big.NewInt(500), abi.TokenAmount{}))
+	require.Equal(t, big.NewInt(1000), floorBaseFee(big.NewInt(500), big.NewInt(1000)))
+	// a zero floor lets the base fee be zero
+	require.Equal(t, big.Zero(), floorBaseFee(big.Zero(), big.Zero()))
+}
+
+func TestTraceFilter(t *testing.T) {
+	tf.UnitTest(t)
+
+	msgs := testMessages(t, 3)
+	msgs[1].VMMessage().Method = 2
+
+	var buf bytes.Buffer
+	trace := types.ExecutionTrace{
+		Msg: types.MessageTrace{From: msgs[0].VMMessage().From, To: msgs[0].VMMessage().To, Value: big.Zero(), Method: 2},
+	}
+	require.NoError(t, trace.MarshalCBOR(&buf))
+	ffiRets := []*ffi.ApplyRet{{ExecTraceBytes: buf.Bytes()}, {ExecTraceBytes: buf.Bytes()}, {ExecTraceBytes: buf.Bytes()}}
+
+	// without a filter every message is traced
+	fvm := &FVM{nv: network.Version18}
+	rets, err := fvm.applyBatch(&fakeBatchApplier{rets: ffiRets}, msgs)
+	require.NoError(t, err)
+	for _, ret := range rets {
+		require.Equal(t, abi.MethodNum(2), ret.GasTracker.ExecutionTrace.Msg.Method)
+	}
+
+	fvm.traceFilter = &vm.TraceFilter{Methods: []abi.MethodNum{2}}
+	rets, err = fvm.applyBatch(&fakeBatchApplier{rets: ffiRets}, msgs)
+	require.NoError(t, err)
+	require.Equal(t, types.ExecutionTrace{}, rets[0].GasTracker.ExecutionTrace)
+	require.Equal(t, abi.MethodNum(2), rets[1].GasTracker.ExecutionTrace.Msg.Method)
+	require.Equal(t, types.ExecutionTrace{}, rets[2].GasTracker.ExecutionTrace)
+
+	noID := func(addr address.Address) address.Address { return addr }
+	other, err := address.NewIDAddress(102)
+	require.NoError(t, err)
+	require.True(t, (&vm.TraceFilter{Addresses: []address.Address{msgs[0].VMMessage().To}}).Match(msgs[0].VMMessage(), noID))
+	require.False(t, (&vm.TraceFilter{Addresses: []address.Address{other}}).Match(msgs[0].VMMessage(), noID))
+	require.False(t, (&vm.TraceFilter{Addresses: []address.Address{msgs[0].VMMessage().To}, Methods: []abi.MethodNum{2}}).Match(msgs[0].VMMessage(), noID))
+
+	// the robust address of an actor matches the messages sent to its ID address and the other way round
+	robust, err := address.NewSecp256k1Address([]byte("trace filter"))
+	require.NoError(t, err)
+	unknown, err := address.NewSecp256k1Address([]byte("created since"))
+	require.NoError(t, err)
+	fvm.lookupID = func(addr address.Address) (address.Address, error) {
+		if addr == robust {
+			return msgs[0].VMMessage().To, nil
+		}
+		return address.Undef, types.ErrActorNotFound
+	}
+	fvm.ids = make(map[address.Address]address.Address)
+	fvm.traceFilter = &vm.TraceFilter{Addresses: []address.Address{robust}}
+	require.True(t, fvm.traced(msgs[0].VMMessage()))
+	fvm.traceFilter = &vm.TraceFilter{Addresses: []address.Address{unknown}}
+	require.False(t, fvm.traced(msgs[0].VMMessage()))
+	require.True(t, fvm.traced(&types.Message{From: msgs[0].VMMessage().From, To: unknown}))
+
+	fvm.traceFilter = &vm.TraceFilter{Addresses: []address.Address{msgs[0].VMMessage().To}}
+	require.True(t, fvm.traced(&types.Message{From: unknown, To: robust}))
+}
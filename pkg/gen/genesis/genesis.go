@@ -510,6 +510,7 @@ func VerifyPreSealedData(ctx context.Context, cs *chain.Store, stateroot cid.Cid
 		NetworkVersion:       nv,
 		Rnd:                  &fakeRand{},
 		BaseFee:              big.NewInt(0),
+		MinBaseFee:           big.Zero(),
 		Epoch:                0,
 		PRoot:                stateroot,
 		Bsstore:              cs.Blockstore(),
@@ -111,6 +111,7 @@ func SetupStorageMiners(ctx context.Context, cs *chain.Store, sroot cid.Cid, min
 			CircSupplyCalculator: csc,
 			Rnd:                  &fakeRand{},
 			BaseFee:              big.NewInt(0),
+			MinBaseFee:           big.Zero(),
 			Epoch:                0,
 			PRoot:                base,
 			NetworkVersion:       nv,
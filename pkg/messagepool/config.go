@@ -4,11 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/venus/pkg/repo"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
@@ -23,6 +27,8 @@ var (
 	MemPoolSizeLimitLoDefault = 20000
 	PruneCooldownDefault      = time.Minute
 	GasLimitOverestimation    = 1.25
+	EVMGasOverestimation      = 1.1
+	OverestimationTarget      = 1.0
 
 	ConfigKey = datastore.NewKey("/mpool/config")
 )
@@ -34,14 +40,53 @@ type MpoolConfig struct {
 	ReplaceByFeeRatio      types.Percent
 	PruneCooldown          time.Duration
 	GasLimitOverestimation float64
+	EVMGasOverestimation   float64
+	// GasEstimateAdaptiveOverestimation enables adjusting the overestimation of native messages by
+	// comparing the gas used by included messages with their estimates. The adjustment starts from
+	// GasLimitOverestimation, it is kept in memory and does not change the config.
+	GasEstimateAdaptiveOverestimation bool
+	// OverestimationTarget scales the observed actual/estimated gas ratio to get the
+	// overestimation the adaptive adjustment moves toward
+	OverestimationTarget float64
+	// MethodMaxFees caps the fee of the messages calling a method of an actor instead of the MaxFee of the
+	// node, keyed by MethodMaxFeeKey. The MaxFee in the send spec of a message still takes precedence.
+	MethodMaxFees map[string]abi.TokenAmount
 }
 
 func (mc *MpoolConfig) Clone() *MpoolConfig {
 	r := new(MpoolConfig)
 	*r = *mc
+	if mc.MethodMaxFees != nil {
+		r.MethodMaxFees = make(map[string]abi.TokenAmount, len(mc.MethodMaxFees))
+		for k, v := range mc.MethodMaxFees {
+			r.MethodMaxFees[k] = v
+		}
+	}
 	return r
 }
 
+// MethodMaxFeeKey returns the key of MpoolConfig.MethodMaxFees for the method of the actors with code, e.g.
+// "bafk2bzaceb.../5" for SubmitWindowedPoSt of the v11 miner actor.
+func MethodMaxFeeKey(code cid.Cid, method abi.MethodNum) string {
+	return fmt.Sprintf("%s/%d", code, method)
+}
+
+func parseMethodMaxFeeKey(key string) (cid.Cid, abi.MethodNum, error) {
+	code, method, ok := strings.Cut(key, "/")
+	if !ok {
+		return cid.Undef, 0, fmt.Errorf("expected <actor code cid>/<method number>")
+	}
+	c, err := cid.Decode(code)
+	if err != nil {
+		return cid.Undef, 0, fmt.Errorf("invalid actor code: %w", err)
+	}
+	num, err := strconv.ParseUint(method, 10, 64)
+	if err != nil {
+		return cid.Undef, 0, fmt.Errorf("invalid method number: %w", err)
+	}
+	return c, abi.MethodNum(num), nil
+}
+
 func loadConfig(ctx context.Context, ds repo.Datastore) (*MpoolConfig, error) {
 	haveCfg, err := ds.Has(ctx, ConfigKey)
 	if err != nil {
@@ -58,9 +103,23 @@ func loadConfig(ctx context.Context, ds repo.Datastore) (*MpoolConfig, error) {
 	}
 	cfg := new(MpoolConfig)
 	err = json.Unmarshal(cfgBytes, cfg)
+	if err == nil {
+		fillConfigDefaults(cfg)
+	}
 	return cfg, err
 }
 
+// fillConfigDefaults sets the fields a config persisted or built before they were introduced
+// leaves at zero to their defaults.
+func fillConfigDefaults(cfg *MpoolConfig) {
+	if cfg.EVMGasOverestimation == 0 {
+		cfg.EVMGasOverestimation = EVMGasOverestimation
+	}
+	if cfg.OverestimationTarget == 0 {
+		cfg.OverestimationTarget = OverestimationTarget
+	}
+}
+
 func saveConfig(ctx context.Context, cfg *MpoolConfig, ds repo.Datastore) error {
 	cfgBytes, err := json.Marshal(cfg)
 	if err != nil {
@@ -83,14 +142,29 @@ func validateConfg(cfg *MpoolConfig) error {
 	if cfg.GasLimitOverestimation < 1 {
 		return fmt.Errorf("'GasLimitOverestimation' cannot be less than 1")
 	}
+	if cfg.EVMGasOverestimation < 1 {
+		return fmt.Errorf("'EVMGasOverestimation' cannot be less than 1")
+	}
+	if cfg.OverestimationTarget <= 0 {
+		return fmt.Errorf("'OverestimationTarget' must be positive")
+	}
+	for key, fee := range cfg.MethodMaxFees {
+		if _, _, err := parseMethodMaxFeeKey(key); err != nil {
+			return fmt.Errorf("'MethodMaxFees' key %q: %w", key, err)
+		}
+		if fee.Int == nil || fee.Sign() < 0 {
+			return fmt.Errorf("'MethodMaxFees' of %q cannot be negative", key)
+		}
+	}
 	return nil
 }
 
 func (mp *MessagePool) SetConfig(ctx context.Context, cfg *MpoolConfig) error {
+	cfg = cfg.Clone()
+	fillConfigDefaults(cfg)
 	if err := validateConfg(cfg); err != nil {
 		return err
 	}
-	cfg = cfg.Clone()
 
 	mp.cfgLk.Lock()
 	mp.cfg = cfg
@@ -99,6 +173,8 @@ func (mp *MessagePool) SetConfig(ctx context.Context, cfg *MpoolConfig) error {
 		log.Warnf("error persisting mpool config: %s", err)
 	}
 	mp.cfgLk.Unlock()
+	// an adjustment made by the gas feedback loop starts over from the new config
+	mp.gasFeedback.reset()
 
 	return nil
 }
@@ -110,5 +186,7 @@ func DefaultConfig() *MpoolConfig {
 		ReplaceByFeeRatio:      ReplaceByFeePercentageDefault,
 		PruneCooldown:          PruneCooldownDefault,
 		GasLimitOverestimation: GasLimitOverestimation,
+		EVMGasOverestimation:   EVMGasOverestimation,
+		OverestimationTarget:   OverestimationTarget,
 	}
 }
@@ -25,6 +25,10 @@ import (
 
 const MinGasPremium = 100e3
 
+// MaxPremiumHistoryEpochs bounds the epochs the gas premium estimate looks back, each of them loads a tipset
+// and its messages.
+const MaxPremiumHistoryEpochs = 300
+
 // const MaxSpendOnFeeDenom = 100
 
 type GasPriceCache struct {
@@ -83,20 +87,40 @@ func (mp *MessagePool) GasEstimateFeeCap(
 		return types.NewGasFeeCap(0), err
 	}
 
-	parentBaseFee := ts.Blocks()[0].ParentBaseFee
+	return GasEstimateFeeCapOffline(ts.Blocks()[0].ParentBaseFee, maxqueueblks, msg.GasPremium), nil
+}
+
+// GasEstimateFeeCapOffline estimates the fee cap of a message the way GasEstimateFeeCap does, without access
+// to the chain. It is meant for wallets signing messages offline, which pass the parent base fee of a recent
+// head in baseFee. The base fee may rise by 1/BaseFeeMaxChangeDenom per epoch, the fee cap covers it rising
+// in each of the next maxqueueblks epochs and adds gasPremium, which may be types.EmptyInt.
+func GasEstimateFeeCapOffline(baseFee abi.TokenAmount, maxqueueblks int64, gasPremium abi.TokenAmount) abi.TokenAmount {
 	increaseFactor := math.Pow(1.+1./float64(constants.BaseFeeMaxChangeDenom), float64(maxqueueblks))
 
-	feeInFuture := types.BigMul(parentBaseFee, types.NewInt(uint64(increaseFactor*(1<<8))))
+	feeInFuture := types.BigMul(baseFee, types.NewInt(uint64(increaseFactor*(1<<8))))
 	out := types.BigDiv(feeInFuture, types.NewInt(1<<8))
 
-	if msg.GasPremium != types.EmptyInt {
-		out = types.BigAdd(out, msg.GasPremium)
+	if gasPremium != types.EmptyInt {
+		out = types.BigAdd(out, gasPremium)
 	}
 
-	return out, nil
+	return out
 }
 
-// finds 55th percntile instead of median to put negative pressure on gas price
+// premiumHistoryEpochs returns the number of epochs the premium estimate looks back, historyBlocks or by
+// default twice the inclusion target, at most MaxPremiumHistoryEpochs.
+func premiumHistoryEpochs(nblocksincl, historyBlocks uint64) uint64 {
+	if historyBlocks == 0 {
+		historyBlocks = nblocksincl * 2
+	}
+	if historyBlocks > MaxPremiumHistoryEpochs {
+		return MaxPremiumHistoryEpochs
+	}
+	return historyBlocks
+}
+
+// finds 55th percntile instead of median to put negative pressure on gas price, blocks is the number of
+// blocks the prices were paid in over the history
 func medianGasPremium(prices []GasMeta, blocks int) abi.TokenAmount {
 	sort.Slice(prices, func(i, j int) bool {
 		// sort desc by price
@@ -127,12 +151,28 @@ func (mp *MessagePool) GasEstimateGasPremium(
 	nblocksincl uint64,
 	sender address.Address,
 	gaslimit int64,
+	tsk types.TipSetKey,
+	cache *GasPriceCache,
+) (big.Int, error) {
+	return mp.GasEstimateGasPremiumWithHistory(ctx, nblocksincl, 0, sender, gaslimit, tsk, cache)
+}
+
+// GasEstimateGasPremiumWithHistory estimates the premium for the message to be included within nblocksincl
+// epochs from the premiums paid over the last historyBlocks epochs, 0 looks back nblocksincl*2 epochs. The history
+// is capped at MaxPremiumHistoryEpochs.
+func (mp *MessagePool) GasEstimateGasPremiumWithHistory(
+	ctx context.Context,
+	nblocksincl uint64,
+	historyBlocks uint64,
+	sender address.Address,
+	gaslimit int64,
 	_ types.TipSetKey,
 	cache *GasPriceCache,
 ) (big.Int, error) {
 	if nblocksincl == 0 {
 		nblocksincl = 1
 	}
+	historyBlocks = premiumHistoryEpochs(nblocksincl, historyBlocks)
 
 	var prices []GasMeta
 	var blocks int
@@ -142,7 +182,7 @@ func (mp *MessagePool) GasEstimateGasPremium(
 		return big.Int{}, err
 	}
 
-	for i := uint64(0); i < nblocksincl*2; i++ {
+	for i := uint64(0); i < historyBlocks; i++ {
 		if ts.Height() == 0 {
 			break // genesis
 		}
@@ -185,16 +225,71 @@ func (mp *MessagePool) GasEstimateGasPremium(
 }
 
 func (mp *MessagePool) GasEstimateGasLimit(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (int64, error) {
+	return mp.gasEstimateGasLimit(ctx, msgIn, types.MessageTypeNative, tsk)
+}
+
+func (mp *MessagePool) gasEstimateGasLimit(ctx context.Context, msgIn *types.Message, msgType types.MessageType, tsk types.TipSetKey) (int64, error) {
+	gasUsed, _, err := mp.gasEstimateGasLimitWithTrace(ctx, msgIn, msgType, tsk)
+	return gasUsed, err
+}
+
+// GasEstimateGasLimitWithTrace estimates the gas limit of msgIn like GasEstimateGasLimit and breaks the gas used
+// down by the calls made while executing it, the calls using the most gas first.
+func (mp *MessagePool) GasEstimateGasLimitWithTrace(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (*types.GasLimitBreakdown, error) {
+	gasUsed, res, err := mp.gasEstimateGasLimitWithTrace(ctx, msgIn, types.MessageTypeNative, tsk)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GasLimitBreakdown{
+		GasLimit:         mp.overestimateGasLimit(gasUsed, types.MessageTypeNative, nil),
+		GasUsed:          gasUsed,
+		SubCallBreakdown: subCallGasBreakdown(res.ExecutionTrace),
+	}, nil
+}
+
+// subCallGasBreakdown flattens the subcalls of trace, sorted by the gas they used including their own subcalls.
+func subCallGasBreakdown(trace types.ExecutionTrace) []types.SubCallGas {
+	var out []types.SubCallGas
+	var walk func(et types.ExecutionTrace, depth int) int64
+	walk = func(et types.ExecutionTrace, depth int) int64 {
+		own := et.SumGas().TotalGas
+		total := own
+		for _, sub := range et.Subcalls {
+			total += walk(sub, depth+1)
+		}
+		if depth > 0 {
+			out = append(out, types.SubCallGas{
+				Depth:    depth,
+				From:     et.Msg.From,
+				To:       et.Msg.To,
+				Method:   et.Msg.Method,
+				GasUsed:  total,
+				CallGas:  own,
+				ExitCode: et.MsgRct.ExitCode,
+			})
+		}
+		return total
+	}
+	walk(trace, 0)
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].GasUsed > out[j].GasUsed
+	})
+	return out
+}
+
+func (mp *MessagePool) gasEstimateGasLimitWithTrace(ctx context.Context, msgIn *types.Message, msgType types.MessageType, tsk types.TipSetKey) (int64, *types.InvocResult, error) {
 	if tsk.IsEmpty() {
 		ts, err := mp.api.ChainHead(ctx)
 		if err != nil {
-			return -1, fmt.Errorf("getting head: %v", err)
+			return -1, nil, fmt.Errorf("getting head: %v", err)
 		}
 		tsk = ts.Key()
 	}
 	currTS, err := mp.api.ChainTipSet(ctx, tsk)
 	if err != nil {
-		return -1, fmt.Errorf("getting tipset: %w", err)
+		return -1, nil, fmt.Errorf("getting tipset: %w", err)
 	}
 
 	msg := *msgIn
@@ -204,7 +299,7 @@ func (mp *MessagePool) GasEstimateGasLimit(ctx context.Context, msgIn *types.Mes
 
 	fromA, err := mp.sm.ResolveToDeterministicAddress(ctx, msgIn.From, currTS)
 	if err != nil {
-		return -1, fmt.Errorf("getting key address: %w", err)
+		return -1, nil, fmt.Errorf("getting key address: %w", err)
 	}
 
 	pending, ts := mp.PendingFor(ctx, fromA)
@@ -216,7 +311,27 @@ func (mp *MessagePool) GasEstimateGasLimit(ctx context.Context, msgIn *types.Mes
 		priorMsgs = append(priorMsgs, m)
 	}
 
-	return mp.evalMessageGasLimit(ctx, msgIn, priorMsgs, ts)
+	return mp.evalMessageGasLimitWithTrace(ctx, msgIn, msgType, priorMsgs, ts)
+}
+
+// GasEstimateGasLimitWithPriors estimates the gas limit of msgIn on top of tsk after applying priors, instead of
+// the pending messages of the sender, so that dependent messages can be estimated before the earlier ones are pushed.
+func (mp *MessagePool) GasEstimateGasLimitWithPriors(ctx context.Context, msgIn *types.Message, priors []types.ChainMsg, tsk types.TipSetKey) (int64, error) {
+	var ts *types.TipSet
+	var err error
+	if tsk.IsEmpty() {
+		ts, err = mp.api.ChainHead(ctx)
+		if err != nil {
+			return -1, fmt.Errorf("getting head: %v", err)
+		}
+	} else {
+		ts, err = mp.api.ChainTipSet(ctx, tsk)
+		if err != nil {
+			return -1, fmt.Errorf("getting tipset: %w", err)
+		}
+	}
+
+	return mp.evalMessageGasLimit(ctx, msgIn, types.MessageTypeNative, priors, ts)
 }
 
 // GasEstimateCallWithGas invokes a message "msgIn" on the earliest available tipset with pending
@@ -243,18 +358,7 @@ func (mp *MessagePool) GasEstimateCallWithGas(
 		priorMsgs = append(priorMsgs, m)
 	}
 
-	// Try calling until we find a height with no migration.
-	var res *types.InvocResult
-	for {
-		res, err = mp.sm.CallWithGas(ctx, &msg, priorMsgs, ts)
-		if err != fork.ErrExpensiveFork {
-			break
-		}
-		ts, err = mp.api.ChainTipSet(ctx, ts.Parents())
-		if err != nil {
-			return nil, []types.ChainMsg{}, nil, fmt.Errorf("getting parent tipset: %w", err)
-		}
-	}
+	res, ts, err := mp.callBeforeMigration(ctx, &msg, priorMsgs, ts)
 	if err != nil {
 		return nil, []types.ChainMsg{}, nil, fmt.Errorf("CallWithGas failed: %w", err)
 	}
@@ -262,32 +366,69 @@ func (mp *MessagePool) GasEstimateCallWithGas(
 	return res, priorMsgs, ts, nil
 }
 
-func (mp *MessagePool) evalMessageGasLimit(ctx context.Context, msgIn *types.Message, priorMsgs []types.ChainMsg, ts *types.TipSet) (int64, error) {
-	msg := *msgIn
-	msg.GasLimit = constants.BlockGasLimit
-	msg.GasFeeCap = big.Zero()
-	msg.GasPremium = big.Zero()
+// The failures of the gas limit estimation, callers tell them apart with errors.Is.
+var (
+	ErrActorNotFound       = types.ErrActorNotFound
+	ErrInsufficientFunds   = errors.New("insufficient funds")
+	ErrMethodNotFound      = errors.New("method not found")
+	ErrExecutionFailed     = errors.New("message execution failed")
+	ErrMigrationInProgress = errors.New("migration in progress")
+)
 
-	// Try calling until we find a height with no migration.
-	var res *types.InvocResult
-	var err error
+// estimationExitError wraps the exit code of a message failing the estimation with the matching sentinel error.
+func estimationExitError(code exitcode.ExitCode, reason string) error {
+	var sentinel error
+	switch code {
+	case exitcode.SysErrSenderInvalid, exitcode.SysErrInvalidReceiver:
+		sentinel = ErrActorNotFound
+	case exitcode.SysErrInsufficientFunds, exitcode.ErrInsufficientFunds:
+		sentinel = ErrInsufficientFunds
+	case exitcode.ErrUnhandledMessage:
+		sentinel = ErrMethodNotFound
+	default:
+		return fmt.Errorf("%w: exit %s, reason: %v", ErrExecutionFailed, code, reason)
+	}
+	return fmt.Errorf("%w: message execution failed: exit %s, reason: %v", sentinel, code, reason)
+}
+
+// callBeforeMigration calls msg on ts, walking back to the parents while calling on ts would run an
+// expensive migration. It returns the tipset msg was called on, or ErrMigrationInProgress once there
+// is no parent left to walk back to.
+func (mp *MessagePool) callBeforeMigration(ctx context.Context, msg *types.Message, priorMsgs []types.ChainMsg, ts *types.TipSet) (*types.InvocResult, *types.TipSet, error) {
 	for {
-		res, err = mp.sm.CallWithGas(ctx, &msg, priorMsgs, ts)
-		if err != fork.ErrExpensiveFork {
-			break
+		res, err := mp.sm.CallWithGas(ctx, msg, priorMsgs, ts)
+		if !errors.Is(err, fork.ErrExpensiveFork) {
+			return res, ts, err
+		}
+		if ts.Height() == 0 {
+			return nil, nil, fmt.Errorf("%w: %v", ErrMigrationInProgress, err)
 		}
 
 		ts, err = mp.api.ChainTipSet(ctx, ts.Parents())
 		if err != nil {
-			return -1, fmt.Errorf("getting parent tipset: %v", err)
+			return nil, nil, fmt.Errorf("getting parent tipset: %w", err)
 		}
 	}
+}
+
+func (mp *MessagePool) evalMessageGasLimit(ctx context.Context, msgIn *types.Message, msgType types.MessageType, priorMsgs []types.ChainMsg, ts *types.TipSet) (int64, error) {
+	gasUsed, _, err := mp.evalMessageGasLimitWithTrace(ctx, msgIn, msgType, priorMsgs, ts)
+	return gasUsed, err
+}
+
+func (mp *MessagePool) evalMessageGasLimitWithTrace(ctx context.Context, msgIn *types.Message, msgType types.MessageType, priorMsgs []types.ChainMsg, ts *types.TipSet) (int64, *types.InvocResult, error) {
+	msg := *msgIn
+	msg.GasLimit = constants.BlockGasLimit
+	msg.GasFeeCap = big.Zero()
+	msg.GasPremium = big.Zero()
+
+	res, ts, err := mp.callBeforeMigration(ctx, &msg, priorMsgs, ts)
 	if err != nil {
-		return -1, fmt.Errorf("CallWithGas failed: %v", err)
+		return -1, nil, fmt.Errorf("CallWithGas failed: %w", err)
 	}
 	if res.MsgRct.ExitCode != exitcode.Ok {
 		log.Warnf("message execution failed: from %v, method %d, exit %s, reason: %v", msg.From, msg.Method, res.MsgRct.ExitCode, res.Error)
-		return -1, fmt.Errorf("message execution failed: exit %s, reason: %v", res.MsgRct.ExitCode, res.Error)
+		return -1, nil, estimationExitError(res.MsgRct.ExitCode, res.Error)
 	}
 
 	ret := res.MsgRct.GasUsed
@@ -347,14 +488,46 @@ func (mp *MessagePool) evalMessageGasLimit(ctx context.Context, msgIn *types.Mes
 	// and we just want to detect existing payment channel actors
 	_, st, err := mp.sm.ParentState(ctx, ts)
 	if err == nil {
-		act, found, err := st.GetActor(ctx, msg.To)
-		if err == nil && found && builtin.IsPaymentChannelActor(act.Code) && msgIn.Method == builtin2.MethodsPaych.Collect {
-			// add the refunded gas for DestroyActor back into the gas used
-			ret += 76e3
-		}
+		ret += paychCollectRefund(ctx, msgIn, msgType, st.GetActor)
 	}
 
-	return ret, nil
+	return ret, res, nil
+}
+
+// paychCollectRefund returns the gas refunded for DestroyActor when msg collects a payment channel,
+// EVM messages never target the payment channel actor directly.
+func paychCollectRefund(ctx context.Context, msg *types.Message, msgType types.MessageType, getActor func(context.Context, address.Address) (*types.Actor, bool, error)) int64 {
+	if msgType == types.MessageTypeEVM || msg.Method != builtin2.MethodsPaych.Collect {
+		return 0
+	}
+	act, found, err := getActor(ctx, msg.To)
+	if err == nil && found && builtin.IsPaymentChannelActor(act.Code) {
+		return 76e3
+	}
+	return 0
+}
+
+// gasLimitOverestimation returns the gas limit multiplier for the given message type, the native one
+// follows the gas feedback loop when GasEstimateAdaptiveOverestimation is enabled.
+func (mp *MessagePool) gasLimitOverestimation(msgType types.MessageType) float64 {
+	cfg := mp.GetConfig()
+	if msgType == types.MessageTypeEVM {
+		return cfg.EVMGasOverestimation
+	}
+	if cfg.GasEstimateAdaptiveOverestimation {
+		return mp.gasFeedback.overestimation(cfg.GasLimitOverestimation)
+	}
+	return cfg.GasLimitOverestimation
+}
+
+// overestimateGasLimit applies the gas limit multiplier of msgType to gasUsed, a GasOverEstimation
+// set in spec takes precedence.
+func (mp *MessagePool) overestimateGasLimit(gasUsed int64, msgType types.MessageType, spec *types.MessageSendSpec) int64 {
+	gasLimitOverestimation := mp.gasLimitOverestimation(msgType)
+	if spec != nil && spec.GasOverEstimation > 0 {
+		gasLimitOverestimation = spec.GasOverEstimation
+	}
+	return int64(float64(gasUsed) * gasLimitOverestimation)
 }
 
 func (mp *MessagePool) GasEstimateMessageGas(ctx context.Context, estimateMessage *types.EstimateMessage, _ types.TipSetKey) (*types.Message, error) {
@@ -363,15 +536,14 @@ func (mp *MessagePool) GasEstimateMessageGas(ctx context.Context, estimateMessag
 	}
 	log.Debugf("call GasEstimateMessageGas %v, send spec: %v", estimateMessage.Msg, estimateMessage.Spec)
 	if estimateMessage.Msg.GasLimit == 0 {
-		gasLimit, err := mp.GasEstimateGasLimit(ctx, estimateMessage.Msg, types.TipSetKey{})
+		gasLimit, err := mp.gasEstimateGasLimit(ctx, estimateMessage.Msg, estimateMessage.MessageType, types.TipSetKey{})
 		if err != nil {
 			return nil, fmt.Errorf("estimating gas used: %w", err)
 		}
-		gasLimitOverestimation := mp.GetConfig().GasLimitOverestimation
-		if estimateMessage.Spec != nil && estimateMessage.Spec.GasOverEstimation > 0 {
-			gasLimitOverestimation = estimateMessage.Spec.GasOverEstimation
+		estimateMessage.Msg.GasLimit = mp.overestimateGasLimit(gasLimit, estimateMessage.MessageType, estimateMessage.Spec)
+		if estimateMessage.MessageType == types.MessageTypeNative {
+			mp.recordGasEstimate(ctx, estimateMessage.Msg, gasLimit)
 		}
-		estimateMessage.Msg.GasLimit = int64(float64(gasLimit) * gasLimitOverestimation)
 	}
 
 	if estimateMessage.Msg.GasPremium == types.EmptyInt || types.BigCmp(estimateMessage.Msg.GasPremium, types.NewInt(0)) == 0 {
@@ -396,7 +568,7 @@ func (mp *MessagePool) GasEstimateMessageGas(ctx context.Context, estimateMessag
 		estimateMessage.Msg.GasFeeCap = feeCap
 	}
 
-	CapGasFee(mp.GetMaxFee, estimateMessage.Msg, estimateMessage.Spec)
+	CapGasFee(mp.maxFeeFunc(ctx, estimateMessage.Msg), estimateMessage.Msg, estimateMessage.Spec)
 
 	return estimateMessage.Msg, nil
 }
@@ -431,7 +603,11 @@ func (mp *MessagePool) GasBatchEstimateMessageGas(ctx context.Context, estimateM
 		log.Debugf("call GasBatchEstimateMessageGas msg %v, spec %v", estimateMsg, estimateMessage.Spec)
 
 		if estimateMsg.GasLimit == 0 {
-			gasUsed, err := mp.evalMessageGasLimit(ctx, estimateMsg, priorMsgs, ts)
+			// a cancelled batch returns the messages estimated so far
+			if err := ctx.Err(); err != nil {
+				return estimateResults, fmt.Errorf("estimating message %d: %w", len(estimateResults), err)
+			}
+			gasUsed, err := mp.evalMessageGasLimit(ctx, estimateMsg, estimateMessage.MessageType, priorMsgs, ts)
 			if err != nil {
 				estimateMsg.Nonce = 0
 				estimateResults = append(estimateResults, &types.EstimateResult{
@@ -440,7 +616,7 @@ func (mp *MessagePool) GasBatchEstimateMessageGas(ctx context.Context, estimateM
 				})
 				continue
 			}
-			estimateMsg.GasLimit = int64(float64(gasUsed) * estimateMessage.Spec.GasOverEstimation)
+			estimateMsg.GasLimit = mp.overestimateGasLimit(gasUsed, estimateMessage.MessageType, estimateMessage.Spec)
 		}
 
 		if estimateMsg.GasPremium == types.EmptyInt || types.BigCmp(estimateMsg.GasPremium, types.NewInt(0)) == 0 {
@@ -475,7 +651,7 @@ func (mp *MessagePool) GasBatchEstimateMessageGas(ctx context.Context, estimateM
 			estimateMsg.GasFeeCap = feeCap
 		}
 
-		CapGasFee(mp.GetMaxFee, estimateMsg, estimateMessage.Spec)
+		CapGasFee(mp.maxFeeFunc(ctx, estimateMsg), estimateMsg, estimateMessage.Spec)
 
 		estimateResults = append(estimateResults, &types.EstimateResult{
 			Msg: estimateMsg,
@@ -0,0 +1,235 @@
+package messagepool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const (
+	// gasFeedbackWindow is the number of included messages the accuracy ratio is averaged over
+	gasFeedbackWindow = 100
+	// gasFeedbackStep is how much the overestimation moves per adjustment
+	gasFeedbackStep = 0.01
+	// gasFeedbackEstimates bounds the number of outstanding estimates waiting for inclusion
+	gasFeedbackEstimates = 4096
+)
+
+// gasFeedback tracks how the gas used by included messages compares to what the node estimated
+// for them, so the gas limit overestimation can follow the accuracy of the estimator.
+type gasFeedback struct {
+	lk sync.Mutex
+
+	// estimates maps the sender and nonce of an estimated message to the estimated gas used
+	estimates *lru.Cache[estimateKey, int64]
+
+	// ratios is the rolling window of actualGasUsed / estimatedGasUsed
+	ratios []float64
+
+	// adjusted is the overestimation moved by the feedback loop, it starts from the configured
+	// GasLimitOverestimation and is never written back to the config
+	adjusted float64
+}
+
+type estimateKey struct {
+	from  address.Address
+	nonce uint64
+}
+
+func newGasFeedback() *gasFeedback {
+	estimates, _ := lru.New[estimateKey, int64](gasFeedbackEstimates)
+	return &gasFeedback{
+		estimates: estimates,
+		ratios:    make([]float64, 0, gasFeedbackWindow),
+	}
+}
+
+func (gf *gasFeedback) recordEstimate(from address.Address, nonce uint64, estimated int64) {
+	if estimated <= 0 {
+		return
+	}
+	gf.estimates.Add(estimateKey{from: from, nonce: nonce}, estimated)
+}
+
+// observe records the gas used by an included message, it reports whether the message was
+// matched with an estimate.
+func (gf *gasFeedback) observe(from address.Address, nonce uint64, gasUsed int64) bool {
+	key := estimateKey{from: from, nonce: nonce}
+	estimated, ok := gf.estimates.Get(key)
+	if !ok {
+		return false
+	}
+	gf.estimates.Remove(key)
+
+	gf.lk.Lock()
+	defer gf.lk.Unlock()
+	if len(gf.ratios) == gasFeedbackWindow {
+		gf.ratios = gf.ratios[1:]
+	}
+	gf.ratios = append(gf.ratios, float64(gasUsed)/float64(estimated))
+	return true
+}
+
+// overestimation returns the adjusted overestimation, or configured when there is none yet.
+func (gf *gasFeedback) overestimation(configured float64) float64 {
+	gf.lk.Lock()
+	defer gf.lk.Unlock()
+	if gf.adjusted == 0 {
+		return configured
+	}
+	return gf.adjusted
+}
+
+func (gf *gasFeedback) setOverestimation(adjusted float64) {
+	gf.lk.Lock()
+	defer gf.lk.Unlock()
+	gf.adjusted = adjusted
+}
+
+// reset drops the adjusted overestimation so the next adjustment starts from the configured value.
+func (gf *gasFeedback) reset() {
+	gf.lk.Lock()
+	defer gf.lk.Unlock()
+	gf.adjusted = 0
+}
+
+// next returns the overestimation one step closer to the average ratio scaled by target,
+// it returns cur unchanged until the window is full.
+func (gf *gasFeedback) next(cur, target float64) float64 {
+	gf.lk.Lock()
+	defer gf.lk.Unlock()
+
+	if len(gf.ratios) < gasFeedbackWindow {
+		return cur
+	}
+
+	var sum float64
+	for _, r := range gf.ratios {
+		sum += r
+	}
+	want := sum / float64(len(gf.ratios)) * target
+	// never aim below 1, validateConfg rejects such values
+	if want < 1 {
+		want = 1
+	}
+
+	switch {
+	case cur < want-gasFeedbackStep:
+		return cur + gasFeedbackStep
+	case cur > want+gasFeedbackStep:
+		return cur - gasFeedbackStep
+	default:
+		return cur
+	}
+}
+
+// notifyGasFeedback queues the applied tipsets for the feedback loop, it never blocks head changes.
+func (mp *MessagePool) notifyGasFeedback(apply []*types.TipSet) {
+	if !mp.GetConfig().GasEstimateAdaptiveOverestimation {
+		return
+	}
+	for _, ts := range apply {
+		select {
+		case mp.gasFeedbackCh <- ts:
+		default:
+			log.Debugf("gas feedback queue is full, skip tipset %d", ts.Height())
+		}
+	}
+}
+
+func (mp *MessagePool) runGasFeedback(ctx context.Context) {
+	for {
+		select {
+		case ts := <-mp.gasFeedbackCh:
+			if err := mp.processGasFeedback(ctx, ts); err != nil {
+				log.Warnf("gas feedback for tipset %d: %s", ts.Height(), err)
+			}
+		case <-mp.closer:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// recordGasEstimate remembers the gas used estimated for msg by its sender and nonce. A message
+// estimated before it gets a nonce is expected to take the next nonce of its sender.
+func (mp *MessagePool) recordGasEstimate(ctx context.Context, msg *types.Message, estimated int64) {
+	if !mp.GetConfig().GasEstimateAdaptiveOverestimation {
+		return
+	}
+
+	mp.lk.Lock()
+	from, err := mp.resolveToKey(ctx, msg.From)
+	mp.lk.Unlock()
+	if err != nil {
+		log.Debugf("gas feedback failed to resolve %s: %s", msg.From, err)
+		return
+	}
+
+	nonce := msg.Nonce
+	if nonce == 0 {
+		nonce, err = mp.GetNonce(ctx, from, types.EmptyTSK)
+		if err != nil {
+			log.Debugf("gas feedback failed to get nonce of %s: %s", from, err)
+			return
+		}
+	}
+	mp.gasFeedback.recordEstimate(from, nonce, estimated)
+}
+
+// processGasFeedback matches the messages executed by ts, which are included in its parent,
+// with their receipts and adjusts the gas limit overestimation when enough samples are collected.
+func (mp *MessagePool) processGasFeedback(ctx context.Context, ts *types.TipSet) error {
+	pts, err := mp.api.LoadTipSet(ctx, ts.Parents())
+	if err != nil {
+		return fmt.Errorf("loading parent tipset: %w", err)
+	}
+	msgs, err := mp.api.MessagesForTipset(ctx, pts)
+	if err != nil {
+		return fmt.Errorf("loading parent messages: %w", err)
+	}
+	receipts, err := mp.api.LoadReceipts(ctx, ts.Blocks()[0].ParentMessageReceipts)
+	if err != nil {
+		return fmt.Errorf("loading parent receipts: %w", err)
+	}
+	if len(msgs) != len(receipts) {
+		return fmt.Errorf("messages and receipts mismatch: %d != %d", len(msgs), len(receipts))
+	}
+
+	matched := false
+	for i, msg := range msgs {
+		from := msg.VMMessage().From
+		if from.Protocol() == address.ID {
+			mp.lk.Lock()
+			from, err = mp.resolveToKey(ctx, from)
+			mp.lk.Unlock()
+			if err != nil {
+				continue
+			}
+		}
+		if mp.gasFeedback.observe(from, msg.VMMessage().Nonce, receipts[i].GasUsed) {
+			matched = true
+		}
+	}
+	if !matched {
+		return nil
+	}
+
+	cfg := mp.GetConfig()
+	if !cfg.GasEstimateAdaptiveOverestimation {
+		return nil
+	}
+	cur := mp.gasFeedback.overestimation(cfg.GasLimitOverestimation)
+	next := mp.gasFeedback.next(cur, cfg.OverestimationTarget)
+	if next != cur {
+		log.Infof("adjust gas limit overestimation from %.2f to %.2f", cur, next)
+		mp.gasFeedback.setOverestimation(next)
+	}
+	return nil
+}
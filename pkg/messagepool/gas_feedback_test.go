@@ -0,0 +1,102 @@
+package messagepool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestGasFeedback(t *testing.T) {
+	tf.UnitTest(t)
+
+	gf := newGasFeedback()
+	from := mkAddress(1000)
+	other := mkAddress(1001)
+
+	// unknown messages are not sampled
+	assert.False(t, gf.observe(from, 0, 900))
+
+	for i := 0; i < gasFeedbackWindow-1; i++ {
+		gf.recordEstimate(from, uint64(i), 800)
+		// the same nonce of another sender is a different message
+		assert.False(t, gf.observe(other, uint64(i), 1200))
+		assert.True(t, gf.observe(from, uint64(i), 1200))
+	}
+	// the window is not full yet
+	assert.Equal(t, 1.25, gf.next(1.25, 1.0))
+
+	gf.recordEstimate(from, 5000, 800)
+	assert.True(t, gf.observe(from, 5000, 1200))
+	// an estimate is only matched once
+	assert.False(t, gf.observe(from, 5000, 1200))
+
+	// actual gas used is 1.5x the estimate, step up
+	assert.InDelta(t, 1.26, gf.next(1.25, 1.0), 1e-9)
+	// already close to the target
+	assert.InDelta(t, 1.5, gf.next(1.5, 1.0), 1e-9)
+	// step down toward the target
+	assert.InDelta(t, 1.99, gf.next(2.0, 1.0), 1e-9)
+	// a target below 1 steps down toward 1 and stops there
+	assert.InDelta(t, 1.04, gf.next(1.05, 0.1), 1e-9)
+	assert.InDelta(t, 1.005, gf.next(1.005, 0.1), 1e-9)
+
+	assert.Equal(t, 1.25, gf.overestimation(1.25))
+	gf.setOverestimation(1.3)
+	assert.Equal(t, 1.3, gf.overestimation(1.25))
+	gf.reset()
+	assert.Equal(t, 1.25, gf.overestimation(1.25))
+}
+
+func TestProcessGasFeedback(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	tma := NewMockProvider()
+	w, mp := newWalletAndMpool(t, tma)
+	defer mp.Close() // nolint
+
+	cfg := mp.GetConfig()
+	cfg.GasEstimateAdaptiveOverestimation = true
+	assert.NoError(t, mp.SetConfig(ctx, cfg))
+
+	sender, err := w.NewAddress(ctx, address.SECP256K1)
+	assert.NoError(t, err)
+	target := mkAddress(1001)
+
+	// a fills a window of messages that used 1.5x their estimate, b carries their receipts
+	a := tma.NextBlock()
+	var msgs []*types.SignedMessage
+	var receipts []types.MessageReceipt
+	for i := 0; i < gasFeedbackWindow; i++ {
+		msgs = append(msgs, &types.SignedMessage{Message: types.Message{From: sender, To: target, Nonce: uint64(i)}})
+		receipts = append(receipts, types.MessageReceipt{GasUsed: 1200})
+		mp.gasFeedback.recordEstimate(sender, uint64(i), 800)
+	}
+	tma.SetBlockMessages(a, msgs...)
+	b := tma.NextBlock()
+
+	// the receipts of a are missing
+	assert.Error(t, mp.processGasFeedback(ctx, mkTipSet(b)))
+
+	tma.SetReceipts(b.ParentMessageReceipts, receipts)
+	require.NoError(t, tma.ApplyBlock(b))
+
+	assert.Eventually(t, func() bool {
+		return mp.gasLimitOverestimation(types.MessageTypeNative) > GasLimitOverestimation
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.InDelta(t, GasLimitOverestimation+gasFeedbackStep, mp.gasLimitOverestimation(types.MessageTypeNative), 1e-9)
+	// the adjustment is not part of the config, reading and writing it back keeps the configured value
+	assert.Equal(t, GasLimitOverestimation, mp.GetConfig().GasLimitOverestimation)
+	assert.Equal(t, EVMGasOverestimation, mp.gasLimitOverestimation(types.MessageTypeEVM))
+
+	// setting the config starts over from the configured value
+	assert.NoError(t, mp.SetConfig(ctx, mp.GetConfig()))
+	assert.Equal(t, GasLimitOverestimation, mp.gasLimitOverestimation(types.MessageTypeNative))
+}
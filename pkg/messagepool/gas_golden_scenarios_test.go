@@ -0,0 +1,303 @@
+package messagepool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	mrand "math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
+	init2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/init"
+	miner2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/miner"
+	paych2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/paych"
+	power2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/power"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/crypto"
+	_ "github.com/filecoin-project/venus/pkg/crypto/secp"
+	"github.com/filecoin-project/venus/pkg/wallet/key"
+	gengen "github.com/filecoin-project/venus/tools/gengen/util"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// gasGoldenChain is the chain the gas estimation goldens are recorded on, a genesis of v2 actors executed at
+// network version 6 with funded secp accounts, with the actors the scenarios call created on top of it.
+type gasGoldenChain struct {
+	t    *testing.T
+	ctx  context.Context
+	exec *stateExecutor
+	bs   blockstoreutil.Blockstore
+	keys []*key.KeyInfo
+	// accounts are the addresses of keys
+	accounts []address.Address
+}
+
+func newGasGoldenChain(t *testing.T) *gasGoldenChain {
+	ctx := context.Background()
+	bs := blockstoreutil.NewMemory()
+
+	// the keys are imported rather than generated as gengen generates bls keys
+	seed := mrand.New(mrand.NewSource(670))
+	cfg := &gengen.GenesisCfg{Seed: 670, Network: "gasgolden", Time: 123456789}
+	var accounts []address.Address
+	for i := 0; i < 4; i++ {
+		ki, err := key.NewSecpKeyFromSeed(seed)
+		require.NoError(t, err)
+		addr, err := ki.Address()
+		require.NoError(t, err)
+		cfg.ImportKeys = append(cfg.ImportKeys, &ki)
+		cfg.PreallocatedFunds = append(cfg.PreallocatedFunds, "1000000")
+		accounts = append(accounts, addr)
+	}
+	// gengen restricts the proof types of new miners to the ones of the miners of the config
+	proofTypes := miner2.PreCommitSealProofTypesV0
+	info, err := gengen.GenGen(ctx, cfg, bs)
+	miner2.PreCommitSealProofTypesV0 = proofTypes
+	require.NoError(t, err)
+
+	var genesis types.BlockHeader
+	require.NoError(t, cbor.NewCborStore(bs).Get(ctx, info.GenesisCid, &genesis))
+
+	return &gasGoldenChain{
+		t:        t,
+		ctx:      ctx,
+		exec:     &stateExecutor{bs: bs, root: genesis.ParentStateRoot, epoch: 1, nv: network.Version6},
+		bs:       bs,
+		keys:     cfg.ImportKeys,
+		accounts: accounts,
+	}
+}
+
+// apply executes msgs on top of the chain and advances it by an epoch.
+func (c *gasGoldenChain) apply(msgs ...*types.Message) []types.MessageReceipt {
+	receipts, err := c.exec.apply(c.ctx, msgs...)
+	require.NoError(c.t, err)
+	c.exec.epoch++
+	return receipts
+}
+
+// create creates an actor of code through the init actor, returning its robust address.
+func (c *gasGoldenChain) create(from address.Address, code cid.Cid, value abi.TokenAmount, params cbg.CBORMarshaler) address.Address {
+	receipts := c.apply(&types.Message{
+		From:   from,
+		To:     builtin2.InitActorAddr,
+		Method: builtin2.MethodsInit.Exec,
+		Value:  value,
+		Params: mustSerialize(c.t, &init2.ExecParams{CodeCID: code, ConstructorParams: mustSerialize(c.t, params)}),
+	})
+	var ret init2.ExecReturn
+	require.NoError(c.t, ret.UnmarshalCBOR(bytes.NewReader(receipts[0].Return)))
+	return ret.RobustAddress
+}
+
+// blsAccount creates an account of a bls key made of the byte b.
+func (c *gasGoldenChain) blsAccount(b byte) address.Address {
+	addr, err := address.NewBLSAddress(bytes.Repeat([]byte{b}, 48))
+	require.NoError(c.t, err)
+	c.apply(&types.Message{From: c.accounts[0], To: addr, Value: types.FromFil(1)})
+	return addr
+}
+
+func (c *gasGoldenChain) voucher(from int, ch address.Address, nonce uint64, amount abi.TokenAmount) *paych2.SignedVoucher {
+	sv := &paych2.SignedVoucher{ChannelAddr: ch, Lane: 0, Nonce: nonce, Amount: amount}
+	data, err := sv.SigningBytes()
+	require.NoError(c.t, err)
+	sig, err := crypto.Sign(data, c.keys[from].Key(), c.keys[from].Type())
+	require.NoError(c.t, err)
+	sv.Signature = sig
+	return sv
+}
+
+// gasGoldenHistory describes the tipsets below the head of a scenario, parent of the head first.
+type gasGoldenHistory struct {
+	tipsets []GasEstimationTipSet
+	baseFee big.Int
+}
+
+// quietHistory has 20 tipsets of a block each, the blocks only carry a message paying the minimum premium.
+func quietHistory() gasGoldenHistory {
+	h := gasGoldenHistory{baseFee: big.NewInt(100)}
+	for i := 0; i < gasPremiumHistory; i++ {
+		h.tipsets = append(h.tipsets, GasEstimationTipSet{Blocks: 1, Messages: []GasMeta{{Price: big.NewInt(100), Limit: 1e6}}})
+	}
+	return h
+}
+
+// fullHistory has 20 tipsets of 5 blocks whose messages use more than the gas target, paying premiums of 1e5 to 5e5.
+func fullHistory() gasGoldenHistory {
+	h := gasGoldenHistory{baseFee: big.NewInt(1e5)}
+	for i := 0; i < gasPremiumHistory; i++ {
+		ts := GasEstimationTipSet{Blocks: 5}
+		for j := 0; j < 30; j++ {
+			ts.Messages = append(ts.Messages, GasMeta{Price: big.NewInt(int64(1e5 * (1 + (i+j)%5))), Limit: 1e9})
+		}
+		h.tipsets = append(h.tipsets, ts)
+	}
+	return h
+}
+
+func (h gasGoldenHistory) withBaseFee(baseFee int64) gasGoldenHistory {
+	h.baseFee = big.NewInt(baseFee)
+	return h
+}
+
+// provider builds the history on a MockProvider and puts a head on top of it.
+func (h gasGoldenHistory) provider() *MockProvider {
+	provider := NewMockProvider()
+	for i := len(h.tipsets) - 1; i >= 0; i-- {
+		ts := provider.NextTipSet(h.tipsets[i].Blocks)
+		provider.SetBlockMessages(ts.Blocks()[0], gasMetaMessages(h.tipsets[i].Messages)...)
+	}
+	provider.SetBaseFee(h.baseFee)
+	provider.NextTipSet(1)
+	return provider
+}
+
+// record estimates the gas of msg on the current state of c and the history and writes the scenario to dir.
+func (c *gasGoldenChain) record(dir, name, description string, h gasGoldenHistory, msg *types.EstimateMessage) {
+	provider := h.provider()
+	rec := NewGasEstimationRecorder(c.bs, c.exec.root, c.exec.epoch, c.exec.nv, provider)
+	mp, err := New(c.ctx, provider, rec.StateManager(), datastore.NewMapDatastore(), config.NewDefaultConfig().NetworkParams,
+		config.DefaultMessagePoolParam, "mptest", nil)
+	require.NoError(c.t, err)
+	defer mp.Close() // nolint
+
+	s, err := rec.Record(c.ctx, mp, name, description, msg)
+	require.NoError(c.t, err, name)
+	require.NoError(c.t, writeGasEstimationScenario(filepath.Join(dir, name+".json"), s))
+}
+
+// recordGasEstimationScenarios replaces the golden files in dir by estimations recorded on a chain built
+// from scratch.
+func recordGasEstimationScenarios(t *testing.T, dir string) {
+	old, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	for _, path := range old {
+		require.NoError(t, os.Remove(path))
+	}
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+
+	c := newGasGoldenChain(t)
+	alice, bob, carol := c.accounts[0], c.accounts[1], c.accounts[2]
+	native := func(msg *types.Message, spec *types.MessageSendSpec) *types.EstimateMessage {
+		if msg.Value.Nil() {
+			msg.Value = big.Zero()
+		}
+		return &types.EstimateMessage{Msg: msg, Spec: spec, MessageType: types.MessageTypeNative}
+	}
+	send := func(to address.Address) *types.Message {
+		return &types.Message{From: alice, To: to, Value: types.FromFil(1)}
+	}
+	fresh, err := address.NewSecp256k1Address([]byte("gas golden fresh account"))
+	require.NoError(t, err)
+	_, st, err := c.exec.ParentState(c.ctx, nil)
+	require.NoError(t, err)
+	bobID, err := st.LookupID(bob)
+	require.NoError(t, err)
+
+	// sends
+	c.record(dir, "send_quiet_chain", "A send between two existing accounts on a chain of blocks paying the minimum premium.",
+		quietHistory(), native(send(bob), nil))
+	c.record(dir, "send_new_account", "A send creating the account of its receiver, which costs more gas than a send to an existing account.",
+		quietHistory(), native(send(fresh), nil))
+	c.record(dir, "send_id_address", "A send to the ID address of an existing account.",
+		quietHistory(), native(send(bobID), nil))
+	c.record(dir, "send_full_blocks", "A send on a chain of full tipsets of 5 blocks, the premium follows the premiums paid in them.",
+		fullHistory(), native(send(bob), nil))
+	c.record(dir, "send_high_base_fee", "A send with a parent base fee of 1e9 on a quiet chain, the fee cap covers 20 epochs of base fee increases.",
+		quietHistory().withBaseFee(1e9), native(send(bob), nil))
+	preset := send(bob)
+	preset.GasLimit = 1e6
+	c.record(dir, "send_preset_gas_limit", "A send with its gas limit set, which is kept and not estimated so no state is read.",
+		quietHistory(), native(preset, nil))
+	c.record(dir, "send_gas_over_estimation", "A send whose spec sets the gas limit overestimation to 2 and the premium overestimation to 1.5.",
+		fullHistory(), native(send(bob), &types.MessageSendSpec{GasOverEstimation: 2, GasOverPremium: 1.5}))
+	c.record(dir, "send_max_fee", "A send on full blocks whose spec caps the fee at 1e12, lowering the fee cap and the premium.",
+		fullHistory().withBaseFee(1e9), native(send(bob), &types.MessageSendSpec{MaxFee: abi.NewTokenAmount(1e12)}))
+
+	// payment channels, alice pays carol
+	paychParams := &paych2.ConstructorParams{From: alice, To: carol}
+	c.record(dir, "paych_create", "Creating a payment channel through the init actor Exec.",
+		quietHistory(), native(&types.Message{
+			From:   alice,
+			To:     builtin2.InitActorAddr,
+			Method: builtin2.MethodsInit.Exec,
+			Value:  types.FromFil(10),
+			Params: mustSerialize(t, &init2.ExecParams{CodeCID: builtin2.PaymentChannelActorCodeID, ConstructorParams: mustSerialize(t, paychParams)}),
+		}, nil))
+	ch := c.create(alice, builtin2.PaymentChannelActorCodeID, types.FromFil(10), paychParams)
+	update := &paych2.UpdateChannelStateParams{Sv: *c.voucher(0, ch, 1, types.FromFil(1))}
+	c.record(dir, "paych_update_channel_state", "Redeeming a voucher signed by the payer of the channel on full blocks, which verifies the signature.",
+		fullHistory(), native(&types.Message{From: carol, To: ch, Method: builtin2.MethodsPaych.UpdateChannelState, Params: mustSerialize(t, update)}, nil))
+	c.apply(&types.Message{From: carol, To: ch, Method: builtin2.MethodsPaych.UpdateChannelState, Params: mustSerialize(t, update)})
+	c.record(dir, "paych_settle", "Settling the channel after a voucher was redeemed.",
+		quietHistory(), native(&types.Message{From: alice, To: ch, Method: builtin2.MethodsPaych.Settle}, nil))
+	c.apply(&types.Message{From: alice, To: ch, Method: builtin2.MethodsPaych.Settle})
+	c.exec.epoch += paych2.SettleDelay
+	c.record(dir, "paych_collect", "Collecting the settled channel, which deletes the actor so the refund of deleting it is added back to the gas used.",
+		quietHistory(), native(&types.Message{From: carol, To: ch, Method: builtin2.MethodsPaych.Collect}, nil))
+
+	// storage miners, owned by bob with a bls worker
+	worker, newWorker := c.blsAccount(1), c.blsAccount(2)
+	createMiner := &power2.CreateMinerParams{
+		Owner:         bob,
+		Worker:        worker,
+		SealProofType: abi.RegisteredSealProof_StackedDrg32GiBV1,
+		Peer:          []byte("gas golden miner"),
+	}
+	c.record(dir, "miner_create", "Creating a miner through the power actor, which creates the actor and enrolls its cron event.",
+		quietHistory(), native(&types.Message{From: bob, To: builtin2.StoragePowerActorAddr, Method: builtin2.MethodsPower.CreateMiner, Value: types.FromFil(10), Params: mustSerialize(t, createMiner)}, nil))
+	receipts := c.apply(&types.Message{From: bob, To: builtin2.StoragePowerActorAddr, Method: builtin2.MethodsPower.CreateMiner, Value: types.FromFil(10), Params: mustSerialize(t, createMiner)})
+	var created power2.CreateMinerReturn
+	require.NoError(t, created.UnmarshalCBOR(bytes.NewReader(receipts[0].Return)))
+	maddr := created.IDAddress
+
+	c.record(dir, "miner_change_worker", "Requesting a change of the worker to another bls account.",
+		quietHistory(), native(&types.Message{From: bob, To: maddr, Method: builtin2.MethodsMiner.ChangeWorkerAddress,
+			Params: mustSerialize(t, &miner2.ChangeWorkerAddressParams{NewWorker: newWorker})}, nil))
+	c.record(dir, "miner_change_peer_id", "Changing the peer id of the miner, sent by its worker.",
+		quietHistory(), native(&types.Message{From: worker, To: maddr, Method: builtin2.MethodsMiner.ChangePeerID,
+			Params: mustSerialize(t, &miner2.ChangePeerIDParams{NewID: []byte("gas golden miner, new peer")})}, nil))
+	ma, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/34567")
+	require.NoError(t, err)
+	c.record(dir, "miner_change_multiaddrs", "Changing the multiaddrs of the miner on full blocks.",
+		fullHistory(), native(&types.Message{From: worker, To: maddr, Method: builtin2.MethodsMiner.ChangeMultiaddrs,
+			Params: mustSerialize(t, &miner2.ChangeMultiaddrsParams{NewMultiaddrs: []abi.Multiaddrs{ma.Bytes()}})}, nil))
+	c.record(dir, "miner_withdraw_balance", "Withdrawing part of the balance the miner was created with, sent by its owner.",
+		quietHistory(), native(&types.Message{From: bob, To: maddr, Method: builtin2.MethodsMiner.WithdrawBalance,
+			Params: mustSerialize(t, &miner2.WithdrawBalanceParams{AmountRequested: types.FromFil(1)})}, nil))
+
+	// storage market
+	c.record(dir, "market_add_balance", "Adding escrow of alice to the storage market.",
+		quietHistory(), native(&types.Message{From: alice, To: builtin2.StorageMarketActorAddr, Method: builtin2.MethodsMarket.AddBalance,
+			Value: types.FromFil(1), Params: mustSerialize(t, &alice)}, nil))
+
+	// the state predates the EVM, these execute as native sends, what differs is the overestimation of EVM messages
+	evm := func(msg *types.Message) *types.EstimateMessage {
+		return &types.EstimateMessage{Msg: msg, MessageType: types.MessageTypeEVM}
+	}
+	c.record(dir, "evm_value_transfer", "A value transfer estimated as an EVM message, whose gas limit is overestimated by EVMGasOverestimation.",
+		quietHistory(), evm(send(bob)))
+	c.record(dir, "evm_value_transfer_new_account", "A value transfer creating its receiver estimated as an EVM message on full blocks.",
+		fullHistory(), evm(send(fresh)))
+}
+
+func mustSerialize(t *testing.T, v cbg.CBORMarshaler) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, v.MarshalCBOR(&buf), fmt.Sprintf("%T", v))
+	return buf.Bytes()
+}
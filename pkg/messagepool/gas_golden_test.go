@@ -0,0 +1,641 @@
+package messagepool
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/network"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ipld/go-car"
+	carutil "github.com/ipld/go-car/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/fork"
+	"github.com/filecoin-project/venus/pkg/fvm"
+	"github.com/filecoin-project/venus/pkg/state"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/pkg/util/ffiwrapper/impl"
+	"github.com/filecoin-project/venus/pkg/vm"
+	"github.com/filecoin-project/venus/pkg/vm/gas"
+	"github.com/filecoin-project/venus/pkg/vmsupport"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// gasGoldenDir holds the recorded gas estimation scenarios
+const gasGoldenDir = "testdata/gas_estimation"
+
+var updateGasGolden = flag.Bool("update-gas-golden", false, "re-record the gas estimation golden files")
+
+// gasPremiumHistory is the number of tipsets below the head GasEstimateMessageGas reads the gas premiums of
+const gasPremiumHistory = 20
+
+// GasEstimationScenario is a GasEstimateMessageGas call with everything it read from the chain and the state.
+type GasEstimationScenario struct {
+	Name string
+	// Description explains the case
+	Description string `json:",omitempty"`
+	Input       *types.EstimateMessage
+	// NetworkVersion and Epoch are the network version and the epoch the message is executed at
+	NetworkVersion network.Version
+	Epoch          abi.ChainEpoch
+	// StateRoot is the state the message is executed on, State a gzipped car of the blocks of it the estimation read
+	StateRoot cid.Cid
+	State     []byte
+	// ParentBaseFee is the parent base fee of the head
+	ParentBaseFee big.Int
+	// History are the tipsets below the head, parent of the head first
+	History []GasEstimationTipSet
+	Output  GasEstimate
+}
+
+// GasEstimate are the gas fields of a message GasEstimateMessageGas sets.
+type GasEstimate struct {
+	GasLimit   int64
+	GasFeeCap  big.Int
+	GasPremium big.Int
+}
+
+// GasEstimationTipSet is the part of a tipset the gas premium estimation reads.
+type GasEstimationTipSet struct {
+	Height   abi.ChainEpoch
+	Blocks   int
+	Messages []GasMeta
+}
+
+func loadGasEstimationScenario(path string) (*GasEstimationScenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s GasEstimationScenario
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+func writeGasEstimationScenario(path string, s *GasEstimationScenario) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// GasEstimationRecorder records GasEstimateMessageGas calls as scenarios. The message pool estimating has to use
+// the state manager of the recorder, which executes the messages on the state of the recorder.
+type GasEstimationRecorder struct {
+	exec  *stateExecutor
+	reads *readRecorder
+	api   Provider
+}
+
+// NewGasEstimationRecorder creates a recorder executing messages on the state root in bs with the vm of nv, at epoch.
+func NewGasEstimationRecorder(bs blockstoreutil.Blockstore, root cid.Cid, epoch abi.ChainEpoch, nv network.Version, api Provider) *GasEstimationRecorder {
+	reads := &readRecorder{Blockstore: bs}
+	return &GasEstimationRecorder{
+		exec:  &stateExecutor{bs: reads, root: root, epoch: epoch, nv: nv},
+		reads: reads,
+		api:   api,
+	}
+}
+
+func (r *GasEstimationRecorder) StateManager() StateManager {
+	return r.exec
+}
+
+// Record estimates the gas of msg with mp and returns the estimation as a scenario named name. The chain is read
+// after the estimation, a head change meanwhile makes the scenario inconsistent.
+func (r *GasEstimationRecorder) Record(ctx context.Context, mp *MessagePool, name, description string, msg *types.EstimateMessage) (*GasEstimationScenario, error) {
+	r.reads.reset()
+	out, err := mp.GasEstimateMessageGas(ctx, copyEstimateMessage(msg), types.EmptyTSK)
+	if err != nil {
+		return nil, err
+	}
+	stateCar, err := exportBlocks(ctx, r.reads.Blockstore, r.exec.root, r.reads.cids())
+	if err != nil {
+		return nil, fmt.Errorf("exporting the state read: %w", err)
+	}
+
+	head, err := r.api.ChainHead(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting head: %w", err)
+	}
+	history, err := r.history(ctx, head)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GasEstimationScenario{
+		Name:           name,
+		Description:    description,
+		Input:          copyEstimateMessage(msg),
+		NetworkVersion: r.exec.nv,
+		Epoch:          r.exec.epoch,
+		StateRoot:      r.exec.root,
+		State:          stateCar,
+		ParentBaseFee:  head.Blocks()[0].ParentBaseFee,
+		History:        history,
+		Output:         GasEstimate{GasLimit: out.GasLimit, GasFeeCap: out.GasFeeCap, GasPremium: out.GasPremium},
+	}, nil
+}
+
+// history walks the tipsets below head the way the gas premium estimation does.
+func (r *GasEstimationRecorder) history(ctx context.Context, head *types.TipSet) ([]GasEstimationTipSet, error) {
+	var history []GasEstimationTipSet
+	ts := head
+	for i := 0; i < gasPremiumHistory && ts.Height() > 0; i++ {
+		pts, err := r.api.LoadTipSet(ctx, ts.Parents())
+		if err != nil {
+			return nil, fmt.Errorf("loading tipset %s: %w", ts.Parents(), err)
+		}
+		msgs, err := r.api.MessagesForTipset(ctx, pts)
+		if err != nil {
+			return nil, fmt.Errorf("loading messages of tipset %s: %w", pts.Key(), err)
+		}
+
+		entry := GasEstimationTipSet{Height: pts.Height(), Blocks: len(pts.Blocks())}
+		for _, msg := range msgs {
+			entry.Messages = append(entry.Messages, GasMeta{
+				Price: msg.VMMessage().GasPremium,
+				Limit: msg.VMMessage().GasLimit,
+			})
+		}
+		history = append(history, entry)
+		ts = pts
+	}
+	return history, nil
+}
+
+// GasEstimationReplayer re-runs recorded scenarios on their recorded state and chain and checks the estimations
+// still match the recorded ones.
+type GasEstimationReplayer struct {
+	// Tolerance is the relative deviation allowed for the estimated gas limit, fee cap and premium,
+	// e.g. 0.05. The gas premium estimation adds up to about 1% of noise.
+	Tolerance float64
+}
+
+func NewGasEstimationReplayer(tolerance float64) *GasEstimationReplayer {
+	return &GasEstimationReplayer{Tolerance: tolerance}
+}
+
+// Replay estimates the gas of the input of s, executing it on the recorded state.
+func (r *GasEstimationReplayer) Replay(ctx context.Context, s *GasEstimationScenario) (*types.Message, error) {
+	bs := blockstoreutil.NewMemory()
+	if err := importBlocks(ctx, bs, s.State); err != nil {
+		return nil, fmt.Errorf("importing the recorded state: %w", err)
+	}
+	sm := &stateExecutor{bs: bs, root: s.StateRoot, epoch: s.Epoch, nv: s.NetworkVersion}
+
+	provider := NewMockProvider()
+	history := s.History
+	if n := len(history); n > 0 && history[n-1].Height == 0 {
+		// the estimation reached genesis
+		provider.SetBlockMessages(provider.Genesis().Blocks()[0], gasMetaMessages(history[n-1].Messages)...)
+		history = history[:n-1]
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		ts := provider.NextTipSet(history[i].Blocks)
+		provider.SetBlockMessages(ts.Blocks()[0], gasMetaMessages(history[i].Messages)...)
+	}
+	provider.SetBaseFee(s.ParentBaseFee)
+	provider.NextTipSet(1)
+
+	mp, err := New(ctx, provider, sm, datastore.NewMapDatastore(), config.NewDefaultConfig().NetworkParams,
+		config.DefaultMessagePoolParam, "mptest", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer mp.Close() // nolint
+
+	return mp.GasEstimateMessageGas(ctx, copyEstimateMessage(s.Input), types.EmptyTSK)
+}
+
+// Verify replays s and returns an error when the estimation deviates from the recorded output by more than
+// the tolerance.
+func (r *GasEstimationReplayer) Verify(ctx context.Context, s *GasEstimationScenario) error {
+	out, err := r.Replay(ctx, s)
+	if err != nil {
+		return err
+	}
+	fields := []struct {
+		name      string
+		got, want big.Int
+	}{
+		{"gas limit", big.NewInt(out.GasLimit), big.NewInt(s.Output.GasLimit)},
+		{"gas fee cap", out.GasFeeCap, s.Output.GasFeeCap},
+		{"gas premium", out.GasPremium, s.Output.GasPremium},
+	}
+	for _, f := range fields {
+		if !withinTolerance(f.got, f.want, r.Tolerance) {
+			return fmt.Errorf("%s: %s estimated %s, expected %s, tolerance %.2f%%", s.Name, f.name, f.got, f.want, r.Tolerance*100)
+		}
+	}
+	return nil
+}
+
+// VerifyDir verifies every scenario in the golden files of dir in a subtest of its own.
+func (r *GasEstimationReplayer) VerifyDir(t *testing.T, dir string) {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths, "no golden files in %s", dir)
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		s, err := loadGasEstimationScenario(path)
+		require.NoError(t, err)
+		t.Run(s.Name, func(t *testing.T) {
+			assert.NoError(t, r.Verify(context.Background(), s))
+		})
+	}
+}
+
+func TestGasEstimationGolden(t *testing.T) {
+	tf.UnitTest(t)
+
+	if *updateGasGolden {
+		recordGasEstimationScenarios(t, gasGoldenDir)
+	}
+	NewGasEstimationReplayer(0.05).VerifyDir(t, gasGoldenDir)
+}
+
+func TestGasEstimationGoldenDetectsExecutionChanges(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	r := NewGasEstimationReplayer(0.05)
+	load := func() *GasEstimationScenario {
+		s, err := loadGasEstimationScenario(filepath.Join(gasGoldenDir, "send_quiet_chain.json"))
+		require.NoError(t, err)
+		return s
+	}
+	require.NoError(t, r.Verify(ctx, load()))
+
+	// the message is executed on the recorded state, a receiver that is not in it fails the estimation
+	s := load()
+	to, err := address.NewIDAddress(9999)
+	require.NoError(t, err)
+	s.Input.Msg.To = to
+	assert.Error(t, r.Verify(ctx, s))
+
+	// as does a recorded gas used the execution no longer matches
+	s = load()
+	s.Output.GasLimit = s.Output.GasLimit * 11 / 10
+	assert.Error(t, r.Verify(ctx, s))
+
+	// and a state missing the blocks the execution reads
+	s = load()
+	s.State = nil
+	assert.Error(t, r.Verify(ctx, s))
+}
+
+func TestWithinTolerance(t *testing.T) {
+	tf.UnitTest(t)
+
+	assert.True(t, withinTolerance(big.NewInt(105), big.NewInt(100), 0.05))
+	assert.True(t, withinTolerance(big.NewInt(95), big.NewInt(100), 0.05))
+	assert.False(t, withinTolerance(big.NewInt(106), big.NewInt(100), 0.05))
+	assert.False(t, withinTolerance(big.NewInt(94), big.NewInt(100), 0.05))
+	assert.True(t, withinTolerance(big.NewInt(0), big.NewInt(0), 0.05))
+}
+
+func withinTolerance(got, want big.Int, tolerance float64) bool {
+	diff := big.Sub(got, want)
+	if diff.Sign() < 0 {
+		diff = big.Sub(want, got)
+	}
+	// tolerance in parts per million, estimations are never negative
+	limit := big.Div(big.Mul(want, big.NewInt(int64(tolerance*1e6))), big.NewInt(1e6))
+	return diff.LessThanEqual(limit)
+}
+
+// stateExecutor is a StateManager executing messages on a fixed state with the vm of its network version.
+type stateExecutor struct {
+	bs    blockstoreutil.Blockstore
+	root  cid.Cid
+	epoch abi.ChainEpoch
+	nv    network.Version
+}
+
+var _ StateManager = (*stateExecutor)(nil)
+
+func (e *stateExecutor) vmOption(bs blockstoreutil.Blockstore, root cid.Cid, baseFee abi.TokenAmount) vm.VmOption {
+	return vm.VmOption{
+		CircSupplyCalculator: func(context.Context, abi.ChainEpoch, tree.Tree) (abi.TokenAmount, error) {
+			return big.Zero(), nil
+		},
+		LookbackStateGetter: func(context.Context, abi.ChainEpoch) (*state.View, error) {
+			return state.NewView(cbor.NewCborStore(bs), root), nil
+		},
+		NetworkVersion:   e.nv,
+		Rnd:              fixedRand{},
+		BaseFee:          baseFee,
+		MinBaseFee:       big.Zero(),
+		Fork:             fork.NewMockFork(),
+		Epoch:            e.epoch,
+		GasPriceSchedule: gas.NewPricesSchedule(config.DefaultForkUpgradeParam),
+		PRoot:            root,
+		Bsstore:          bs,
+		TipSetGetter: func(context.Context, abi.ChainEpoch) (types.TipSetKey, error) {
+			return types.EmptyTSK, nil
+		},
+		SysCallsImpl: vmsupport.NewSyscalls(&vmsupport.NilFaultChecker{}, &impl.FakeVerifier{}),
+	}
+}
+
+func (e *stateExecutor) GetNetworkVersion(context.Context, abi.ChainEpoch) network.Version {
+	return e.nv
+}
+
+func (e *stateExecutor) ResolveToDeterministicAddress(ctx context.Context, addr address.Address, _ *types.TipSet) (address.Address, error) {
+	st, err := tree.LoadState(ctx, cbor.NewCborStore(e.bs), e.root)
+	if err != nil {
+		return address.Undef, err
+	}
+	return vm.ResolveToDeterministicAddress(ctx, st, addr, cbor.NewCborStore(e.bs))
+}
+
+// CallWithGas applies priorMsgs and msg on the state like Stmgr.CallWithGas, with a zero base fee.
+func (e *stateExecutor) CallWithGas(ctx context.Context, msg *types.Message, priorMsgs []types.ChainMsg, _ *types.TipSet) (*types.InvocResult, error) {
+	bs := blockstoreutil.NewTieredBstore(e.bs, blockstoreutil.NewTemporarySync())
+	opts := e.vmOption(bs, e.root, big.Zero())
+	vmi, err := fvm.NewVM(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i, m := range priorMsgs {
+		if _, err := vmi.ApplyMessage(ctx, m); err != nil {
+			return nil, fmt.Errorf("applying prior message (%d, %s): %w", i, m.Cid(), err)
+		}
+	}
+	root, err := vmi.Flush(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := tree.LoadState(ctx, cbor.NewCborStore(bs), root)
+	if err != nil {
+		return nil, err
+	}
+	from, found, err := st.GetActor(ctx, msg.From)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("call raw get actor %s: %w", msg.From, types.ErrActorNotFound)
+	}
+	fromKey, err := vm.ResolveToDeterministicAddress(ctx, st, msg.From, cbor.NewCborStore(bs))
+	if err != nil {
+		return nil, err
+	}
+	msgCopy := *msg
+	msgCopy.Nonce = from.Nonce
+
+	opts.PRoot = root
+	if vmi, err = fvm.NewVM(ctx, opts); err != nil {
+		return nil, err
+	}
+	ret, err := vmi.ApplyMessage(ctx, withSignatureOf(&msgCopy, fromKey))
+	if err != nil {
+		return nil, err
+	}
+
+	var errs string
+	if ret.ActorErr != nil {
+		errs = ret.ActorErr.Error()
+	}
+	return &types.InvocResult{
+		MsgCid: msgCopy.Cid(),
+		Msg:    &msgCopy,
+		MsgRct: &ret.Receipt,
+		Error:  errs,
+	}, nil
+}
+
+func (e *stateExecutor) ParentState(ctx context.Context, ts *types.TipSet) (*types.TipSet, *tree.State, error) {
+	st, err := tree.LoadState(ctx, cbor.NewCborStore(e.bs), e.root)
+	return ts, st, err
+}
+
+// apply executes msgs with a base fee of 100 and moves the executor to the resulting state, the nonces of the
+// messages are set from the state and every message has to succeed.
+func (e *stateExecutor) apply(ctx context.Context, msgs ...*types.Message) ([]types.MessageReceipt, error) {
+	vmi, err := fvm.NewVM(ctx, e.vmOption(e.bs, e.root, abi.NewTokenAmount(100)))
+	if err != nil {
+		return nil, err
+	}
+	receipts := make([]types.MessageReceipt, 0, len(msgs))
+	for _, msg := range msgs {
+		root, err := vmi.Flush(ctx)
+		if err != nil {
+			return nil, err
+		}
+		st, err := tree.LoadState(ctx, cbor.NewCborStore(e.bs), root)
+		if err != nil {
+			return nil, err
+		}
+		from, found, err := st.GetActor(ctx, msg.From)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("sender %s: %w", msg.From, types.ErrActorNotFound)
+		}
+		m := *msg
+		m.Nonce = from.Nonce
+		m.GasLimit = constants.BlockGasLimit / 10
+		m.GasFeeCap = abi.NewTokenAmount(1000)
+		m.GasPremium = abi.NewTokenAmount(1)
+		if m.Value.Nil() {
+			m.Value = big.Zero()
+		}
+
+		ret, err := vmi.ApplyMessage(ctx, withSignatureOf(&m, msg.From))
+		if err != nil {
+			return nil, err
+		}
+		if ret.Receipt.ExitCode.IsError() {
+			return nil, fmt.Errorf("message %d to %s failed: exit %s, %v", msg.Method, msg.To, ret.Receipt.ExitCode, ret.ActorErr)
+		}
+		receipts = append(receipts, ret.Receipt)
+	}
+	if e.root, err = vmi.Flush(ctx); err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// withSignatureOf wraps msg as the chain message signed by key, with a signature of the right size.
+func withSignatureOf(msg *types.Message, key address.Address) types.ChainMsg {
+	if key.Protocol() != address.SECP256K1 {
+		return msg
+	}
+	return &types.SignedMessage{
+		Message:   *msg,
+		Signature: crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: make([]byte, 65)},
+	}
+}
+
+// fixedRand returns the same randomness for every draw.
+type fixedRand struct{}
+
+func (fixedRand) ChainGetRandomnessFromBeacon(context.Context, crypto.DomainSeparationTag, abi.ChainEpoch, []byte) (abi.Randomness, error) {
+	return []byte("i_am_random_____i_am_random_____"), nil
+}
+
+func (fixedRand) ChainGetRandomnessFromTickets(context.Context, crypto.DomainSeparationTag, abi.ChainEpoch, []byte) (abi.Randomness, error) {
+	return []byte("i_am_random_____i_am_random_____"), nil
+}
+
+// readRecorder records the cids of the blocks read from the blockstore it wraps.
+type readRecorder struct {
+	blockstoreutil.Blockstore
+
+	lk   sync.Mutex
+	read map[cid.Cid]struct{}
+}
+
+func (r *readRecorder) add(c cid.Cid) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	if r.read == nil {
+		r.read = make(map[cid.Cid]struct{})
+	}
+	r.read[c] = struct{}{}
+}
+
+func (r *readRecorder) reset() {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	r.read = nil
+}
+
+func (r *readRecorder) cids() []cid.Cid {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	out := make([]cid.Cid, 0, len(r.read))
+	for c := range r.read {
+		out = append(out, c)
+	}
+	return out
+}
+
+func (r *readRecorder) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	r.add(c)
+	return r.Blockstore.Get(ctx, c)
+}
+
+func (r *readRecorder) View(ctx context.Context, c cid.Cid, cb func([]byte) error) error {
+	r.add(c)
+	return r.Blockstore.View(ctx, c, cb)
+}
+
+func (r *readRecorder) GetSize(ctx context.Context, c cid.Cid) (int, error) {
+	r.add(c)
+	return r.Blockstore.GetSize(ctx, c)
+}
+
+func (r *readRecorder) Has(ctx context.Context, c cid.Cid) (bool, error) {
+	r.add(c)
+	return r.Blockstore.Has(ctx, c)
+}
+
+// exportBlocks writes the blocks cids found in bs to a gzipped car with the given root, in cid order.
+func exportBlocks(ctx context.Context, bs blockstoreutil.Blockstore, root cid.Cid, cids []cid.Cid) ([]byte, error) {
+	sort.Slice(cids, func(i, j int) bool {
+		return cids[i].KeyString() < cids[j].KeyString()
+	})
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := car.WriteHeader(&car.CarHeader{Roots: []cid.Cid{root}, Version: 1}, gz); err != nil {
+		return nil, err
+	}
+	for _, c := range cids {
+		blk, err := bs.Get(ctx, c)
+		if ipld.IsNotFound(err) {
+			// written by the execution
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := carutil.LdWrite(gz, c.Bytes(), blk.RawData()); err != nil {
+			return nil, err
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func importBlocks(ctx context.Context, bs blockstoreutil.Blockstore, data []byte) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	_, err = car.LoadCar(ctx, bs, gz)
+	return err
+}
+
+// gasMetaMessages creates messages with the gas premiums and limits of metas.
+func gasMetaMessages(metas []GasMeta) []*types.SignedMessage {
+	from, err := address.NewIDAddress(1000)
+	if err != nil {
+		panic(err)
+	}
+
+	msgs := make([]*types.SignedMessage, len(metas))
+	for i, meta := range metas {
+		msgs[i] = &types.SignedMessage{
+			Message: types.Message{
+				To:         from,
+				From:       from,
+				Nonce:      uint64(i),
+				Value:      big.Zero(),
+				GasLimit:   meta.Limit,
+				GasFeeCap:  meta.Price,
+				GasPremium: meta.Price,
+			},
+		}
+	}
+	return msgs
+}
+
+func copyEstimateMessage(in *types.EstimateMessage) *types.EstimateMessage {
+	out := *in
+	if in.Msg != nil {
+		msg := *in.Msg
+		out.Msg = &msg
+	}
+	if in.Spec != nil {
+		spec := *in.Spec
+		out.Spec = &spec
+	}
+	return &out
+}
@@ -0,0 +1,373 @@
+package messagepool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/fork"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/pkg/statemanger"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/pkg/vm"
+	"github.com/filecoin-project/venus/pkg/wallet"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestOverestimateGasLimit(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	_, mp := newWalletAndMpool(t, NewMockProvider())
+	defer mp.Close() // nolint
+
+	assert.Equal(t, int64(1250), mp.overestimateGasLimit(1000, types.MessageTypeNative, nil))
+	assert.Equal(t, int64(1100), mp.overestimateGasLimit(1000, types.MessageTypeEVM, nil))
+
+	// the spec takes precedence over the multiplier of the message type
+	spec := &types.MessageSendSpec{GasOverEstimation: 2}
+	assert.Equal(t, int64(2000), mp.overestimateGasLimit(1000, types.MessageTypeNative, spec))
+	assert.Equal(t, int64(2000), mp.overestimateGasLimit(1000, types.MessageTypeEVM, spec))
+
+	cfg := mp.GetConfig()
+	cfg.EVMGasOverestimation = 1.5
+	assert.NoError(t, mp.SetConfig(ctx, cfg))
+	assert.Equal(t, int64(1500), mp.overestimateGasLimit(1000, types.MessageTypeEVM, nil))
+	assert.Equal(t, int64(1250), mp.overestimateGasLimit(1000, types.MessageTypeNative, nil))
+}
+
+func TestSetConfigFillsDefaults(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	_, mp := newWalletAndMpool(t, NewMockProvider())
+	defer mp.Close() // nolint
+
+	// a config built by a client that does not know the newer fields
+	cfg := mp.GetConfig()
+	cfg.EVMGasOverestimation = 0
+	cfg.OverestimationTarget = 0
+	assert.NoError(t, mp.SetConfig(ctx, cfg))
+
+	got := mp.GetConfig()
+	assert.Equal(t, EVMGasOverestimation, got.EVMGasOverestimation)
+	assert.Equal(t, OverestimationTarget, got.OverestimationTarget)
+}
+
+func TestPaychCollectRefund(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	paych := mkAddress(1000)
+	msg := &types.Message{To: paych, Method: builtin.MethodsPaych.Collect}
+
+	lookups := 0
+	getActor := func(ctx context.Context, addr address.Address) (*types.Actor, bool, error) {
+		lookups++
+		return &types.Actor{Code: builtin2.PaymentChannelActorCodeID}, true, nil
+	}
+
+	assert.Equal(t, int64(76e3), paychCollectRefund(ctx, msg, types.MessageTypeNative, getActor))
+	assert.Equal(t, 1, lookups)
+
+	// EVM messages skip the payment channel special case without a lookup
+	assert.Equal(t, int64(0), paychCollectRefund(ctx, msg, types.MessageTypeEVM, getActor))
+	assert.Equal(t, 1, lookups)
+
+	other := &types.Message{To: paych, Method: builtin.MethodsPaych.Settle}
+	assert.Equal(t, int64(0), paychCollectRefund(ctx, other, types.MessageTypeNative, getActor))
+
+	notPaych := func(ctx context.Context, addr address.Address) (*types.Actor, bool, error) {
+		return &types.Actor{Code: builtin2.AccountActorCodeID}, true, nil
+	}
+	assert.Equal(t, int64(0), paychCollectRefund(ctx, msg, types.MessageTypeNative, notPaych))
+}
+
+// newMockVMMpool returns a message pool served by tma that estimates with a mock vm, and an account of the wallet
+// that is in the state the vm flushes, the calls read the nonce of the sender from there
+func newMockVMMpool(t *testing.T, tma *MockProvider) (*MessagePool, *vm.MockFVM, *chain.Builder, *wallet.Wallet, address.Address) {
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	stmgr := statemanger.NewStateManger(builder.Store(), builder.MessageStore(), builder.FakeStateEvaluator(), nil, fork.NewMockFork(), nil, nil, false)
+	mp, err := New(ctx, tma, stmgr, datastore.NewMapDatastore(), config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
+	require.NoError(t, err)
+
+	w := newWallet(t)
+	sender, err := w.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	st, err := tree.NewStateWithBuiltinActor(t, builder.Cstore(), tree.StateTreeVersion1)
+	require.NoError(t, err)
+	tree.AddAccount(t, st, builder.Cstore(), sender)
+	root, err := st.Flush(ctx)
+	require.NoError(t, err)
+
+	mockVM := vm.NewMockFVM(root)
+	mockVM.SetDefaultResult(&vm.Ret{Receipt: types.MessageReceipt{ExitCode: exitcode.Ok, GasUsed: 1000}})
+	stmgr.SetVMConstructor(func(ctx context.Context, opts vm.VmOption) (vm.Interface, error) {
+		return mockVM, nil
+	})
+
+	return mp, mockVM, builder, w, sender
+}
+
+func TestEvalMessageGasLimit(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	mp, mockVM, builder, _, from := newMockVMMpool(t, NewMockProvider())
+	defer mp.Close() // nolint
+
+	msg := &types.Message{From: from, To: mkAddress(1001), Value: big.NewInt(1)}
+	prior := &types.Message{From: from, To: mkAddress(1002), Value: big.NewInt(1)}
+	gasLimit, err := mp.evalMessageGasLimit(ctx, msg, types.MessageTypeNative, []types.ChainMsg{prior}, builder.Genesis())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), gasLimit)
+
+	applied := mockVM.Applied()
+	require.Len(t, applied, 2)
+	assert.Equal(t, prior.Cid(), applied[0].Cid())
+	assert.Equal(t, msg.To, applied[1].VMMessage().To)
+
+	// failed executions are not estimated
+	mockVM.SetApplyResult(prior.Cid(), &vm.Ret{Receipt: types.MessageReceipt{ExitCode: exitcode.Ok, GasUsed: 500}})
+	mockVM.SetDefaultResult(&vm.Ret{Receipt: types.MessageReceipt{ExitCode: exitcode.ErrInsufficientFunds, GasUsed: 1000}})
+	_, err = mp.evalMessageGasLimit(ctx, msg, types.MessageTypeNative, []types.ChainMsg{prior}, builder.Genesis())
+	assert.ErrorContains(t, err, exitcode.ErrInsufficientFunds.String())
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+	mockVM.SetDefaultResult(&vm.Ret{Receipt: types.MessageReceipt{ExitCode: exitcode.ErrUnhandledMessage}})
+	_, err = mp.evalMessageGasLimit(ctx, msg, types.MessageTypeNative, []types.ChainMsg{prior}, builder.Genesis())
+	assert.ErrorIs(t, err, ErrMethodNotFound)
+
+	mockVM.SetDefaultResult(&vm.Ret{Receipt: types.MessageReceipt{ExitCode: exitcode.ErrIllegalState}})
+	_, err = mp.evalMessageGasLimit(ctx, msg, types.MessageTypeNative, []types.ChainMsg{prior}, builder.Genesis())
+	assert.ErrorIs(t, err, ErrExecutionFailed)
+
+	// the sender is missing from the state
+	unknown, err := newWallet(t).NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	_, err = mp.evalMessageGasLimit(ctx, &types.Message{From: unknown, To: mkAddress(1001), Value: big.NewInt(1)}, types.MessageTypeNative, nil, builder.Genesis())
+	assert.ErrorIs(t, err, ErrActorNotFound)
+}
+
+func TestGasEstimateGasLimit(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	tma := NewMockProvider()
+	mp, mockVM, builder, w, from := newMockVMMpool(t, tma)
+	defer mp.Close() // nolint
+
+	// the estimation runs after the pending messages of the sender with a lower nonce
+	tma.SetBalance(from, types.FromFil(100))
+	pending := makeTestMessage(w, from, mkAddress(1002), 0, 1_000_000, 100)
+	mustAdd(t, mp, pending)
+	mp.curTSLk.Lock()
+	mp.curTS = builder.Genesis()
+	mp.curTSLk.Unlock()
+	mockVM.SetApplyResult(pending.Cid(), &vm.Ret{Receipt: types.MessageReceipt{ExitCode: exitcode.Ok, GasUsed: 500}})
+
+	msg := &types.Message{From: from, To: mkAddress(1001), Value: big.NewInt(1), Nonce: 1}
+	gasLimit, err := mp.GasEstimateGasLimit(ctx, msg, types.EmptyTSK)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), gasLimit)
+
+	applied := mockVM.Applied()
+	require.Len(t, applied, 2)
+	assert.Equal(t, pending.Cid(), applied[0].Cid())
+	assert.Equal(t, msg.To, applied[1].VMMessage().To)
+
+	// a message replacing the pending one is estimated without it
+	msg.Nonce = 0
+	_, err = mp.GasEstimateGasLimit(ctx, msg, types.EmptyTSK)
+	require.NoError(t, err)
+	applied = mockVM.Applied()
+	require.Len(t, applied, 3)
+	assert.Equal(t, msg.To, applied[2].VMMessage().To)
+}
+
+func TestGasBatchEstimateMessageGasCancelled(t *testing.T) {
+	tf.UnitTest(t)
+
+	builder := chain.NewBuilder(t, address.Undef)
+	stmgr := statemanger.NewStateManger(builder.Store(), builder.MessageStore(), builder.FakeStateEvaluator(), nil, fork.NewMockFork(), nil, nil, false)
+	mp, err := New(context.Background(), NewMockProvider(), stmgr, datastore.NewMapDatastore(), config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
+	require.NoError(t, err)
+	defer mp.Close() // nolint
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	from, err := newWallet(t).NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	// the first message needs no estimation, the second one is aborted
+	estimated := &types.Message{From: from, To: mkAddress(1001), Value: big.NewInt(1), GasLimit: 1000, GasFeeCap: big.NewInt(100), GasPremium: big.NewInt(10)}
+	pending := &types.Message{From: from, To: mkAddress(1001), Value: big.NewInt(1)}
+	results, err := mp.GasBatchEstimateMessageGas(ctx, []*types.EstimateMessage{{Msg: estimated}, {Msg: pending}}, 0, types.EmptyTSK)
+	assert.ErrorIs(t, err, context.Canceled)
+	require.Len(t, results, 1)
+	assert.Equal(t, int64(1000), results[0].Msg.GasLimit)
+	assert.Empty(t, results[0].Err)
+}
+
+func TestPremiumHistoryEpochs(t *testing.T) {
+	tf.UnitTest(t)
+
+	assert.Equal(t, uint64(2), premiumHistoryEpochs(1, 0))
+	assert.Equal(t, uint64(20), premiumHistoryEpochs(10, 0))
+	// a long history still targets the inclusion in the next block
+	assert.Equal(t, uint64(100), premiumHistoryEpochs(1, 100))
+	assert.Equal(t, uint64(3), premiumHistoryEpochs(10, 3))
+	assert.Equal(t, uint64(MaxPremiumHistoryEpochs), premiumHistoryEpochs(1, 1e9))
+	assert.Equal(t, uint64(MaxPremiumHistoryEpochs), premiumHistoryEpochs(1e6, 0))
+}
+
+// loadCountingProvider counts the tipsets loaded through it.
+type loadCountingProvider struct {
+	*MockProvider
+	loads int
+}
+
+func (p *loadCountingProvider) LoadTipSet(ctx context.Context, tsk types.TipSetKey) (*types.TipSet, error) {
+	p.loads++
+	return p.MockProvider.LoadTipSet(ctx, tsk)
+}
+
+func TestGasEstimateGasPremiumWithHistory(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	// a tipset whose messages pay a premium of 1e7 for more than the gas target of the history
+	expensive := make([]GasMeta, 200)
+	for i := range expensive {
+		expensive[i] = GasMeta{Price: big.NewInt(1e7), Limit: constants.BlockGasTarget}
+	}
+	newProvider := func(quiet int) *loadCountingProvider {
+		provider := NewMockProvider()
+		provider.SetBlockMessages(provider.NextBlock(), gasMetaMessages(expensive)...)
+		for i := 0; i < quiet; i++ {
+			provider.NextBlock()
+		}
+		provider.NextBlock()
+		return &loadCountingProvider{MockProvider: provider}
+	}
+	estimate := func(provider *loadCountingProvider, nblocksincl, historyBlocks uint64) big.Int {
+		_, mp := newWalletAndMpool(t, provider.MockProvider)
+		defer mp.Close() // nolint
+		mp.api = provider
+		provider.loads = 0
+
+		premium, err := mp.GasEstimateGasPremiumWithHistory(ctx, nblocksincl, historyBlocks, mkAddress(1000), 1e6, types.EmptyTSK, NewGasPriceCache())
+		require.NoError(t, err)
+		return premium
+	}
+	// the estimate adds up to about 1% of noise
+	assertPremium := func(want int64, got big.Int) {
+		assert.True(t, withinTolerance(got, big.NewInt(want), 0.05), "premium %s, expected about %d", got, want)
+	}
+
+	// the expensive tipset is within the history, the walk stops at genesis
+	provider := newProvider(10)
+	assertPremium(1e7, estimate(provider, 10, 0))
+	assert.Equal(t, 12, provider.loads)
+
+	provider = newProvider(MaxPremiumHistoryEpochs)
+	assertPremium(MinGasPremium, estimate(provider, 10, 0))
+	assert.Equal(t, 20, provider.loads)
+	assertPremium(MinGasPremium, estimate(provider, 10, MaxPremiumHistoryEpochs))
+	assert.Equal(t, MaxPremiumHistoryEpochs, provider.loads)
+	// a longer history is capped short of the expensive tipset
+	assertPremium(MinGasPremium, estimate(provider, 10, 1e9))
+	assert.Equal(t, MaxPremiumHistoryEpochs, provider.loads)
+	assertPremium(MinGasPremium, estimate(provider, 1e6, 0))
+	assert.Equal(t, MaxPremiumHistoryEpochs, provider.loads)
+}
+
+func TestGasEstimateFeeCapOffline(t *testing.T) {
+	tf.UnitTest(t)
+
+	baseFee := abi.NewTokenAmount(25600)
+	assert.Equal(t, baseFee, GasEstimateFeeCapOffline(baseFee, 0, types.EmptyInt))
+	// 1.125^10 * 256 rounds down to 831
+	assert.Equal(t, abi.NewTokenAmount(83100), GasEstimateFeeCapOffline(baseFee, 10, types.EmptyInt))
+	assert.Equal(t, abi.NewTokenAmount(83200), GasEstimateFeeCapOffline(baseFee, 10, abi.NewTokenAmount(100)))
+}
+
+func TestSubCallGasBreakdown(t *testing.T) {
+	tf.UnitTest(t)
+
+	call := func(to uint64, gas int64, subcalls ...types.ExecutionTrace) types.ExecutionTrace {
+		return types.ExecutionTrace{
+			Msg:        types.MessageTrace{To: mkAddress(to), Method: 2},
+			GasCharges: []*types.GasTrace{{TotalGas: gas}},
+			Subcalls:   subcalls,
+		}
+	}
+	trace := call(1000, 100,
+		call(1001, 10, call(1003, 50)),
+		call(1002, 40),
+	)
+
+	breakdown := subCallGasBreakdown(trace)
+	require.Len(t, breakdown, 3)
+	assert.Equal(t, mkAddress(1001), breakdown[0].To)
+	assert.Equal(t, int64(60), breakdown[0].GasUsed)
+	assert.Equal(t, int64(10), breakdown[0].CallGas)
+	assert.Equal(t, 1, breakdown[0].Depth)
+	assert.Equal(t, mkAddress(1003), breakdown[1].To)
+	assert.Equal(t, 2, breakdown[1].Depth)
+	assert.Equal(t, mkAddress(1002), breakdown[2].To)
+
+	assert.Empty(t, subCallGasBreakdown(call(1000, 100)))
+}
+
+func TestCallBeforeMigration(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	tma := NewMockProvider()
+	sm := NewMockStateManager()
+	mp, err := New(ctx, tma, sm, datastore.NewMapDatastore(), config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
+	require.NoError(t, err)
+	defer mp.Close() // nolint
+
+	genesis := tma.Genesis()
+	parent := tma.NextTipSet(1)
+	head := tma.NextTipSet(1)
+	msg := &types.Message{From: mkAddress(1001), To: mkAddress(1002), Value: big.NewInt(1)}
+	sm.SetKeyAddress(msg.From, msg.From)
+	mp.curTSLk.Lock()
+	mp.curTS = head
+	mp.curTSLk.Unlock()
+
+	// the call walks back to the parent of a tipset running a migration
+	sm.SetExpensiveFork(head.Height())
+	gasUsed, err := mp.evalMessageGasLimit(ctx, msg, types.MessageTypeNative, nil, head)
+	require.NoError(t, err)
+	assert.Equal(t, int64(MockDefaultGasUsed), gasUsed)
+
+	res, _, ts, err := mp.GasEstimateCallWithGas(ctx, msg, head)
+	require.NoError(t, err)
+	assert.Equal(t, parent, ts)
+	assert.Equal(t, exitcode.Ok, res.MsgRct.ExitCode)
+
+	// there is no tipset before the migration to call on
+	sm.SetExpensiveFork(parent.Height())
+	sm.SetExpensiveFork(genesis.Height())
+	_, err = mp.evalMessageGasLimit(ctx, msg, types.MessageTypeNative, nil, head)
+	assert.ErrorIs(t, err, ErrMigrationInProgress)
+	_, _, _, err = mp.GasEstimateCallWithGas(ctx, msg, head)
+	assert.ErrorIs(t, err, ErrMigrationInProgress)
+}
@@ -30,13 +30,13 @@ import (
 	lps "github.com/filecoin-project/pubsub"
 
 	"github.com/filecoin-project/venus/pkg/chain"
+	vclock "github.com/filecoin-project/venus/pkg/clock"
 	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/consensus"
 	"github.com/filecoin-project/venus/pkg/constants"
 	crypto2 "github.com/filecoin-project/venus/pkg/crypto"
 	"github.com/filecoin-project/venus/pkg/messagepool/journal"
 	"github.com/filecoin-project/venus/pkg/repo"
-	"github.com/filecoin-project/venus/pkg/statemanger"
 	"github.com/filecoin-project/venus/pkg/vm/gas"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
@@ -93,6 +93,7 @@ var (
 	ErrRBFTooLowPremium       = errors.New("replace by fee has too low GasPremium")
 	ErrTooManyPendingMessages = errors.New("too many pending messages for actor")
 	ErrNonceGap               = errors.New("unfulfilled nonce gap")
+	ErrSyncLag                = errors.New("node is behind the network head")
 )
 
 const (
@@ -124,7 +125,7 @@ type MessagePoolEvtMessage struct { // nolint
 type MessagePool struct {
 	lk sync.Mutex
 
-	sm *statemanger.Stmgr
+	sm StateManager
 	ds repo.Datastore
 
 	addSema chan struct{}
@@ -180,6 +181,25 @@ type MessagePool struct {
 
 	GetMaxFee  DefaultMaxFeeFunc
 	PriceCache *GasPriceCache
+
+	gasFeedback   *gasFeedback
+	gasFeedbackCh chan *types.TipSet
+
+	validatorsLk sync.RWMutex
+	validators   []MessageValidator
+
+	// chainClock gives the network head for the SyncLagLimit check, nil disables the check
+	chainClock   vclock.ChainEpochClock
+	syncLagLimit abi.ChainEpoch
+
+	rebroadcastMinDelay abi.ChainEpoch
+	// localPendingSince records the epoch local messages were added at, guarded by lk
+	localPendingSince map[cid.Cid]abi.ChainEpoch
+
+	// bestGasLimit caches the result of BestGasLimit for the head bestGasLimitTS
+	bestGasLimitLk sync.Mutex
+	bestGasLimitTS types.TipSetKey
+	bestGasLimit   int64
 }
 
 func newDefaultMaxFeeFunc(maxFee types.FIL) DefaultMaxFeeFunc {
@@ -214,6 +234,8 @@ func ComputeRBF(curPrem abi.TokenAmount, replaceByFeeRatio types.Percent) abi.To
 	return types.BigAdd(minPrice, types.NewInt(1))
 }
 
+// CapGasFee lowers the fee cap and the premium of msg so its fee is at most the MaxFee of sendSepc, or the
+// fee returned by mff when it is not set.
 func CapGasFee(mff DefaultMaxFeeFunc, msg *types.Message, sendSepc *types.MessageSendSpec) {
 	var maxFee abi.TokenAmount
 	if sendSepc != nil {
@@ -381,7 +403,7 @@ func (ms *msgSet) toSlice() []*types.SignedMessage {
 
 func New(ctx context.Context,
 	api Provider,
-	sm *statemanger.Stmgr,
+	sm StateManager,
 	ds repo.Datastore,
 	networkParams *config.NetworkParamsConfig,
 	mpoolCfg *config.MessagePoolConfig,
@@ -432,6 +454,12 @@ func New(ctx context.Context,
 		gasPriceSchedule: gas.NewPricesSchedule(networkParams.ForkUpgradeParam),
 		GetMaxFee:        newDefaultMaxFeeFunc(mpoolCfg.MaxFee),
 		PriceCache:       NewGasPriceCache(),
+		gasFeedback:      newGasFeedback(),
+		gasFeedbackCh:    make(chan *types.TipSet, 16),
+		syncLagLimit:     mpoolCfg.SyncLagLimit,
+
+		rebroadcastMinDelay: mpoolCfg.RebroadcastMinDelay,
+		localPendingSince:   make(map[cid.Cid]abi.ChainEpoch),
 	}
 
 	// enable initial prunes
@@ -464,6 +492,7 @@ func New(ctx context.Context,
 
 		log.Info("mpool ready")
 
+		go mp.runGasFeedback(ctx)
 		mp.runLoop(ctx)
 	}()
 
@@ -525,6 +554,9 @@ func (mp *MessagePool) deletePendingMset(ctx context.Context, addr address.Addre
 		return err
 	}
 
+	if ms, ok := mp.pending[ra]; ok {
+		mp.currentSize -= len(ms.msgs)
+	}
 	delete(mp.pending, ra)
 
 	return nil
@@ -533,6 +565,7 @@ func (mp *MessagePool) deletePendingMset(ctx context.Context, addr address.Addre
 // This method isn't strictly necessary, since it doesn't resolve any addresses, but it's safer to have
 func (mp *MessagePool) clearPending() {
 	mp.pending = make(map[address.Address]*msgSet)
+	mp.currentSize = 0
 }
 
 func (mp *MessagePool) isLocal(ctx context.Context, addr address.Address) (bool, error) {
@@ -568,7 +601,8 @@ func (mp *MessagePool) DeleteByAdress(address address.Address) error {
 	mp.lk.Lock()
 	defer mp.lk.Unlock()
 
-	if mp.pending != nil {
+	if ms, ok := mp.pending[address]; ok {
+		mp.currentSize -= len(ms.msgs)
 		delete(mp.pending, address)
 	}
 	return nil
@@ -780,6 +814,10 @@ func (mp *MessagePool) Push(ctx context.Context, m *types.SignedMessage) (cid.Ci
 }
 
 func (mp *MessagePool) checkMessage(ctx context.Context, m *types.SignedMessage) error {
+	if err := mp.runValidators(ctx, m); err != nil {
+		return err
+	}
+
 	// big messages are bad, anti DOS
 	if m.ChainLength() > MaxMessageSize {
 		return fmt.Errorf("mpool message too large (%dB): %w", m.ChainLength(), ErrMessageTooBig)
@@ -825,10 +863,40 @@ func (mp *MessagePool) Add(ctx context.Context, m *types.SignedMessage) error {
 	mp.curTSLk.Lock()
 	defer mp.curTSLk.Unlock()
 
+	if err := mp.checkSyncLag(mp.curTS); err != nil {
+		return err
+	}
+
 	_, err = mp.addTS(ctx, m, mp.curTS, false, false)
 	return err
 }
 
+// SetChainClock sets the clock the age of the chain head is measured with, messages from the network are
+// rejected with ErrSyncLag while the head was mined more than SyncLagLimit epochs ago.
+func (mp *MessagePool) SetChainClock(clk vclock.ChainEpochClock) {
+	mp.curTSLk.Lock()
+	defer mp.curTSLk.Unlock()
+
+	mp.chainClock = clk
+}
+
+// checkSyncLag must be called with curTSLk held
+func (mp *MessagePool) checkSyncLag(head *types.TipSet) error {
+	if mp.chainClock == nil || mp.syncLagLimit <= 0 || head == nil {
+		return nil
+	}
+
+	// the lag is the time elapsed since the head was mined, the network head is not known
+	headTime := time.Unix(int64(head.MinTimestamp()), 0)
+	elapsed := mp.chainClock.Now().Sub(headTime)
+	if lag := abi.ChainEpoch(elapsed / mp.chainClock.EpochDuration()); lag > mp.syncLagLimit {
+		return fmt.Errorf("%w: head %d was mined %s ago, %d epochs behind the network, the limit is %d",
+			ErrSyncLag, head.Height(), elapsed.Truncate(time.Second), lag, mp.syncLagLimit)
+	}
+
+	return nil
+}
+
 func sigCacheKey(m *types.SignedMessage) (string, error) {
 	switch m.Signature.Type {
 	case crypto.SigTypeBLS:
@@ -945,6 +1013,7 @@ func (mp *MessagePool) addTS(ctx context.Context, m *types.SignedMessage, curTS
 		if err != nil {
 			return false, fmt.Errorf("error persisting local message: %v", err)
 		}
+		mp.localPendingSince[m.Cid()] = curTS.Height()
 	}
 
 	return publish, nil
@@ -1068,6 +1137,38 @@ func (mp *MessagePool) GetNonce(ctx context.Context, addr address.Address, _ typ
 	return mp.getNonceLocked(ctx, addr, mp.curTS)
 }
 
+// GetPendingNonce returns the nonce the next message of addr should use, that is
+// max(state nonce, highest pending nonce + 1). Unlike GetNonce, nonce gaps in the
+// pending messages are skipped over.
+func (mp *MessagePool) GetPendingNonce(ctx context.Context, addr address.Address) (uint64, error) {
+	mp.curTSLk.Lock()
+	defer mp.curTSLk.Unlock()
+
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	nonce, err := mp.getStateNonce(ctx, addr, mp.curTS)
+	if err != nil {
+		return 0, err
+	}
+
+	mset, ok, err := mp.getPendingMset(ctx, addr)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending messages for %s: %w", addr, err)
+	}
+	if !ok {
+		return nonce, nil
+	}
+
+	for n := range mset.msgs {
+		if n+1 > nonce {
+			nonce = n + 1
+		}
+	}
+
+	return nonce, nil
+}
+
 // GetActor should not be used. It is only here to satisfy interface mess caused by lite node handling
 func (mp *MessagePool) GetActor(ctx context.Context, addr address.Address, _ types.TipSetKey) (*types.Actor, error) {
 	mp.curTSLk.Lock()
@@ -1215,6 +1316,14 @@ func (mp *MessagePool) Pending(ctx context.Context) ([]*types.SignedMessage, *ty
 	return mp.allPending(ctx)
 }
 
+// PendingCount returns the number of pending messages without copying them like Pending does.
+func (mp *MessagePool) PendingCount() int {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	return mp.currentSize
+}
+
 func (mp *MessagePool) allPending(ctx context.Context) ([]*types.SignedMessage, *types.TipSet) {
 	out := make([]*types.SignedMessage, 0)
 	mp.forEachPending(func(a address.Address, mset *msgSet) {
@@ -1247,10 +1356,78 @@ func (mp *MessagePool) pendingFor(ctx context.Context, a address.Address) []*typ
 	return mset.toSlice()
 }
 
+// ReplaceMessage returns a copy of the pending message of from with the given nonce whose GasPremium
+// and GasFeeCap are bumped by the configured ReplaceByFeeRatio. The gas limit of the pending message
+// is kept unless it has none. The fees are capped according to spec, ErrRBFTooLowPremium is returned
+// when the capped GasPremium can no longer replace the pending message.
+func (mp *MessagePool) ReplaceMessage(ctx context.Context, from address.Address, nonce uint64, spec *types.MessageSendSpec) (*types.Message, error) {
+	mp.curTSLk.Lock()
+	mp.lk.Lock()
+	mset, ok, err := mp.getPendingMset(ctx, from)
+	var found *types.SignedMessage
+	if err == nil && ok {
+		found = mset.msgs[nonce]
+	}
+	mp.lk.Unlock()
+	mp.curTSLk.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("getting pending messages of %s: %w", from, err)
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no pending message found from %s with nonce %d", from, nonce)
+	}
+
+	rbfRatio := mp.GetConfig().ReplaceByFeeRatio
+	msg := found.Message
+	minPremium := ComputeRBF(msg.GasPremium, rbfRatio)
+	msg.GasPremium = minPremium
+	msg.GasFeeCap = big.Max(ComputeRBF(msg.GasFeeCap, rbfRatio), msg.GasPremium)
+
+	if msg.GasLimit == 0 {
+		gasLimit, err := mp.GasEstimateGasLimit(ctx, &msg, types.EmptyTSK)
+		if err != nil {
+			return nil, fmt.Errorf("estimating gas used: %w", err)
+		}
+		msg.GasLimit = mp.overestimateGasLimit(gasLimit, types.MessageTypeNative, spec)
+	}
+
+	CapGasFee(mp.maxFeeFunc(ctx, &msg), &msg, spec)
+	if msg.GasPremium.LessThan(minPremium) {
+		return nil, fmt.Errorf("%w: %s is less than the required %s, try to increase the max fee", ErrRBFTooLowPremium, msg.GasPremium, minPremium)
+	}
+
+	return &msg, nil
+}
+
+// maxFeeFunc returns the max fee of msg for CapGasFee, the one of MethodMaxFees for the method msg calls or
+// else the MaxFee of the node. The actor msg is sent to is looked up at the current head, curTSLk must not be held.
+func (mp *MessagePool) maxFeeFunc(ctx context.Context, msg *types.Message) DefaultMaxFeeFunc {
+	return func() (abi.TokenAmount, error) {
+		mp.cfgLk.Lock()
+		fees := mp.cfg.MethodMaxFees
+		mp.cfgLk.Unlock()
+		if len(fees) == 0 {
+			return mp.GetMaxFee()
+		}
+
+		act, err := mp.GetActor(ctx, msg.To, types.EmptyTSK)
+		if err != nil {
+			// e.g. the first message to an address creates its account
+			log.Debugf("looking up the actor of %s for its method max fees: %v", msg.To, err)
+			return mp.GetMaxFee()
+		}
+		if fee, ok := fees[MethodMaxFeeKey(act.Code, msg.Method)]; ok {
+			return fee, nil
+		}
+		return mp.GetMaxFee()
+	}
+}
+
 func (mp *MessagePool) HeadChange(ctx context.Context, revert []*types.TipSet, apply []*types.TipSet) error {
 	mp.curTSLk.Lock()
 	defer mp.curTSLk.Unlock()
 
+	prevTS := mp.curTS
 	repubTrigger := false
 	rmsgs := make(map[address.Address]map[uint64]*types.SignedMessage)
 	add := func(m *types.SignedMessage) {
@@ -1343,6 +1520,8 @@ func (mp *MessagePool) HeadChange(ctx context.Context, revert []*types.TipSet, a
 		}
 	}
 
+	mp.notifyGasFeedback(apply)
+
 	for _, s := range rmsgs {
 		for _, msg := range s {
 			if err := mp.addSkipChecks(ctx, msg); err != nil {
@@ -1351,6 +1530,14 @@ func (mp *MessagePool) HeadChange(ctx context.Context, revert []*types.TipSet, a
 		}
 	}
 
+	// the pending messages are checked against the version of the next block
+	if prevTS != nil {
+		epoch := mp.curTS.Height() + 1
+		if nv := mp.api.StateNetworkVersion(ctx, epoch); nv != mp.api.StateNetworkVersion(ctx, prevTS.Height()+1) {
+			mp.OnNetworkUpgrade(ctx, nv, epoch)
+		}
+	}
+
 	if len(revert) > 0 && futureDebug {
 		mp.lk.Lock()
 		msgs, ts := mp.allPending(ctx)
@@ -1641,6 +1828,116 @@ func (mp *MessagePool) Clear(ctx context.Context, local bool) {
 	})
 }
 
+// ClearRange removes the pending messages of addr whose nonce is in [fromNonce, toNonce] and returns
+// the number of removed messages. The messages of a local sender are only removed when local is true,
+// in which case they are deleted from the local message store as well.
+// The next nonce of addr drops back to the first removed nonce, messages above the range are kept.
+func (mp *MessagePool) ClearRange(ctx context.Context, addr address.Address, fromNonce, toNonce uint64, local bool) (int, error) {
+	if fromNonce > toNonce {
+		return 0, fmt.Errorf("invalid nonce range [%d, %d]", fromNonce, toNonce)
+	}
+
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	isLocal, err := mp.isLocal(ctx, addr)
+	if err != nil {
+		return 0, fmt.Errorf("determining isLocal: %w", err)
+	}
+	if isLocal && !local {
+		return 0, nil
+	}
+
+	mset, ok, err := mp.getPendingMset(ctx, addr)
+	if err != nil {
+		return 0, fmt.Errorf("getting pending mset: %w", err)
+	}
+	if !ok {
+		return 0, nil
+	}
+
+	var nonces []uint64
+	for nonce := range mset.msgs {
+		if nonce >= fromNonce && nonce <= toNonce {
+			nonces = append(nonces, nonce)
+		}
+	}
+	sort.Slice(nonces, func(i, j int) bool {
+		return nonces[i] < nonces[j]
+	})
+
+	for _, nonce := range nonces {
+		m := mset.msgs[nonce]
+		if isLocal {
+			if err := mp.localMsgs.Delete(ctx, datastore.NewKey(string(m.Cid().Bytes()))); err != nil {
+				log.Warnf("error deleting local message: %s", err)
+			}
+		}
+		log.Infow("clear message from mpool", "cid", m.Cid(), "from", m.Message.From, "nonce", nonce)
+		delete(mp.republished, m.Cid())
+		mp.remove(ctx, addr, nonce, false)
+	}
+
+	return len(nonces), nil
+}
+
+// ClearStale removes the pending messages whose nonce is below the nonce of their sender in the state after ts,
+// which can't be included on a chain containing ts anymore, and returns the number of removed messages.
+// Messages still needed on top of that state are kept. The messages of local senders are only removed when
+// local is true, in which case they are deleted from the local message store as well.
+func (mp *MessagePool) ClearStale(ctx context.Context, local bool, ts *types.TipSet) (int, error) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	type stale struct {
+		from    address.Address
+		msg     *types.SignedMessage
+		isLocal bool
+	}
+	var toRemove []stale
+	var err error
+	mp.forEachPending(func(a address.Address, ms *msgSet) {
+		if err != nil {
+			return
+		}
+		isLocal, lerr := mp.isLocal(ctx, a)
+		if lerr != nil {
+			err = fmt.Errorf("determining isLocal: %w", lerr)
+			return
+		}
+		if isLocal && !local {
+			return
+		}
+
+		act, aerr := mp.api.GetActorAfter(ctx, a, ts)
+		if aerr != nil {
+			err = fmt.Errorf("getting actor %s at %d: %w", a, ts.Height(), aerr)
+			return
+		}
+		for nonce, m := range ms.msgs {
+			if nonce < act.Nonce {
+				toRemove = append(toRemove, stale{from: a, msg: m, isLocal: isLocal})
+			}
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, r := range toRemove {
+		if r.isLocal {
+			if err := mp.localMsgs.Delete(ctx, datastore.NewKey(string(r.msg.Cid().Bytes()))); err != nil {
+				log.Warnf("error deleting local message: %s", err)
+			}
+		}
+		log.Infow("clear stale message from mpool", "cid", r.msg.Cid(), "from", r.from, "nonce", r.msg.Message.Nonce)
+		delete(mp.republished, r.msg.Cid())
+		mp.remove(ctx, r.from, r.msg.Message.Nonce, true)
+	}
+
+	return len(toRemove), nil
+}
+
 func getBaseFeeLowerBound(baseFee, factor big.Int) big.Int {
 	baseFeeLowerBound := big.Div(baseFee, factor)
 	if big.Cmp(baseFeeLowerBound, minimumBaseFee) < 0 {
@@ -3,9 +3,10 @@ package messagepool
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"sort"
 	"testing"
+	"time"
 
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/fork"
@@ -16,17 +17,17 @@ import (
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	tbig "github.com/filecoin-project/go-state-types/big"
-	"github.com/filecoin-project/go-state-types/network"
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
 
+	"github.com/filecoin-project/venus/pkg/clock"
 	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/constants"
-	"github.com/filecoin-project/venus/pkg/crypto"
 	"github.com/filecoin-project/venus/pkg/messagepool/gasguess"
 	"github.com/filecoin-project/venus/pkg/repo"
 	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
@@ -38,20 +39,6 @@ func init() {
 	_ = logging.SetLogLevel("*", "INFO")
 }
 
-type testMpoolAPI struct {
-	cb func(rev, app []*types.TipSet) error
-
-	bmsgs      map[cid.Cid][]*types.SignedMessage
-	statenonce map[address.Address]uint64
-	balance    map[address.Address]tbig.Int
-
-	tipsets []*types.TipSet
-
-	published int
-
-	baseFee tbig.Int
-}
-
 func mkAddress(i uint64) address.Address {
 	a, err := address.NewIDAddress(i)
 	if err != nil {
@@ -82,240 +69,6 @@ func mkMessage(from, to address.Address, nonce uint64, w *wallet.Wallet) *types.
 	}
 }
 
-func mkBlock(parents *types.TipSet, weightInc int64, ticketNonce uint64) *types.BlockHeader {
-	addr := mkAddress(123561)
-
-	c, err := cid.Decode("bafyreicmaj5hhoy5mgqvamfhgexxyergw7hdeshizghodwkjg6qmpoco7i")
-	if err != nil {
-		panic(err)
-	}
-
-	pstateRoot := c
-	if parents != nil {
-		pstateRoot = parents.Blocks()[0].ParentStateRoot
-	}
-
-	var height abi.ChainEpoch
-	var tsKey types.TipSetKey
-	weight := tbig.NewInt(weightInc)
-	var timestamp uint64
-	if parents != nil {
-		height = parents.Height()
-		height = height + 1
-		timestamp = parents.MinTimestamp() + constants.MainNetBlockDelaySecs
-		weight = tbig.Add(parents.Blocks()[0].ParentWeight, weight)
-		tsKey = parents.Key()
-	}
-
-	return &types.BlockHeader{
-		Miner: addr,
-		ElectionProof: &types.ElectionProof{
-			VRFProof: []byte(fmt.Sprintf("====%d=====", ticketNonce)),
-		},
-		Ticket: &types.Ticket{
-			VRFProof: []byte(fmt.Sprintf("====%d=====", ticketNonce)),
-		},
-		Parents:               tsKey.Cids(),
-		ParentMessageReceipts: c,
-		BLSAggregate:          &crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte("boo! im a signature")},
-		ParentWeight:          weight,
-		Messages:              c,
-		Height:                height,
-		Timestamp:             timestamp,
-		ParentStateRoot:       pstateRoot,
-		BlockSig:              &crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte("boo! im a signature")},
-		ParentBaseFee:         tbig.NewInt(int64(constants.MinimumBaseFee)),
-	}
-}
-
-func mkTipSet(blks ...*types.BlockHeader) *types.TipSet {
-	ts, err := types.NewTipSet(blks)
-	if err != nil {
-		panic(err)
-	}
-	return ts
-}
-
-func newTestMpoolAPI() *testMpoolAPI {
-	tma := &testMpoolAPI{
-		bmsgs:      make(map[cid.Cid][]*types.SignedMessage),
-		statenonce: make(map[address.Address]uint64),
-		balance:    make(map[address.Address]tbig.Int),
-		baseFee:    tbig.NewInt(100),
-	}
-	genesis := mkBlock(nil, 1, 1)
-	tma.tipsets = append(tma.tipsets, mkTipSet(genesis))
-	return tma
-}
-
-func (tma *testMpoolAPI) nextBlock() *types.BlockHeader {
-	newBlk := mkBlock(tma.tipsets[len(tma.tipsets)-1], 1, 1)
-	tma.tipsets = append(tma.tipsets, mkTipSet(newBlk))
-	return newBlk
-}
-
-func (tma *testMpoolAPI) nextBlockWithHeight(height uint64) *types.BlockHeader {
-	newBlk := mkBlock(tma.tipsets[len(tma.tipsets)-1], 1, 1)
-	newBlk.Height = abi.ChainEpoch(height)
-	tma.tipsets = append(tma.tipsets, mkTipSet(newBlk))
-	return newBlk
-}
-
-func (tma *testMpoolAPI) applyBlock(t *testing.T, b *types.BlockHeader) {
-	t.Helper()
-	if err := tma.cb(nil, []*types.TipSet{mkTipSet(b)}); err != nil {
-		t.Fatal(err)
-	}
-}
-
-func (tma *testMpoolAPI) revertBlock(t *testing.T, b *types.BlockHeader) {
-	t.Helper()
-	if err := tma.cb([]*types.TipSet{mkTipSet(b)}, nil); err != nil {
-		t.Fatal(err)
-	}
-}
-
-func (tma *testMpoolAPI) setStateNonce(addr address.Address, v uint64) {
-	tma.statenonce[addr] = v
-}
-
-func (tma *testMpoolAPI) setBalance(addr address.Address, v uint64) {
-	tma.balance[addr] = types.FromFil(v)
-}
-
-func (tma *testMpoolAPI) setBalanceRaw(addr address.Address, v tbig.Int) {
-	tma.balance[addr] = v
-}
-
-func (tma *testMpoolAPI) setBlockMessages(h *types.BlockHeader, msgs ...*types.SignedMessage) {
-	tma.bmsgs[h.Cid()] = msgs
-}
-
-func (tma *testMpoolAPI) ChainHead(ctx context.Context) (*types.TipSet, error) {
-	return &types.TipSet{}, nil
-}
-
-func (tma *testMpoolAPI) ChainTipSet(ctx context.Context, key types.TipSetKey) (*types.TipSet, error) {
-	return &types.TipSet{}, nil
-}
-
-func (tma *testMpoolAPI) SubscribeHeadChanges(ctx context.Context, cb func(rev, app []*types.TipSet) error) *types.TipSet {
-	tma.cb = cb
-	return tma.tipsets[0]
-}
-
-func (tma *testMpoolAPI) PutMessage(ctx context.Context, m types.ChainMsg) (cid.Cid, error) {
-	return cid.Undef, nil
-}
-
-func (tma *testMpoolAPI) IsLite() bool {
-	return false
-}
-
-func (tma *testMpoolAPI) PubSubPublish(context.Context, string, []byte) error {
-	tma.published++
-	return nil
-}
-
-func (tma *testMpoolAPI) GetActorAfter(ctx context.Context, addr address.Address, ts *types.TipSet) (*types.Actor, error) {
-	// regression check for load bug
-	if ts == nil {
-		panic("GetActorAfter called with nil tipset")
-	}
-
-	balance, ok := tma.balance[addr]
-	if !ok {
-		balance = tbig.NewInt(1000e6)
-		tma.balance[addr] = balance
-	}
-
-	msgs := make([]*types.SignedMessage, 0)
-	for _, b := range ts.Blocks() {
-		for _, m := range tma.bmsgs[b.Cid()] {
-			if m.Message.From == addr {
-				msgs = append(msgs, m)
-			}
-		}
-	}
-
-	sort.Slice(msgs, func(i, j int) bool {
-		return msgs[i].Message.Nonce < msgs[j].Message.Nonce
-	})
-
-	nonce := tma.statenonce[addr]
-
-	for _, m := range msgs {
-		if m.Message.Nonce != nonce {
-			break
-		}
-		nonce++
-	}
-
-	return &types.Actor{
-		Code:    builtin2.AccountActorCodeID,
-		Nonce:   nonce,
-		Balance: balance,
-	}, nil
-}
-
-func (tma *testMpoolAPI) StateAccountKeyAtFinality(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error) {
-	if addr.Protocol() != address.BLS && addr.Protocol() != address.SECP256K1 && addr.Protocol() != address.Delegated {
-		return address.Undef, fmt.Errorf("given address was not a key addr")
-	}
-	return addr, nil
-}
-
-func (tma *testMpoolAPI) StateNetworkVersion(ctx context.Context, h abi.ChainEpoch) network.Version {
-	return constants.TestNetworkVersion
-}
-
-func (tma *testMpoolAPI) StateAccountKey(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error) {
-	if addr.Protocol() != address.BLS && addr.Protocol() != address.SECP256K1 {
-		return address.Undef, fmt.Errorf("given address was not a key addr")
-	}
-	return addr, nil
-}
-
-func (tma *testMpoolAPI) MessagesForBlock(ctx context.Context, h *types.BlockHeader) ([]*types.Message, []*types.SignedMessage, error) {
-	return nil, tma.bmsgs[h.Cid()], nil
-}
-
-func (tma *testMpoolAPI) MessagesForTipset(ctx context.Context, ts *types.TipSet) ([]types.ChainMsg, error) {
-	if len(ts.Blocks()) != 1 {
-		panic("cant deal with multiblock tipsets in this test")
-	}
-
-	bm, sm, err := tma.MessagesForBlock(ctx, ts.Blocks()[0])
-	if err != nil {
-		return nil, err
-	}
-
-	var out []types.ChainMsg
-	for _, m := range bm {
-		out = append(out, m)
-	}
-
-	for _, m := range sm {
-		out = append(out, m)
-	}
-
-	return out, nil
-}
-
-func (tma *testMpoolAPI) LoadTipSet(ctx context.Context, tsk types.TipSetKey) (*types.TipSet, error) {
-	for _, ts := range tma.tipsets {
-		if tsk.Equals(ts.Key()) {
-			return ts, nil
-		}
-	}
-
-	return nil, fmt.Errorf("tipset not found")
-}
-
-func (tma *testMpoolAPI) ChainComputeBaseFee(ctx context.Context, ts *types.TipSet) (tbig.Int, error) {
-	return tma.baseFee, nil
-}
-
 func assertNonce(t *testing.T, mp *MessagePool, addr address.Address, val uint64) {
 	tf.UnitTest(t)
 
@@ -339,7 +92,7 @@ func mustAdd(t *testing.T, mp *MessagePool, msg *types.SignedMessage) {
 	}
 }
 
-func newWalletAndMpool(t *testing.T, tma *testMpoolAPI) (*wallet.Wallet, *MessagePool) {
+func newWalletAndMpool(t *testing.T, tma *MockProvider) (*wallet.Wallet, *MessagePool) {
 	ds := datastore.NewMapDatastore()
 
 	builder := chain.NewBuilder(t, address.Undef)
@@ -366,13 +119,13 @@ func TestMessagePool(t *testing.T) {
 	tf.UnitTest(t)
 
 	ctx := context.Background()
-	tma := newTestMpoolAPI()
+	tma := NewMockProvider()
 
 	w, mp := newWalletAndMpool(t, tma)
 	// stm: @MESSAGEPOOL_POOL_CLOSE_001
 	defer mp.Close() // nolint
 
-	a := tma.nextBlock()
+	a := tma.NextBlock()
 
 	sender, err := w.NewAddress(context.Background(), address.SECP256K1)
 	if err != nil {
@@ -385,7 +138,7 @@ func TestMessagePool(t *testing.T) {
 		msgs = append(msgs, mkMessage(sender, target, uint64(i), w))
 	}
 
-	tma.setStateNonce(sender, 0)
+	tma.SetStateNonce(sender, 0)
 	assertNonce(t, mp, sender, 0)
 	// stm: @MESSAGEPOOL_POOL_ADD_001
 	mustAdd(t, mp, msgs[0])
@@ -393,13 +146,13 @@ func TestMessagePool(t *testing.T) {
 	mustAdd(t, mp, msgs[1])
 	assertNonce(t, mp, sender, 2)
 
-	tma.setBlockMessages(a, msgs[0], msgs[1])
+	tma.SetBlockMessages(a, msgs[0], msgs[1])
 
 	// stm: @MESSAGEPOOL_POOL_GET_MESSAGES_FOR_BLOCKS_001
 	blockMsgs, err := mp.MessagesForBlocks(ctx, []*types.BlockHeader{a})
 	assert.NoError(t, err)
 	assert.Equal(t, len(blockMsgs), 2)
-	tma.applyBlock(t, a)
+	require.NoError(t, tma.ApplyBlock(a))
 
 	assertNonce(t, mp, sender, 2)
 	{ // test verify message signature
@@ -469,14 +222,85 @@ func TestMessagePool(t *testing.T) {
 
 }
 
+func TestGetPendingNonce(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	tma := NewMockProvider()
+
+	w, mp := newWalletAndMpool(t, tma)
+	defer mp.Close() // nolint
+
+	sender, err := w.NewAddress(ctx, address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := mkAddress(1001)
+
+	tma.SetStateNonce(sender, 0)
+
+	nonce, err := mp.GetPendingNonce(ctx, sender)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), nonce)
+
+	mustAdd(t, mp, mkMessage(sender, target, 0, w))
+	mustAdd(t, mp, mkMessage(sender, target, 1, w))
+	// leave a gap at nonce 2
+	mustAdd(t, mp, mkMessage(sender, target, 3, w))
+
+	assertNonce(t, mp, sender, 2)
+	nonce, err = mp.GetPendingNonce(ctx, sender)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), nonce)
+}
+
+func TestReplaceMessage(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	tma := NewMockProvider()
+
+	w, mp := newWalletAndMpool(t, tma)
+	defer mp.Close() // nolint
+
+	sender, err := w.NewAddress(ctx, address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := mkAddress(1001)
+
+	tma.SetStateNonce(sender, 0)
+	orig := mkMessage(sender, target, 0, w)
+	mustAdd(t, mp, orig)
+
+	cfg := mp.GetConfig()
+	cfg.ReplaceByFeeRatio = 200
+	assert.NoError(t, mp.SetConfig(ctx, cfg))
+
+	replace, err := mp.ReplaceMessage(ctx, sender, 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, orig.Message.Nonce, replace.Nonce)
+	assert.Equal(t, orig.Message.GasLimit, replace.GasLimit)
+	// premium 1 * 200% + 1, fee cap 100 * 200% + 1
+	assert.Equal(t, int64(3), replace.GasPremium.Int64())
+	assert.Equal(t, int64(201), replace.GasFeeCap.Int64())
+
+	_, err = mp.ReplaceMessage(ctx, sender, 1, nil)
+	assert.Error(t, err)
+
+	// a max fee of 2 per gas caps the premium below the required 3
+	_, err = mp.ReplaceMessage(ctx, sender, 0, &types.MessageSendSpec{MaxFee: tbig.NewInt(2 * orig.Message.GasLimit)})
+	assert.ErrorIs(t, err, ErrRBFTooLowPremium)
+}
+
 func TestCheckMessageBig(t *testing.T) {
-	tma := newTestMpoolAPI()
+	tma := NewMockProvider()
 
 	w, mp := newWalletAndMpool(t, tma)
 	from, err := w.NewAddress(context.Background(), address.SECP256K1)
 	assert.NoError(t, err)
 
-	tma.setBalance(from, 1000e9)
+	tma.SetBalance(from, types.FromFil(1000e9))
 
 	to := mkAddress(1001)
 
@@ -535,11 +359,11 @@ func TestCheckMessageBig(t *testing.T) {
 func TestMessagePoolMessagesInEachBlock(t *testing.T) {
 	tf.UnitTest(t)
 
-	tma := newTestMpoolAPI()
+	tma := NewMockProvider()
 
 	w, mp := newWalletAndMpool(t, tma)
 
-	a := tma.nextBlock()
+	a := tma.NextBlock()
 
 	sender, err := w.NewAddress(context.Background(), address.SECP256K1)
 	if err != nil {
@@ -554,10 +378,10 @@ func TestMessagePoolMessagesInEachBlock(t *testing.T) {
 		mustAdd(t, mp, m)
 	}
 
-	tma.setStateNonce(sender, 0)
+	tma.SetStateNonce(sender, 0)
 
-	tma.setBlockMessages(a, msgs[0], msgs[1])
-	tma.applyBlock(t, a)
+	tma.SetBlockMessages(a, msgs[0], msgs[1])
+	require.NoError(t, tma.ApplyBlock(a))
 	tsa := mkTipSet(a)
 
 	_, _ = mp.Pending(context.TODO())
@@ -577,12 +401,12 @@ func TestRevertMessages(t *testing.T) {
 		futureDebug = false
 	}()
 
-	tma := newTestMpoolAPI()
+	tma := NewMockProvider()
 
 	w, mp := newWalletAndMpool(t, tma)
 
-	a := tma.nextBlock()
-	b := tma.nextBlock()
+	a := tma.NextBlock()
+	b := tma.NextBlock()
 
 	sender, err := w.NewAddress(context.Background(), address.SECP256K1)
 	if err != nil {
@@ -595,23 +419,23 @@ func TestRevertMessages(t *testing.T) {
 		msgs = append(msgs, mkMessage(sender, target, uint64(i), w))
 	}
 
-	tma.setBlockMessages(a, msgs[0])
-	tma.setBlockMessages(b, msgs[1], msgs[2], msgs[3])
+	tma.SetBlockMessages(a, msgs[0])
+	tma.SetBlockMessages(b, msgs[1], msgs[2], msgs[3])
 
 	mustAdd(t, mp, msgs[0])
 	mustAdd(t, mp, msgs[1])
 	mustAdd(t, mp, msgs[2])
 	mustAdd(t, mp, msgs[3])
 
-	tma.setStateNonce(sender, 0)
-	tma.applyBlock(t, a)
+	tma.SetStateNonce(sender, 0)
+	require.NoError(t, tma.ApplyBlock(a))
 	assertNonce(t, mp, sender, 4)
 
-	tma.setStateNonce(sender, 1)
-	tma.applyBlock(t, b)
+	tma.SetStateNonce(sender, 1)
+	require.NoError(t, tma.ApplyBlock(b))
 	assertNonce(t, mp, sender, 4)
-	tma.setStateNonce(sender, 0)
-	tma.revertBlock(t, b)
+	tma.SetStateNonce(sender, 0)
+	require.NoError(t, tma.RevertBlock(b))
 
 	assertNonce(t, mp, sender, 4)
 
@@ -631,18 +455,18 @@ func TestPruningSimple(t *testing.T) {
 		MaxNonceGap = oldMaxNonceGap
 	}()
 
-	tma := newTestMpoolAPI()
+	tma := NewMockProvider()
 
 	w, mp := newWalletAndMpool(t, tma)
 
-	a := tma.nextBlock()
-	tma.applyBlock(t, a)
+	a := tma.NextBlock()
+	require.NoError(t, tma.ApplyBlock(a))
 
 	sender, err := w.NewAddress(context.Background(), address.SECP256K1)
 	if err != nil {
 		t.Fatal(err)
 	}
-	tma.setBalance(sender, 1) // in FIL
+	tma.SetBalance(sender, types.FromFil(1)) // in FIL
 	target := mkAddress(1001)
 
 	for i := 0; i < 5; i++ {
@@ -674,7 +498,7 @@ func TestPruningSimple(t *testing.T) {
 func TestLoadLocal(t *testing.T) {
 	tf.UnitTest(t)
 
-	tma := newTestMpoolAPI()
+	tma := NewMockProvider()
 	ds := datastore.NewMapDatastore()
 
 	mp, err := New(context.Background(), tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
@@ -695,8 +519,8 @@ func TestLoadLocal(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setBalance(a2, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 	msgs := make(map[cid.Cid]struct{})
 	for i := 0; i < 10; i++ {
@@ -741,7 +565,7 @@ func TestLoadLocal(t *testing.T) {
 func TestClearAll(t *testing.T) {
 	tf.UnitTest(t)
 
-	tma := newTestMpoolAPI()
+	tma := NewMockProvider()
 	ds := datastore.NewMapDatastore()
 
 	mp, err := New(context.Background(), tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
@@ -762,8 +586,8 @@ func TestClearAll(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setBalance(a2, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 	for i := 0; i < 10; i++ {
 		m := makeTestMessage(w1, a1, a2, uint64(i), gasLimit, uint64(i+1))
@@ -785,12 +609,15 @@ func TestClearAll(t *testing.T) {
 	if len(pending) > 0 {
 		t.Fatalf("cleared the mpool, but got %d pending messages", len(pending))
 	}
+	if count := mp.PendingCount(); count != 0 {
+		t.Fatalf("cleared the mpool, but counted %d pending messages", count)
+	}
 }
 
 func TestClearNonLocal(t *testing.T) {
 	tf.UnitTest(t)
 
-	tma := newTestMpoolAPI()
+	tma := NewMockProvider()
 	ds := datastore.NewMapDatastore()
 
 	mp, err := New(context.Background(), tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
@@ -811,8 +638,8 @@ func TestClearNonLocal(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setBalance(a2, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 	for i := 0; i < 10; i++ {
@@ -828,12 +655,19 @@ func TestClearNonLocal(t *testing.T) {
 		mustAdd(t, mp, m)
 	}
 
+	if count := mp.PendingCount(); count != 20 {
+		t.Fatalf("expected 20 pending messages, but counted %d instead", count)
+	}
+
 	mp.Clear(context.Background(), false)
 
 	pending, _ := mp.Pending(context.TODO())
 	if len(pending) != 10 {
 		t.Fatalf("expected 10 pending messages, but got %d instead", len(pending))
 	}
+	if count := mp.PendingCount(); count != 10 {
+		t.Fatalf("expected 10 pending messages, but counted %d instead", count)
+	}
 
 	for _, m := range pending {
 		if m.Message.From != a1 {
@@ -842,10 +676,159 @@ func TestClearNonLocal(t *testing.T) {
 	}
 }
 
+func TestClearRange(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	tma := NewMockProvider()
+	ds := datastore.NewMapDatastore()
+
+	mp, err := New(ctx, tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the actors
+	w1 := newWallet(t)
+	a1, err := w1.NewAddress(ctx, address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w2 := newWallet(t)
+	a2, err := w2.NewAddress(ctx, address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
+
+	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
+	for i := 0; i < 5; i++ {
+		m := makeTestMessage(w1, a1, a2, uint64(i), gasLimit, uint64(i+1))
+		_, err := mp.Push(ctx, m)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var remoteMsgs []*types.SignedMessage
+	for i := 0; i < 5; i++ {
+		m := makeTestMessage(w2, a2, a1, uint64(i), gasLimit, uint64(i+1))
+		mustAdd(t, mp, m)
+		remoteMsgs = append(remoteMsgs, m)
+	}
+
+	// inverted range
+	_, err = mp.ClearRange(ctx, a2, 3, 1, false)
+	assert.Error(t, err)
+
+	// local senders are only cleared when local is set
+	n, err := mp.ClearRange(ctx, a1, 0, 4, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	pending, _ := mp.PendingFor(ctx, a1)
+	assert.Len(t, pending, 5)
+
+	mp.lk.Lock()
+	mp.republished = map[cid.Cid]struct{}{remoteMsgs[1].Cid(): {}, remoteMsgs[3].Cid(): {}}
+	mp.lk.Unlock()
+
+	// clearing a middle range rewinds the next nonce to the first cleared one
+	n, err = mp.ClearRange(ctx, a2, 1, 2, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	pending, _ = mp.PendingFor(ctx, a2)
+	nonces := make([]uint64, 0, len(pending))
+	for _, m := range pending {
+		nonces = append(nonces, m.Message.Nonce)
+	}
+	assert.Equal(t, []uint64{0, 3, 4}, nonces)
+	assertNonce(t, mp, a2, 1)
+
+	mp.lk.Lock()
+	assert.Equal(t, map[cid.Cid]struct{}{remoteMsgs[3].Cid(): {}}, mp.republished)
+	mp.lk.Unlock()
+
+	n, err = mp.ClearRange(ctx, a1, 3, 10, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assertNonce(t, mp, a1, 3)
+}
+
+func TestClearStale(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	tma := NewMockProvider()
+	ds := datastore.NewMapDatastore()
+
+	mp, err := New(ctx, tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the actors
+	w1 := newWallet(t)
+	a1, err := w1.NewAddress(ctx, address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w2 := newWallet(t)
+	a2, err := w2.NewAddress(ctx, address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
+
+	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
+	for i := 0; i < 5; i++ {
+		m := makeTestMessage(w1, a1, a2, uint64(i), gasLimit, uint64(i+1))
+		_, err := mp.Push(ctx, m)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		m := makeTestMessage(w2, a2, a1, uint64(i), gasLimit, uint64(i+1))
+		mustAdd(t, mp, m)
+	}
+
+	// the first 3 nonces of both senders were used on chain
+	tma.SetStateNonce(a1, 3)
+	tma.SetStateNonce(a2, 3)
+	ts := mkTipSet(tma.NextBlock())
+
+	n, err := mp.ClearStale(ctx, false, ts)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	pending, _ := mp.PendingFor(ctx, a1)
+	assert.Len(t, pending, 5)
+	pending, _ = mp.PendingFor(ctx, a2)
+	nonces := make([]uint64, 0, len(pending))
+	for _, m := range pending {
+		nonces = append(nonces, m.Message.Nonce)
+	}
+	assert.Equal(t, []uint64{3, 4}, nonces)
+	assertNonce(t, mp, a2, 5)
+
+	n, err = mp.ClearStale(ctx, true, ts)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	pending, _ = mp.PendingFor(ctx, a1)
+	assert.Len(t, pending, 2)
+}
+
 func TestUpdates(t *testing.T) {
 	tf.UnitTest(t)
 
-	tma := newTestMpoolAPI()
+	tma := NewMockProvider()
 	ds := datastore.NewMapDatastore()
 
 	mp, err := New(context.Background(), tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
@@ -876,8 +859,8 @@ func TestUpdates(t *testing.T) {
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setBalance(a2, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
 
 	for i := 0; i < 10; i++ {
 		m := makeTestMessage(w1, a1, a2, uint64(i), gasLimit, uint64(i+1))
@@ -939,3 +922,158 @@ func TestCapGasFee(t *testing.T) {
 		assert.Equal(t, msg.GasPremium.Int.Int64(), int64(100_000))
 	})
 }
+
+func TestMethodMaxFees(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	_, mp := newWalletAndMpool(t, NewMockProvider())
+	defer mp.Close() // nolint
+
+	cfg := mp.GetConfig()
+	cfg.MethodMaxFees = map[string]abi.TokenAmount{
+		MethodMaxFeeKey(builtin2.AccountActorCodeID, builtin2.MethodsAccount.PubkeyAddress): abi.NewTokenAmount(1_000_000),
+	}
+	require.NoError(t, mp.SetConfig(ctx, cfg))
+
+	newMsg := func(method abi.MethodNum) *types.Message {
+		return &types.Message{
+			To:         mkAddress(1000),
+			Method:     method,
+			GasLimit:   1000,
+			GasFeeCap:  abi.NewTokenAmount(100_000),
+			GasPremium: abi.NewTokenAmount(100_000),
+		}
+	}
+
+	msg := newMsg(builtin2.MethodsAccount.PubkeyAddress)
+	CapGasFee(mp.maxFeeFunc(ctx, msg), msg, nil)
+	assert.Equal(t, abi.NewTokenAmount(1000), msg.GasFeeCap)
+
+	// other methods fall back to the max fee of the node
+	msg = newMsg(builtin2.MethodSend)
+	CapGasFee(mp.maxFeeFunc(ctx, msg), msg, nil)
+	assert.Equal(t, abi.NewTokenAmount(100_000), msg.GasFeeCap)
+
+	// the spec takes precedence
+	msg = newMsg(builtin2.MethodsAccount.PubkeyAddress)
+	CapGasFee(mp.maxFeeFunc(ctx, msg), msg, &types.MessageSendSpec{MaxFee: abi.NewTokenAmount(50_000_000)})
+	assert.Equal(t, abi.NewTokenAmount(50_000), msg.GasFeeCap)
+
+	// the config is cloned
+	cfg.MethodMaxFees[MethodMaxFeeKey(builtin2.AccountActorCodeID, builtin2.MethodSend)] = abi.NewTokenAmount(1)
+	assert.Len(t, mp.GetConfig().MethodMaxFees, 1)
+
+	for _, key := range []string{"", "5", builtin2.AccountActorCodeID.String(), "notacid/5", builtin2.AccountActorCodeID.String() + "/x"} {
+		cfg := mp.GetConfig()
+		cfg.MethodMaxFees = map[string]abi.TokenAmount{key: abi.NewTokenAmount(1)}
+		assert.Error(t, mp.SetConfig(ctx, cfg), key)
+	}
+	cfg = mp.GetConfig()
+	cfg.MethodMaxFees = map[string]abi.TokenAmount{MethodMaxFeeKey(builtin2.AccountActorCodeID, 2): abi.NewTokenAmount(-1)}
+	assert.Error(t, mp.SetConfig(ctx, cfg))
+}
+
+func TestSyncLag(t *testing.T) {
+	tf.UnitTest(t)
+
+	tma := NewMockProvider()
+	w, mp := newWalletAndMpool(t, tma)
+	defer mp.Close() // nolint
+
+	sender, err := w.NewAddress(context.Background(), address.SECP256K1)
+	require.NoError(t, err)
+	target := mkAddress(1001)
+	tma.SetStateNonce(sender, 0)
+
+	// the head is at epoch 0, the network is at epoch 5
+	epoch := time.Duration(constants.MainNetBlockDelaySecs) * time.Second
+	fake, clk := clock.NewFakeChain(0, epoch, int64(5*constants.MainNetBlockDelaySecs))
+	mp.SetChainClock(clk)
+	assert.Equal(t, abi.ChainEpoch(5), config.DefaultMessagePoolParam.SyncLagLimit)
+
+	mustAdd(t, mp, mkMessage(sender, target, 0, w))
+
+	fake.Advance(epoch)
+	err = mp.Add(context.Background(), mkMessage(sender, target, 1, w))
+	assert.ErrorIs(t, err, ErrSyncLag)
+
+	// resumes once the head catches up
+	require.NoError(t, tma.ApplyBlock(tma.NextBlock()))
+	mustAdd(t, mp, mkMessage(sender, target, 1, w))
+
+	mp.SetChainClock(nil)
+	fake.Advance(10 * epoch)
+	mustAdd(t, mp, mkMessage(sender, target, 2, w))
+}
+
+type testValidator struct {
+	id  string
+	err error
+}
+
+func (v *testValidator) ID() string { return v.id }
+
+func (v *testValidator) Validate(context.Context, *types.SignedMessage) error { return v.err }
+
+func TestMessageValidators(t *testing.T) {
+	tf.UnitTest(t)
+
+	tma := NewMockProvider()
+	w, mp := newWalletAndMpool(t, tma)
+	from, err := w.NewAddress(context.Background(), address.SECP256K1)
+	assert.NoError(t, err)
+	tma.SetBalance(from, types.FromFil(1000e9))
+	to := mkAddress(1001)
+
+	errSanctioned := errors.New("sanctioned")
+	mp.AddValidator(&testValidator{id: "pass"})
+	mp.AddValidator(&testValidator{id: "sanctions", err: errSanctioned})
+
+	msg := makeTestMessage(w, from, to, 0, 50000000, 1)
+	err = mp.Add(context.TODO(), msg)
+	assert.ErrorIs(t, err, errSanctioned)
+
+	mp.RemoveValidator("sanctions")
+	mustAdd(t, mp, msg)
+}
+
+func TestOnNetworkUpgrade(t *testing.T) {
+	tf.UnitTest(t)
+
+	tma := NewMockProvider()
+	w, mp := newWalletAndMpool(t, tma)
+	from, err := w.NewAddress(context.Background(), address.SECP256K1)
+	assert.NoError(t, err)
+	tma.SetBalance(from, types.FromFil(1000e9))
+	to := mkAddress(1001)
+
+	valid := makeTestMessage(w, from, to, 0, 50000000, 1)
+	mustAdd(t, mp, valid)
+	// a gas limit below the on chain message cost can not be included anymore
+	invalid := makeTestMessage(w, from, to, 1, 1, 1)
+	assert.NoError(t, mp.addSkipChecks(context.TODO(), invalid))
+	// the receiver is an account actor, which does not export the method
+	badMethod := &types.Message{
+		From:       from,
+		To:         to,
+		Method:     99,
+		Value:      types.FromFil(0),
+		Nonce:      2,
+		GasLimit:   50000000,
+		GasFeeCap:  tbig.NewInt(101),
+		GasPremium: tbig.NewInt(1),
+	}
+	sig, err := w.WalletSign(context.Background(), from, badMethod.Cid().Bytes(), types.MsgMeta{})
+	assert.NoError(t, err)
+	assert.NoError(t, mp.addSkipChecks(context.TODO(), &types.SignedMessage{Message: *badMethod, Signature: *sig}))
+
+	pending, _ := mp.Pending(context.TODO())
+	assert.Len(t, pending, 3)
+
+	mp.OnNetworkUpgrade(context.TODO(), constants.TestNetworkVersion, 1)
+
+	pending, _ = mp.Pending(context.TODO())
+	assert.Len(t, pending, 1)
+	assert.Equal(t, valid.Cid(), pending[0].Cid())
+}
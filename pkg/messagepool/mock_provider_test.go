@@ -0,0 +1,398 @@
+package messagepool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// MockDefaultBalance is the balance of actors without a balance of their own on a MockProvider
+var MockDefaultBalance = big.NewInt(1000e6)
+
+var _ Provider = (*MockProvider)(nil)
+
+// MockProvider is a Provider serving a simulated chain of single block tipsets. The nonce of an
+// actor is its state nonce plus the messages of the tipset it is read at continuing it.
+type MockProvider struct {
+	lk sync.Mutex
+
+	cb func(rev, app []*types.TipSet) error
+
+	bmsgs      map[cid.Cid][]*types.SignedMessage
+	stateNonce map[address.Address]uint64
+	balance    map[address.Address]big.Int
+	receipts   map[cid.Cid][]types.MessageReceipt
+
+	tipsets []*types.TipSet
+
+	published int
+
+	baseFee        big.Int
+	networkVersion network.Version
+}
+
+// NewMockProvider creates a MockProvider whose chain only has a genesis tipset.
+func NewMockProvider() *MockProvider {
+	return &MockProvider{
+		bmsgs:          make(map[cid.Cid][]*types.SignedMessage),
+		stateNonce:     make(map[address.Address]uint64),
+		balance:        make(map[address.Address]big.Int),
+		receipts:       make(map[cid.Cid][]types.MessageReceipt),
+		tipsets:        []*types.TipSet{mkTipSet(mkBlock(nil, 1, 1))},
+		baseFee:        big.NewInt(100),
+		networkVersion: constants.TestNetworkVersion,
+	}
+}
+
+func (mp *MockProvider) SetStateNonce(addr address.Address, nonce uint64) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	mp.stateNonce[addr] = nonce
+}
+
+func (mp *MockProvider) SetBalance(addr address.Address, balance big.Int) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	mp.balance[addr] = balance
+}
+
+// SetBaseFee sets the base fee computed for tipsets, new blocks carry it as their parent base fee.
+func (mp *MockProvider) SetBaseFee(baseFee big.Int) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	mp.baseFee = baseFee
+}
+
+func (mp *MockProvider) SetNetworkVersion(nv network.Version) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	mp.networkVersion = nv
+}
+
+func (mp *MockProvider) SetReceipts(c cid.Cid, receipts []types.MessageReceipt) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	mp.receipts[c] = receipts
+}
+
+// SetBlockMessages sets the messages included in blk.
+func (mp *MockProvider) SetBlockMessages(blk *types.BlockHeader, msgs ...*types.SignedMessage) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	mp.bmsgs[blk.Cid()] = msgs
+}
+
+// NextBlock extends the chain by a block on top of the head, it is not announced until ApplyBlock.
+func (mp *MockProvider) NextBlock() *types.BlockHeader {
+	return mp.NextTipSet(1).Blocks()[0]
+}
+
+// NextBlockWithHeight extends the chain by a block at height on top of the head, the epochs in between are null rounds.
+func (mp *MockProvider) NextBlockWithHeight(height abi.ChainEpoch) *types.BlockHeader {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	blk := mkBlock(mp.tipsets[len(mp.tipsets)-1], 1, 1)
+	blk.Height = height
+	blk.ParentBaseFee = mp.baseFee
+	mp.tipsets = append(mp.tipsets, mkTipSet(blk))
+	return blk
+}
+
+// NextTipSet extends the chain by a tipset of n blocks on top of the head, the blocks carry the
+// current base fee as their parent base fee.
+func (mp *MockProvider) NextTipSet(n int) *types.TipSet {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	blks := make([]*types.BlockHeader, n)
+	for i := range blks {
+		blks[i] = mkBlock(mp.tipsets[len(mp.tipsets)-1], 1, uint64(i+1))
+		blks[i].ParentBaseFee = mp.baseFee
+	}
+	ts := mkTipSet(blks...)
+	mp.tipsets = append(mp.tipsets, ts)
+	return ts
+}
+
+// Genesis returns the first tipset of the chain.
+func (mp *MockProvider) Genesis() *types.TipSet {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	return mp.tipsets[0]
+}
+
+// Head returns the last tipset of the chain.
+func (mp *MockProvider) Head() *types.TipSet {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	return mp.tipsets[len(mp.tipsets)-1]
+}
+
+// ApplyBlock announces blk as a new head to the subscriber.
+func (mp *MockProvider) ApplyBlock(blk *types.BlockHeader) error {
+	return mp.notify(nil, []*types.TipSet{mkTipSet(blk)})
+}
+
+// RevertBlock announces blk as reverted to the subscriber.
+func (mp *MockProvider) RevertBlock(blk *types.BlockHeader) error {
+	return mp.notify([]*types.TipSet{mkTipSet(blk)}, nil)
+}
+
+func (mp *MockProvider) notify(rev, app []*types.TipSet) error {
+	mp.lk.Lock()
+	cb := mp.cb
+	mp.lk.Unlock()
+
+	// the subscriber calls back into the provider
+	if cb == nil {
+		return fmt.Errorf("no head change subscriber")
+	}
+	return cb(rev, app)
+}
+
+// FindMessage returns the tipset of the chain including the message with cid c, which is the cid of
+// either the signed or the unsigned message.
+func (mp *MockProvider) FindMessage(c cid.Cid) (*types.TipSet, bool) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	for _, ts := range mp.tipsets {
+		for _, blk := range ts.Blocks() {
+			for _, m := range mp.bmsgs[blk.Cid()] {
+				if m.Cid() == c || m.Message.Cid() == c {
+					return ts, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// Published returns the number of messages published so far.
+func (mp *MockProvider) Published() int {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	return mp.published
+}
+
+func (mp *MockProvider) ChainHead(ctx context.Context) (*types.TipSet, error) {
+	return mp.Head(), nil
+}
+
+func (mp *MockProvider) ChainTipSet(ctx context.Context, key types.TipSetKey) (*types.TipSet, error) {
+	if key.IsEmpty() {
+		return mp.Head(), nil
+	}
+	return mp.LoadTipSet(ctx, key)
+}
+
+func (mp *MockProvider) SubscribeHeadChanges(ctx context.Context, cb func(rev, app []*types.TipSet) error) *types.TipSet {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	mp.cb = cb
+	return mp.tipsets[len(mp.tipsets)-1]
+}
+
+func (mp *MockProvider) PutMessage(ctx context.Context, m types.ChainMsg) (cid.Cid, error) {
+	return m.Cid(), nil
+}
+
+func (mp *MockProvider) PubSubPublish(context.Context, string, []byte) error {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	mp.published++
+	return nil
+}
+
+func (mp *MockProvider) GetActorAfter(ctx context.Context, addr address.Address, ts *types.TipSet) (*types.Actor, error) {
+	if ts == nil {
+		return nil, fmt.Errorf("GetActorAfter called with nil tipset")
+	}
+
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	balance, ok := mp.balance[addr]
+	if !ok {
+		balance = MockDefaultBalance
+	}
+
+	var msgs []*types.SignedMessage
+	for _, b := range ts.Blocks() {
+		for _, m := range mp.bmsgs[b.Cid()] {
+			if m.Message.From == addr {
+				msgs = append(msgs, m)
+			}
+		}
+	}
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].Message.Nonce < msgs[j].Message.Nonce
+	})
+
+	nonce := mp.stateNonce[addr]
+	for _, m := range msgs {
+		if m.Message.Nonce != nonce {
+			break
+		}
+		nonce++
+	}
+
+	return &types.Actor{
+		Code:    builtin2.AccountActorCodeID,
+		Nonce:   nonce,
+		Balance: balance,
+	}, nil
+}
+
+func (mp *MockProvider) StateAccountKeyAtFinality(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error) {
+	if addr.Protocol() != address.BLS && addr.Protocol() != address.SECP256K1 && addr.Protocol() != address.Delegated {
+		return address.Undef, fmt.Errorf("given address was not a key addr")
+	}
+	return addr, nil
+}
+
+func (mp *MockProvider) StateNetworkVersion(ctx context.Context, h abi.ChainEpoch) network.Version {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	return mp.networkVersion
+}
+
+func (mp *MockProvider) StateAccountKey(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error) {
+	if addr.Protocol() != address.BLS && addr.Protocol() != address.SECP256K1 {
+		return address.Undef, fmt.Errorf("given address was not a key addr")
+	}
+	return addr, nil
+}
+
+func (mp *MockProvider) MessagesForBlock(ctx context.Context, h *types.BlockHeader) ([]*types.Message, []*types.SignedMessage, error) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	return nil, mp.bmsgs[h.Cid()], nil
+}
+
+func (mp *MockProvider) MessagesForTipset(ctx context.Context, ts *types.TipSet) ([]types.ChainMsg, error) {
+	var out []types.ChainMsg
+	for _, blk := range ts.Blocks() {
+		_, smsgs, err := mp.MessagesForBlock(ctx, blk)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range smsgs {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func (mp *MockProvider) LoadTipSet(ctx context.Context, tsk types.TipSetKey) (*types.TipSet, error) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	for _, ts := range mp.tipsets {
+		if tsk.Equals(ts.Key()) {
+			return ts, nil
+		}
+	}
+	return nil, fmt.Errorf("tipset not found")
+}
+
+func (mp *MockProvider) LoadReceipts(ctx context.Context, c cid.Cid) ([]types.MessageReceipt, error) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	receipts, ok := mp.receipts[c]
+	if !ok {
+		return nil, fmt.Errorf("receipts not found")
+	}
+	return receipts, nil
+}
+
+func (mp *MockProvider) ChainComputeBaseFee(ctx context.Context, ts *types.TipSet) (big.Int, error) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	return mp.baseFee, nil
+}
+
+func (mp *MockProvider) IsLite() bool {
+	return false
+}
+
+func mkBlock(parents *types.TipSet, weightInc int64, ticketNonce uint64) *types.BlockHeader {
+	addr, err := address.NewIDAddress(123561)
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := cid.Decode("bafyreicmaj5hhoy5mgqvamfhgexxyergw7hdeshizghodwkjg6qmpoco7i")
+	if err != nil {
+		panic(err)
+	}
+
+	pstateRoot := c
+	var height abi.ChainEpoch
+	var tsKey types.TipSetKey
+	weight := big.NewInt(weightInc)
+	var timestamp uint64
+	if parents != nil {
+		pstateRoot = parents.Blocks()[0].ParentStateRoot
+		height = parents.Height() + 1
+		timestamp = parents.MinTimestamp() + constants.MainNetBlockDelaySecs
+		weight = big.Add(parents.Blocks()[0].ParentWeight, weight)
+		tsKey = parents.Key()
+	}
+
+	return &types.BlockHeader{
+		Miner: addr,
+		ElectionProof: &types.ElectionProof{
+			VRFProof: []byte(fmt.Sprintf("====%d=====", ticketNonce)),
+		},
+		Ticket: &types.Ticket{
+			VRFProof: []byte(fmt.Sprintf("====%d=====", ticketNonce)),
+		},
+		Parents:               tsKey.Cids(),
+		ParentMessageReceipts: c,
+		BLSAggregate:          &crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte("boo! im a signature")},
+		ParentWeight:          weight,
+		Messages:              c,
+		Height:                height,
+		Timestamp:             timestamp,
+		ParentStateRoot:       pstateRoot,
+		BlockSig:              &crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte("boo! im a signature")},
+		ParentBaseFee:         big.NewInt(int64(constants.MinimumBaseFee)),
+	}
+}
+
+func mkTipSet(blks ...*types.BlockHeader) *types.TipSet {
+	ts, err := types.NewTipSet(blks)
+	if err != nil {
+		panic(err)
+	}
+	return ts
+}
@@ -0,0 +1,137 @@
+package messagepool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/network"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/fork"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// MockDefaultGasUsed is the gas used by calls of a MockStateManager without a receipt of their own
+const MockDefaultGasUsed = 1_000_000
+
+// errMockNoState is returned for the parent state, the mock keeps no state tree
+var errMockNoState = errors.New("mock state manager has no state")
+
+var _ StateManager = (*MockStateManager)(nil)
+
+// mockCall is the receiver and the method of a call
+type mockCall struct {
+	to     address.Address
+	method abi.MethodNum
+}
+
+// MockStateManager is a StateManager executing no messages, calls return preset receipts.
+type MockStateManager struct {
+	lk sync.Mutex
+
+	networkVersion network.Version
+	keys           map[address.Address]address.Address
+	receipts       map[mockCall]types.MessageReceipt
+	forks          map[abi.ChainEpoch]struct{}
+	calls          []*types.Message
+}
+
+func NewMockStateManager() *MockStateManager {
+	return &MockStateManager{
+		networkVersion: constants.TestNetworkVersion,
+		keys:           make(map[address.Address]address.Address),
+		receipts:       make(map[mockCall]types.MessageReceipt),
+		forks:          make(map[abi.ChainEpoch]struct{}),
+	}
+}
+
+func (sm *MockStateManager) SetNetworkVersion(nv network.Version) {
+	sm.lk.Lock()
+	defer sm.lk.Unlock()
+
+	sm.networkVersion = nv
+}
+
+// SetKeyAddress makes id resolve to the key address key.
+func (sm *MockStateManager) SetKeyAddress(id, key address.Address) {
+	sm.lk.Lock()
+	defer sm.lk.Unlock()
+
+	sm.keys[id] = key
+}
+
+// SetReceipt sets the receipt of calls of method of the actor to, other calls succeed using MockDefaultGasUsed.
+func (sm *MockStateManager) SetReceipt(to address.Address, method abi.MethodNum, receipt types.MessageReceipt) {
+	sm.lk.Lock()
+	defer sm.lk.Unlock()
+
+	sm.receipts[mockCall{to: to, method: method}] = receipt
+}
+
+// SetExpensiveFork makes calls on tipsets at height fail with fork.ErrExpensiveFork.
+func (sm *MockStateManager) SetExpensiveFork(height abi.ChainEpoch) {
+	sm.lk.Lock()
+	defer sm.lk.Unlock()
+
+	sm.forks[height] = struct{}{}
+}
+
+// Calls returns the messages passed to CallWithGas so far.
+func (sm *MockStateManager) Calls() []*types.Message {
+	sm.lk.Lock()
+	defer sm.lk.Unlock()
+
+	return append([]*types.Message(nil), sm.calls...)
+}
+
+func (sm *MockStateManager) GetNetworkVersion(ctx context.Context, h abi.ChainEpoch) network.Version {
+	sm.lk.Lock()
+	defer sm.lk.Unlock()
+
+	return sm.networkVersion
+}
+
+func (sm *MockStateManager) ResolveToDeterministicAddress(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error) {
+	switch addr.Protocol() {
+	case address.BLS, address.SECP256K1, address.Delegated:
+		return addr, nil
+	}
+
+	sm.lk.Lock()
+	defer sm.lk.Unlock()
+
+	key, ok := sm.keys[addr]
+	if !ok {
+		return address.Undef, fmt.Errorf("no key address for %s", addr)
+	}
+	return key, nil
+}
+
+func (sm *MockStateManager) CallWithGas(ctx context.Context, msg *types.Message, priorMsgs []types.ChainMsg, ts *types.TipSet) (*types.InvocResult, error) {
+	sm.lk.Lock()
+	defer sm.lk.Unlock()
+
+	if _, ok := sm.forks[ts.Height()]; ok {
+		return nil, fork.ErrExpensiveFork
+	}
+	sm.calls = append(sm.calls, msg)
+	receipt, ok := sm.receipts[mockCall{to: msg.To, method: msg.Method}]
+	if !ok {
+		receipt = types.MessageReceipt{ExitCode: exitcode.Ok, GasUsed: MockDefaultGasUsed}
+	}
+	return &types.InvocResult{
+		MsgCid: msg.Cid(),
+		Msg:    msg,
+		MsgRct: &receipt,
+	}, nil
+}
+
+func (sm *MockStateManager) ParentState(ctx context.Context, ts *types.TipSet) (*types.TipSet, *tree.State, error) {
+	return nil, nil, errMockNoState
+}
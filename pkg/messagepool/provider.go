@@ -12,6 +12,7 @@ import (
 	"github.com/filecoin-project/go-state-types/network"
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/state/tree"
 	"github.com/filecoin-project/venus/pkg/statemanger"
 	"github.com/filecoin-project/venus/venus-shared/actors/policy"
 	"github.com/filecoin-project/venus/venus-shared/types"
@@ -38,10 +39,21 @@ type Provider interface {
 	MessagesForBlock(context.Context, *types.BlockHeader) ([]*types.Message, []*types.SignedMessage, error)
 	MessagesForTipset(context.Context, *types.TipSet) ([]types.ChainMsg, error)
 	LoadTipSet(context.Context, types.TipSetKey) (*types.TipSet, error)
+	LoadReceipts(context.Context, cid.Cid) ([]types.MessageReceipt, error)
 	ChainComputeBaseFee(ctx context.Context, ts *types.TipSet) (tbig.Int, error)
 	IsLite() bool
 }
 
+// StateManager is the part of the state manager the message pool uses, implemented by *statemanger.Stmgr
+type StateManager interface {
+	GetNetworkVersion(ctx context.Context, h abi.ChainEpoch) network.Version
+	ResolveToDeterministicAddress(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error)
+	CallWithGas(ctx context.Context, msg *types.Message, priorMsgs []types.ChainMsg, ts *types.TipSet) (*types.InvocResult, error)
+	ParentState(ctx context.Context, ts *types.TipSet) (*types.TipSet, *tree.State, error)
+}
+
+var _ StateManager = (*statemanger.Stmgr)(nil)
+
 type mpoolProvider struct {
 	stmgr  *statemanger.Stmgr
 	sm     *chain.Store
@@ -158,6 +170,13 @@ func (mpp *mpoolProvider) LoadTipSet(ctx context.Context, tsk types.TipSetKey) (
 	return mpp.sm.GetTipSet(ctx, tsk)
 }
 
+func (mpp *mpoolProvider) LoadReceipts(ctx context.Context, c cid.Cid) ([]types.MessageReceipt, error) {
+	if mpp.IsLite() {
+		return nil, errors.New("receipts are not available in lite mode")
+	}
+	return mpp.cms.LoadReceipts(ctx, c)
+}
+
 func (mpp *mpoolProvider) ChainComputeBaseFee(ctx context.Context, ts *types.TipSet) (tbig.Int, error) {
 	baseFee, err := mpp.cms.ComputeBaseFee(ctx, ts, mpp.config.ForkUpgradeParam)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/ipfs/go-cid"
 
 	"github.com/filecoin-project/venus/pkg/constants"
@@ -31,6 +32,7 @@ func (mp *MessagePool) republishPendingMessages(ctx context.Context) error {
 	baseFeeLowerBound := getBaseFeeLowerBound(baseFee, baseFeeLowerBoundFactor)
 
 	pending := make(map[address.Address]map[uint64]*types.SignedMessage)
+	pendingSince := make(map[cid.Cid]abi.ChainEpoch, len(mp.localPendingSince))
 	mp.lk.Lock()
 	mp.republished = nil // clear this to avoid races triggering an early republish
 	for actor := range mp.localAddrs {
@@ -44,10 +46,26 @@ func (mp *MessagePool) republishPendingMessages(ctx context.Context) error {
 		// we need to copy this while holding the lock to avoid races with concurrent modification
 		pend := make(map[uint64]*types.SignedMessage, len(mset.msgs))
 		for nonce, m := range mset.msgs {
+			// messages loaded from the datastore count as pending from the first republish that sees them
+			since, ok := mp.localPendingSince[m.Cid()]
+			if !ok {
+				since = ts.Height()
+			}
+			pendingSince[m.Cid()] = since
+			// leave the messages added recently to their first publication, the chains built below
+			// stop at the first nonce left out
+			if ts.Height()-since < mp.rebroadcastMinDelay {
+				continue
+			}
 			pend[nonce] = m
 		}
+		if len(pend) == 0 {
+			continue
+		}
 		pending[actor] = pend
 	}
+	// forget the messages which are no longer pending
+	mp.localPendingSince = pendingSince
 	mp.lk.Unlock()
 	mp.curTSLk.Unlock()
 
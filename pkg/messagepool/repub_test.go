@@ -8,10 +8,13 @@ import (
 	"github.com/filecoin-project/go-address"
 	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
 	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/messagepool/gasguess"
 	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
 func TestRepubMessages(t *testing.T) {
@@ -23,10 +26,13 @@ func TestRepubMessages(t *testing.T) {
 		RepublishBatchDelay = oldRepublishBatchDelay
 	}()
 
-	tma := newTestMpoolAPI()
+	tma := NewMockProvider()
 	ds := datastore.NewMapDatastore()
 
-	mp, err := New(context.Background(), tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
+	// republish the messages right after they are pushed
+	mpoolCfg := *config.DefaultMessagePoolParam
+	mpoolCfg.RebroadcastMinDelay = 0
+	mp, err := New(context.Background(), tma, nil, ds, config.NewDefaultConfig().NetworkParams, &mpoolCfg, "mptest", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -47,7 +53,7 @@ func TestRepubMessages(t *testing.T) {
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 
-	tma.setBalance(a1, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
 
 	for i := 0; i < 10; i++ {
 		m := makeTestMessage(w1, a1, a2, uint64(i), gasLimit, uint64(i+1))
@@ -57,14 +63,63 @@ func TestRepubMessages(t *testing.T) {
 		}
 	}
 
-	if tma.published != 10 {
-		t.Fatalf("expected to have published 10 messages, but got %d instead", tma.published)
+	if tma.Published() != 10 {
+		t.Fatalf("expected to have published 10 messages, but got %d instead", tma.Published())
 	}
 
 	mp.repubTrigger <- struct{}{}
 	time.Sleep(100 * time.Millisecond)
 
-	if tma.published != 20 {
-		t.Fatalf("expected to have published 20 messages, but got %d instead", tma.published)
+	if tma.Published() != 20 {
+		t.Fatalf("expected to have published 20 messages, but got %d instead", tma.Published())
 	}
 }
+
+func TestRepubMinDelay(t *testing.T) {
+	tf.UnitTest(t)
+
+	oldRepublishBatchDelay := RepublishBatchDelay
+	RepublishBatchDelay = time.Microsecond
+	defer func() {
+		RepublishBatchDelay = oldRepublishBatchDelay
+	}()
+
+	tma := NewMockProvider()
+	w, mp := newWalletAndMpool(t, tma)
+	defer mp.Close() // nolint
+
+	ctx := context.Background()
+	a1, err := w.NewAddress(ctx, address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a2 := mkAddress(1001)
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+
+	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
+	push := func(nonce uint64) {
+		if _, err := mp.Push(ctx, makeTestMessage(w, a1, a2, nonce, gasLimit, nonce+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := uint64(0); i < 3; i++ {
+		push(i)
+	}
+	assert.Equal(t, 3, tma.Published())
+
+	// not pending for RebroadcastMinDelay epochs yet
+	assert.NoError(t, mp.republishPendingMessages(ctx))
+	assert.Equal(t, 3, tma.Published())
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, tma.ApplyBlock(tma.NextBlock()))
+	}
+	push(3)
+	assert.Equal(t, 4, tma.Published())
+
+	// the old messages are republished, the new one is left to its first publication
+	assert.NoError(t, mp.republishPendingMessages(ctx))
+	assert.Equal(t, 7, tma.Published())
+	assert.Len(t, mp.republished, 3)
+	assert.Len(t, mp.localPendingSince, 4)
+}
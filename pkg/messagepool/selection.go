@@ -38,6 +38,36 @@ type msgChain struct {
 	sigType      crypto.SigType
 }
 
+// BestGasLimit returns the gas left in a block built on the current head after the messages the pool would
+// select for it, senders can size the gas limit of a message to fill it. The result is cached until the head
+// changes.
+func (mp *MessagePool) BestGasLimit(ctx context.Context) (int64, error) {
+	mp.curTSLk.Lock()
+	ts := mp.curTS
+	mp.curTSLk.Unlock()
+
+	mp.bestGasLimitLk.Lock()
+	defer mp.bestGasLimitLk.Unlock()
+
+	if mp.bestGasLimitTS.Equals(ts.Key()) {
+		return mp.bestGasLimit, nil
+	}
+
+	msgs, err := mp.SelectMessages(ctx, ts, 1.0)
+	if err != nil {
+		return 0, fmt.Errorf("selecting messages: %w", err)
+	}
+
+	gasLimit := int64(constants.BlockGasLimit)
+	for _, m := range msgs {
+		gasLimit -= m.Message.GasLimit
+	}
+
+	mp.bestGasLimitTS = ts.Key()
+	mp.bestGasLimit = gasLimit
+	return gasLimit, nil
+}
+
 func (mp *MessagePool) SelectMessages(ctx context.Context, ts *types.TipSet, tq float64) ([]*types.SignedMessage, error) {
 	mp.curTSLk.Lock()
 	defer mp.curTSLk.Unlock()
@@ -65,8 +65,8 @@ func makeTestMessage(w *wallet.Wallet, from, to address.Address, nonce uint64, g
 	}
 }
 
-func makeTestMpool() (*MessagePool, *testMpoolAPI) {
-	tma := newTestMpoolAPI()
+func makeTestMpool() (*MessagePool, *MockProvider) {
+	tma := NewMockProvider()
 	ds := datastore.NewMapDatastore()
 	mp, err := New(context.Background(), tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "test", nil)
 	if err != nil {
@@ -94,12 +94,12 @@ func TestMessageChains(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 
-	tma.setBalance(a1, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
 
 	// test chain aggregations
 
@@ -284,7 +284,7 @@ func TestMessageChains(t *testing.T) {
 	}
 
 	// test5: insufficient balance for all messages
-	tma.setBalanceRaw(a1, tbig.NewInt(300*gasLimit+1))
+	tma.SetBalance(a1, tbig.NewInt(300*gasLimit+1))
 
 	mset = make(map[uint64]*types.SignedMessage)
 	for i := 0; i < 10; i++ {
@@ -325,14 +325,14 @@ func TestMessageChainSkipping(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 	baseFee := tbig.NewInt(0)
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setStateNonce(a1, 10)
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetStateNonce(a1, 10)
 
 	mset := make(map[uint64]*types.SignedMessage)
 	for i := 0; i < 20; i++ {
@@ -394,14 +394,14 @@ func TestBasicMessageSelection(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
-	tma.applyBlock(t, block)
+	require.NoError(t, tma.ApplyBlock(block))
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setBalance(a2, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
 
 	// we create 10 messages from each actor to another, with the first actor paying higher
 	// gas prices than the second; we expect message selection to order his messages first
@@ -447,9 +447,9 @@ func TestBasicMessageSelection(t *testing.T) {
 	}
 
 	// now we make a block with all the messages and advance the chain
-	block2 := tma.nextBlock()
-	tma.setBlockMessages(block2, msgs...)
-	tma.applyBlock(t, block2)
+	block2 := tma.NextBlock()
+	tma.SetBlockMessages(block2, msgs...)
+	require.NoError(t, tma.ApplyBlock(block2))
 
 	// we should have no pending messages in the mpool
 	pend, _ := mp.Pending(context.TODO())
@@ -465,8 +465,8 @@ func TestBasicMessageSelection(t *testing.T) {
 		m = makeTestMessage(w2, a2, a1, uint64(i), gasLimit, uint64(i+1))
 		msgs = append(msgs, m)
 	}
-	block3 := tma.nextBlock()
-	tma.setBlockMessages(block3, msgs...)
+	block3 := tma.NextBlock()
+	tma.SetBlockMessages(block3, msgs...)
 	ts3 := mkTipSet(block3)
 
 	// now create another set of messages and add them to the mpool
@@ -480,8 +480,8 @@ func TestBasicMessageSelection(t *testing.T) {
 	// select messages in the last tipset; this should include the missed messages as well as
 	// the last messages we added, with the first actor's messages first
 	// first we need to update the nonce on the tma
-	tma.setStateNonce(a1, 10)
-	tma.setStateNonce(a2, 10)
+	tma.SetStateNonce(a1, 10)
+	tma.SetStateNonce(a2, 10)
 
 	msgs, err = mp.SelectMessages(context.Background(), ts3, 1.0)
 	if err != nil {
@@ -530,14 +530,14 @@ func TestMessageSelectionTrimmingGas(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
-	tma.applyBlock(t, block)
+	require.NoError(t, tma.ApplyBlock(block))
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setBalance(a2, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
 
 	// make many small chains for the two actors
 	nMessages := int((constants.BlockGasLimit / gasLimit) + 1)
@@ -568,6 +568,43 @@ func TestMessageSelectionTrimmingGas(t *testing.T) {
 	}
 }
 
+func TestBestGasLimit(t *testing.T) {
+	tf.UnitTest(t)
+
+	mp, tma := makeTestMpool()
+	ctx := context.Background()
+
+	w1 := newWallet(t)
+	a1, err := w1.NewAddress(ctx, address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a2 := mkAddress(1001)
+
+	require.NoError(t, tma.ApplyBlock(tma.NextBlock()))
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+
+	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
+	for i := 0; i < 3; i++ {
+		mustAdd(t, mp, makeTestMessage(w1, a1, a2, uint64(i), gasLimit, uint64(i+1)))
+	}
+
+	best, err := mp.BestGasLimit(ctx)
+	require.NoError(t, err)
+	require.Equal(t, constants.BlockGasLimit-3*gasLimit, best)
+
+	// cached until the head changes
+	mustAdd(t, mp, makeTestMessage(w1, a1, a2, 3, gasLimit, 4))
+	best, err = mp.BestGasLimit(ctx)
+	require.NoError(t, err)
+	require.Equal(t, constants.BlockGasLimit-3*gasLimit, best)
+
+	require.NoError(t, tma.ApplyBlock(tma.NextBlock()))
+	best, err = mp.BestGasLimit(ctx)
+	require.NoError(t, err)
+	require.Equal(t, constants.BlockGasLimit-4*gasLimit, best)
+}
+
 func TestMessageSelectionTrimmingMsgsBasic(t *testing.T) {
 	mp, tma := makeTestMpool()
 
@@ -578,11 +615,11 @@ func TestMessageSelectionTrimmingMsgsBasic(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
-	tma.applyBlock(t, block)
+	require.NoError(t, tma.ApplyBlock(block))
 
-	tma.setBalance(a1, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
 
 	// create a larger than selectable chain
 	for i := 0; i < constants.BlockMessageLimit; i++ {
@@ -625,12 +662,12 @@ func TestMessageSelectionTrimmingMsgsTwoSendersBasic(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
-	tma.applyBlock(t, block)
+	require.NoError(t, tma.ApplyBlock(block))
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setBalance(a2, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
 
 	// create 2 larger than selectable chains
 	for i := 0; i < constants.BlockMessageLimit; i++ {
@@ -683,12 +720,12 @@ func TestMessageSelectionTrimmingMsgsTwoSendersAdvanced(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
-	tma.applyBlock(t, block)
+	require.NoError(t, tma.ApplyBlock(block))
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setBalance(a2, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
 
 	// create 2 almost max-length chains of equal value
 	i := 0
@@ -758,14 +795,14 @@ func TestPriorityMessageSelection(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
-	tma.applyBlock(t, block)
+	require.NoError(t, tma.ApplyBlock(block))
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setBalance(a2, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
 
 	mp.cfg.PriorityAddrs = []address.Address{a1}
 
@@ -831,14 +868,14 @@ func TestPriorityMessageSelection2(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
-	tma.applyBlock(t, block)
+	require.NoError(t, tma.ApplyBlock(block))
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setBalance(a2, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
 
 	mp.cfg.PriorityAddrs = []address.Address{a1}
 
@@ -894,18 +931,18 @@ func TestPriorityMessageSelection3(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
-	tma.applyBlock(t, block)
+	require.NoError(t, tma.ApplyBlock(block))
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setBalance(a2, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
 
 	mp.cfg.PriorityAddrs = []address.Address{a1}
 
-	tma.baseFee = tbig.NewInt(1000)
+	tma.SetBaseFee(tbig.NewInt(1000))
 	nMessages := 10
 	for i := 0; i < nMessages; i++ {
 		bias := (nMessages - i) / 3
@@ -984,14 +1021,14 @@ func TestOptimalMessageSelection1(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
-	tma.applyBlock(t, block)
+	require.NoError(t, tma.ApplyBlock(block))
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setBalance(a2, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
 
 	nMessages := int(10 * constants.BlockGasLimit / gasLimit)
 	for i := 0; i < nMessages; i++ {
@@ -1045,14 +1082,14 @@ func TestOptimalMessageSelection2(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
-	tma.applyBlock(t, block)
+	require.NoError(t, tma.ApplyBlock(block))
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 
-	tma.setBalance(a1, 1) // in FIL
-	tma.setBalance(a2, 1) // in FIL
+	tma.SetBalance(a1, types.FromFil(1)) // in FIL
+	tma.SetBalance(a2, types.FromFil(1)) // in FIL
 
 	nMessages := int(5 * constants.BlockGasLimit / gasLimit)
 	for i := 0; i < nMessages; i++ {
@@ -1122,14 +1159,14 @@ func TestOptimalMessageSelection3(t *testing.T) {
 		wallets = append(wallets, w)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
-	tma.applyBlock(t, block)
+	require.NoError(t, tma.ApplyBlock(block))
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 
 	for _, a := range actors {
-		tma.setBalance(a, 1) // in FIL
+		tma.SetBalance(a, types.FromFil(1)) // in FIL
 	}
 
 	nMessages := int(constants.BlockGasLimit/gasLimit) + 1
@@ -1199,15 +1236,15 @@ func testCompetitiveMessageSelection(t *testing.T, rng *rand.Rand, getPremium fu
 		wallets = append(wallets, w)
 	}
 
-	block := tma.nextBlock()
+	block := tma.NextBlock()
 	ts := mkTipSet(block)
-	tma.applyBlock(t, block)
+	require.NoError(t, tma.ApplyBlock(block))
 
 	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
 	baseFee := tbig.NewInt(0)
 
 	for _, a := range actors {
-		tma.setBalance(a, 1) // in FIL
+		tma.SetBalance(a, types.FromFil(1)) // in FIL
 	}
 
 	nMessages := int(10 * constants.BlockGasLimit / gasLimit)
@@ -1512,15 +1549,15 @@ readLoop:
 
 	mp, tma := makeTestMpool()
 
-	block := tma.nextBlockWithHeight(UpgradeBreezeHeight + 10)
+	block := tma.NextBlockWithHeight(UpgradeBreezeHeight + 10)
 	ts := mkTipSet(block)
-	tma.applyBlock(t, block)
+	require.NoError(t, tma.ApplyBlock(block))
 
 	for _, a := range actorMap {
-		tma.setBalance(a, 1000000)
+		tma.SetBalance(a, types.FromFil(1000000))
 	}
 
-	tma.baseFee = tbig.NewInt(800_000_000)
+	tma.SetBaseFee(tbig.NewInt(800_000_000))
 
 	sort.Slice(msgs, func(i, j int) bool {
 		return msgs[i].Message.Nonce < msgs[j].Message.Nonce
@@ -0,0 +1,107 @@
+package messagepool
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/ipfs/go-datastore"
+
+	"github.com/filecoin-project/venus/pkg/consensus"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/utils"
+)
+
+// firstExportedMethodNum is the first FRC-42 method number, these methods may be handled by user code and are not
+// checked against the methods of the builtin actors
+const firstExportedMethodNum = abi.MethodNum(1 << 24)
+
+// OnNetworkUpgrade re-validates the pending messages against the rules of newVersion activated at epoch
+// and removes the ones that can no longer be included in a block: those failing the block inclusion checks,
+// those of senders that are not valid top-level senders anymore and those calling a method the builtin
+// receiver does not export after the actors migration.
+func (mp *MessagePool) OnNetworkUpgrade(ctx context.Context, newVersion network.Version, epoch abi.ChainEpoch) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	pricelist := mp.gasPriceSchedule.PricelistByEpoch(epoch)
+	av, err := actorstypes.VersionForNetwork(newVersion)
+	checkMethods := err == nil
+	if !checkMethods {
+		log.Warnf("no actors version for network version %d, methods of pending messages are not checked: %s", newVersion, err)
+	}
+
+	type pendingMsg struct {
+		from  address.Address
+		nonce uint64
+	}
+	var invalid []pendingMsg
+	mp.forEachPending(func(from address.Address, mset *msgSet) {
+		var senderErr error
+		if senderAct, err := mp.api.GetActorAfter(ctx, from, mp.curTS); err == nil && !consensus.IsValidForSending(newVersion, senderAct) {
+			senderErr = fmt.Errorf("sender actor %s is not a valid top-level sender", from)
+		}
+
+		for nonce, m := range mset.msgs {
+			err := senderErr
+			if err == nil {
+				minGas := pricelist.OnChainMessage(m.ChainLength())
+				err = m.VMMessage().ValidForBlockInclusion(minGas.Total(), newVersion)
+			}
+			if err == nil && checkMethods {
+				err = mp.checkMethodAfterUpgrade(ctx, m.VMMessage(), av)
+			}
+			if err != nil {
+				log.Infow("removing message invalid after network upgrade", "cid", m.Cid(), "from", m.Message.From,
+					"nonce", nonce, "version", newVersion, "reason", err)
+				invalid = append(invalid, pendingMsg{from: from, nonce: nonce})
+			}
+		}
+	})
+
+	for _, pm := range invalid {
+		isLocal, err := mp.isLocal(ctx, pm.from)
+		if err != nil {
+			log.Warnf("determining isLocal: %s", err)
+		}
+		if mset, ok, err := mp.getPendingMset(ctx, pm.from); err == nil && ok {
+			if m, ok := mset.msgs[pm.nonce]; ok {
+				if isLocal {
+					if err := mp.localMsgs.Delete(ctx, datastore.NewKey(string(m.Cid().Bytes()))); err != nil {
+						log.Warnf("error deleting local message: %s", err)
+					}
+				}
+				delete(mp.republished, m.Cid())
+			}
+		}
+		mp.remove(ctx, pm.from, pm.nonce, false)
+	}
+}
+
+// checkMethodAfterUpgrade checks that the builtin actor m is sent to still exports its method once it is migrated to
+// the actors of av. Plain sends, FRC-42 methods and receivers that don't exist yet or aren't builtin actors are not
+// checked.
+func (mp *MessagePool) checkMethodAfterUpgrade(ctx context.Context, m *types.Message, av actorstypes.Version) error {
+	if m.Method == builtin.MethodSend || m.Method >= firstExportedMethodNum {
+		return nil
+	}
+	act, err := mp.api.GetActorAfter(ctx, m.To, mp.curTS)
+	if err != nil || !builtin.IsBuiltinActor(act.Code) {
+		return nil
+	}
+
+	name := actors.CanonicalName(builtin.ActorNameByCode(act.Code))
+	code, ok := actors.GetActorCodeID(av, name)
+	if !ok {
+		return fmt.Errorf("receiver actor %s does not exist in actors version %d", name, av)
+	}
+	if _, ok := utils.MethodsMap[code][m.Method]; !ok {
+		return fmt.Errorf("method %d is not exported by the %s actor in actors version %d", m.Method, name, av)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package messagepool
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// MessageValidator is an external check, e.g. a compliance filter, run on every message before it is
+// accepted into the pool
+type MessageValidator interface {
+	// ID identifies the validator for RemoveValidator
+	ID() string
+	// Validate returns a non-nil error to reject msg
+	Validate(ctx context.Context, msg *types.SignedMessage) error
+}
+
+// AddValidator registers v after the others, replacing the validator with the same id if any
+func (mp *MessagePool) AddValidator(v MessageValidator) {
+	mp.validatorsLk.Lock()
+	defer mp.validatorsLk.Unlock()
+
+	// runValidators iterates a snapshot of the slice, so it is never modified in place
+	validators := make([]MessageValidator, 0, len(mp.validators)+1)
+	for _, old := range mp.validators {
+		if old.ID() != v.ID() {
+			validators = append(validators, old)
+		}
+	}
+	mp.validators = append(validators, v)
+}
+
+// RemoveValidator unregisters the validator with the given id
+func (mp *MessagePool) RemoveValidator(id string) {
+	mp.validatorsLk.Lock()
+	defer mp.validatorsLk.Unlock()
+
+	for i, v := range mp.validators {
+		if v.ID() == id {
+			mp.validators = append(mp.validators[:i:i], mp.validators[i+1:]...)
+			return
+		}
+	}
+}
+
+// runValidators calls the registered validators in order, the first error rejects m
+func (mp *MessagePool) runValidators(ctx context.Context, m *types.SignedMessage) error {
+	mp.validatorsLk.RLock()
+	validators := mp.validators
+	mp.validatorsLk.RUnlock()
+
+	for _, v := range validators {
+		if err := v.Validate(ctx, m); err != nil {
+			return fmt.Errorf("message rejected by validator %s: %w", v.ID(), err)
+		}
+	}
+
+	return nil
+}
@@ -17,6 +17,16 @@ func NewTimerMs(name, desc string, tagKeys ...tag.Key) *Float64Timer {
 	return NewTimerWithBuckets(name, desc, stats.UnitMilliseconds, defaultBounds, tagKeys...)
 }
 
+// NewTimerUs creates a Float64Timer with units of microseconds and a default set of aggregation
+// bounds for latencies up to a hundred milliseconds.
+func NewTimerUs(name, desc string, tagKeys ...tag.Key) *Float64Timer {
+	// [>=0us, >=10us, >=25us, >=50us, >=100us, >=250us, >=500us, >=1ms, >=2.5ms, >=5ms, >=10ms, >=25ms, >=50ms, >=100ms]
+	defaultBounds := []float64{10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 25000, 50000, 100000}
+	t := NewTimerWithBuckets(name, desc, "us", defaultBounds, tagKeys...)
+	t.unit = time.Microsecond
+	return t
+}
+
 // NewTimerWithBuckets creates a Float64Timer wrapping an opencensus float64 measurement.
 func NewTimerWithBuckets(name, desc, unit string, bounds []float64, tagKeys ...tag.Key) *Float64Timer {
 	log.Infof("registering timer: %s - %s", name, desc)
@@ -38,6 +48,7 @@ func NewTimerWithBuckets(name, desc, unit string, bounds []float64, tagKeys ...t
 	return &Float64Timer{
 		measureMs: fMeasure,
 		view:      fView,
+		unit:      time.Millisecond,
 	}
 }
 
@@ -45,6 +56,8 @@ func NewTimerWithBuckets(name, desc, unit string, bounds []float64, tagKeys ...t
 type Float64Timer struct {
 	measureMs *stats.Float64Measure
 	view      *view.View
+	// unit is the duration the recorded values are counted in
+	unit time.Duration
 }
 
 // Start starts a timer and returns a Stopwatch.
@@ -52,6 +65,7 @@ func (t *Float64Timer) Start(ctx context.Context) *Stopwatch {
 	return &Stopwatch{
 		ctx:      ctx,
 		start:    time.Now(),
+		unit:     t.unit,
 		recorder: t.measureMs.M,
 	}
 }
@@ -61,13 +75,14 @@ func (t *Float64Timer) Start(ctx context.Context) *Stopwatch {
 type Stopwatch struct {
 	ctx      context.Context
 	start    time.Time
+	unit     time.Duration
 	recorder func(v float64) stats.Measurement
 }
 
-// Stop rounds the time since Start was called to milliseconds and records the value
-// in the corresponding opencensus view.
+// Stop rounds the time since Start was called to the unit of the timer, milliseconds unless
+// created with NewTimerUs, and records the value in the corresponding opencensus view.
 func (sw *Stopwatch) Stop(ctx context.Context) time.Duration {
-	duration := time.Since(sw.start).Round(time.Millisecond)
-	stats.Record(ctx, sw.recorder(float64(duration)/1e6))
+	duration := time.Since(sw.start).Round(sw.unit)
+	stats.Record(ctx, sw.recorder(float64(duration)/float64(sw.unit)))
 	return duration
 }
@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"testing"
+	"time"
 
 	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
 	"github.com/stretchr/testify/assert"
@@ -27,6 +28,22 @@ func TestTimerSimple(t *testing.T) {
 	assert.NotEqual(t, 0, sw.start)
 }
 
+func TestTimerUs(t *testing.T) {
+	tf.BadUnitTestWithSideEffects(t)
+
+	ctx := context.Background()
+
+	testTimer := NewTimerUs("testNameUs", "testDesc")
+	defer view.Unregister(testTimer.view)
+
+	sw := testTimer.Start(ctx)
+	time.Sleep(time.Millisecond)
+	d := sw.Stop(ctx)
+	assert.Equal(t, time.Microsecond, sw.unit)
+	assert.Equal(t, d.Round(time.Microsecond), d)
+	assert.GreaterOrEqual(t, d, time.Millisecond)
+}
+
 func TestDuplicateTimersPanics(t *testing.T) {
 	tf.BadUnitTestWithSideEffects(t)
 
@@ -0,0 +1,231 @@
+package net
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+	manet "github.com/multiformats/go-multiaddr/net"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// blockRules are the parsed entries of a block list
+type blockRules struct {
+	peers   []peer.ID
+	ips     []net.IP
+	subnets []*net.IPNet
+}
+
+func parseBlockList(acl types.NetBlockList) (*blockRules, error) {
+	rules := &blockRules{peers: acl.Peers}
+	for _, addr := range acl.IPAddrs {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return nil, fmt.Errorf("error parsing IP address %s", addr)
+		}
+		rules.ips = append(rules.ips, ip)
+	}
+	for _, subnet := range acl.IPSubnets {
+		_, cidr, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing subnet %s: %w", subnet, err)
+		}
+		rules.subnets = append(rules.subnets, cidr)
+	}
+	return rules, nil
+}
+
+// NewBlockListGater creates a connection gater blocking the entries of the block list file at path.
+// The file is created by the first change of the block list, an empty path keeps the block list in memory only.
+func NewBlockListGater(path string) (*conngater.BasicConnectionGater, error) {
+	gater, err := conngater.NewBasicConnectionGater(nil)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return gater, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return gater, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read net block list: %w", err)
+	}
+	var acl types.NetBlockList
+	if err := json.Unmarshal(data, &acl); err != nil {
+		return nil, fmt.Errorf("failed to decode net block list %s: %w", path, err)
+	}
+	rules, err := parseBlockList(acl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid net block list %s: %w", path, err)
+	}
+
+	for _, p := range rules.peers {
+		if err := gater.BlockPeer(p); err != nil {
+			return nil, err
+		}
+	}
+	for _, ip := range rules.ips {
+		if err := gater.BlockAddr(ip); err != nil {
+			return nil, err
+		}
+	}
+	for _, cidr := range rules.subnets {
+		if err := gater.BlockSubnet(cidr); err != nil {
+			return nil, err
+		}
+	}
+	return gater, nil
+}
+
+// BlockAdd blocks the peers, IP addresses and subnets of acl and closes the existing connections to them
+func (network *Network) BlockAdd(acl types.NetBlockList) error {
+	rules, err := parseBlockList(acl)
+	if err != nil {
+		return err
+	}
+
+	network.blockListLk.Lock()
+	defer network.blockListLk.Unlock()
+
+	for _, p := range rules.peers {
+		if err := network.gater.BlockPeer(p); err != nil {
+			return fmt.Errorf("error blocking peer %s: %w", p, err)
+		}
+
+		for _, c := range network.host.Network().ConnsToPeer(p) {
+			if err := c.Close(); err != nil {
+				// just log this, don't fail
+				netLog.Warnf("error closing connection to blocked peer %s: %s", p, err)
+			}
+		}
+	}
+
+	for _, ip := range rules.ips {
+		ip := ip
+		if err := network.gater.BlockAddr(ip); err != nil {
+			return fmt.Errorf("error blocking IP address %s: %w", ip, err)
+		}
+
+		network.closeConns(func(remote net.IP) bool { return ip.Equal(remote) })
+	}
+
+	for _, cidr := range rules.subnets {
+		if err := network.gater.BlockSubnet(cidr); err != nil {
+			return fmt.Errorf("error blocking subnet %s: %w", cidr, err)
+		}
+
+		network.closeConns(cidr.Contains)
+	}
+
+	return network.saveBlockList()
+}
+
+// BlockRemove unblocks the peers, IP addresses and subnets of acl
+func (network *Network) BlockRemove(acl types.NetBlockList) error {
+	rules, err := parseBlockList(acl)
+	if err != nil {
+		return err
+	}
+
+	network.blockListLk.Lock()
+	defer network.blockListLk.Unlock()
+
+	for _, p := range rules.peers {
+		if err := network.gater.UnblockPeer(p); err != nil {
+			return fmt.Errorf("error unblocking peer %s: %w", p, err)
+		}
+	}
+
+	for _, ip := range rules.ips {
+		if err := network.gater.UnblockAddr(ip); err != nil {
+			return fmt.Errorf("error unblocking IP address %s: %w", ip, err)
+		}
+	}
+
+	for _, cidr := range rules.subnets {
+		if err := network.gater.UnblockSubnet(cidr); err != nil {
+			return fmt.Errorf("error unblocking subnet %s: %w", cidr, err)
+		}
+	}
+
+	return network.saveBlockList()
+}
+
+// BlockList returns the blocked peers, IP addresses and subnets
+func (network *Network) BlockList() (types.NetBlockList, error) {
+	var acl types.NetBlockList
+	acl.Peers = network.gater.ListBlockedPeers()
+	for _, ip := range network.gater.ListBlockedAddrs() {
+		acl.IPAddrs = append(acl.IPAddrs, ip.String())
+	}
+	for _, cidr := range network.gater.ListBlockedSubnets() {
+		acl.IPSubnets = append(acl.IPSubnets, cidr.String())
+	}
+
+	return acl, nil
+}
+
+// saveBlockList writes the block list to its file, replacing the previous one once it is complete
+func (network *Network) saveBlockList() error {
+	if network.blockListPath == "" {
+		return nil
+	}
+
+	acl, err := network.BlockList()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(acl, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(network.blockListPath), filepath.Base(network.blockListPath)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to save net block list: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // nolint: errcheck
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to save net block list: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save net block list: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), network.blockListPath); err != nil {
+		return fmt.Errorf("failed to save net block list: %w", err)
+	}
+	return nil
+}
+
+func (network *Network) closeConns(blocked func(net.IP) bool) {
+	for _, c := range network.host.Network().Conns() {
+		if closeIfBlocked(c, blocked) {
+			netLog.Infof("closed connection to blocked address %s", c.RemoteMultiaddr())
+		}
+	}
+}
+
+func closeIfBlocked(c network.Conn, blocked func(net.IP) bool) bool {
+	remote, err := manet.ToIP(c.RemoteMultiaddr())
+	if err != nil || !blocked(remote) {
+		return false
+	}
+
+	if err := c.Close(); err != nil {
+		// just log this, don't fail
+		netLog.Warnf("error closing connection to blocked address %s: %s", c.RemoteMultiaddr(), err)
+	}
+
+	return true
+}
@@ -0,0 +1,117 @@
+// stm: #unit
+package net_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/metrics"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vnet "github.com/filecoin-project/venus/pkg/net"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestBlockListPersistence(t *testing.T) {
+	tf.UnitTest(t)
+
+	mn, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	defer mn.Close() // nolint: errcheck
+	a, b := mn.Hosts()[0], mn.Hosts()[1]
+
+	path := filepath.Join(t.TempDir(), "netblocklist.json")
+	// there is no file before the first change
+	gater, err := vnet.NewBlockListGater(path)
+	require.NoError(t, err)
+	n := vnet.New(a, nil, nil, metrics.NewBandwidthCounter(), gater, path, nil)
+	defer n.Close() // nolint: errcheck
+	_, err = os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	other, err := peer.Decode("12D3KooWGzxzKZYveHXtpG6AsrUJBcWxHBFS2HsEoGTxrMLvKXtf")
+	require.NoError(t, err)
+	require.NoError(t, n.BlockAdd(types.NetBlockList{
+		Peers:     []peer.ID{b.ID(), other},
+		IPAddrs:   []string{"1.2.3.4", "fd00::1"},
+		IPSubnets: []string{"10.0.0.0/8"},
+	}))
+	// the connection to the blocked peer is closed
+	assert.NotEqual(t, network.Connected, a.Network().Connectedness(b.ID()))
+
+	reload := func() types.NetBlockList {
+		gater, err := vnet.NewBlockListGater(path)
+		require.NoError(t, err)
+		reloaded := vnet.New(a, nil, nil, metrics.NewBandwidthCounter(), gater, path, nil)
+		defer reloaded.Close() // nolint: errcheck
+		acl, err := reloaded.BlockList()
+		require.NoError(t, err)
+		return acl
+	}
+	acl := reload()
+	assert.ElementsMatch(t, []peer.ID{b.ID(), other}, acl.Peers)
+	assert.ElementsMatch(t, []string{"1.2.3.4", "fd00::1"}, acl.IPAddrs)
+	assert.ElementsMatch(t, []string{"10.0.0.0/8"}, acl.IPSubnets)
+
+	// the reloaded rules are enforced
+	gater, err = vnet.NewBlockListGater(path)
+	require.NoError(t, err)
+	assert.False(t, gater.InterceptPeerDial(other))
+	assert.True(t, gater.InterceptPeerDial(a.ID()))
+
+	require.NoError(t, n.BlockRemove(types.NetBlockList{Peers: []peer.ID{b.ID()}, IPAddrs: []string{"1.2.3.4"}}))
+	acl = reload()
+	assert.ElementsMatch(t, []peer.ID{other}, acl.Peers)
+	assert.ElementsMatch(t, []string{"fd00::1"}, acl.IPAddrs)
+	assert.ElementsMatch(t, []string{"10.0.0.0/8"}, acl.IPSubnets)
+
+	// an invalid entry rejects the whole change
+	require.Error(t, n.BlockAdd(types.NetBlockList{Peers: []peer.ID{b.ID()}, IPAddrs: []string{"not an ip"}}))
+	require.Error(t, n.BlockAdd(types.NetBlockList{IPSubnets: []string{"10.0.0.0/33"}}))
+	current, err := n.BlockList()
+	require.NoError(t, err)
+	assert.Equal(t, acl.Peers, current.Peers)
+	assert.Equal(t, acl, reload())
+
+	// the file is plain json
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"IPSubnets": [`)
+	assert.Contains(t, string(data), "10.0.0.0/8")
+}
+
+func TestBlockListGaterFile(t *testing.T) {
+	tf.UnitTest(t)
+
+	// an empty path keeps the block list in memory
+	mn, err := mocknet.WithNPeers(1)
+	require.NoError(t, err)
+	defer mn.Close() // nolint: errcheck
+	gater, err := vnet.NewBlockListGater("")
+	require.NoError(t, err)
+	n := vnet.New(mn.Hosts()[0], nil, nil, metrics.NewBandwidthCounter(), gater, "", nil)
+	defer n.Close() // nolint: errcheck
+	require.NoError(t, n.BlockAdd(types.NetBlockList{IPAddrs: []string{"1.2.3.4"}}))
+	blocked, err := manet.FromIP(net.ParseIP("1.2.3.4"))
+	require.NoError(t, err)
+	assert.False(t, gater.InterceptAddrDial(mn.Hosts()[0].ID(), blocked))
+
+	dir := t.TempDir()
+	corrupted := filepath.Join(dir, "corrupted.json")
+	require.NoError(t, os.WriteFile(corrupted, []byte("{"), 0o644))
+	_, err = vnet.NewBlockListGater(corrupted)
+	assert.Error(t, err)
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"IPAddrs": ["not an ip"]}`), 0o644))
+	_, err = vnet.NewBlockListGater(invalid)
+	assert.Error(t, err)
+}
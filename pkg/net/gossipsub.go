@@ -47,6 +47,7 @@ func NewGossipSub(ctx context.Context,
 	networkName string,
 	drandSchedule map[abi.ChainEpoch]config.DrandEnum,
 	bootNodes []peer.AddrInfo,
+	seenMessagesTTL time.Duration,
 ) (*pubsub.PubSub, error) {
 	bootstrappers := make(map[peer.ID]struct{})
 	for _, info := range bootNodes {
@@ -315,6 +316,11 @@ func NewGossipSub(ctx context.Context,
 				pubsub.NewAllowlistSubscriptionFilter(allowTopics...),
 				100)))
 
+	// zero keeps the libp2p default
+	if seenMessagesTTL > 0 {
+		options = append(options, pubsub.WithSeenMessagesTTL(seenMessagesTTL))
+	}
+
 	return pubsub.NewGossipSub(ctx, h, options...)
 }
 
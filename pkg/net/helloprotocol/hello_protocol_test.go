@@ -197,7 +197,7 @@ func mockPeerMgr(ctx context.Context, t *testing.T, h host.Host) (*peermgr.PeerM
 	addrInfo, err := net.ParseAddresses(ctx, repo.NewInMemoryRepo().Config().Bootstrap.Addresses)
 	require.NoError(t, err)
 
-	return peermgr.NewPeerMgr(h, dht.NewDHT(ctx, h, ds.NewMapDatastore()), 10, addrInfo)
+	return peermgr.NewPeerMgr(h, dht.NewDHT(ctx, h, ds.NewMapDatastore()), 10, addrInfo, 0, 0)
 }
 
 func copyStoreAndSetHead(ctx context.Context, t *testing.T, store *chain.Store, ts *types.TipSet) *chain.Store {
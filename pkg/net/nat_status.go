@@ -0,0 +1,123 @@
+package net
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/host"
+	network2 "github.com/libp2p/go-libp2p/core/network"
+	basichost "github.com/libp2p/go-libp2p/p2p/host/basic"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// natTracker records the reachability and NAT device type events emitted by autonat and identify
+type natTracker struct {
+	lk            sync.Mutex
+	reachability  network2.Reachability
+	natTypes      map[network2.NATTransportProtocol]network2.NATDeviceType
+	lastDiscovery time.Time
+	// sub is nil if the subscription to the events failed
+	sub event.Subscription
+}
+
+func newNatTracker(h host.Host) *natTracker {
+	t := &natTracker{natTypes: make(map[network2.NATTransportProtocol]network2.NATDeviceType)}
+
+	sub, err := h.EventBus().Subscribe([]interface{}{
+		new(event.EvtLocalReachabilityChanged),
+		new(event.EvtNATDeviceTypeChanged),
+	})
+	if err != nil {
+		netLog.Warnf("failed to subscribe to nat events: %s", err)
+		return t
+	}
+
+	t.sub = sub
+	go func() {
+		for evt := range sub.Out() {
+			t.record(evt)
+		}
+	}()
+
+	return t
+}
+
+// close ends the subscription to the events, which stops the goroutine recording them
+func (t *natTracker) close() error {
+	if t.sub == nil {
+		return nil
+	}
+	return t.sub.Close()
+}
+
+func (t *natTracker) record(evt interface{}) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	switch e := evt.(type) {
+	case event.EvtLocalReachabilityChanged:
+		t.reachability = e.Reachability
+	case event.EvtNATDeviceTypeChanged:
+		t.natTypes[e.TransportProtocol] = e.NatDeviceType
+	default:
+		return
+	}
+	t.lastDiscovery = time.Now()
+}
+
+// natType prefers the device type found for TCP, which most connections use
+func (t *natTracker) natType() network2.NATDeviceType {
+	if nt := t.natTypes[network2.NATTransportTCP]; nt != network2.NATDeviceTypeUnknown {
+		return nt
+	}
+	return t.natTypes[network2.NATTransportUDP]
+}
+
+// NATStatus returns the reachability, public addresses and NAT type of the node
+func (network *Network) NATStatus() (*types.NATStatus, error) {
+	network.nat.lk.Lock()
+	status := &types.NATStatus{
+		Reachability:  network.nat.reachability,
+		NATType:       network.nat.natType().String(),
+		LastDiscovery: network.nat.lastDiscovery,
+	}
+	network.nat.lk.Unlock()
+
+	var candidates []ma.Multiaddr
+	if bh, ok := network.rawHost.(*basichost.BasicHost); ok {
+		if autonat := bh.GetAutoNat(); autonat != nil {
+			status.Reachability = autonat.Status()
+			if status.Reachability == network2.ReachabilityPublic {
+				if pa, err := autonat.PublicAddr(); err == nil {
+					candidates = append(candidates, pa)
+				}
+			}
+		}
+		if ids := bh.IDService(); ids != nil {
+			candidates = append(candidates, ids.OwnObservedAddrs()...)
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for _, addr := range candidates {
+		if !manet.IsPublicAddr(addr) {
+			continue
+		}
+		if _, ok := seen[addr.String()]; ok {
+			continue
+		}
+		seen[addr.String()] = struct{}{}
+		status.PublicAddresses = append(status.PublicAddresses, addr)
+	}
+
+	return status, nil
+}
+
+// Close stops tracking the NAT events
+func (network *Network) Close() error {
+	return network.nat.close()
+}
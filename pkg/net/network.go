@@ -3,13 +3,16 @@ package net
 import (
 	"context"
 	"sort"
+	"sync"
 
+	logging "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/metrics"
 	network2 "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	basichost "github.com/libp2p/go-libp2p/p2p/host/basic"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
 	swarm "github.com/libp2p/go-libp2p/p2p/net/swarm"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
@@ -17,11 +20,21 @@ import (
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
+var netLog = logging.Logger("net")
+
 // Network is a unified interface for dealing with libp2p
 type Network struct {
 	host    host.Host
 	rawHost types.RawHost
+	gater   *conngater.BasicConnectionGater
+	// blockListPath is the file the block list of the gater is saved to
+	blockListPath string
+	blockListLk   sync.Mutex
 	metrics.Reporter
+	peerStats *peerStatsTracker
+	// tagWeights multiply the value of the tags set by TagPeer
+	tagWeights map[string]int
+	nat        *natTracker
 	*Router
 }
 
@@ -31,12 +44,27 @@ func New(
 	rawHost types.RawHost,
 	router *Router,
 	reporter metrics.Reporter,
+	gater *conngater.BasicConnectionGater,
+	blockListPath string,
+	tagWeights map[string]int,
 ) *Network {
+	peerStats := newPeerStatsTracker(reporter)
+	host.Network().Notify(peerStats.notifiee())
+	// peers may have connected while the host was set up
+	for _, p := range host.Network().Peers() {
+		peerStats.connected(p)
+	}
+
 	return &Network{
-		host:     host,
-		rawHost:  rawHost,
-		Reporter: reporter,
-		Router:   router,
+		host:          host,
+		rawHost:       rawHost,
+		gater:         gater,
+		blockListPath: blockListPath,
+		Reporter:      reporter,
+		peerStats:     peerStats,
+		tagWeights:    tagWeights,
+		nat:           newNatTracker(host),
+		Router:        router,
 	}
 }
 
@@ -180,6 +208,23 @@ func (network *Network) ProtectList() ([]peer.ID, error) {
 	return result, nil
 }
 
+// TagPeer tags p with value in the connection manager, scaled by the weight configured for tag if any
+func (network *Network) TagPeer(p peer.ID, tag string, value int) error {
+	if weight, ok := network.tagWeights[tag]; ok {
+		value *= weight
+	}
+	network.host.ConnManager().TagPeer(p, tag, value)
+
+	return nil
+}
+
+// UntagPeer removes tag from p in the connection manager
+func (network *Network) UntagPeer(p peer.ID, tag string) error {
+	network.host.ConnManager().UntagPeer(p, tag)
+
+	return nil
+}
+
 // Connectedness returns a state signaling connection capabilities
 func (network *Network) Connectedness(p peer.ID) (network2.Connectedness, error) {
 	return network.host.Network().Connectedness(p), nil
@@ -0,0 +1,105 @@
+package net
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/metrics"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// peerSession is the time a peer connected and its bandwidth totals at that time,
+// the traffic of the session is the difference to the current totals
+type peerSession struct {
+	since time.Time
+	base  metrics.Stats
+}
+
+// peerStatsTracker keeps a session per connected peer, so that its counters restart on reconnect
+type peerStatsTracker struct {
+	lk       sync.Mutex
+	reporter metrics.Reporter
+	sessions map[peer.ID]*peerSession
+}
+
+func newPeerStatsTracker(reporter metrics.Reporter) *peerStatsTracker {
+	return &peerStatsTracker{
+		reporter: reporter,
+		sessions: make(map[peer.ID]*peerSession),
+	}
+}
+
+func (t *peerStatsTracker) notifiee() network.Notifiee {
+	return &network.NotifyBundle{
+		ConnectedF: func(_ network.Network, c network.Conn) {
+			t.connected(c.RemotePeer())
+		},
+		DisconnectedF: func(n network.Network, c network.Conn) {
+			// the session lasts until the last connection to the peer is closed
+			if n.Connectedness(c.RemotePeer()) != network.Connected {
+				t.disconnected(c.RemotePeer())
+			}
+		},
+	}
+}
+
+func (t *peerStatsTracker) connected(p peer.ID) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	if _, ok := t.sessions[p]; !ok {
+		t.sessions[p] = &peerSession{since: time.Now(), base: t.reporter.GetBandwidthForPeer(p)}
+	}
+}
+
+func (t *peerStatsTracker) disconnected(p peer.ID) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	delete(t.sessions, p)
+}
+
+func (t *peerStatsTracker) stats(p peer.ID) (types.PeerStats, bool) {
+	t.lk.Lock()
+	session, ok := t.sessions[p]
+	t.lk.Unlock()
+	if !ok {
+		return types.PeerStats{}, false
+	}
+
+	cur := t.reporter.GetBandwidthForPeer(p)
+	return types.PeerStats{
+		ID:             p,
+		BytesSent:      cur.TotalOut - session.base.TotalOut,
+		BytesRecv:      cur.TotalIn - session.base.TotalIn,
+		ConnectedSince: session.since,
+	}, true
+}
+
+// PeerStats returns the traffic exchanged with each connected peer since it connected,
+// the peers exchanging the most bytes first
+func (network *Network) PeerStats() ([]types.PeerStats, error) {
+	var out []types.PeerStats
+	for _, p := range network.host.Network().Peers() {
+		// peers connecting concurrently have no session yet
+		network.peerStats.connected(p)
+		s, ok := network.peerStats.stats(p)
+		if !ok {
+			continue
+		}
+		for _, c := range network.host.Network().ConnsToPeer(p) {
+			s.Addrs = append(s.Addrs, c.RemoteMultiaddr().String())
+		}
+		out = append(out, s)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].BytesSent+out[i].BytesRecv > out[j].BytesSent+out[j].BytesRecv
+	})
+
+	return out, nil
+}
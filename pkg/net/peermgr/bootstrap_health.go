@@ -0,0 +1,111 @@
+package peermgr
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const bootstrapDialTimeout = 30 * time.Second
+
+func newBootstrapHealth(bootstrap []peer.AddrInfo) map[peer.ID]*types.BootstrapStatus {
+	health := make(map[peer.ID]*types.BootstrapStatus, len(bootstrap))
+	for _, bsp := range bootstrap {
+		status := &types.BootstrapStatus{ID: bsp.ID, Active: true}
+		for _, addr := range bsp.Addrs {
+			status.Addrs = append(status.Addrs, addr.String())
+		}
+		health[bsp.ID] = status
+	}
+	return health
+}
+
+// BootstrapHealth returns the health of the configured bootstrap peers
+func (pmgr *PeerMgr) BootstrapHealth() []types.BootstrapStatus {
+	pmgr.bootstrapLk.Lock()
+	defer pmgr.bootstrapLk.Unlock()
+
+	out := make([]types.BootstrapStatus, 0, len(pmgr.bootstrapHealth))
+	for _, status := range pmgr.bootstrapHealth {
+		out = append(out, *status)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+
+	return out
+}
+
+// activeBootstrappers returns the bootstrap peers that have not failed too many consecutive checks
+func (pmgr *PeerMgr) activeBootstrappers() []peer.AddrInfo {
+	pmgr.bootstrapLk.Lock()
+	defer pmgr.bootstrapLk.Unlock()
+
+	var out []peer.AddrInfo
+	for _, bsp := range pmgr.bootstrappers {
+		if status, ok := pmgr.bootstrapHealth[bsp.ID]; !ok || status.Active {
+			out = append(out, bsp)
+		}
+	}
+	return out
+}
+
+func (pmgr *PeerMgr) runBootstrapHealth(ctx context.Context) {
+	if len(pmgr.bootstrappers) == 0 || pmgr.healthInterval <= 0 {
+		return
+	}
+
+	tick := time.NewTicker(pmgr.healthInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-tick.C:
+			pmgr.checkBootstrappers(ctx)
+		case <-ctx.Done():
+			return
+		case <-pmgr.done:
+			return
+		}
+	}
+}
+
+// checkBootstrappers dials each bootstrap peer and records the outcome
+func (pmgr *PeerMgr) checkBootstrappers(ctx context.Context) {
+	for _, bsp := range pmgr.bootstrappers {
+		dctx, cancel := context.WithTimeout(ctx, bootstrapDialTimeout)
+		err := pmgr.h.Connect(dctx, bsp)
+		cancel()
+		pmgr.recordBootstrapCheck(bsp.ID, err)
+	}
+}
+
+func (pmgr *PeerMgr) recordBootstrapCheck(p peer.ID, err error) {
+	pmgr.bootstrapLk.Lock()
+	defer pmgr.bootstrapLk.Unlock()
+
+	status, ok := pmgr.bootstrapHealth[p]
+	if !ok {
+		return
+	}
+	status.LastChecked = time.Now()
+
+	if err == nil {
+		if !status.Active {
+			log.Infof("bootstrap peer %s is responsive again", p)
+		}
+		status.Active = true
+		status.ConsecutiveFailures = 0
+		status.LastError = ""
+		return
+	}
+
+	status.ConsecutiveFailures++
+	status.LastError = err.Error()
+	if status.Active && pmgr.maxFailures > 0 && status.ConsecutiveFailures >= pmgr.maxFailures {
+		log.Warnf("bootstrap peer %s failed %d consecutive checks, not using it until it responds: %s", p, status.ConsecutiveFailures, err)
+		status.Active = false
+	}
+}
@@ -0,0 +1,47 @@
+package peermgr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestBootstrapHealth(t *testing.T) {
+	tf.UnitTest(t)
+
+	bootstrap := []peer.AddrInfo{{ID: peer.ID("bootstrap-a")}, {ID: peer.ID("bootstrap-b")}}
+	pmgr := &PeerMgr{
+		bootstrappers:   bootstrap,
+		bootstrapHealth: newBootstrapHealth(bootstrap),
+		maxFailures:     2,
+	}
+	require.Len(t, pmgr.activeBootstrappers(), 2)
+
+	errDial := errors.New("dial failed")
+	pmgr.recordBootstrapCheck(bootstrap[0].ID, errDial)
+	assert.Len(t, pmgr.activeBootstrappers(), 2)
+
+	pmgr.recordBootstrapCheck(bootstrap[0].ID, errDial)
+	assert.Equal(t, []peer.AddrInfo{bootstrap[1]}, pmgr.activeBootstrappers())
+
+	health := pmgr.BootstrapHealth()
+	require.Len(t, health, 2)
+	assert.Equal(t, bootstrap[0].ID, health[0].ID)
+	assert.False(t, health[0].Active)
+	assert.Equal(t, 2, health[0].ConsecutiveFailures)
+	assert.Equal(t, errDial.Error(), health[0].LastError)
+	assert.True(t, health[1].Active)
+
+	// a responsive peer is used again
+	pmgr.recordBootstrapCheck(bootstrap[0].ID, nil)
+	assert.Len(t, pmgr.activeBootstrappers(), 2)
+	health = pmgr.BootstrapHealth()
+	assert.True(t, health[0].Active)
+	assert.Equal(t, 0, health[0].ConsecutiveFailures)
+	assert.Empty(t, health[0].LastError)
+}
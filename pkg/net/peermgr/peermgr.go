@@ -13,6 +13,8 @@ import (
 	peer "github.com/libp2p/go-libp2p/core/peer"
 
 	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
 var log = logging.Logger("peermgr")
@@ -29,6 +31,7 @@ type IPeerMgr interface {
 	Disconnect(p peer.ID)
 	Stop(ctx context.Context) error
 	Run(ctx context.Context)
+	BootstrapHealth() []types.BootstrapStatus
 }
 
 var (
@@ -39,6 +42,11 @@ var (
 type PeerMgr struct {
 	bootstrappers []peer.AddrInfo
 
+	bootstrapLk     sync.Mutex
+	bootstrapHealth map[peer.ID]*types.BootstrapStatus
+	healthInterval  time.Duration
+	maxFailures     int
+
 	// peerLeads is a set of peers we hear about through the network
 	// and who may be good peers to connect to for expanding our peer set
 	// peerLeads map[peer.ID]time.Time // TODO: unused
@@ -73,11 +81,18 @@ const (
 	RemoveFilPeerEvt
 )
 
-func NewPeerMgr(h host.Host, dht *dht.IpfsDHT, period time.Duration, bootstrap []peer.AddrInfo) (*PeerMgr, error) {
+// NewPeerMgr creates a peer manager, the bootstrap peers are dialed every healthInterval and are no longer
+// used after maxFailures consecutive failures until they respond again
+func NewPeerMgr(h host.Host, dht *dht.IpfsDHT, period time.Duration, bootstrap []peer.AddrInfo,
+	healthInterval time.Duration, maxFailures int,
+) (*PeerMgr, error) {
 	pm := &PeerMgr{
-		h:             h,
-		dht:           dht,
-		bootstrappers: bootstrap,
+		h:               h,
+		dht:             dht,
+		bootstrappers:   bootstrap,
+		bootstrapHealth: newBootstrapHealth(bootstrap),
+		healthInterval:  healthInterval,
+		maxFailures:     maxFailures,
 
 		peers:     make(map[peer.ID]time.Duration),
 		expanding: make(chan struct{}, 1),
@@ -155,6 +170,8 @@ func (pmgr *PeerMgr) Run(ctx context.Context) {
 	tick := time.NewTicker(pmgr.period)
 	defer tick.Stop()
 
+	go pmgr.runBootstrapHealth(ctx)
+
 	for {
 		pCount := pmgr.getPeerCount()
 		if pCount < pmgr.minFilPeers {
@@ -204,8 +221,14 @@ func (pmgr *PeerMgr) doExpand(ctx context.Context) {
 			return
 		}
 
+		bootstrappers := pmgr.activeBootstrappers()
+		if len(bootstrappers) == 0 {
+			log.Warn("no peers connected, and all bootstrappers are unresponsive")
+			return
+		}
+
 		log.Info("connecting to bootstrap peers")
-		for _, bsp := range pmgr.bootstrappers {
+		for _, bsp := range bootstrappers {
 			if err := pmgr.h.Connect(ctx, bsp); err != nil {
 				log.Warnf("failed to connect to bootstrap peer: %s", err)
 			}
@@ -236,3 +259,7 @@ func (m MockPeerMgr) Stop(ctx context.Context) error {
 }
 
 func (m MockPeerMgr) Run(ctx context.Context) {}
+
+func (m MockPeerMgr) BootstrapHealth() []types.BootstrapStatus {
+	return nil
+}
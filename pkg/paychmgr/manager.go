@@ -341,6 +341,22 @@ func (pm *Manager) AllocateLane(ctx context.Context, ch address.Address) (uint64
 	return ca.allocateLane(ctx, ch)
 }
 
+func (pm *Manager) AllocateLaneWithLabel(ctx context.Context, ch address.Address, label string) (uint64, error) {
+	ca, err := pm.accessorByAddress(ctx, ch)
+	if err != nil {
+		return 0, err
+	}
+	return ca.allocateLaneWithLabel(ctx, ch, label)
+}
+
+func (pm *Manager) LaneLabels(ctx context.Context, ch address.Address) (map[uint64]string, error) {
+	ca, err := pm.accessorByAddress(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+	return ca.laneLabels(ctx, ch)
+}
+
 func (pm *Manager) ListVouchers(ctx context.Context, ch address.Address) ([]*pchTypes.VoucherInfo, error) {
 	ca, err := pm.accessorByAddress(ctx, ch)
 	if err != nil {
@@ -349,6 +365,15 @@ func (pm *Manager) ListVouchers(ctx context.Context, ch address.Address) ([]*pch
 	return ca.listVouchers(ctx, ch)
 }
 
+// PendingOutgoing returns the amount of the vouchers of the channel that have not been redeemed on chain yet
+func (pm *Manager) PendingOutgoing(ctx context.Context, ch address.Address) (big.Int, error) {
+	ca, err := pm.accessorByAddress(ctx, ch)
+	if err != nil {
+		return big.Int{}, err
+	}
+	return ca.pendingOutgoing(ctx, ch)
+}
+
 func (pm *Manager) Settle(ctx context.Context, addr address.Address) (cid.Cid, error) {
 	ca, err := pm.accessorByAddress(ctx, addr)
 	if err != nil {
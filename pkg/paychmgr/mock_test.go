@@ -3,6 +3,7 @@ package paychmgr
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 
 	crypto2 "github.com/filecoin-project/venus/pkg/crypto"
@@ -68,6 +69,13 @@ func (sm *mockStateManager) setPaychState(a address.Address, actor *types.Actor,
 	sm.paychState[a] = mockPchState{actor, state}
 }
 
+// removePaychState deletes the channel actor, as collecting the channel does.
+func (sm *mockStateManager) removePaychState(a address.Address) {
+	sm.lk.Lock()
+	defer sm.lk.Unlock()
+	delete(sm.paychState, a)
+}
+
 func (sm *mockStateManager) ResolveToDeterministicAddress(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error) {
 	sm.lk.Lock()
 	defer sm.lk.Unlock()
@@ -83,7 +91,7 @@ func (sm *mockStateManager) GetPaychState(ctx context.Context, addr address.Addr
 	defer sm.lk.Unlock()
 	info, ok := sm.paychState[addr]
 	if !ok {
-		return nil, nil, errors.New("not found")
+		return nil, nil, fmt.Errorf("paych %s: %w", addr, types.ErrActorNotFound)
 	}
 	return info.actor, info.state, nil
 }
@@ -465,6 +465,20 @@ func (ca *channelAccessor) allocateLane(ctx context.Context, ch address.Address)
 	return ca.store.AllocateLane(ctx, ch)
 }
 
+func (ca *channelAccessor) allocateLaneWithLabel(ctx context.Context, ch address.Address, label string) (uint64, error) {
+	ca.lk.Lock()
+	defer ca.lk.Unlock()
+
+	return ca.store.AllocateLaneWithLabel(ctx, ch, label)
+}
+
+func (ca *channelAccessor) laneLabels(ctx context.Context, ch address.Address) (map[uint64]string, error) {
+	ca.lk.Lock()
+	defer ca.lk.Unlock()
+
+	return ca.store.LaneLabels(ctx, ch)
+}
+
 func (ca *channelAccessor) listVouchers(ctx context.Context, ch address.Address) ([]*pchTypes.VoucherInfo, error) {
 	ca.lk.Lock()
 	defer ca.lk.Unlock()
@@ -474,6 +488,57 @@ func (ca *channelAccessor) listVouchers(ctx context.Context, ch address.Address)
 	return ca.store.VouchersForPaych(ctx, ch)
 }
 
+// pendingOutgoing returns the amount of the vouchers of the channel that have not been redeemed on chain yet: on each
+// lane, the amount of the latest voucher minus the amount the lane has redeemed on chain
+func (ca *channelAccessor) pendingOutgoing(ctx context.Context, ch address.Address) (big.Int, error) {
+	ca.lk.Lock()
+	defer ca.lk.Unlock()
+
+	_, pchState, err := ca.sa.loadPaychActorState(ctx, ch)
+	if errors.Is(err, types.ErrActorNotFound) {
+		// the channel was collected, its vouchers were redeemed or can no longer be
+		return big.Zero(), nil
+	}
+	if err != nil {
+		return big.Int{}, err
+	}
+	redeemed := make(map[uint64]big.Int)
+	err = pchState.ForEachLaneState(func(idx uint64, ls lpaych.LaneState) error {
+		r, err := ls.Redeemed()
+		if err != nil {
+			return err
+		}
+		redeemed[idx] = r
+		return nil
+	})
+	if err != nil {
+		return big.Int{}, err
+	}
+
+	vouchers, err := ca.store.VouchersForPaych(ctx, ch)
+	if err != nil && err != ErrChannelNotTracked {
+		return big.Int{}, err
+	}
+	latest := make(map[uint64]*types.SignedVoucher)
+	for _, vi := range vouchers {
+		if sv, ok := latest[vi.Voucher.Lane]; !ok || vi.Voucher.Nonce > sv.Nonce {
+			latest[vi.Voucher.Lane] = vi.Voucher
+		}
+	}
+
+	pending := big.Zero()
+	for lane, sv := range latest {
+		r, ok := redeemed[lane]
+		if !ok {
+			r = big.Zero()
+		}
+		if sv.Amount.GreaterThan(r) {
+			pending = big.Add(pending, big.Sub(sv.Amount, r))
+		}
+	}
+	return pending, nil
+}
+
 // laneState gets the LaneStates from chain, then applies all vouchers in
 // the data store over the chain state
 func (ca *channelAccessor) laneState(ctx context.Context, state lpaych.State, ch address.Address) (map[uint64]lpaych.LaneState, error) {
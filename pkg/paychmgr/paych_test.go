@@ -840,3 +840,41 @@ func (m *mockBestSpendableAPI) PaychVoucherCheckSpendable(ctx context.Context, c
 func newMockBestSpendableAPI(mgr *Manager) BestSpendableAPI {
 	return &mockBestSpendableAPI{mgr: mgr}
 }
+
+func TestPendingOutgoing(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	s := testSetupMgrWithChannel(t)
+	for _, v := range []types.SignedVoucher{
+		{Lane: 1, Amount: big.NewInt(5)},
+		{Lane: 1, Amount: big.NewInt(8)},
+		{Lane: 2, Amount: big.NewInt(3)},
+	} {
+		res, err := s.mgr.CreateVoucher(ctx, s.ch, v)
+		require.NoError(t, err)
+		require.NotNil(t, res.Voucher)
+	}
+
+	// nothing is redeemed yet, only the latest voucher of a lane counts
+	pending, err := s.mgr.PendingOutgoing(ctx, s.ch)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(11), pending)
+
+	// the recipient redeemed the first voucher of lane 1 and all of lane 2
+	act, _, err := s.mock.GetPaychState(ctx, s.ch, nil)
+	require.NoError(t, err)
+	s.mock.setPaychState(s.ch, act, paychmock.NewMockPayChState(s.fromAcct, tutils.NewActorAddr(t, "toAct"), abi.ChainEpoch(0), map[uint64]paych.LaneState{
+		1: paychmock.NewMockLaneState(big.NewInt(5), 1),
+		2: paychmock.NewMockLaneState(big.NewInt(3), 1),
+	}))
+	pending, err = s.mgr.PendingOutgoing(ctx, s.ch)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3), pending)
+
+	// nothing is pending once the channel was collected and its actor deleted
+	s.mock.removePaychState(s.ch)
+	pending, err = s.mgr.PendingOutgoing(ctx, s.ch)
+	require.NoError(t, err)
+	require.True(t, pending.IsZero())
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/google/uuid"
 
@@ -39,6 +40,7 @@ func NewStore(ds repo.Datastore) *Store {
 const (
 	dsKeyChannelInfo = "ChannelInfo"
 	dsKeyMsgCid      = "MsgCid"
+	dsKeyLaneLabel   = "LaneLabel"
 )
 
 // TrackChannel stores a channel, returning an error if the channel was already
@@ -149,6 +151,47 @@ func (ps *Store) AllocateLane(ctx context.Context, ch address.Address) (uint64,
 	return out, ps.putChannelInfo(ctx, ci)
 }
 
+// AllocateLaneWithLabel allocates a new lane for the given channel and stores
+// the label alongside the lane ID
+func (ps *Store) AllocateLaneWithLabel(ctx context.Context, ch address.Address, label string) (uint64, error) {
+	lane, err := ps.AllocateLane(ctx, ch)
+	if err != nil {
+		return 0, err
+	}
+
+	return lane, ps.ds.Put(ctx, dskeyForLaneLabel(ch, lane), []byte(label))
+}
+
+// LaneLabels gets the labels of the lanes of the given channel, keyed by lane ID
+func (ps *Store) LaneLabels(ctx context.Context, ch address.Address) (map[uint64]string, error) {
+	prefix := datastore.KeyWithNamespaces([]string{dsKeyLaneLabel, ch.String()})
+	res, err := ps.ds.Query(ctx, dsq.Query{Prefix: prefix.String()})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close() //nolint:errcheck
+
+	labels := make(map[uint64]string)
+	for r := range res.Next() {
+		if r.Error != nil {
+			return nil, r.Error
+		}
+
+		lane, err := strconv.ParseUint(datastore.NewKey(r.Key).Name(), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing lane of key %s: %w", r.Key, err)
+		}
+		labels[lane] = string(r.Value)
+	}
+
+	return labels, nil
+}
+
+// The datastore key used to identify the label of a lane
+func dskeyForLaneLabel(ch address.Address, lane uint64) datastore.Key {
+	return datastore.KeyWithNamespaces([]string{dsKeyLaneLabel, ch.String(), strconv.FormatUint(lane, 10)})
+}
+
 // VouchersForPaych gets the vouchers for the given channel
 func (ps *Store) VouchersForPaych(ctx context.Context, ch address.Address) ([]*pchTypes.VoucherInfo, error) {
 	ci, err := ps.ByAddress(ctx, ch)
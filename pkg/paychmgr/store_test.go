@@ -88,3 +88,42 @@ func TestStore(t *testing.T) {
 	_, err = store.AllocateLane(ctx, tutils.NewIDAddr(t, 300))
 	require.Equal(t, err, ErrChannelNotTracked)
 }
+
+func TestStoreLaneLabels(t *testing.T) {
+	tf.UnitTest(t)
+	store := NewStore(ds_sync.MutexWrap(ds.NewMapDatastore()))
+	ctx := context.Background()
+
+	ch := tutils.NewIDAddr(t, 100)
+	// the address of ch is a prefix of the address of ch2
+	ch2 := tutils.NewIDAddr(t, 1000)
+	for _, addr := range []address.Address{ch, ch2} {
+		addr := addr
+		_, err := store.TrackChannel(ctx, &pchTypes.ChannelInfo{
+			Channel:   &addr,
+			Control:   tutils.NewIDAddr(t, 101),
+			Target:    tutils.NewIDAddr(t, 102),
+			Direction: pchTypes.DirOutbound,
+		})
+		require.NoError(t, err)
+	}
+
+	lane, err := store.AllocateLaneWithLabel(ctx, ch, "storage")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), lane)
+	// lanes without a label are not listed
+	_, err = store.AllocateLane(ctx, ch)
+	require.NoError(t, err)
+	lane, err = store.AllocateLaneWithLabel(ctx, ch, "retrieval")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), lane)
+	_, err = store.AllocateLaneWithLabel(ctx, ch2, "other")
+	require.NoError(t, err)
+
+	labels, err := store.LaneLabels(ctx, ch)
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]string{0: "storage", 2: "retrieval"}, labels)
+
+	_, err = store.AllocateLaneWithLabel(ctx, tutils.NewIDAddr(t, 300), "missing")
+	require.Equal(t, ErrChannelNotTracked, err)
+}
@@ -43,6 +43,7 @@ const (
 	snapshotFilenamePrefix = "snapshot"
 	dataTransfer           = "data-transfer"
 	fsSqlite               = "sqlite"
+	netBlockListFilename   = "netblocklist.json"
 )
 
 var log = logging.Logger("repo")
@@ -627,6 +628,11 @@ func (r *FSRepo) JournalPath() string {
 	return fmt.Sprintf("%s/journal.json", r.path)
 }
 
+// NetBlockListPath returns the path of the net block list file.
+func (r *FSRepo) NetBlockListPath() string {
+	return filepath.Join(r.path, netBlockListFilename)
+}
+
 func (r *FSRepo) SqlitePath() (string, error) {
 	r.sqlOnce.Do(func() {
 		path := filepath.Join(r.path, fsSqlite)
@@ -147,6 +147,11 @@ func (mr *MemRepo) JournalPath() string {
 	return "in_memory_filecoin_journal_path"
 }
 
+// NetBlockListPath returns an empty path, the net block list of a memory repo is not persisted.
+func (mr *MemRepo) NetBlockListPath() string {
+	return ""
+}
+
 // SqlitePath returns In-Memory Databases
 func (mr *MemRepo) SqlitePath() (string, error) {
 	return ":memory:", nil
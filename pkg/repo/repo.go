@@ -57,6 +57,9 @@ type Repo interface {
 	// SqlitePath returns the path for the Sqlite database
 	SqlitePath() (string, error)
 
+	// NetBlockListPath returns the path of the file the net block list is persisted to, empty if it is not persisted.
+	NetBlockListPath() string
+
 	// Close shuts down the repo.
 	Close() error
 
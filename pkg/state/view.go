@@ -548,6 +548,41 @@ func (v *View) StateSectorExpiration(ctx context.Context, maddr addr.Address, se
 	return mas.GetSectorExpiration(sectorNumber)
 }
 
+// StateSectorExpirationBatch returns the expirations of the given sectors, loading the miner state and the
+// expiration queues of their partitions once. The sectors missing from the sector set are absent from the result.
+func (v *View) StateSectorExpirationBatch(ctx context.Context, maddr addr.Address, sectorNums []abi.SectorNumber) (map[abi.SectorNumber]*lminer.SectorExpiration, error) {
+	mas, err := v.LoadMinerState(ctx, maddr)
+	if err != nil {
+		return nil, err
+	}
+
+	live := make([]abi.SectorNumber, 0, len(sectorNums))
+	seen := make(map[abi.SectorNumber]struct{}, len(sectorNums))
+	for _, num := range sectorNums {
+		if _, ok := seen[num]; ok {
+			continue
+		}
+		seen[num] = struct{}{}
+		info, err := mas.GetSector(num)
+		if err != nil {
+			return nil, fmt.Errorf("loading sector %d: %v", num, err)
+		}
+		if info != nil {
+			live = append(live, num)
+		}
+	}
+	out, err := mas.GetSectorExpirations(live)
+	if err != nil {
+		return nil, err
+	}
+	for _, num := range live {
+		if _, ok := out[num]; !ok {
+			return nil, fmt.Errorf("failed to find sector %d", num)
+		}
+	}
+	return out, nil
+}
+
 // StateMinerAvailableBalance returns the portion of a miner's balance that can be withdrawn or spent
 func (v *View) StateMinerAvailableBalance(ctx context.Context, maddr addr.Address, ts *types.TipSet) (big.Int, error) {
 	resolvedAddr, err := v.InitResolveAddress(ctx, maddr)
@@ -6,16 +6,27 @@ import (
 	"testing"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	cbor "github.com/ipfs/go-ipld-cbor"
 
 	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/v11/miner"
+	"github.com/filecoin-project/go-state-types/manifest"
 	"github.com/filecoin-project/go-state-types/network"
 	"github.com/filecoin-project/venus/pkg/state"
+	"github.com/filecoin-project/venus/pkg/state/tree"
 	"github.com/filecoin-project/venus/pkg/testhelpers"
 	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
 	"github.com/filecoin-project/venus/pkg/wallet/key"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
 	"github.com/filecoin-project/venus/venus-shared/types"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func setupTestMinerView(t *testing.T, numMiners int) (*state.View, map[address.Address]*key.KeyInfo) {
@@ -82,3 +93,68 @@ func TestView(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestStateMinerAvailableBalance(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	cst := cbor.NewCborStore(blockstoreutil.NewBlockstore(datastore.NewMapDatastore()))
+
+	height := abi.ChainEpoch(100)
+	vesting := []miner.VestingFund{
+		{Epoch: 50, Amount: types.FromFil(10)},
+		{Epoch: height, Amount: types.FromFil(5)},
+		{Epoch: 200, Amount: types.FromFil(15)},
+	}
+	balance := types.FromFil(100)
+	mst := &miner.State{
+		PreCommitDeposits: types.FromFil(5),
+		LockedFunds:       types.FromFil(30),
+		FeeDebt:           types.FromFil(3),
+		InitialPledge:     types.FromFil(40),
+	}
+
+	emptyCid, err := cst.Put(ctx, []cid.Cid{})
+	require.NoError(t, err)
+	mst.Info, mst.PreCommittedSectors, mst.PreCommittedSectorsCleanUp = emptyCid, emptyCid, emptyCid
+	mst.AllocatedSectors, mst.Sectors, mst.Deadlines = emptyCid, emptyCid, emptyCid
+	mst.VestingFunds, err = cst.Put(ctx, &miner.VestingFunds{Funds: vesting})
+	require.NoError(t, err)
+	head, err := cst.Put(ctx, mst)
+	require.NoError(t, err)
+
+	st, err := tree.NewState(cst, tree.StateTreeVersion5)
+	require.NoError(t, err)
+	maddr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	code, ok := actors.GetActorCodeID(actorstypes.Version11, manifest.MinerKey)
+	require.True(t, ok)
+	require.NoError(t, st.SetActor(ctx, maddr, &types.Actor{Code: code, Head: head, Balance: balance}))
+	root, err := st.Flush(ctx)
+	require.NoError(t, err)
+
+	ts, err := types.NewTipSet([]*types.BlockHeader{{
+		Miner:                 maddr,
+		Ticket:                &types.Ticket{VRFProof: []byte{0}},
+		ParentWeight:          big.Zero(),
+		Height:                height,
+		ParentMessageReceipts: testhelpers.EmptyMessagesCID,
+		Messages:              testhelpers.EmptyTxMetaCID,
+		ParentStateRoot:       root,
+	}})
+	require.NoError(t, err)
+
+	available, err := state.NewView(cst, root).StateMinerAvailableBalance(ctx, maddr, ts)
+	require.NoError(t, err)
+
+	// WithdrawBalance first unlocks the funds vested before the current epoch, then the balance left
+	// once the locked funds, the deposits, the pledge and the fee debt are covered can be withdrawn.
+	locked := mst.LockedFunds
+	for _, vf := range vesting {
+		if vf.Epoch < height {
+			locked = big.Sub(locked, vf.Amount)
+		}
+	}
+	withdrawable := big.Subtract(balance, locked, mst.PreCommitDeposits, mst.InitialPledge, mst.FeeDebt)
+	assert.Equal(t, types.FromFil(32), withdrawable)
+	assert.Equal(t, withdrawable, available)
+}
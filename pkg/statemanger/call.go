@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/filecoin-project/venus/pkg/chain"
-	"github.com/filecoin-project/venus/pkg/fvm"
 
 	"github.com/filecoin-project/venus/pkg/consensus"
 	"github.com/filecoin-project/venus/pkg/vm/vmcontext"
@@ -163,7 +162,7 @@ func (s *Stmgr) callInternal(ctx context.Context, msg *types.Message, priorMsgs
 		Tracing:             true,
 		ActorDebugging:      s.actorDebugging,
 	}
-	vmi, err := fvm.NewVM(ctx, vmopt)
+	vmi, err := s.newVM(ctx, vmopt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to set up vm: %w", err)
 	}
@@ -187,9 +186,12 @@ func (s *Stmgr) callInternal(ctx context.Context, msg *types.Message, priorMsgs
 	}
 
 	fromActor, found, err := st.GetActor(ctx, msg.From)
-	if err != nil || !found {
+	if err != nil {
 		return nil, fmt.Errorf("call raw get actor: %s", err)
 	}
+	if !found {
+		return nil, fmt.Errorf("call raw get actor %s: %w", msg.From, types.ErrActorNotFound)
+	}
 
 	msg.Nonce = fromActor.Nonce
 
@@ -197,9 +199,10 @@ func (s *Stmgr) callInternal(ctx context.Context, msg *types.Message, priorMsgs
 	if msg.GasFeeCap.NilOrZero() {
 		// Now estimate with a new VM with no base fee.
 		vmopt.BaseFee = big.Zero()
+		vmopt.MinBaseFee = big.Zero()
 		vmopt.PRoot = stateCid
 
-		vmi, err = fvm.NewVM(ctx, vmopt)
+		vmi, err = s.newVM(ctx, vmopt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to set up estimation vm: %w", err)
 		}
@@ -213,30 +216,7 @@ func (s *Stmgr) callInternal(ctx context.Context, msg *types.Message, priorMsgs
 			return nil, fmt.Errorf("could not resolve key: %w", err)
 		}
 
-		var msgApply types.ChainMsg
-
-		switch fromKey.Protocol() {
-		case address.BLS:
-			msgApply = msg
-		case address.SECP256K1:
-			msgApply = &types.SignedMessage{
-				Message: *msg,
-				Signature: crypto.Signature{
-					Type: crypto.SigTypeSecp256k1,
-					Data: make([]byte, 65),
-				},
-			}
-		case address.Delegated:
-			msgApply = &types.SignedMessage{
-				Message: *msg,
-				Signature: crypto.Signature{
-					Type: crypto.SigTypeDelegated,
-					Data: make([]byte, 65),
-				},
-			}
-		}
-
-		ret, err = vmi.ApplyMessage(ctx, msgApply)
+		ret, err = vmi.ApplyMessage(ctx, withPlaceholderSignature(msg, fromKey))
 		if err != nil {
 			return nil, fmt.Errorf("gas estimation failed: %w", err)
 		}
@@ -263,3 +243,185 @@ func (s *Stmgr) callInternal(ctx context.Context, msg *types.Message, priorMsgs
 		Duration:       ret.Duration,
 	}, err
 }
+
+// withPlaceholderSignature wraps msg as the chain message its sender key would sign, with an empty signature of
+// the right size so the message is charged the same gas as the signed one.
+func withPlaceholderSignature(msg *types.Message, fromKey address.Address) types.ChainMsg {
+	switch fromKey.Protocol() {
+	case address.SECP256K1:
+		return &types.SignedMessage{
+			Message: *msg,
+			Signature: crypto.Signature{
+				Type: crypto.SigTypeSecp256k1,
+				Data: make([]byte, 65),
+			},
+		}
+	case address.Delegated:
+		return &types.SignedMessage{
+			Message: *msg,
+			Signature: crypto.Signature{
+				Type: crypto.SigTypeDelegated,
+				Data: make([]byte, 65),
+			},
+		}
+	default:
+		return msg
+	}
+}
+
+// SimulateMessages applies msgs in order on the state of ts, after the messages of ts itself, like CallWithGas does
+// for a single message. The nonces of msgs are ignored: the messages of each sender get consecutive nonces starting
+// at the sender's nonce in the simulated state, the results carry the messages as applied. Unset gas fields are
+// defaulted like Call does, with the fee cap defaulting to the base fee. All writes go to a temporary overlay of the
+// chain blockstore that is dropped afterwards.
+//
+// Besides the results it returns a synthetic tipset on top of ts whose parent state root and receipts are the
+// simulation's. Its state only existed in the overlay, so it can't be loaded from the chain store.
+func (s *Stmgr) SimulateMessages(ctx context.Context, msgs []*types.Message, ts *types.TipSet) ([]*types.InvocResult, *types.TipSet, error) {
+	ctx, span := trace.StartSpan(ctx, "statemanager.SimulateMessages")
+	defer span.End()
+
+	if ts == nil {
+		ts = s.cs.GetHead()
+	}
+	if ts.Height() > 0 {
+		pts, err := s.cs.GetTipSet(ctx, ts.Parents())
+		if err != nil {
+			return nil, nil, fmt.Errorf("loading parent tipset: %w", err)
+		}
+		if s.fork.HasExpensiveForkBetween(pts.Height(), ts.Height()+1) {
+			return nil, nil, fork.ErrExpensiveFork
+		}
+	}
+
+	tsMsgs, err := s.ms.MessagesForTipset(ts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to lookup messages for tipset: %w", err)
+	}
+	stateCid, err := s.fork.HandleStateForks(ctx, ts.ParentState(), ts.Height(), ts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to handle fork: %w", err)
+	}
+
+	buffStore := blockstoreutil.NewTieredBstore(s.cs.Blockstore(), blockstoreutil.NewTemporarySync())
+	baseFee := ts.Blocks()[0].ParentBaseFee
+	vmi, err := s.newVM(ctx, vm.VmOption{
+		CircSupplyCalculator: func(ctx context.Context, epoch abi.ChainEpoch, tree tree.Tree) (abi.TokenAmount, error) {
+			cs, err := s.cs.GetCirculatingSupplyDetailed(ctx, epoch, tree)
+			if err != nil {
+				return abi.TokenAmount{}, err
+			}
+			return cs.FilCirculating, nil
+		},
+		PRoot:               stateCid,
+		Epoch:               ts.Height(),
+		Timestamp:           ts.MinTimestamp(),
+		Rnd:                 consensus.NewHeadRandomness(s.rnd, ts.Key()),
+		Bsstore:             buffStore,
+		SysCallsImpl:        s.syscallsImpl,
+		GasPriceSchedule:    s.gasSchedule,
+		NetworkVersion:      s.GetNetworkVersion(ctx, ts.Height()),
+		BaseFee:             baseFee,
+		Fork:                s.fork,
+		LookbackStateGetter: vmcontext.LookbackStateGetterForTipset(ctx, s.cs, s.fork, ts),
+		TipSetGetter:        vmcontext.TipSetGetterForTipset(s.cs.GetTipSetByHeight, ts),
+		Tracing:             true,
+		ActorDebugging:      s.actorDebugging,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to set up vm: %w", err)
+	}
+	for i, m := range tsMsgs {
+		if _, err := vmi.ApplyMessage(ctx, m); err != nil {
+			return nil, nil, fmt.Errorf("applying tipset message (%d, %s): %w", i, m.Cid(), err)
+		}
+	}
+
+	nonces := make(map[address.Address]uint64)
+	results := make([]*types.InvocResult, 0, len(msgs))
+	receipts := make([]types.MessageReceipt, 0, len(msgs))
+	for i, msg := range msgs {
+		msgCopy := *msg
+		msg = &msgCopy
+		if msg.GasLimit == 0 {
+			msg.GasLimit = constants.BlockGasLimit
+		}
+		if msg.GasFeeCap == types.EmptyInt || msg.GasFeeCap.NilOrZero() {
+			msg.GasFeeCap = baseFee
+		}
+		if msg.GasPremium == types.EmptyInt {
+			msg.GasPremium = types.NewInt(0)
+		}
+		if msg.Value == types.EmptyInt {
+			msg.Value = types.NewInt(0)
+		}
+
+		fromKey, err := s.ResolveToDeterministicAddress(ctx, msg.From, ts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not resolve key of message %d: %w", i, err)
+		}
+		nonce, ok := nonces[fromKey]
+		if !ok {
+			// flush to read the sender nonce after the messages applied so far
+			root, err := vmi.Flush(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("flushing vm: %w", err)
+			}
+			st, err := tree.LoadState(ctx, cbor.NewCborStore(buffStore), root)
+			if err != nil {
+				return nil, nil, fmt.Errorf("loading state: %w", err)
+			}
+			fromActor, found, err := st.GetActor(ctx, msg.From)
+			if err != nil {
+				return nil, nil, fmt.Errorf("get actor of message %d sender %s: %w", i, msg.From, err)
+			}
+			if !found {
+				return nil, nil, fmt.Errorf("sender %s of message %d: %w", msg.From, i, types.ErrActorNotFound)
+			}
+			nonce = fromActor.Nonce
+		}
+		msg.Nonce = nonce
+		nonces[fromKey] = nonce + 1
+
+		ret, err := vmi.ApplyMessage(ctx, withPlaceholderSignature(msg, fromKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("applying message %d: %w", i, err)
+		}
+
+		var errs string
+		if ret.ActorErr != nil {
+			errs = ret.ActorErr.Error()
+		}
+		results = append(results, &types.InvocResult{
+			MsgCid:         msg.Cid(),
+			Msg:            msg,
+			MsgRct:         &ret.Receipt,
+			GasCost:        MakeMsgGasCost(msg, ret),
+			ExecutionTrace: ret.GasTracker.ExecutionTrace,
+			Error:          errs,
+			Duration:       ret.Duration,
+		})
+		receipts = append(receipts, ret.Receipt)
+	}
+
+	root, err := vmi.Flush(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("flushing vm: %w", err)
+	}
+	receiptsRoot, err := chain.GetReceiptRoot(receipts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("computing receipts root: %w", err)
+	}
+
+	hdr := *ts.At(0)
+	hdr.Parents = ts.Cids()
+	hdr.Height = ts.Height() + 1
+	hdr.ParentStateRoot = root
+	hdr.ParentMessageReceipts = receiptsRoot
+	simulated, err := types.NewTipSet([]*types.BlockHeader{&hdr})
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating post-simulation tipset: %w", err)
+	}
+
+	return results, simulated, nil
+}
@@ -0,0 +1,113 @@
+package statemanger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/fork"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/pkg/vm"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestSimulateMessages(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	stmgr := NewStateManger(builder.Store(), builder.MessageStore(), builder.FakeStateEvaluator(), nil, fork.NewMockFork(), nil, nil, false)
+
+	// alice has sent 5 messages before, bob none
+	newAddr := testhelpers.NewForTestGetter()
+	alice, bob, carol, to := newAddr(), newAddr(), newAddr(), newAddr()
+	st, err := tree.NewStateWithBuiltinActor(t, builder.Cstore(), tree.StateTreeVersion1)
+	require.NoError(t, err)
+	tree.AddAccount(t, st, builder.Cstore(), alice)
+	tree.AddAccount(t, st, builder.Cstore(), bob)
+	tree.AddAccount(t, st, builder.Cstore(), carol)
+	act, found, err := st.GetActor(ctx, alice)
+	require.NoError(t, err)
+	require.True(t, found)
+	act.Nonce = 5
+	require.NoError(t, st.SetActor(ctx, alice, act))
+	root, err := st.Flush(ctx)
+	require.NoError(t, err)
+
+	mockVM := vm.NewMockFVM(root)
+	mockVM.SetDefaultResult(&vm.Ret{Receipt: types.MessageReceipt{ExitCode: exitcode.Ok, GasUsed: 1000}})
+	stmgr.SetVMConstructor(func(ctx context.Context, opts vm.VmOption) (vm.Interface, error) {
+		return mockVM, nil
+	})
+
+	// the message of the tipset is applied before the simulated ones
+	tsMsg := testhelpers.NewMeteredMessage(carol, to, 0, big.NewInt(1), 0, nil, big.NewInt(100), big.NewInt(1), 1_000_000)
+	// the builder computes a fake parent state, the messages are loaded against the real one
+	hdr := *builder.BuildOneOn(ctx, builder.Genesis(), func(b *chain.BlockBuilder) {
+		b.AddMessages(nil, []*types.Message{tsMsg})
+	}).At(0)
+	hdr.ParentStateRoot = root
+	ts, err := types.NewTipSet([]*types.BlockHeader{&hdr})
+	require.NoError(t, err)
+
+	// the nonces of the messages are ignored
+	msgs := []*types.Message{
+		testhelpers.NewMeteredMessage(alice, to, 100, big.NewInt(1), 0, nil, big.NewInt(100), big.NewInt(1), 1_000_000),
+		testhelpers.NewMeteredMessage(bob, to, 100, big.NewInt(2), 0, nil, big.NewInt(100), big.NewInt(1), 1_000_000),
+		testhelpers.NewMeteredMessage(alice, to, 100, big.NewInt(3), 0, nil, big.NewInt(100), big.NewInt(1), 1_000_000),
+		testhelpers.NewMeteredMessage(alice, to, 100, big.NewInt(4), 0, nil, big.NewInt(100), big.NewInt(1), 1_000_000),
+	}
+	expectNonces := []uint64{5, 0, 6, 7}
+	expectReceipts := make([]types.MessageReceipt, len(msgs))
+	for i, msg := range msgs {
+		applied := *msg
+		applied.Nonce = expectNonces[i]
+		expectReceipts[i] = types.MessageReceipt{ExitCode: exitcode.Ok, GasUsed: int64(100 * (i + 1))}
+		mockVM.SetApplyResult(applied.Cid(), &vm.Ret{Receipt: expectReceipts[i]})
+	}
+	// a failing message doesn't stop the simulation
+	failed := *msgs[2]
+	failed.Nonce = expectNonces[2]
+	expectReceipts[2] = types.MessageReceipt{ExitCode: exitcode.ErrInsufficientFunds, GasUsed: 300}
+	mockVM.SetApplyResult(failed.Cid(), &vm.Ret{Receipt: expectReceipts[2]})
+
+	results, simulated, err := stmgr.SimulateMessages(ctx, msgs, ts)
+	require.NoError(t, err)
+	require.Len(t, results, len(msgs))
+	for i, res := range results {
+		assert.Equal(t, expectNonces[i], res.Msg.Nonce)
+		assert.Equal(t, msgs[i].Value, res.Msg.Value)
+		assert.Equal(t, res.Msg.Cid(), res.MsgCid)
+		assert.Equal(t, expectReceipts[i], *res.MsgRct)
+	}
+	// the messages passed in are left untouched
+	assert.Equal(t, uint64(100), msgs[0].Nonce)
+
+	applied := mockVM.Applied()
+	require.Len(t, applied, len(msgs)+1)
+	assert.Equal(t, tsMsg.Cid(), applied[0].VMMessage().Cid())
+	for i, res := range results {
+		assert.Equal(t, res.Msg.Cid(), applied[i+1].VMMessage().Cid())
+	}
+
+	receiptsRoot, err := chain.GetReceiptRoot(expectReceipts)
+	require.NoError(t, err)
+	assert.Equal(t, receiptsRoot, simulated.Blocks()[0].ParentMessageReceipts)
+	assert.Equal(t, root, simulated.ParentState())
+	assert.Equal(t, ts.Height()+1, simulated.Height())
+	assert.Equal(t, ts.Key(), simulated.Parents())
+
+	// the senders must exist
+	_, _, err = stmgr.SimulateMessages(ctx, []*types.Message{
+		testhelpers.NewMeteredMessage(newAddr(), to, 0, big.NewInt(1), 0, nil, big.NewInt(100), big.NewInt(1), 1_000_000),
+	}, ts)
+	assert.ErrorIs(t, err, types.ErrActorNotFound)
+}
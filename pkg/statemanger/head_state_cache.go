@@ -0,0 +1,132 @@
+package statemanger
+
+import (
+	"context"
+	"sync"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/ipfs/go-cid"
+)
+
+// headStateCacheSize is the number of chain heads whose parent state is kept
+const headStateCacheSize = 4
+
+type headState struct {
+	// done is closed once the fields below are set
+	done   chan struct{}
+	parent *types.TipSet
+	root   cid.Cid
+	err    error
+}
+
+// HeadStateCache holds the verified parent state of the latest chain heads. The state of a new
+// head is computed in the background as soon as it arrives, callers asking for it meanwhile wait
+// for the computation instead of starting their own.
+//
+// Only the verified state root is cached rather than the tree, a tree.State is not safe for
+// concurrent use because its hamt lazily caches loaded nodes, so every caller loads its own.
+type HeadStateCache struct {
+	compute func(context.Context, *types.TipSet) (*types.TipSet, cid.Cid, error)
+
+	lk      sync.Mutex
+	entries map[types.TipSetKey]*headState
+	keys    []types.TipSetKey // oldest first
+}
+
+func newHeadStateCache(compute func(context.Context, *types.TipSet) (*types.TipSet, cid.Cid, error)) *HeadStateCache {
+	return &HeadStateCache{
+		compute: compute,
+		entries: make(map[types.TipSetKey]*headState, headStateCacheSize),
+	}
+}
+
+// prepare computes and caches the parent state of ts unless it is already cached or in progress.
+func (c *HeadStateCache) prepare(ctx context.Context, ts *types.TipSet) {
+	key := ts.Key()
+
+	c.lk.Lock()
+	if _, ok := c.entries[key]; ok {
+		c.lk.Unlock()
+		return
+	}
+	if len(c.keys) >= headStateCacheSize {
+		// waiters hold the entry itself, so evicting one still being computed is fine
+		c.removeLocked(c.keys[0], nil)
+	}
+	entry := &headState{done: make(chan struct{})}
+	c.entries[key] = entry
+	c.keys = append(c.keys, key)
+	c.lk.Unlock()
+
+	entry.parent, entry.root, entry.err = c.compute(ctx, ts)
+	close(entry.done)
+
+	if entry.err != nil {
+		// callers compute the state themselves after a failure, e.g. a cancelled computation
+		c.lk.Lock()
+		c.removeLocked(key, entry)
+		c.lk.Unlock()
+	}
+}
+
+// invalidate drops the parent state of the head with key.
+func (c *HeadStateCache) invalidate(key types.TipSetKey) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	c.removeLocked(key, nil)
+}
+
+// removeLocked drops the entry of key, only if it is entry when entry is not nil.
+func (c *HeadStateCache) removeLocked(key types.TipSetKey, entry *headState) {
+	cur, ok := c.entries[key]
+	if !ok || (entry != nil && cur != entry) {
+		return
+	}
+	delete(c.entries, key)
+	for i, k := range c.keys {
+		if k == key {
+			c.keys = append(c.keys[:i], c.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// get returns the parent state of the head with key, waiting for it if it is being computed until
+// ctx is done. ok is false if the head is not cached or its computation failed.
+func (c *HeadStateCache) get(ctx context.Context, key types.TipSetKey) (parent *types.TipSet, root cid.Cid, ok bool, err error) {
+	c.lk.Lock()
+	entry, ok := c.entries[key]
+	c.lk.Unlock()
+	if !ok {
+		return nil, cid.Undef, false, nil
+	}
+
+	select {
+	case <-entry.done:
+	case <-ctx.Done():
+		return nil, cid.Undef, false, ctx.Err()
+	}
+	if entry.err != nil {
+		return nil, cid.Undef, false, nil
+	}
+	return entry.parent, entry.root, true, nil
+}
+
+// run precomputes the parent state of every new chain head until ctx is done, the reverted heads are
+// dropped.
+func (c *HeadStateCache) run(ctx context.Context, headChanges <-chan []*types.HeadChange) {
+	for changes := range headChanges {
+		var head *types.TipSet
+		for _, change := range changes {
+			switch change.Type {
+			case types.HCApply, types.HCCurrent:
+				head = change.Val
+			case types.HCRevert:
+				c.invalidate(change.Val.Key())
+			}
+		}
+		if head != nil {
+			go c.prepare(ctx, head)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package statemanger
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// blockingCompute returns the root of a tipset once release is closed, counting the computations.
+type blockingCompute struct {
+	release chan struct{}
+	calls   int32
+	roots   map[types.TipSetKey]cid.Cid
+	err     error
+}
+
+func (b *blockingCompute) compute(ctx context.Context, ts *types.TipSet) (*types.TipSet, cid.Cid, error) {
+	atomic.AddInt32(&b.calls, 1)
+	select {
+	case <-b.release:
+	case <-ctx.Done():
+		return nil, cid.Undef, ctx.Err()
+	}
+	if b.err != nil {
+		return nil, cid.Undef, b.err
+	}
+	return ts, b.roots[ts.Key()], nil
+}
+
+func newHeadStateCacheTest(t *testing.T, n int) (*HeadStateCache, *blockingCompute, []*types.TipSet) {
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	newCid := testhelpers.NewCidForTestGetter()
+
+	bc := &blockingCompute{release: make(chan struct{}), roots: make(map[types.TipSetKey]cid.Cid)}
+	heads := make([]*types.TipSet, n)
+	parent := builder.Genesis()
+	for i := range heads {
+		heads[i] = builder.AppendOn(ctx, parent, 1)
+		bc.roots[heads[i].Key()] = newCid()
+		parent = heads[i]
+	}
+	return newHeadStateCache(bc.compute), bc, heads
+}
+
+func TestHeadStateCacheHitAndMiss(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	c, bc, heads := newHeadStateCacheTest(t, 2)
+	close(bc.release)
+
+	c.prepare(ctx, heads[0])
+	parent, root, ok, err := c.get(ctx, heads[0].Key())
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, heads[0], parent)
+	assert.Equal(t, bc.roots[heads[0].Key()], root)
+
+	// a prepared head is not computed again
+	c.prepare(ctx, heads[0])
+	assert.EqualValues(t, 1, atomic.LoadInt32(&bc.calls))
+
+	_, _, ok, err = c.get(ctx, heads[1].Key())
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// a failed computation is not cached
+	bc.err = errors.New("boom")
+	c.prepare(ctx, heads[1])
+	_, _, ok, err = c.get(ctx, heads[1].Key())
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestHeadStateCacheConcurrentWaiters(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	c, bc, heads := newHeadStateCacheTest(t, 1)
+	go c.prepare(ctx, heads[0])
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&bc.calls) == 1 }, 5*time.Second, time.Millisecond)
+
+	const waiters = 10
+	var wg sync.WaitGroup
+	roots := make(chan cid.Cid, waiters)
+	for i := 0; i < waiters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, root, ok, err := c.get(ctx, heads[0].Key())
+			if err == nil && ok {
+				roots <- root
+			}
+		}()
+	}
+	close(bc.release)
+	wg.Wait()
+	close(roots)
+
+	count := 0
+	for root := range roots {
+		assert.Equal(t, bc.roots[heads[0].Key()], root)
+		count++
+	}
+	assert.Equal(t, waiters, count)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&bc.calls))
+}
+
+func TestHeadStateCacheGetCancelled(t *testing.T) {
+	tf.UnitTest(t)
+
+	c, bc, heads := newHeadStateCacheTest(t, 1)
+	defer close(bc.release)
+	go c.prepare(context.Background(), heads[0])
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&bc.calls) == 1 }, 5*time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, ok, err := c.get(ctx, heads[0].Key())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, ok)
+}
+
+func TestHeadStateCacheHeadChanges(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, bc, heads := newHeadStateCacheTest(t, headStateCacheSize+2)
+	close(bc.release)
+
+	changes := make(chan []*types.HeadChange)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.run(ctx, changes)
+	}()
+	// called from the goroutine of Eventually too, ctx is never done before the test ends
+	cached := func(ts *types.TipSet) bool {
+		_, _, ok, err := c.get(ctx, ts.Key())
+		return ok && err == nil
+	}
+
+	for _, head := range heads[:headStateCacheSize+1] {
+		changes <- []*types.HeadChange{{Type: types.HCApply, Val: head}}
+		head := head
+		require.Eventually(t, func() bool { return cached(head) }, 5*time.Second, time.Millisecond)
+	}
+	// the oldest head is evicted
+	assert.False(t, cached(heads[0]))
+	assert.True(t, cached(heads[1]))
+
+	// a reorg drops the reverted head and prepares the new one
+	last := heads[headStateCacheSize]
+	changes <- []*types.HeadChange{{Type: types.HCRevert, Val: last}, {Type: types.HCApply, Val: heads[headStateCacheSize+1]}}
+	require.Eventually(t, func() bool { return cached(heads[headStateCacheSize+1]) }, 5*time.Second, time.Millisecond)
+	assert.False(t, cached(last))
+
+	close(changes)
+	<-done
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/filecoin-project/go-address"
@@ -15,17 +17,21 @@ import (
 	"github.com/filecoin-project/venus/pkg/consensus"
 	"github.com/filecoin-project/venus/pkg/fork"
 	"github.com/filecoin-project/venus/pkg/fvm"
+	"github.com/filecoin-project/venus/pkg/metrics"
 	appstate "github.com/filecoin-project/venus/pkg/state"
 	"github.com/filecoin-project/venus/pkg/state/tree"
 	"github.com/filecoin-project/venus/pkg/vm"
 	"github.com/filecoin-project/venus/pkg/vm/gas"
 	"github.com/filecoin-project/venus/pkg/vm/vmcontext"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/cron"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/market"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/paych"
 	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log/v2"
+	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 )
 
@@ -44,6 +50,15 @@ type stateComputeResult struct {
 
 var _ IStateManager = &Stmgr{}
 
+var (
+	networkVersionKey    = tag.MustNewKey("network_version")
+	tipSetExecutionTimer = metrics.NewTimerMs("stmgr/tipset_execution", "Duration of executing a tipset in milliseconds", networkVersionKey)
+
+	messagesAppliedCt         = metrics.NewInt64Counter("stmgr/messages_applied_total", "The number of messages applied executing tipsets")
+	implicitMessagesAppliedCt = metrics.NewInt64Counter("stmgr/implicit_messages_applied_total", "The number of implicit messages applied executing tipsets")
+	cronExecutionsCt          = metrics.NewInt64Counter("stmgr/cron_executions_total", "The number of cron ticks executed, including the ones of null rounds")
+)
+
 type Stmgr struct {
 	cs  *chain.Store
 	ms  *chain.MessageStore
@@ -61,9 +76,17 @@ type Stmgr struct {
 	chsWorkingOn map[types.TipSetKey]chan struct{}
 	stLk         sync.Mutex
 
+	headStates *HeadStateCache
+
+	// newVM creates the vms messages are applied with outside of tipset execution
+	newVM VMConstructor
+
 	fStop   chan struct{}
 	fStopLk sync.Mutex
 
+	// lastExecTime is the duration in nanoseconds of the last tipset executed, accessed atomically
+	lastExecTime int64
+
 	log *logging.ZapEventLogger
 }
 
@@ -76,7 +99,7 @@ func NewStateManger(cs *chain.Store,
 	syscallsImpl vm.SyscallsImpl,
 	actorDebugging bool,
 ) *Stmgr {
-	return &Stmgr{
+	s := &Stmgr{
 		cs:             cs,
 		ms:             ms,
 		fork:           fork,
@@ -88,7 +111,26 @@ func NewStateManger(cs *chain.Store,
 		stCache:        make(map[types.TipSetKey]stateComputeResult),
 		chsWorkingOn:   make(map[types.TipSetKey]chan struct{}, 1),
 		actorDebugging: actorDebugging,
+		newVM:          fvm.NewVM,
 	}
+	s.headStates = newHeadStateCache(s.computeParentState)
+	return s
+}
+
+// VMConstructor creates a vm with the given options.
+type VMConstructor func(ctx context.Context, opts vm.VmOption) (vm.Interface, error)
+
+// SetVMConstructor replaces fvm.NewVM as the constructor of the vms Call, CallWithGas, SimulateMessages
+// and ComputeState apply messages with, e.g. with one returning a vm.MockFVM in tests. Tipset execution
+// is not affected.
+func (s *Stmgr) SetVMConstructor(newVM VMConstructor) {
+	s.newVM = newVM
+}
+
+// RunHeadStateCache precomputes the parent state of every new chain head, so ParentState
+// calls for the head don't have to compute it, until ctx is done.
+func (s *Stmgr) RunHeadStateCache(ctx context.Context) {
+	s.headStates.run(ctx, s.cs.SubHeadChanges(ctx))
 }
 
 func (s *Stmgr) ResolveToDeterministicAddress(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error) {
@@ -147,20 +189,35 @@ func (s *Stmgr) ParentState(ctx context.Context, ts *types.TipSet) (*types.TipSe
 	if ts == nil {
 		ts = s.cs.GetHead()
 	}
+
+	parent, root, ok, err := s.headStates.get(ctx, ts.Key())
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		if parent, root, err = s.computeParentState(ctx, ts); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	state, err := tree.LoadState(ctx, s.cs.Store(ctx), root)
+	return parent, state, err
+}
+
+// computeParentState runs the state transition of the parent of ts and checks it matches the parent state root of ts
+func (s *Stmgr) computeParentState(ctx context.Context, ts *types.TipSet) (*types.TipSet, cid.Cid, error) {
 	parent, err := s.cs.GetTipSet(ctx, ts.Parents())
 	if err != nil {
-		return nil, nil, fmt.Errorf("find tipset(%s) parent failed:%w",
+		return nil, cid.Undef, fmt.Errorf("find tipset(%s) parent failed:%w",
 			ts.Key().String(), err)
 	}
 
 	if stateRoot, _, err := s.RunStateTransition(ctx, parent, nil, false); err != nil {
-		return nil, nil, fmt.Errorf("runstateTransition failed:%w", err)
+		return nil, cid.Undef, fmt.Errorf("runstateTransition failed:%w", err)
 	} else if !stateRoot.Equals(ts.At(0).ParentStateRoot) {
-		return nil, nil, fmt.Errorf("runstateTransition error, %w", consensus.ErrStateRootMismatch)
+		return nil, cid.Undef, fmt.Errorf("runstateTransition error, %w", consensus.ErrStateRootMismatch)
 	}
-
-	state, err := tree.LoadState(ctx, s.cs.Store(ctx), ts.At(0).ParentStateRoot)
-	return parent, state, err
+	return parent, ts.At(0).ParentStateRoot, nil
 }
 
 func (s *Stmgr) TipsetStateTsk(ctx context.Context, tsk types.TipSetKey) (*types.TipSet, *tree.State, error) {
@@ -269,13 +326,46 @@ func (s *Stmgr) RunStateTransition(ctx context.Context, ts *types.TipSet, cb vm.
 		return ts.Blocks()[0].ParentStateRoot, ts.Blocks()[0].ParentMessageReceipts, nil
 	}
 
-	if root, receipts, err = s.cp.RunStateTransition(ctx, ts, cb, vmTracing); err != nil {
+	tagCtx := ctx
+	if s.fork != nil {
+		nv := s.fork.GetNetworkVersion(ctx, ts.Height())
+		tagCtx, _ = tag.New(ctx, tag.Upsert(networkVersionKey, strconv.Itoa(int(nv))))
+	}
+	// the metrics are only recorded here, where the state of a tipset is computed once for the chain, and not
+	// when a tipset is re-executed to replay or trace its messages
+	stopwatch := tipSetExecutionTimer.Start(tagCtx)
+	if root, receipts, err = s.cp.RunStateTransition(ctx, ts, countApplied(ctx, cb), vmTracing); err != nil {
 		return cid.Undef, cid.Undef, err
 	}
+	atomic.StoreInt64(&s.lastExecTime, int64(stopwatch.Stop(tagCtx)))
 
 	return root, receipts, nil
 }
 
+// countApplied wraps cb to count the messages applied executing a tipset, the implicit messages are the ones
+// sent by the system actor.
+func countApplied(ctx context.Context, cb vm.ExecCallBack) vm.ExecCallBack {
+	return func(mcid cid.Cid, msg *types.Message, ret *vm.Ret) error {
+		if msg.From == builtin.SystemActorAddr {
+			implicitMessagesAppliedCt.Inc(ctx, 1)
+			if msg.To == cron.Address {
+				cronExecutionsCt.Inc(ctx, 1)
+			}
+		} else {
+			messagesAppliedCt.Inc(ctx, 1)
+		}
+		if cb != nil {
+			return cb(mcid, msg, ret)
+		}
+		return nil
+	}
+}
+
+// LastTipSetExecutionTime returns how long executing the last tipset computed took, zero before any is.
+func (s *Stmgr) LastTipSetExecutionTime() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.lastExecTime))
+}
+
 // ctx context.Context, ts *types.TipSet, addr address.Address
 func (s *Stmgr) GetActorAtTsk(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*types.Actor, error) {
 	ts, err := s.cs.GetTipSet(ctx, tsk)
@@ -429,12 +519,17 @@ func (s *Stmgr) Replay(ctx context.Context, ts *types.TipSet, msgCID cid.Cid) (*
 		if msgCID.Equals(mcid) {
 			outm = msg
 			outr = ret
+			// the cron tick of the null rounds has the same cid as the one of the tipset, which is applied last
+			if msg.To == cron.Address {
+				return nil
+			}
 			return errHaltExecution
 		}
 		return nil
 	}
 
-	_, _, err := s.cp.RunStateTransition(ctx, ts, cb, true)
+	// the trace of the replayed message is returned whether or not it matches the trace filter
+	_, _, err := s.cp.RunStateTransition(consensus.WithUnfilteredTraces(ctx), ts, cb, true)
 	if err != nil && !errors.Is(err, errHaltExecution) {
 		return nil, nil, fmt.Errorf("unexpected error during execution: %w", err)
 	}
@@ -536,7 +631,7 @@ func ComputeState(ctx context.Context, s *Stmgr, height abi.ChainEpoch, msgs []*
 		ActorDebugging:      s.actorDebugging,
 	}
 
-	vmi, err := fvm.NewVM(ctx, vmopt)
+	vmi, err := s.newVM(ctx, vmopt)
 	if err != nil {
 		return cid.Undef, nil, err
 	}
@@ -0,0 +1,137 @@
+package statemanger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/consensus"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/pkg/vm"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/cron"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/reward"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type appliedMessage struct {
+	msg *types.Message
+	ret *vm.Ret
+}
+
+// fakeTransformer hands the messages to the callback in order, the way the processor applies a tipset
+type fakeTransformer struct {
+	applied []appliedMessage
+	calls   int
+	// root is returned as the state root and the receipts root of the tipset
+	root cid.Cid
+	// unfiltered records whether the last run ignored the trace filter
+	unfiltered bool
+}
+
+func (f *fakeTransformer) RunStateTransition(ctx context.Context, ts *types.TipSet, cb vm.ExecCallBack, vmTracing bool) (cid.Cid, cid.Cid, error) {
+	f.unfiltered = consensus.UnfilteredTraces(ctx)
+	for _, am := range f.applied {
+		f.calls++
+		if err := cb(am.msg.Cid(), am.msg, am.ret); err != nil {
+			return cid.Undef, cid.Undef, err
+		}
+	}
+	return f.root, f.root, nil
+}
+
+func TestReplayImplicitMessages(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	cronTick := &types.Message{To: cron.Address, From: builtin.SystemActorAddr, Value: big.Zero(), Method: cron.Methods.EpochTick}
+	rewardMsg := &types.Message{To: reward.Address, From: builtin.SystemActorAddr, Value: big.Zero(), Method: 2}
+	nullRoundCron := &vm.Ret{Duration: 1}
+	tipsetCron := &vm.Ret{Duration: 2}
+	rewardRet := &vm.Ret{Duration: 3}
+
+	cp := &fakeTransformer{applied: []appliedMessage{
+		{cronTick, nullRoundCron},
+		{rewardMsg, rewardRet},
+		{cronTick, tipsetCron},
+	}}
+	stmgr := &Stmgr{cp: cp}
+
+	// the cron tick of the tipset is applied after the ones of the null rounds
+	_, ret, err := stmgr.Replay(ctx, &types.TipSet{}, cronTick.Cid())
+	require.NoError(t, err)
+	assert.Same(t, tipsetCron, ret)
+	// the trace of the replayed message is kept whatever the trace filter
+	assert.True(t, cp.unfiltered)
+
+	cp.calls = 0
+	msg, ret, err := stmgr.Replay(ctx, &types.TipSet{}, rewardMsg.Cid())
+	require.NoError(t, err)
+	assert.Equal(t, rewardMsg.Cid(), msg.Cid())
+	assert.Same(t, rewardRet, ret)
+	assert.Equal(t, 2, cp.calls)
+
+	_, _, err = stmgr.Replay(ctx, &types.TipSet{}, (&types.Message{To: cron.Address, From: builtin.SystemActorAddr, Nonce: 1}).Cid())
+	assert.ErrorContains(t, err, "not found")
+}
+
+// countOf returns the number of times the counter of the view name was incremented
+func countOf(t *testing.T, name string) int64 {
+	rows, err := view.RetrieveData(name)
+	require.NoError(t, err)
+	if len(rows) == 0 {
+		return 0
+	}
+	return rows[0].Data.(*view.CountData).Value
+}
+
+func TestRunStateTransitionMetrics(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	ts := builder.AppendOn(ctx, builder.Genesis(), 1)
+
+	cronTick := &types.Message{To: cron.Address, From: builtin.SystemActorAddr, Value: big.Zero(), Method: cron.Methods.EpochTick}
+	rewardMsg := &types.Message{To: reward.Address, From: builtin.SystemActorAddr, Value: big.Zero(), Method: 2}
+	send := &types.Message{To: reward.Address, From: builtin.BurntFundsActorAddr, Value: big.Zero()}
+	cp := &fakeTransformer{applied: []appliedMessage{
+		{cronTick, &vm.Ret{}},
+		{send, &vm.Ret{}},
+		{send, &vm.Ret{}},
+		{rewardMsg, &vm.Ret{}},
+		{cronTick, &vm.Ret{}},
+	}, root: ts.ParentState()}
+	stmgr := &Stmgr{cs: builder.Store(), cp: cp, chsWorkingOn: make(map[types.TipSetKey]chan struct{})}
+
+	counts := func() [3]int64 {
+		return [3]int64{
+			countOf(t, "stmgr/messages_applied_total"),
+			countOf(t, "stmgr/implicit_messages_applied_total"),
+			countOf(t, "stmgr/cron_executions_total"),
+		}
+	}
+	before := counts()
+
+	_, _, err := stmgr.RunStateTransition(ctx, ts, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, [3]int64{before[0] + 2, before[1] + 3, before[2] + 2}, counts())
+	assert.False(t, cp.unfiltered)
+
+	// the state of the tipset is not computed again
+	_, _, err = stmgr.RunStateTransition(ctx, ts, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, [3]int64{before[0] + 2, before[1] + 3, before[2] + 2}, counts())
+
+	// replaying the messages of the tipset doesn't count them
+	_, _, err = stmgr.Replay(ctx, ts, send.Cid())
+	require.NoError(t, err)
+	assert.Equal(t, [3]int64{before[0] + 2, before[1] + 3, before[2] + 2}, counts())
+}
@@ -0,0 +1,90 @@
+package chainsnapshot
+
+import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// Scenario names of the configs returned by Scenarios
+const (
+	NullBlocks        = "null_blocks"
+	ActorsAndMessages = "actors_and_messages"
+	LongChain         = "long_chain"
+	Upgrade           = "upgrade"
+)
+
+// Alice, Bob and Carol are the accounts of the scenarios with actors
+var (
+	Alice = mustSecpAddress("alice")
+	Bob   = mustSecpAddress("bob")
+	Carol = mustSecpAddress("carol")
+)
+
+// Scenarios returns the configs of the chains commonly needed by tests, keyed by scenario name.
+// Every call returns new configs, callers may modify them.
+func Scenarios() map[string]*SnapshotConfig {
+	return map[string]*SnapshotConfig{
+		NullBlocks: {
+			Epochs:             30,
+			NullBlockFrequency: 3,
+		},
+		ActorsAndMessages: {
+			Epochs: 10,
+			Actors: []SnapshotActor{
+				{Address: Alice, Balance: types.FromFil(1000)},
+				{Address: Bob, Balance: types.FromFil(100), Nonce: 5},
+				{Address: Carol},
+			},
+			Messages: map[abi.ChainEpoch][]types.ChainMsg{
+				2: {TransferMessage(Alice, Bob, 0), TransferMessage(Alice, Carol, 1)},
+				5: {&types.SignedMessage{
+					Message:   *TransferMessage(Bob, Alice, 5),
+					Signature: crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: []byte("signature")},
+				}},
+				9: {TransferMessage(Alice, Bob, 2)},
+			},
+		},
+		LongChain: {
+			Epochs:             200,
+			NullBlockFrequency: 50,
+		},
+		// the upgrade epoch is a null round, the migration runs when the tipset after it is executed
+		Upgrade: {
+			Epochs:             20,
+			NullBlockFrequency: 10,
+			UpgradeEpoch:       10,
+			Actors: []SnapshotActor{
+				{Address: Alice, Balance: types.FromFil(1000)},
+				{Address: Bob},
+			},
+			Messages: map[abi.ChainEpoch][]types.ChainMsg{
+				5:  {TransferMessage(Alice, Bob, 0)},
+				15: {TransferMessage(Alice, Bob, 1)},
+			},
+		},
+	}
+}
+
+// TransferMessage returns a message sending 1 FIL from from to to.
+func TransferMessage(from, to address.Address, nonce uint64) *types.Message {
+	return &types.Message{
+		From:       from,
+		To:         to,
+		Nonce:      nonce,
+		Value:      types.FromFil(1),
+		GasLimit:   10_000_000,
+		GasFeeCap:  abi.NewTokenAmount(1000),
+		GasPremium: abi.NewTokenAmount(100),
+	}
+}
+
+func mustSecpAddress(seed string) address.Address {
+	addr, err := address.NewSecp256k1Address([]byte(seed))
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
@@ -0,0 +1,393 @@
+// Package chainsnapshot generates small executed chains as CAR files, for tests needing a chain in a given state.
+package chainsnapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	acrypto "github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/filecoin-project/go-state-types/network"
+	miner2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/miner"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/ipld/go-car"
+	carutil "github.com/ipld/go-car/util"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/fork"
+	"github.com/filecoin-project/venus/pkg/fvm"
+	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/state"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/pkg/util/ffiwrapper/impl"
+	"github.com/filecoin-project/venus/pkg/vm"
+	"github.com/filecoin-project/venus/pkg/vm/gas"
+	"github.com/filecoin-project/venus/pkg/vm/vmcontext"
+	"github.com/filecoin-project/venus/pkg/vmsupport"
+	gengen "github.com/filecoin-project/venus/tools/gengen/util"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/adt"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/account"
+	init_ "github.com/filecoin-project/venus/venus-shared/actors/builtin/init"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const (
+	// GenesisNetworkVersion is the network version of a generated chain before its upgrade
+	GenesisNetworkVersion = network.Version9
+	// UpgradeNetworkVersion is the network version of a generated chain from its upgrade on, with actors v3
+	UpgradeNetworkVersion = network.Version10
+)
+
+// SnapshotConfig describes a chain generated by GenerateTestChainSnapshot.
+type SnapshotConfig struct {
+	// Epochs is the height of the head of the chain, the head is never a null round
+	Epochs abi.ChainEpoch
+	// NullBlockFrequency makes every NullBlockFrequency-th epoch a null round, zero for none
+	NullBlockFrequency abi.ChainEpoch
+	// Actors are account actors created on top of the genesis state
+	Actors []SnapshotActor
+	// Messages are included in the block of the epoch they are keyed by, either as *types.SignedMessage
+	// or as unsigned bls *types.Message. Messages of null rounds are dropped.
+	Messages map[abi.ChainEpoch][]types.ChainMsg
+	// Miner mines every block, a fixed secp256k1 address is used when undefined
+	Miner address.Address
+	// UpgradeEpoch is the epoch the chain migrates to UpgradeNetworkVersion at, zero for no upgrade
+	UpgradeEpoch abi.ChainEpoch
+}
+
+// SnapshotActor is an account actor created in a generated chain.
+type SnapshotActor struct {
+	// Address is the key address of the account
+	Address address.Address
+	Balance abi.TokenAmount
+	Nonce   uint64
+}
+
+// GenerateTestChainSnapshot builds a chain on top of a genesis of v2 actors as described by cfg and
+// exports it as a CAR file rooted at its head, with the state and receipts of every tipset.
+//
+// The messages are executed: the parent state and receipts of a block are the result of applying the
+// messages of its parent, bls messages first, at the network version of the epoch, running the upgrade
+// migration on the way. No cron or reward messages are applied. Like the Builder the blocks carry no valid
+// tickets, proofs or signatures, and the signatures of messages aren't checked.
+func GenerateTestChainSnapshot(cfg *SnapshotConfig) ([]byte, error) {
+	if cfg.Epochs < 1 {
+		return nil, fmt.Errorf("invalid snapshot height %d", cfg.Epochs)
+	}
+	if cfg.NullBlockFrequency < 0 {
+		return nil, fmt.Errorf("invalid null block frequency %d", cfg.NullBlockFrequency)
+	}
+	if cfg.UpgradeEpoch < 0 {
+		return nil, fmt.Errorf("invalid upgrade epoch %d", cfg.UpgradeEpoch)
+	}
+	ctx := context.TODO()
+
+	miner := cfg.Miner
+	if miner.Empty() {
+		var err error
+		if miner, err = address.NewSecp256k1Address([]byte("miner")); err != nil {
+			return nil, err
+		}
+	}
+
+	r := repo.NewInMemoryRepo()
+	bs := r.Datastore()
+	cst := cbor.NewCborStore(bs)
+	mstore := chain.NewMessageStore(bs, config.DefaultForkUpgradeParam)
+
+	genesis, err := generateGenesis(ctx, bs)
+	if err != nil {
+		return nil, fmt.Errorf("generating genesis: %w", err)
+	}
+	stateRoot, err := createSnapshotActors(ctx, cst, genesis.ParentStateRoot, cfg.Actors)
+	if err != nil {
+		return nil, fmt.Errorf("creating actors: %w", err)
+	}
+	chainFork, err := fork.NewChainFork(ctx, nil, cst, bs, snapshotNetworkParams(cfg.UpgradeEpoch))
+	if err != nil {
+		return nil, err
+	}
+
+	var receipts []types.MessageReceipt
+	head, err := types.NewTipSet([]*types.BlockHeader{genesis})
+	if err != nil {
+		return nil, err
+	}
+	for h := abi.ChainEpoch(1); h <= cfg.Epochs; h++ {
+		if cfg.NullBlockFrequency > 0 && h%cfg.NullBlockFrequency == 0 && h != cfg.Epochs {
+			continue
+		}
+
+		var secpMsgs []*types.SignedMessage
+		var blsMsgs []*types.Message
+		for _, msg := range cfg.Messages[h] {
+			switch m := msg.(type) {
+			case *types.SignedMessage:
+				secpMsgs = append(secpMsgs, m)
+			case *types.Message:
+				blsMsgs = append(blsMsgs, m)
+			default:
+				return nil, fmt.Errorf("unexpected message type %T at epoch %d", msg, h)
+			}
+		}
+		msgMeta, err := mstore.StoreMessages(ctx, secpMsgs, blsMsgs)
+		if err != nil {
+			return nil, fmt.Errorf("storing messages of epoch %d: %w", h, err)
+		}
+		receiptsRoot, err := mstore.StoreReceipts(ctx, receipts)
+		if err != nil {
+			return nil, err
+		}
+
+		vrf := make([]byte, 8)
+		binary.BigEndian.PutUint64(vrf, uint64(h))
+		blk := &types.BlockHeader{
+			Miner:                 miner,
+			Ticket:                &types.Ticket{VRFProof: vrf},
+			ElectionProof:         &types.ElectionProof{WinCount: 1, VRFProof: vrf},
+			Parents:               head.Cids(),
+			ParentWeight:          big.Add(head.ParentWeight(), big.NewInt(1)),
+			Height:                h,
+			ParentStateRoot:       stateRoot,
+			ParentMessageReceipts: receiptsRoot,
+			Messages:              msgMeta,
+			BLSAggregate:          &crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte{}},
+			Timestamp:             genesis.Timestamp + uint64(h)*constants.MainNetBlockDelaySecs,
+			BlockSig:              &crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: []byte{}},
+			ParentBaseFee:         abi.NewTokenAmount(constants.MinimumBaseFee),
+		}
+		if _, err := cst.Put(ctx, blk); err != nil {
+			return nil, fmt.Errorf("storing block of epoch %d: %w", h, err)
+		}
+		parentHeight := head.Height()
+		if head, err = types.NewTipSet([]*types.BlockHeader{blk}); err != nil {
+			return nil, err
+		}
+
+		msgs := make([]types.ChainMsg, 0, len(blsMsgs)+len(secpMsgs))
+		for _, m := range blsMsgs {
+			msgs = append(msgs, m)
+		}
+		for _, m := range secpMsgs {
+			msgs = append(msgs, m)
+		}
+		if stateRoot, receipts, err = execute(ctx, bs, chainFork, head, parentHeight, msgs); err != nil {
+			return nil, fmt.Errorf("executing epoch %d: %w", h, err)
+		}
+	}
+
+	store := chain.NewStore(r.ChainDatastore(), bs, genesis.Cid(), chain.NewMockCirculatingSupplyCalculator())
+	buf := new(bytes.Buffer)
+	if err := car.WriteHeader(&car.CarHeader{Roots: head.Cids(), Version: 1}, buf); err != nil {
+		return nil, err
+	}
+	// unlike Store.Export the receipts are kept, tests look them up
+	if err := store.WalkSnapshot(ctx, head, cfg.Epochs+1, false, false, func(c cid.Cid) error {
+		blk, err := bs.Get(ctx, c)
+		if err != nil {
+			return err
+		}
+		return carutil.LdWrite(buf, c.Bytes(), blk.RawData())
+	}); err != nil {
+		return nil, fmt.Errorf("exporting chain: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// generateGenesis creates a genesis of v2 actors without miners in bs.
+func generateGenesis(ctx context.Context, bs blockstoreutil.Blockstore) (*types.BlockHeader, error) {
+	// gengen restricts the proof types of new miners to the ones of the miners of the config
+	proofTypes := miner2.PreCommitSealProofTypesV0
+	info, err := gengen.GenGen(ctx, &gengen.GenesisCfg{Seed: 671, Network: "snapshot", Time: 123456789}, bs)
+	miner2.PreCommitSealProofTypesV0 = proofTypes
+	if err != nil {
+		return nil, err
+	}
+
+	var genesis types.BlockHeader
+	if err := cbor.NewCborStore(bs).Get(ctx, info.GenesisCid, &genesis); err != nil {
+		return nil, fmt.Errorf("loading genesis block: %w", err)
+	}
+	return &genesis, nil
+}
+
+// snapshotNetworkParams are the network parameters of a generated chain, which starts at GenesisNetworkVersion
+// and upgrades to UpgradeNetworkVersion at upgradeEpoch, if any.
+func snapshotNetworkParams(upgradeEpoch abi.ChainEpoch) *config.NetworkParamsConfig {
+	never := abi.ChainEpoch(1 << 50)
+	if upgradeEpoch == 0 {
+		upgradeEpoch = never
+	}
+	return &config.NetworkParamsConfig{
+		NetworkType:           types.Network2k,
+		GenesisNetworkVersion: GenesisNetworkVersion,
+		ForkUpgradeParam: &config.ForkUpgradeConfig{
+			UpgradeBreezeHeight:        -1,
+			UpgradeSmokeHeight:         -1,
+			UpgradeIgnitionHeight:      -1,
+			UpgradeRefuelHeight:        -1,
+			UpgradeAssemblyHeight:      -1,
+			UpgradeTapeHeight:          -1,
+			UpgradeLiftoffHeight:       -1,
+			UpgradeKumquatHeight:       -1,
+			UpgradePriceListOopsHeight: -1,
+			UpgradeCalicoHeight:        -1,
+			UpgradePersianHeight:       -1,
+			UpgradeOrangeHeight:        -1,
+			UpgradeClausHeight:         -1,
+			UpgradeTrustHeight:         upgradeEpoch,
+			UpgradeNorwegianHeight:     never + 1,
+			UpgradeTurboHeight:         never + 2,
+			UpgradeHyperdriveHeight:    never + 3,
+			UpgradeChocolateHeight:     never + 4,
+			UpgradeOhSnapHeight:        never + 5,
+			UpgradeSkyrHeight:          never + 6,
+			UpgradeSharkHeight:         never + 7,
+			UpgradeHyggeHeight:         never + 8,
+			UpgradeLightningHeight:     never + 9,
+			UpgradeThunderHeight:       never + 10,
+		},
+	}
+}
+
+// execute runs the migrations of the epochs from parentHeight up to the height of ts on the parent state of ts,
+// then applies msgs and returns the resulting state with the receipts of msgs.
+func execute(ctx context.Context, bs blockstoreutil.Blockstore, chainFork *fork.ChainFork, ts *types.TipSet, parentHeight abi.ChainEpoch, msgs []types.ChainMsg) (cid.Cid, []types.MessageReceipt, error) {
+	root := ts.At(0).ParentStateRoot
+	for h := parentHeight; h < ts.Height(); h++ {
+		var err error
+		if root, err = chainFork.HandleStateForks(ctx, root, h, ts); err != nil {
+			return cid.Undef, nil, fmt.Errorf("migrating at %d: %w", h, err)
+		}
+	}
+
+	cst := cbor.NewCborStore(bs)
+	vmi, err := fvm.NewVM(ctx, vm.VmOption{
+		CircSupplyCalculator: func(context.Context, abi.ChainEpoch, tree.Tree) (abi.TokenAmount, error) {
+			return big.Zero(), nil
+		},
+		LookbackStateGetter: func(context.Context, abi.ChainEpoch) (*state.View, error) {
+			return state.NewView(cst, root), nil
+		},
+		TipSetGetter: func(context.Context, abi.ChainEpoch) (types.TipSetKey, error) {
+			return ts.Parents(), nil
+		},
+		NetworkVersion:   chainFork.GetNetworkVersion(ctx, ts.Height()),
+		Rnd:              fixedRand{},
+		BaseFee:          ts.Blocks()[0].ParentBaseFee,
+		Fork:             chainFork,
+		Epoch:            ts.Height(),
+		Timestamp:        ts.MinTimestamp(),
+		GasPriceSchedule: gas.NewPricesSchedule(config.DefaultForkUpgradeParam),
+		PRoot:            root,
+		Bsstore:          bs,
+		SysCallsImpl:     vmsupport.NewSyscalls(&vmsupport.NilFaultChecker{}, &impl.FakeVerifier{}),
+	})
+	if err != nil {
+		return cid.Undef, nil, err
+	}
+
+	receipts := make([]types.MessageReceipt, 0, len(msgs))
+	for i, m := range msgs {
+		ret, err := vmi.ApplyMessage(ctx, m)
+		if err != nil {
+			return cid.Undef, nil, fmt.Errorf("applying message (%d, %s): %w", i, m.Cid(), err)
+		}
+		receipts = append(receipts, ret.Receipt)
+	}
+	root, err = vmi.Flush(ctx)
+	if err != nil {
+		return cid.Undef, nil, err
+	}
+	return root, receipts, nil
+}
+
+// createSnapshotActors adds account actors to the state at root and returns the new root.
+func createSnapshotActors(ctx context.Context, cst cbor.IpldStore, root cid.Cid, actorsToCreate []SnapshotActor) (cid.Cid, error) {
+	if len(actorsToCreate) == 0 {
+		return root, nil
+	}
+
+	st, err := tree.LoadState(ctx, cst, root)
+	if err != nil {
+		return cid.Undef, err
+	}
+	av, err := stateActorsVersion(ctx, st)
+	if err != nil {
+		return cid.Undef, err
+	}
+	code, ok := actors.GetActorCodeID(av, manifest.AccountKey)
+	if !ok {
+		return cid.Undef, fmt.Errorf("no account actor code for actors version %d", av)
+	}
+
+	for _, a := range actorsToCreate {
+		if a.Address.Protocol() != address.SECP256K1 && a.Address.Protocol() != address.BLS {
+			return cid.Undef, fmt.Errorf("actor address %s is not a key address", a.Address)
+		}
+		if _, err := st.RegisterNewAddress(a.Address); err != nil {
+			return cid.Undef, fmt.Errorf("registering %s: %w", a.Address, err)
+		}
+
+		ast, err := account.MakeState(adt.WrapStore(ctx, cst), av, a.Address)
+		if err != nil {
+			return cid.Undef, err
+		}
+		head, err := cst.Put(ctx, ast.GetState())
+		if err != nil {
+			return cid.Undef, err
+		}
+		balance := a.Balance
+		if balance.Nil() {
+			balance = big.Zero()
+		}
+		if err := st.SetActor(ctx, a.Address, &types.Actor{
+			Code:    code,
+			Head:    head,
+			Nonce:   a.Nonce,
+			Balance: balance,
+		}); err != nil {
+			return cid.Undef, fmt.Errorf("setting %s: %w", a.Address, err)
+		}
+	}
+	return st.Flush(ctx)
+}
+
+// stateActorsVersion returns the actors version of st by the code of its init actor.
+func stateActorsVersion(ctx context.Context, st *tree.State) (actorstypes.Version, error) {
+	initAct, found, err := st.GetActor(ctx, init_.Address)
+	if err != nil || !found {
+		return 0, fmt.Errorf("loading init actor: %v", err)
+	}
+	for _, v := range actors.Versions {
+		if code, ok := actors.GetActorCodeID(actorstypes.Version(v), manifest.InitKey); ok && code == initAct.Code {
+			return actorstypes.Version(v), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown init actor code %s", initAct.Code)
+}
+
+// fixedRand returns the same randomness for every draw.
+type fixedRand struct{}
+
+var _ vmcontext.HeadChainRandomness = fixedRand{}
+
+func (fixedRand) ChainGetRandomnessFromBeacon(context.Context, acrypto.DomainSeparationTag, abi.ChainEpoch, []byte) (abi.Randomness, error) {
+	return []byte("i_am_random_____i_am_random_____"), nil
+}
+
+func (fixedRand) ChainGetRandomnessFromTickets(context.Context, acrypto.DomainSeparationTag, abi.ChainEpoch, []byte) (abi.Randomness, error) {
+	return []byte("i_am_random_____i_am_random_____"), nil
+}
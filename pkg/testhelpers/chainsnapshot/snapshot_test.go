@@ -0,0 +1,242 @@
+// stm: #unit
+package chainsnapshot_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/pkg/testhelpers/chainsnapshot"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	init_ "github.com/filecoin-project/venus/venus-shared/actors/builtin/init"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type snapshot struct {
+	store  *chain.Store
+	mstore *chain.MessageStore
+	cst    cbor.IpldStore
+	head   *types.TipSet
+}
+
+// importSnapshot imports data into a new store.
+func importSnapshot(ctx context.Context, t *testing.T, data []byte) *snapshot {
+	r := repo.NewInMemoryRepo()
+	store := chain.NewStore(r.ChainDatastore(), r.Datastore(), cid.Undef, chain.NewMockCirculatingSupplyCalculator())
+	head, err := store.Import(ctx, bytes.NewReader(data))
+	require.NoError(t, err)
+	return &snapshot{
+		store:  store,
+		mstore: chain.NewMessageStore(r.Datastore(), config.DefaultForkUpgradeParam),
+		cst:    cbor.NewCborStore(r.Datastore()),
+		head:   head,
+	}
+}
+
+// heights returns the heights of the tipsets from the head down to genesis.
+func (s *snapshot) heights(ctx context.Context, t *testing.T) []abi.ChainEpoch {
+	var heights []abi.ChainEpoch
+	ts := s.head
+	for {
+		heights = append(heights, ts.Height())
+		if ts.Height() == 0 {
+			return heights
+		}
+		var err error
+		ts, err = s.store.GetTipSet(ctx, ts.Parents())
+		require.NoError(t, err)
+	}
+}
+
+// child returns the tipset following the one at h, which holds the result of its execution.
+func (s *snapshot) child(ctx context.Context, t *testing.T, h abi.ChainEpoch) *types.TipSet {
+	ts, err := s.store.GetTipSetByHeight(ctx, s.head, h+1, false)
+	require.NoError(t, err)
+	return ts
+}
+
+func (s *snapshot) actor(ctx context.Context, t *testing.T, ts *types.TipSet, addr address.Address) *types.Actor {
+	st, err := tree.LoadState(ctx, s.cst, ts.ParentState())
+	require.NoError(t, err)
+	act, found, err := st.GetActor(ctx, addr)
+	require.NoError(t, err)
+	require.True(t, found, addr)
+	return act
+}
+
+func TestGenerateTestChainSnapshot(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	t.Run("null blocks", func(t *testing.T) {
+		data, err := chainsnapshot.GenerateTestChainSnapshot(&chainsnapshot.SnapshotConfig{Epochs: 9, NullBlockFrequency: 3})
+		require.NoError(t, err)
+
+		s := importSnapshot(ctx, t, data)
+		assert.Equal(t, []abi.ChainEpoch{9, 8, 7, 5, 4, 2, 1, 0}, s.heights(ctx, t))
+	})
+
+	t.Run("messages are executed", func(t *testing.T) {
+		cfg := chainsnapshot.Scenarios()[chainsnapshot.ActorsAndMessages]
+		data, err := chainsnapshot.GenerateTestChainSnapshot(cfg)
+		require.NoError(t, err)
+		s := importSnapshot(ctx, t, data)
+		require.Len(t, s.heights(ctx, t), int(cfg.Epochs)+1)
+
+		for h, msgs := range cfg.Messages {
+			ts, err := s.store.GetTipSetByHeight(ctx, s.head, h, false)
+			require.NoError(t, err)
+			secpMsgs, blsMsgs, err := s.mstore.LoadMetaMessages(ctx, ts.At(0).Messages)
+			require.NoError(t, err)
+
+			var got []cid.Cid
+			for _, m := range blsMsgs {
+				got = append(got, m.Cid())
+			}
+			for _, m := range secpMsgs {
+				got = append(got, m.Cid())
+			}
+			var want []cid.Cid
+			for _, m := range msgs {
+				want = append(want, m.Cid())
+			}
+			assert.ElementsMatch(t, want, got, "messages of epoch %d", h)
+
+			receipts, err := s.mstore.LoadReceipts(ctx, s.child(ctx, t, h).At(0).ParentMessageReceipts)
+			require.NoError(t, err)
+			require.Len(t, receipts, len(msgs), "receipts of epoch %d", h)
+			for _, r := range receipts {
+				assert.Equal(t, exitcode.Ok, r.ExitCode)
+				assert.Positive(t, r.GasUsed)
+			}
+		}
+
+		// the actors are created at genesis, the head state holds the effects of the messages before it
+		genesis, err := s.store.GetTipSetByHeight(ctx, s.head, 1, false)
+		require.NoError(t, err)
+		for _, a := range cfg.Actors {
+			act := s.actor(ctx, t, genesis, a.Address)
+			assert.Equal(t, a.Nonce, act.Nonce)
+			if a.Balance.Nil() {
+				assert.True(t, act.Balance.IsZero())
+			} else {
+				assert.True(t, big.Cmp(a.Balance, act.Balance) == 0)
+			}
+		}
+		alice := s.actor(ctx, t, s.head, chainsnapshot.Alice)
+		bob := s.actor(ctx, t, s.head, chainsnapshot.Bob)
+		carol := s.actor(ctx, t, s.head, chainsnapshot.Carol)
+		assert.Equal(t, uint64(3), alice.Nonce)
+		assert.Equal(t, uint64(6), bob.Nonce)
+		assert.Equal(t, uint64(0), carol.Nonce)
+		// alice sent 3 FIL and received 1, paying the gas
+		assert.True(t, big.Cmp(alice.Balance, types.FromFil(998)) < 0)
+		assert.True(t, big.Cmp(alice.Balance, types.FromFil(997)) > 0)
+		assert.True(t, big.Cmp(carol.Balance, types.FromFil(1)) == 0)
+	})
+
+	t.Run("upgrade", func(t *testing.T) {
+		cfg := chainsnapshot.Scenarios()[chainsnapshot.Upgrade]
+		data, err := chainsnapshot.GenerateTestChainSnapshot(cfg)
+		require.NoError(t, err)
+		s := importSnapshot(ctx, t, data)
+
+		initCode := func(ts *types.TipSet) cid.Cid {
+			return s.actor(ctx, t, ts, init_.Address).Code
+		}
+		v2Init, _ := actors.GetActorCodeID(actorstypes.Version2, manifest.InitKey)
+		v3Init, _ := actors.GetActorCodeID(actorstypes.Version3, manifest.InitKey)
+
+		before := s.child(ctx, t, cfg.UpgradeEpoch-2)
+		assert.Equal(t, v2Init, initCode(before))
+		// the migration runs with the first tipset after the upgrade epoch
+		after := s.child(ctx, t, cfg.UpgradeEpoch+1)
+		assert.Equal(t, v3Init, initCode(after))
+		assert.Equal(t, v3Init, initCode(s.head))
+
+		st, err := tree.LoadState(ctx, s.cst, s.head.ParentState())
+		require.NoError(t, err)
+		assert.Equal(t, tree.StateTreeVersion2, st.Version())
+
+		// messages are executed on both sides of the upgrade
+		for h := range cfg.Messages {
+			receipts, err := s.mstore.LoadReceipts(ctx, s.child(ctx, t, h).At(0).ParentMessageReceipts)
+			require.NoError(t, err)
+			require.Len(t, receipts, 1)
+			assert.Equal(t, exitcode.Ok, receipts[0].ExitCode)
+		}
+		assert.Equal(t, uint64(2), s.actor(ctx, t, s.head, chainsnapshot.Alice).Nonce)
+		assert.True(t, big.Cmp(s.actor(ctx, t, s.head, chainsnapshot.Bob).Balance, types.FromFil(2)) == 0)
+	})
+
+	t.Run("failing messages", func(t *testing.T) {
+		data, err := chainsnapshot.GenerateTestChainSnapshot(&chainsnapshot.SnapshotConfig{
+			Epochs: 3,
+			Actors: []chainsnapshot.SnapshotActor{{Address: chainsnapshot.Alice}, {Address: chainsnapshot.Bob}},
+			Messages: map[abi.ChainEpoch][]types.ChainMsg{
+				1: {chainsnapshot.TransferMessage(chainsnapshot.Alice, chainsnapshot.Bob, 0)},
+			},
+		})
+		require.NoError(t, err)
+		s := importSnapshot(ctx, t, data)
+
+		// alice can't pay for the gas, the message is not applied
+		receipts, err := s.mstore.LoadReceipts(ctx, s.child(ctx, t, 1).At(0).ParentMessageReceipts)
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		assert.Equal(t, exitcode.SysErrSenderStateInvalid, receipts[0].ExitCode)
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		cfg := chainsnapshot.Scenarios()[chainsnapshot.ActorsAndMessages]
+		first, err := chainsnapshot.GenerateTestChainSnapshot(cfg)
+		require.NoError(t, err)
+		second, err := chainsnapshot.GenerateTestChainSnapshot(cfg)
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(first, second))
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		id, err := address.NewIDAddress(1000)
+		require.NoError(t, err)
+		for _, cfg := range []*chainsnapshot.SnapshotConfig{
+			{},
+			{Epochs: 1, NullBlockFrequency: -1},
+			{Epochs: 1, UpgradeEpoch: -1},
+			{Epochs: 1, Actors: []chainsnapshot.SnapshotActor{{Address: id}}},
+		} {
+			_, err := chainsnapshot.GenerateTestChainSnapshot(cfg)
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestScenarios(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	for name, cfg := range chainsnapshot.Scenarios() {
+		name, cfg := name, cfg
+		t.Run(name, func(t *testing.T) {
+			data, err := chainsnapshot.GenerateTestChainSnapshot(cfg)
+			require.NoError(t, err)
+			assert.Equal(t, cfg.Epochs, importSnapshot(ctx, t, data).head.Height())
+		})
+	}
+}
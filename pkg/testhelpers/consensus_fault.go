@@ -0,0 +1,142 @@
+package testhelpers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/wallet/key"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// FaultType is a consensus fault, the values match the ones of the actors.
+type FaultType int
+
+const (
+	DoubleForkMiningFault FaultType = iota + 1
+	ParentGrindingFault
+	TimeOffsetMiningFault
+)
+
+func (f FaultType) String() string {
+	switch f {
+	case DoubleForkMiningFault:
+		return "double-fork mining"
+	case ParentGrindingFault:
+		return "parent grinding"
+	case TimeOffsetMiningFault:
+		return "time-offset mining"
+	default:
+		return fmt.Sprintf("unknown fault %d", int(f))
+	}
+}
+
+var (
+	// FaultMiner mines the faulty blocks generated by GenerateConsensusFaultProof
+	FaultMiner = mustIDAddress(1000)
+	// faultWitnessMiner mines the witness block of a parent grinding fault
+	faultWitnessMiner = mustIDAddress(1001)
+)
+
+// GenerateConsensusFaultProof builds the serialized block headers proving FaultMiner committed faultType at
+// epoch, as taken by VerifyConsensusFault. The faulty blocks are signed with workerKey, which has to be the
+// worker key of FaultMiner in the lookback state for the proof to be accepted. extra is only set for parent
+// grinding faults, it is the sibling of a included by b in its parents instead of a.
+func GenerateConsensusFaultProof(ctx context.Context, workerKey key.KeyInfo, faultType FaultType, epoch abi.ChainEpoch) (a, b, extra []byte, err error) {
+	if epoch < 1 {
+		return nil, nil, nil, fmt.Errorf("invalid fault epoch %d", epoch)
+	}
+
+	parent := faultBlock(faultWitnessMiner, epoch-1, []cid.Cid{EmptyTxMetaCID}, 0)
+	parents := []cid.Cid{parent.Cid()}
+
+	var blkA, blkB, blkC *types.BlockHeader
+	switch faultType {
+	case DoubleForkMiningFault:
+		// two blocks at the same epoch
+		blkA = faultBlock(FaultMiner, epoch, parents, 1)
+		blkB = faultBlock(FaultMiner, epoch, parents, 2)
+	case TimeOffsetMiningFault:
+		// two blocks with the same parents at different epochs
+		blkA = faultBlock(FaultMiner, epoch, parents, 1)
+		blkB = faultBlock(FaultMiner, epoch+1, parents, 2)
+	case ParentGrindingFault:
+		// b builds on c, a sibling of a, omitting a from its parents
+		blkA = faultBlock(FaultMiner, epoch, parents, 1)
+		blkC = faultBlock(faultWitnessMiner, epoch, parents, 2)
+		blkB = faultBlock(FaultMiner, epoch+1, []cid.Cid{blkC.Cid()}, 3)
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown fault type %d", faultType)
+	}
+
+	for _, blk := range []*types.BlockHeader{blkA, blkB} {
+		if err := signFaultBlock(blk, &workerKey); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if a, err = marshalFaultBlock(blkA); err != nil {
+		return nil, nil, nil, err
+	}
+	if b, err = marshalFaultBlock(blkB); err != nil {
+		return nil, nil, nil, err
+	}
+	if blkC != nil {
+		if extra, err = marshalFaultBlock(blkC); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return a, b, extra, nil
+}
+
+// faultBlock creates an unsigned block header, blocks with different tickets have different cids.
+func faultBlock(miner address.Address, height abi.ChainEpoch, parents []cid.Cid, ticket byte) *types.BlockHeader {
+	return &types.BlockHeader{
+		Miner:                 miner,
+		Ticket:                &types.Ticket{VRFProof: []byte{ticket}},
+		ElectionProof:         &types.ElectionProof{WinCount: 1, VRFProof: []byte{ticket}},
+		Parents:               parents,
+		ParentWeight:          big.NewInt(int64(height)),
+		Height:                height,
+		ParentStateRoot:       EmptyTxMetaCID,
+		ParentMessageReceipts: EmptyReceiptsCID,
+		Messages:              EmptyTxMetaCID,
+		BLSAggregate:          &crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte{}},
+		ParentBaseFee:         abi.NewTokenAmount(100),
+	}
+}
+
+func signFaultBlock(blk *types.BlockHeader, workerKey *key.KeyInfo) error {
+	sd, err := blk.SignatureData()
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.Sign(sd, workerKey.Key(), workerKey.SigType)
+	if err != nil {
+		return fmt.Errorf("signing block: %w", err)
+	}
+	blk.BlockSig = sig
+	return nil
+}
+
+func marshalFaultBlock(blk *types.BlockHeader) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := blk.MarshalCBOR(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func mustIDAddress(id uint64) address.Address {
+	addr, err := address.NewIDAddress(id)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
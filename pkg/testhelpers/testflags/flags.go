@@ -79,6 +79,14 @@ func BenchUnitTest(t *testing.B) {
 	//	t.Parallel()
 }
 
+// FuzzUnitTest will run the fuzz test its called from iff the `-unit` or `-short` flag
+// is passed when calling `go test`. Otherwise the fuzz test will be skipped.
+func FuzzUnitTest(f *testing.F) {
+	if !*unitTest && !testing.Short() {
+		f.SkipNow()
+	}
+}
+
 // BadUnitTestWithSideEffects will run the test its called from iff the
 // `-unit` or `-short` flag is passed when calling `go test`. Otherwise the test
 // will be skipped. BadUnitTestWithSideEffects will run the test its called
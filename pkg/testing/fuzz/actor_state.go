@@ -0,0 +1,213 @@
+// Package fuzz holds fuzz harnesses for code decoding untrusted chain data. The seed corpora in testdata
+// run with the unit tests, run a harness with e.g.
+//
+//	go test ./pkg/testing/fuzz -run '^$' -fuzz FuzzActorState -fuzztime 5m
+package fuzz
+
+import (
+	"context"
+	"fmt"
+
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/manifest"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multihash"
+
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/adt"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/account"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/cron"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/datacap"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/evm"
+	init_ "github.com/filecoin-project/venus/venus-shared/actors/builtin/init"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/market"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/multisig"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/paych"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/power"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/reward"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/system"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/verifreg"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// actorStateLoader loads the state of an actor and reads it through its accessors, which decode the
+// fields loaded lazily. Errors are expected for garbage states, only panics are bugs.
+type actorStateLoader struct {
+	key  string
+	load func(store adt.Store, act *types.Actor) error
+}
+
+// actorStateLoaders are the actors fuzzed by FuzzActorState, the corpus refers to them by index so
+// new actors have to be appended.
+var actorStateLoaders = []actorStateLoader{
+	{manifest.AccountKey, func(store adt.Store, act *types.Actor) error {
+		st, err := account.Load(store, act)
+		if err != nil {
+			return err
+		}
+		_, err = st.PubkeyAddress()
+		return err
+	}},
+	{manifest.CronKey, func(store adt.Store, act *types.Actor) error {
+		_, err := cron.Load(store, act)
+		return err
+	}},
+	{manifest.InitKey, func(store adt.Store, act *types.Actor) error {
+		st, err := init_.Load(store, act)
+		if err != nil {
+			return err
+		}
+		_, err = st.NetworkName()
+		return err
+	}},
+	{manifest.MarketKey, func(store adt.Store, act *types.Actor) error {
+		st, err := market.Load(store, act)
+		if err != nil {
+			return err
+		}
+		if _, err := st.TotalLocked(); err != nil {
+			return err
+		}
+		_, err = st.NextID()
+		return err
+	}},
+	{manifest.MinerKey, func(store adt.Store, act *types.Actor) error {
+		st, err := miner.Load(store, act)
+		if err != nil {
+			return err
+		}
+		if _, err := st.LockedFunds(); err != nil {
+			return err
+		}
+		if _, err := st.FeeDebt(); err != nil {
+			return err
+		}
+		if _, err := st.DeadlineInfo(0); err != nil {
+			return err
+		}
+		_, err = st.Info()
+		return err
+	}},
+	{manifest.MultisigKey, func(store adt.Store, act *types.Actor) error {
+		st, err := multisig.Load(store, act)
+		if err != nil {
+			return err
+		}
+		if _, err := st.Threshold(); err != nil {
+			return err
+		}
+		if _, err := st.Signers(); err != nil {
+			return err
+		}
+		_, err = st.LockedBalance(0)
+		return err
+	}},
+	{manifest.PaychKey, func(store adt.Store, act *types.Actor) error {
+		st, err := paych.Load(store, act)
+		if err != nil {
+			return err
+		}
+		if _, err := st.From(); err != nil {
+			return err
+		}
+		if _, err := st.To(); err != nil {
+			return err
+		}
+		if _, err := st.ToSend(); err != nil {
+			return err
+		}
+		_, err = st.LaneCount()
+		return err
+	}},
+	{manifest.PowerKey, func(store adt.Store, act *types.Actor) error {
+		st, err := power.Load(store, act)
+		if err != nil {
+			return err
+		}
+		if _, err := st.TotalPower(); err != nil {
+			return err
+		}
+		_, err = st.TotalPowerSmoothed()
+		return err
+	}},
+	{manifest.RewardKey, func(store adt.Store, act *types.Actor) error {
+		st, err := reward.Load(store, act)
+		if err != nil {
+			return err
+		}
+		if _, err := st.ThisEpochReward(); err != nil {
+			return err
+		}
+		_, err = st.EffectiveBaselinePower()
+		return err
+	}},
+	{manifest.SystemKey, func(store adt.Store, act *types.Actor) error {
+		_, err := system.Load(store, act)
+		return err
+	}},
+	{manifest.VerifregKey, func(store adt.Store, act *types.Actor) error {
+		st, err := verifreg.Load(store, act)
+		if err != nil {
+			return err
+		}
+		_, err = st.RootKey()
+		return err
+	}},
+	{manifest.DatacapKey, func(store adt.Store, act *types.Actor) error {
+		st, err := datacap.Load(store, act)
+		if err != nil {
+			return err
+		}
+		_, err = st.Governor()
+		return err
+	}},
+	{manifest.EvmKey, func(store adt.Store, act *types.Actor) error {
+		st, err := evm.Load(store, act)
+		if err != nil {
+			return err
+		}
+		if _, err := st.Nonce(); err != nil {
+			return err
+		}
+		_, err = st.GetBytecodeHash()
+		return err
+	}},
+}
+
+// loadActorState loads data as the state of the actor at index actor of actorStateLoaders, of the actors
+// version at index version of actors.Versions. Indexes out of range wrap around.
+func loadActorState(actor, version uint8, data []byte) error {
+	loader := actorStateLoaders[int(actor)%len(actorStateLoaders)]
+	av := actorstypes.Version(actors.Versions[int(version)%len(actors.Versions)])
+	code, ok := actors.GetActorCodeID(av, loader.key)
+	if !ok {
+		return fmt.Errorf("no %s actor in actors version %d", loader.key, av)
+	}
+
+	ctx := context.Background()
+	bs := blockstoreutil.NewBlockstore(datastore.NewMapDatastore())
+	head, err := putRawBlock(ctx, bs, data)
+	if err != nil {
+		return err
+	}
+	store := adt.WrapStore(ctx, cbor.NewCborStore(bs))
+	return loader.load(store, &types.Actor{Code: code, Head: head})
+}
+
+// putRawBlock stores data as a dag-cbor block without checking it decodes.
+func putRawBlock(ctx context.Context, bs blockstoreutil.Blockstore, data []byte) (cid.Cid, error) {
+	c, err := cid.V1Builder{Codec: cid.DagCBOR, MhType: multihash.BLAKE2B_MIN + 31}.Sum(data)
+	if err != nil {
+		return cid.Undef, err
+	}
+	blk, err := blocks.NewBlockWithCid(data, c)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return c, bs.Put(ctx, blk)
+}
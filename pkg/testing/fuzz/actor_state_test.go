@@ -0,0 +1,31 @@
+package fuzz
+
+import (
+	"testing"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+)
+
+// FuzzActorState feeds random states to the Load functions of the builtin actors. The corpus in
+// testdata/fuzz/FuzzActorState holds the actor states of the calibnet genesis and edge cases.
+func FuzzActorState(f *testing.F) {
+	tf.FuzzUnitTest(f)
+
+	for actor := range actorStateLoaders {
+		for _, data := range [][]byte{
+			nil,
+			{0xf6},       // null
+			{0x80},       // empty array
+			{0xa0},       // empty map
+			{0x9f, 0xff}, // indefinite length array
+			{0x81, 0x40}, // array of an empty byte string
+		} {
+			f.Add(uint8(actor), uint8(len(actors.Versions)-1), data)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, actor, version uint8, data []byte) {
+		_ = loadActorState(actor, version, data)
+	})
+}
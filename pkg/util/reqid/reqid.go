@@ -0,0 +1,50 @@
+// Package reqid carries the id of an api request through its context, so log lines
+// written while serving the request can be correlated.
+package reqid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Header is the http header a request id is read from and echoed in.
+const Header = "X-Request-ID"
+
+type ctxKey struct{}
+
+// WithID returns a copy of ctx carrying the request id.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the request id carried by ctx, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(ctxKey{}).(string)
+	return id, ok && id != ""
+}
+
+// Logger returns l annotated with the request id carried by ctx.
+func Logger(ctx context.Context, l interface {
+	With(args ...interface{}) *zap.SugaredLogger
+}) *zap.SugaredLogger {
+	if id, ok := FromContext(ctx); ok {
+		return l.With("request_id", id)
+	}
+	return l.With()
+}
+
+// Handler reads the request id from the Header of incoming requests, or generates one if
+// it is missing, stores it in the request context and echoes it in the response header.
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if id == "" {
+			id = uuid.NewString()
+		}
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(WithID(r.Context(), id)))
+	})
+}
@@ -0,0 +1,47 @@
+package reqid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestHandler(t *testing.T) {
+	tf.UnitTest(t)
+
+	var got string
+	h := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = FromContext(r.Context())
+	}))
+
+	// an id sent by the client is kept
+	req := httptest.NewRequest(http.MethodPost, "/rpc/v1", nil)
+	req.Header.Set(Header, "abc")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, "abc", got)
+	require.Equal(t, "abc", rec.Header().Get(Header))
+
+	// otherwise one is generated
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/rpc/v1", nil))
+	require.NotEmpty(t, got)
+	require.NotEqual(t, "abc", got)
+	require.Equal(t, got, rec.Header().Get(Header))
+}
+
+func TestLogger(t *testing.T) {
+	tf.UnitTest(t)
+
+	log := logging.Logger("reqid-test")
+	_, ok := FromContext(context.Background())
+	require.False(t, ok)
+	require.NotNil(t, Logger(context.Background(), log))
+	require.NotNil(t, Logger(WithID(context.Background(), "abc"), log))
+}
@@ -0,0 +1,98 @@
+package vm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/pkg/vm/gas"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var _ Interface = (*MockFVM)(nil)
+
+// MockFVM is an Interface for tests that returns preset results instead of executing messages,
+// so code applying messages can be tested without real chain state. It never modifies the state,
+// Flush returns the root the mock was created with.
+type MockFVM struct {
+	lk      sync.Mutex
+	root    cid.Cid
+	results map[cid.Cid]*Ret
+	def     *Ret
+	applied []types.ChainMsg
+}
+
+// NewMockFVM creates a MockFVM whose state root is root.
+func NewMockFVM(root cid.Cid) *MockFVM {
+	return &MockFVM{
+		root:    root,
+		results: make(map[cid.Cid]*Ret),
+	}
+}
+
+// SetApplyResult sets the result of applying the message with cid msg. Signed messages match by
+// their own cid or by the cid of the message they sign.
+func (m *MockFVM) SetApplyResult(msg cid.Cid, ret *Ret) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	m.results[msg] = ret
+}
+
+// SetDefaultResult sets the result of applying a message without a result of its own.
+func (m *MockFVM) SetDefaultResult(ret *Ret) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	m.def = ret
+}
+
+// Applied returns the messages applied so far, implicit ones included.
+func (m *MockFVM) Applied() []types.ChainMsg {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	return append([]types.ChainMsg(nil), m.applied...)
+}
+
+func (m *MockFVM) ApplyMessage(ctx context.Context, cmsg types.ChainMsg) (*Ret, error) {
+	return m.apply(cmsg)
+}
+
+func (m *MockFVM) ApplyImplicitMessage(ctx context.Context, msg types.ChainMsg) (*Ret, error) {
+	return m.apply(msg)
+}
+
+func (m *MockFVM) Flush(ctx context.Context) (cid.Cid, error) {
+	return m.root, nil
+}
+
+func (m *MockFVM) apply(cmsg types.ChainMsg) (*Ret, error) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	m.applied = append(m.applied, cmsg)
+
+	ret, ok := m.results[cmsg.Cid()]
+	if !ok {
+		ret, ok = m.results[cmsg.VMMessage().Cid()]
+	}
+	if !ok {
+		ret = m.def
+	}
+	if ret == nil {
+		return nil, fmt.Errorf("no result set for message %s", cmsg.Cid())
+	}
+
+	// callers read the gas tracker and outputs of every result, fill in what the test left out
+	out := *ret
+	if out.GasTracker == nil {
+		out.GasTracker = &gas.GasTracker{}
+	}
+	if out.OutPuts.Refund.Int == nil {
+		out.OutPuts = gas.ZeroGasOutputs()
+	}
+	return &out, nil
+}
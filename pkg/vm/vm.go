@@ -29,6 +29,7 @@ type (
 	VmMessage       = vmcontext.VmMessage //nolint
 	FakeSyscalls    = vmcontext.FakeSyscalls
 	ChainRandomness = vmcontext.HeadChainRandomness
+	TraceFilter     = vmcontext.TraceFilter
 )
 
 type Interface = vmcontext.Interface // nolint
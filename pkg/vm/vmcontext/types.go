@@ -53,6 +53,50 @@ type VmOption struct { //nolint
 	ActorDebugging bool
 	// ReturnEvents decodes and returns emitted events.
 	ReturnEvents bool
+	// MinBaseFee is the floor of the base fee given to the FVM, constants.MinimumBaseFee when unset.
+	MinBaseFee abi.TokenAmount
+	// TraceFilter keeps the execution traces of the matching messages only when Tracing is on.
+	TraceFilter *TraceFilter
+}
+
+// TraceFilter selects the messages whose execution traces are kept. A message matches when it is sent from or to
+// one of the addresses and calls one of the methods, an empty list does not restrict the messages.
+// The FVM traces either all the messages or none, so executing with a filter still pays for tracing every message,
+// the filter saves decoding the traces of the other messages and keeps them out of the results.
+type TraceFilter struct {
+	Addresses []address.Address
+	Methods   []abi.MethodNum
+}
+
+// Match tells whether the execution trace of the message is kept, a nil filter matches all the messages.
+// The addresses are compared by the ID address idOf returns for them, so an actor matches whether the filter
+// or the message gives its ID address or its robust address.
+func (f *TraceFilter) Match(msg *types.Message, idOf func(address.Address) address.Address) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.Addresses) > 0 {
+		from, to := idOf(msg.From), idOf(msg.To)
+		var found bool
+		for _, addr := range f.Addresses {
+			if id := idOf(addr); id == from || id == to {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.Methods) > 0 {
+		for _, method := range f.Methods {
+			if method == msg.Method {
+				return true
+			}
+		}
+		return false
+	}
+	return true
 }
 
 type ILookBack interface {
@@ -120,6 +120,7 @@ func (d *Driver) ExecuteTipset(bs blockstoreutil.Blockstore, chainDs ds.Batching
 			NetworkVersion:      chainFork.GetNetworkVersion(ctx, execEpoch),
 			Rnd:                 NewFixedRand(),
 			BaseFee:             big.NewFromGo(&tipset.BaseFee),
+			MinBaseFee:          big.Zero(),
 			Fork:                chainFork,
 			Epoch:               execEpoch,
 			GasPriceSchedule:    gas.NewPricesSchedule(mainNetParams.Network.ForkUpgradeParam),
@@ -274,6 +275,7 @@ func (d *Driver) ExecuteMessage(bs blockstoreutil.Blockstore, params ExecuteMess
 			NetworkVersion:      params.NetworkVersion,
 			Rnd:                 params.Rand,
 			BaseFee:             params.BaseFee,
+			MinBaseFee:          big.Zero(),
 			Fork:                chainFork,
 			ActorCodeLoader:     &coderLoader,
 			Epoch:               params.Epoch,
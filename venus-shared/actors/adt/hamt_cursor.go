@@ -0,0 +1,95 @@
+package adt
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	hamt "github.com/filecoin-project/go-hamt-ipld/v3"
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// ForEachAfter calls cb on the entries of the hamt rooted at root in the iteration order of the hamt, starting
+// after the entry with the key after, or at the first entry when after is nil. The walk descends along the
+// sha256 hash of after, so only the nodes on the path to it and past it are loaded, and it resumes at the
+// position of after even if that entry was removed in the meantime.
+// The hamt has to be laid out by go-hamt-ipld v3, as the hamts of the actors of version 3 and later are.
+func ForEachAfter(store Store, root cid.Cid, bitwidth int, after []byte, cb func(k []byte, val *cbg.Deferred) error) error {
+	var nd hamt.Node
+	if err := store.Get(store.Context(), root, &nd); err != nil {
+		return fmt.Errorf("loading hamt root %s: %w", root, err)
+	}
+
+	var hash []byte
+	if after != nil {
+		h := sha256.Sum256(after)
+		hash = h[:]
+	}
+	return forEachAfter(store, &nd, bitwidth, 0, hash, after, cb)
+}
+
+// forEachAfter walks nd at depth, hash is the hash of after as long as the walk is on the path to after and
+// nil once it is past it
+func forEachAfter(store Store, nd *hamt.Node, bitwidth, depth int, hash, after []byte, cb func(k []byte, val *cbg.Deferred) error) error {
+	start := 0
+	if hash != nil {
+		if (depth+1)*bitwidth > len(hash)*8 {
+			return fmt.Errorf("hamt deeper than the key hash at depth %d", depth)
+		}
+		start = hashIndex(hash, depth, bitwidth)
+	}
+
+	// the pointers are stored in the order of their bit in the bitfield
+	ptr := 0
+	for idx := 0; idx < 1<<bitwidth; idx++ {
+		if nd.Bitfield.Bit(idx) == 0 {
+			continue
+		}
+		if ptr >= len(nd.Pointers) {
+			return fmt.Errorf("hamt node has %d pointers for a bitfield of more", len(nd.Pointers))
+		}
+		p := nd.Pointers[ptr]
+		ptr++
+		if idx < start {
+			continue
+		}
+		onPath := hash != nil && idx == start
+
+		if p.Link.Defined() {
+			var child hamt.Node
+			if err := store.Get(store.Context(), p.Link, &child); err != nil {
+				return fmt.Errorf("loading hamt node %s: %w", p.Link, err)
+			}
+			childHash := hash
+			if !onPath {
+				childHash = nil
+			}
+			if err := forEachAfter(store, &child, bitwidth, depth+1, childHash, after, cb); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// the entries of a bucket are sorted by key
+		for _, kv := range p.KVs {
+			if onPath && bytes.Compare(kv.Key, after) <= 0 {
+				continue
+			}
+			if err := cb(kv.Key, kv.Value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// hashIndex returns the bitwidth bits of hash used as index at depth, most significant bit first
+func hashIndex(hash []byte, depth, bitwidth int) int {
+	idx := 0
+	for i := depth * bitwidth; i < (depth+1)*bitwidth; i++ {
+		idx = idx<<1 | int(hash[i/8]>>(7-i%8)&1)
+	}
+	return idx
+}
@@ -0,0 +1,90 @@
+package adt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	builtin11 "github.com/filecoin-project/go-state-types/builtin"
+	adt11 "github.com/filecoin-project/go-state-types/builtin/v11/util/adt"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+var errPageFull = errors.New("page full")
+
+func TestForEachAfter(t *testing.T) {
+	tf.UnitTest(t)
+
+	store := WrapStore(context.Background(), cbor.NewMemCborStore())
+	m, err := adt11.MakeEmptyMap(store, builtin11.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	// enough entries for several levels of nodes
+	for i := 0; i < 2000; i++ {
+		v := cbg.CborInt(i)
+		require.NoError(t, m.Put(abi.UIntKey(uint64(i)), &v))
+	}
+	root, err := m.Root()
+	require.NoError(t, err)
+
+	var all []string
+	require.NoError(t, m.ForEach(nil, func(k string) error {
+		all = append(all, k)
+		return nil
+	}))
+
+	// pages resumed after their last key visit every entry once, in the order of ForEach
+	var paged []string
+	var after []byte
+	for {
+		var page []string
+		err := ForEachAfter(store, root, builtin11.DefaultHamtBitwidth, after, func(k []byte, _ *cbg.Deferred) error {
+			if len(page) == 150 {
+				return errPageFull
+			}
+			page = append(page, string(k))
+			return nil
+		})
+		if err != nil && !errors.Is(err, errPageFull) {
+			require.NoError(t, err)
+		}
+		paged = append(paged, page...)
+		if err == nil {
+			break
+		}
+		after = []byte(page[len(page)-1])
+	}
+	require.Equal(t, all, paged)
+
+	// resuming after a removed key continues with the entry that followed it
+	for _, i := range []int{0, 1, 777, 1500, len(all) - 2} {
+		removed := all[i]
+		found, err := m.TryDelete(stringKey(removed))
+		require.NoError(t, err)
+		require.True(t, found)
+		root, err := m.Root()
+		require.NoError(t, err)
+
+		var next string
+		err = ForEachAfter(store, root, builtin11.DefaultHamtBitwidth, []byte(removed), func(k []byte, _ *cbg.Deferred) error {
+			next = string(k)
+			return errPageFull
+		})
+		require.ErrorIs(t, err, errPageFull, fmt.Sprintf("no entry after %d", i))
+		require.Equal(t, all[i+1], next)
+
+		v := cbg.CborInt(0)
+		require.NoError(t, m.Put(stringKey(removed), &v))
+	}
+}
+
+type stringKey string
+
+func (k stringKey) Key() string {
+	return string(k)
+}
@@ -148,6 +148,7 @@ type State interface {
 	GetSector(abi.SectorNumber) (*SectorOnChainInfo, error)
 	FindSector(abi.SectorNumber) (*SectorLocation, error)
 	GetSectorExpiration(abi.SectorNumber) (*SectorExpiration, error)
+	GetSectorExpirations([]abi.SectorNumber) (map[abi.SectorNumber]*SectorExpiration, error)
 	GetPrecommittedSector(abi.SectorNumber) (*SectorPreCommitOnChainInfo, error)
 	ForEachPrecommittedSector(func(SectorPreCommitOnChainInfo) error) error
 	LoadSectors(sectorNos *bitfield.BitField) ([]*SectorOnChainInfo, error)
@@ -189,6 +190,13 @@ type Deadline interface {
 
 	PartitionsChanged(Deadline) (bool, error)
 	DisputableProofCount() (uint64, error)
+
+	// LiveSectors is the number of sectors in the deadline that are not terminated (but may be faulty).
+	LiveSectors() (uint64, error)
+	// TotalSectors is the number of sectors in the deadline, including the terminated ones.
+	TotalSectors() (uint64, error)
+	// FaultyPower is the power of the faulty sectors of the deadline.
+	FaultyPower() (PowerPair, error)
 }
 
 type Partition interface {
@@ -209,6 +217,9 @@ type Partition interface {
 	// Active sectors are those that are neither terminated nor faulty nor unproven, i.e. actively contributing power.
 	ActiveSectors() (bitfield.BitField, error)
 
+	// LivePower is the power of the live sectors of the partition, including the faulty and unproven ones.
+	LivePower() (PowerPair, error)
+
 	// Unproven sectors in this partition. This bitfield will be cleared on
 	// a successful window post (or at the end of the partition's next
 	// deadline). At that time, any still unproven sectors will be added to
@@ -275,6 +286,7 @@ type MinerInfo = minertypes.MinerInfo
 type BeneficiaryTerm = minertypes.BeneficiaryTerm
 type PendingBeneficiaryChange = minertypes.PendingBeneficiaryChange
 type WorkerKeyChange = minertypes.WorkerKeyChange
+type PowerPair = minertypes.PowerPair
 type SectorPreCommitOnChainInfo = minertypes.SectorPreCommitOnChainInfo
 type SectorPreCommitInfo = minertypes.SectorPreCommitInfo
 type WindowPostVerifyInfo = proof.WindowPoStVerifyInfo
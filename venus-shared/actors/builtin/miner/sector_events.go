@@ -0,0 +1,119 @@
+package miner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// SectorEventType is the sector lifecycle step reported by a miner actor event
+type SectorEventType string
+
+// sector lifecycle events emitted by the miner actor, faults, recoveries and expirations
+// are not emitted as events and can not be subscribed to
+const (
+	SectorPreCommitted SectorEventType = "sector-precommitted"
+	SectorActivated    SectorEventType = "sector-activated"
+	SectorUpdated      SectorEventType = "sector-updated"
+	SectorTerminated   SectorEventType = "sector-terminated"
+)
+
+// SectorEvent is a sector lifecycle event of a miner
+type SectorEvent struct {
+	SectorNumber abi.SectorNumber
+	EventType    SectorEventType
+	// Epoch is the epoch of the tipset including the message that emitted the event
+	Epoch abi.ChainEpoch
+	// Reverted is set when the tipset including the message was reverted
+	Reverted bool
+}
+
+// ActorEventsAPI is the subset of the full node api SubscribeSectorEvents relies on
+type ActorEventsAPI interface {
+	GetActorEventsRaw(ctx context.Context, filter *types.ActorEventFilter) (<-chan *types.ActorEvent, error)
+}
+
+// SubscribeSectorEvents delivers the sector events emitted by minerAddr to ch from the current head on,
+// native emitters are matched by their id address so minerAddr must be one.
+// It blocks until ctx is cancelled or the event stream is closed by the node, events that are not
+// well formed sector lifecycle events are skipped.
+func SubscribeSectorEvents(ctx context.Context, api ActorEventsAPI, minerAddr address.Address, ch chan<- SectorEvent) error {
+	if minerAddr.Protocol() != address.ID {
+		return fmt.Errorf("miner address %s is not an id address", minerAddr)
+	}
+
+	events, err := api.GetActorEventsRaw(ctx, &types.ActorEventFilter{
+		Addresses: []address.Address{minerAddr},
+		FromEpoch: -1,
+		ToEpoch:   -1,
+	})
+	if err != nil {
+		return fmt.Errorf("subscribing to events of %s: %w", minerAddr, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-events:
+			if !ok {
+				return nil
+			}
+			se, ok, err := decodeSectorEvent(ev)
+			if err != nil || !ok {
+				// malformed events are dropped like unrelated ones
+				continue
+			}
+			select {
+			case ch <- se:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// decodeSectorEvent decodes the $type and sector entries of a miner actor event, it reports
+// false for events that are not about the lifecycle of a sector.
+func decodeSectorEvent(ev *types.ActorEvent) (SectorEvent, bool, error) {
+	se := SectorEvent{
+		Epoch:    ev.Height,
+		Reverted: ev.Reverted,
+	}
+
+	typ, err := types.ActorEventType(ev.Entries)
+	if err != nil {
+		return se, false, err
+	}
+	se.EventType = SectorEventType(typ)
+	switch se.EventType {
+	case SectorPreCommitted, SectorActivated, SectorUpdated, SectorTerminated:
+	default:
+		return se, false, nil
+	}
+
+	for _, entry := range ev.Entries {
+		if entry.Key != "sector" {
+			continue
+		}
+		if entry.Codec != types.EventCodecCbor {
+			return se, false, fmt.Errorf("unexpected codec %d of sector", entry.Codec)
+		}
+		maj, num, err := cbg.NewCborReader(bytes.NewReader(entry.Value)).ReadHeader()
+		if err != nil {
+			return se, false, fmt.Errorf("reading sector number: %w", err)
+		}
+		if maj != cbg.MajUnsignedInt {
+			return se, false, fmt.Errorf("expected an unsigned sector number, was major type %d", maj)
+		}
+		se.SectorNumber = abi.SectorNumber(num)
+		return se, true, nil
+	}
+	return se, false, fmt.Errorf("%s event has no sector", se.EventType)
+}
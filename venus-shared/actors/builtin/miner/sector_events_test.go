@@ -0,0 +1,122 @@
+package miner
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func cborEntry(t *testing.T, key string, write func(w *cbg.CborWriter) error) types.EventEntry {
+	var buf bytes.Buffer
+	require.NoError(t, write(cbg.NewCborWriter(&buf)))
+	return types.EventEntry{Flags: types.EventFlagIndexedValue, Key: key, Codec: types.EventCodecCbor, Value: buf.Bytes()}
+}
+
+func typeEntry(t *testing.T, typ string) types.EventEntry {
+	return cborEntry(t, types.EventTypeKey, func(w *cbg.CborWriter) error {
+		if err := w.WriteMajorTypeHeader(cbg.MajTextString, uint64(len(typ))); err != nil {
+			return err
+		}
+		_, err := w.Write([]byte(typ))
+		return err
+	})
+}
+
+func sectorEntry(t *testing.T, num uint64) types.EventEntry {
+	return cborEntry(t, "sector", func(w *cbg.CborWriter) error {
+		return w.WriteMajorTypeHeader(cbg.MajUnsignedInt, num)
+	})
+}
+
+func TestDecodeSectorEvent(t *testing.T) {
+	rawSector := sectorEntry(t, 7)
+	rawSector.Codec = 0x55
+
+	testCases := []struct {
+		name    string
+		entries []types.EventEntry
+		want    SectorEvent
+		ok      bool
+		err     string
+	}{
+		{
+			name:    "precommitted",
+			entries: []types.EventEntry{typeEntry(t, "sector-precommitted"), sectorEntry(t, 7)},
+			want:    SectorEvent{SectorNumber: 7, EventType: SectorPreCommitted, Epoch: 100},
+			ok:      true,
+		},
+		{
+			name:    "activated",
+			entries: []types.EventEntry{typeEntry(t, "sector-activated"), sectorEntry(t, 8)},
+			want:    SectorEvent{SectorNumber: 8, EventType: SectorActivated, Epoch: 100},
+			ok:      true,
+		},
+		{
+			name:    "updated",
+			entries: []types.EventEntry{typeEntry(t, "sector-updated"), sectorEntry(t, 9)},
+			want:    SectorEvent{SectorNumber: 9, EventType: SectorUpdated, Epoch: 100},
+			ok:      true,
+		},
+		{
+			name:    "terminated",
+			entries: []types.EventEntry{typeEntry(t, "sector-terminated"), sectorEntry(t, 10)},
+			want:    SectorEvent{SectorNumber: 10, EventType: SectorTerminated, Epoch: 100},
+			ok:      true,
+		},
+		{
+			name:    "not a sector event",
+			entries: []types.EventEntry{typeEntry(t, "claim")},
+			want:    SectorEvent{EventType: "claim", Epoch: 100},
+		},
+		{
+			name:    "missing sector",
+			entries: []types.EventEntry{typeEntry(t, "sector-activated")},
+			err:     "has no sector",
+		},
+		{
+			name:    "wrong codec",
+			entries: []types.EventEntry{typeEntry(t, "sector-activated"), rawSector},
+			err:     "unexpected codec",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok, err := decodeSectorEvent(&types.ActorEvent{Entries: tc.entries, Height: abi.ChainEpoch(100)})
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				require.False(t, ok)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+type actorEventsAPI func(ctx context.Context, filter *types.ActorEventFilter) (<-chan *types.ActorEvent, error)
+
+func (f actorEventsAPI) GetActorEventsRaw(ctx context.Context, filter *types.ActorEventFilter) (<-chan *types.ActorEvent, error) {
+	return f(ctx, filter)
+}
+
+func TestSubscribeSectorEventsRequiresIDAddress(t *testing.T) {
+	api := actorEventsAPI(func(ctx context.Context, filter *types.ActorEventFilter) (<-chan *types.ActorEvent, error) {
+		t.Fatal("unexpected subscription")
+		return nil, nil
+	})
+
+	minerAddr, err := address.NewActorAddress([]byte("miner"))
+	require.NoError(t, err)
+	err = SubscribeSectorEvents(context.Background(), api, minerAddr, make(chan SectorEvent))
+	require.ErrorContains(t, err, "is not an id address")
+}
@@ -0,0 +1,92 @@
+package miner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	miner7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/adt"
+)
+
+func TestGetSectorExpirations(t *testing.T) {
+	ctx := context.Background()
+	store := adt.WrapStore(ctx, cbor.NewMemCborStore())
+
+	// partition builds a partition of sectors whose expiration queue holds the early and on time sectors of each epoch
+	partition := func(sectors, terminated []uint64, early, onTime map[int64][]uint64) *miner7.Partition {
+		part, err := miner7.ConstructPartition(store)
+		require.NoError(t, err)
+		part.Sectors = bitfield.NewFromSet(sectors)
+		part.Terminated = bitfield.NewFromSet(terminated)
+
+		queue, err := adt7.MakeEmptyArray(store, miner7.PartitionExpirationAmtBitwidth)
+		require.NoError(t, err)
+		for _, epoch := range []int64{10, 20, 30} {
+			if early[epoch] == nil && onTime[epoch] == nil {
+				continue
+			}
+			set := miner7.NewExpirationSetEmpty()
+			set.EarlySectors = bitfield.NewFromSet(early[epoch])
+			set.OnTimeSectors = bitfield.NewFromSet(onTime[epoch])
+			require.NoError(t, queue.Set(uint64(epoch), set))
+		}
+		part.ExpirationsEpochs, err = queue.Root()
+		require.NoError(t, err)
+		return part
+	}
+	deadline := func(parts ...*miner7.Partition) cid.Cid {
+		dl, err := miner7.ConstructDeadline(store)
+		require.NoError(t, err)
+		arr, err := adt7.MakeEmptyArray(store, miner7.DeadlinePartitionsAmtBitwidth)
+		require.NoError(t, err)
+		for i, part := range parts {
+			require.NoError(t, arr.Set(uint64(i), part))
+		}
+		dl.Partitions, err = arr.Root()
+		require.NoError(t, err)
+		c, err := store.Put(ctx, dl)
+		require.NoError(t, err)
+		return c
+	}
+
+	emptyDeadline := deadline()
+	dls := miner7.ConstructDeadlines(emptyDeadline)
+	// sector 2 is faulty and expires early at 10, sector 3 is terminated
+	dls.Due[0] = deadline(partition([]uint64{1, 2, 3}, []uint64{3},
+		map[int64][]uint64{10: {2}},
+		map[int64][]uint64{10: {1, 3}, 20: {2}}))
+	dls.Due[1] = deadline(
+		partition([]uint64{4}, nil, nil, map[int64][]uint64{30: {4}}),
+		partition([]uint64{5, 6}, nil, nil, map[int64][]uint64{20: {5}, 30: {6}}),
+	)
+	dlsCid, err := store.Put(ctx, dls)
+	require.NoError(t, err)
+
+	st := &state7{State: miner7.State{Deadlines: dlsCid}, store: store}
+	exps, err := st.GetSectorExpirations([]abi.SectorNumber{1, 2, 3, 4, 6, 7})
+	require.NoError(t, err)
+	require.Equal(t, map[abi.SectorNumber]*SectorExpiration{
+		1: {OnTime: 10},
+		2: {Early: 10, OnTime: 20},
+		4: {OnTime: 30},
+		6: {OnTime: 30},
+	}, exps)
+
+	// the batch agrees with the expirations of the single sectors
+	for num, exp := range exps {
+		single, err := st.GetSectorExpiration(num)
+		require.NoError(t, err)
+		require.Equal(t, exp, single)
+	}
+
+	exps, err = st.GetSectorExpirations(nil)
+	require.NoError(t, err)
+	require.Empty(t, exps)
+}
@@ -199,6 +199,101 @@ func (s *state2) GetSectorExpiration(num abi.SectorNumber) (*SectorExpiration, e
 	return &out, nil
 }
 
+// GetSectorExpirations returns the effective expirations of the given sectors like GetSectorExpiration, walking the
+// deadlines once and loading the expiration queue of a partition once for all its sectors.
+//
+// The sectors that are not in a partition or are terminated are absent from the result.
+func (s *state2) GetSectorExpirations(nums []abi.SectorNumber) (map[abi.SectorNumber]*SectorExpiration, error) {
+	sectorNos := make([]uint64, 0, len(nums))
+	for _, num := range nums {
+		sectorNos = append(sectorNos, uint64(num))
+	}
+	want := bitfield.NewFromSet(sectorNos)
+
+	dls, err := s.State.LoadDeadlines(s.store)
+	if err != nil {
+		return nil, err
+	}
+
+	stopErr := errors.New("stop")
+	out := make(map[abi.SectorNumber]*SectorExpiration, len(nums))
+	err = dls.ForEach(s.store, func(dlIdx uint64, dl *miner2.Deadline) error {
+		partitions, err := dl.PartitionsArray(s.store)
+		if err != nil {
+			return err
+		}
+		quant := s.State.QuantSpecForDeadline(dlIdx)
+		var part miner2.Partition
+		return partitions.ForEach(&part, func(partIdx int64) error {
+			pending, err := bitfield.IntersectBitField(part.Sectors, want)
+			if err != nil {
+				return err
+			}
+			if pending, err = bitfield.SubtractBitField(pending, part.Terminated); err != nil {
+				return err
+			}
+			if empty, err := pending.IsEmpty(); err != nil || empty {
+				return err
+			}
+
+			q, err := miner2.LoadExpirationQueue(s.store, part.ExpirationsEpochs, quant)
+			if err != nil {
+				return err
+			}
+			expiration := func(num uint64) *SectorExpiration {
+				exp, ok := out[abi.SectorNumber(num)]
+				if !ok {
+					exp = &SectorExpiration{}
+					out[abi.SectorNumber(num)] = exp
+				}
+				return exp
+			}
+			var exp miner2.ExpirationSet
+			err = q.ForEach(&exp, func(epoch int64) error {
+				early, err := bitfield.IntersectBitField(exp.EarlySectors, pending)
+				if err != nil {
+					return err
+				}
+				if err := early.ForEach(func(num uint64) error {
+					expiration(num).Early = abi.ChainEpoch(epoch)
+					return nil
+				}); err != nil {
+					return err
+				}
+
+				onTime, err := bitfield.IntersectBitField(exp.OnTimeSectors, pending)
+				if err != nil {
+					return err
+				}
+				if err := onTime.ForEach(func(num uint64) error {
+					expiration(num).OnTime = abi.ChainEpoch(epoch)
+					return nil
+				}); err != nil {
+					return err
+				}
+				// the sectors expiring on time are done, the queue is walked until all of them are
+				if pending, err = bitfield.SubtractBitField(pending, onTime); err != nil {
+					return err
+				}
+				if empty, err := pending.IsEmpty(); err != nil {
+					return err
+				} else if empty {
+					return stopErr
+				}
+				return nil
+			})
+			if err == stopErr {
+				err = nil
+			}
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (s *state2) GetPrecommittedSector(num abi.SectorNumber) (*SectorPreCommitOnChainInfo, error) {
 	info, ok, err := s.State.GetPrecommittedSector(s.store, num)
 	if !ok || err != nil {
@@ -512,6 +607,18 @@ func (d *deadline2) DisputableProofCount() (uint64, error) {
 
 }
 
+func (d *deadline2) LiveSectors() (uint64, error) {
+	return d.Deadline.LiveSectors, nil
+}
+
+func (d *deadline2) TotalSectors() (uint64, error) {
+	return d.Deadline.TotalSectors, nil
+}
+
+func (d *deadline2) FaultyPower() (PowerPair, error) {
+	return PowerPair{Raw: d.Deadline.FaultyPower.Raw, QA: d.Deadline.FaultyPower.QA}, nil
+}
+
 func (p *partition2) AllSectors() (bitfield.BitField, error) {
 	return p.Partition.Sectors, nil
 }
@@ -528,6 +635,10 @@ func (p *partition2) UnprovenSectors() (bitfield.BitField, error) {
 	return p.Partition.Unproven, nil
 }
 
+func (p *partition2) LivePower() (PowerPair, error) {
+	return PowerPair{Raw: p.Partition.LivePower.Raw, QA: p.Partition.LivePower.QA}, nil
+}
+
 func fromV2SectorOnChainInfo(v2 miner2.SectorOnChainInfo) SectorOnChainInfo {
 	info := SectorOnChainInfo{
 		SectorNumber:          v2.SectorNumber,
@@ -153,6 +153,9 @@ type State interface {
 	MinerNominalPowerMeetsConsensusMinimum(address.Address) (bool, error)
 	ListAllMiners() ([]address.Address, error)
 	ForEachClaim(func(miner address.Address, claim Claim) error) error
+	// ForEachClaimAfter calls cb on the claims in the order of the claims table, starting after the claim of the
+	// miner after, or at the first claim when after is address.Undef
+	ForEachClaimAfter(after address.Address, cb func(miner address.Address, claim Claim) error) error
 	ClaimsChanged(State) (bool, error)
 
 	// Testing or genesis setup only
@@ -144,6 +144,12 @@ func (s *state0) ForEachClaim(cb func(miner address.Address, claim Claim) error)
 	})
 }
 
+func (s *state0) ForEachClaimAfter(after address.Address, cb func(miner address.Address, claim Claim) error) error {
+
+	return fmt.Errorf("unsupported in actors v0")
+
+}
+
 func (s *state0) ClaimsChanged(other State) (bool, error) {
 	other0, ok := other.(*state0)
 	if !ok {
@@ -144,6 +144,12 @@ func (s *state2) ForEachClaim(cb func(miner address.Address, claim Claim) error)
 	})
 }
 
+func (s *state2) ForEachClaimAfter(after address.Address, cb func(miner address.Address, claim Claim) error) error {
+
+	return fmt.Errorf("unsupported in actors v2")
+
+}
+
 func (s *state2) ClaimsChanged(other State) (bool, error) {
 	other2, ok := other.(*state2)
 	if !ok {
@@ -141,6 +141,29 @@ func (s *state3) ForEachClaim(cb func(miner address.Address, claim Claim) error)
 	})
 }
 
+func (s *state3) ForEachClaimAfter(after address.Address, cb func(miner address.Address, claim Claim) error) error {
+
+	var key []byte
+	if after != address.Undef {
+		key = after.Bytes()
+	}
+	return adt.ForEachAfter(s.store, s.Claims, builtin3.DefaultHamtBitwidth, key, func(k []byte, val *cbg.Deferred) error {
+		miner, err := address.NewFromBytes(k)
+		if err != nil {
+			return err
+		}
+		var claim power3.Claim
+		if err := claim.UnmarshalCBOR(bytes.NewReader(val.Raw)); err != nil {
+			return err
+		}
+		return cb(miner, Claim{
+			RawBytePower:    claim.RawBytePower,
+			QualityAdjPower: claim.QualityAdjPower,
+		})
+	})
+
+}
+
 func (s *state3) ClaimsChanged(other State) (bool, error) {
 	other3, ok := other.(*state3)
 	if !ok {
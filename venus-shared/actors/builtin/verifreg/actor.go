@@ -147,6 +147,12 @@ type State interface {
 	ForEachClient(func(addr address.Address, dcap abi.StoragePower) error) error
 	GetAllocation(clientIdAddr address.Address, allocationId AllocationId) (*Allocation, bool, error)
 	GetAllocations(clientIdAddr address.Address) (map[AllocationId]Allocation, error)
+	// ForEachAllocationClientAfter calls cb on the clients with allocations in the order of the allocations table,
+	// starting after the client after, or at the first client when after is address.Undef
+	ForEachAllocationClientAfter(after address.Address, cb func(clientIdAddr address.Address) error) error
+	// ForEachAllocationAfter calls cb on the allocations of clientIdAddr in the order of its allocations table,
+	// starting after the allocation after, or at the first allocation when after is nil
+	ForEachAllocationAfter(clientIdAddr address.Address, after *AllocationId, cb func(allocationId AllocationId, allocation Allocation) error) error
 	GetClaim(providerIdAddr address.Address, claimId ClaimId) (*Claim, bool, error)
 	GetClaims(providerIdAddr address.Address) (map[ClaimId]Claim, error)
 	GetState() interface{}
@@ -108,6 +108,18 @@ func (s *state2) GetAllocations(clientIdAddr address.Address) (map[AllocationId]
 
 }
 
+func (s *state2) ForEachAllocationClientAfter(after address.Address, cb func(clientIdAddr address.Address) error) error {
+
+	return fmt.Errorf("unsupported in actors v2")
+
+}
+
+func (s *state2) ForEachAllocationAfter(clientIdAddr address.Address, after *AllocationId, cb func(allocationId AllocationId, allocation Allocation) error) error {
+
+	return fmt.Errorf("unsupported in actors v2")
+
+}
+
 func (s *state2) GetClaim(providerIdAddr address.Address, claimId verifreg9.ClaimId) (*Claim, bool, error) {
 
 	return nil, false, fmt.Errorf("unsupported in actors v2")
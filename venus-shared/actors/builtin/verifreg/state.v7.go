@@ -109,6 +109,18 @@ func (s *state7) GetAllocations(clientIdAddr address.Address) (map[AllocationId]
 
 }
 
+func (s *state7) ForEachAllocationClientAfter(after address.Address, cb func(clientIdAddr address.Address) error) error {
+
+	return fmt.Errorf("unsupported in actors v7")
+
+}
+
+func (s *state7) ForEachAllocationAfter(clientIdAddr address.Address, after *AllocationId, cb func(allocationId AllocationId, allocation Allocation) error) error {
+
+	return fmt.Errorf("unsupported in actors v7")
+
+}
+
 func (s *state7) GetClaim(providerIdAddr address.Address, claimId verifreg9.ClaimId) (*Claim, bool, error) {
 
 	return nil, false, fmt.Errorf("unsupported in actors v7")
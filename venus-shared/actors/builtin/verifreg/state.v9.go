@@ -3,6 +3,8 @@
 package verifreg
 
 import (
+	"bytes"
+
 	"fmt"
 
 	"github.com/filecoin-project/go-address"
@@ -19,6 +21,7 @@ import (
 	verifreg9 "github.com/filecoin-project/go-state-types/builtin/v9/verifreg"
 
 	"github.com/filecoin-project/go-state-types/big"
+	cbg "github.com/whyrusleeping/cbor-gen"
 )
 
 var _ State = (*state9)(nil)
@@ -115,6 +118,52 @@ func (s *state9) GetAllocations(clientIdAddr address.Address) (map[AllocationId]
 
 }
 
+func (s *state9) ForEachAllocationClientAfter(after address.Address, cb func(clientIdAddr address.Address) error) error {
+
+	var key []byte
+	if after != address.Undef {
+		key = after.Bytes()
+	}
+	return adt.ForEachAfter(s.store, s.Allocations, builtin9.DefaultHamtBitwidth, key, func(k []byte, _ *cbg.Deferred) error {
+		clientIdAddr, err := address.NewFromBytes(k)
+		if err != nil {
+			return err
+		}
+		return cb(clientIdAddr)
+	})
+
+}
+
+func (s *state9) ForEachAllocationAfter(clientIdAddr address.Address, after *AllocationId, cb func(allocationId AllocationId, allocation Allocation) error) error {
+
+	clients, err := adt9.AsMap(s.store, s.Allocations, builtin9.DefaultHamtBitwidth)
+	if err != nil {
+		return err
+	}
+	var root cbg.CborCid
+	found, err := clients.Get(abi.IdAddrKey(clientIdAddr), &root)
+	if err != nil || !found {
+		return err
+	}
+
+	var key []byte
+	if after != nil {
+		key = []byte(abi.UIntKey(uint64(*after)).Key())
+	}
+	return adt.ForEachAfter(s.store, cid.Cid(root), builtin9.DefaultHamtBitwidth, key, func(k []byte, val *cbg.Deferred) error {
+		id, err := abi.ParseUIntKey(string(k))
+		if err != nil {
+			return err
+		}
+		var allocation verifreg9.Allocation
+		if err := allocation.UnmarshalCBOR(bytes.NewReader(val.Raw)); err != nil {
+			return err
+		}
+		return cb(AllocationId(id), Allocation(allocation))
+	})
+
+}
+
 func (s *state9) GetClaim(providerIdAddr address.Address, claimId verifreg9.ClaimId) (*Claim, bool, error) {
 
 	claim, ok, err := s.FindClaim(s.store, providerIdAddr, verifreg9.ClaimId(claimId))
@@ -6,6 +6,7 @@ import (
 	actorstypes "github.com/filecoin-project/go-state-types/actors"
 
 	"fmt"
+	"sort"
 
 	"github.com/filecoin-project/go-state-types/big"
 
@@ -250,6 +251,30 @@ func SetConsensusMinerMinPower(p abi.StoragePower) {
 
 }
 
+// GetSupportedProofTypes returns the seal proof types miners may register with, sorted.
+func GetSupportedProofTypes() []abi.RegisteredSealProof {
+	types := make([]abi.RegisteredSealProof, 0, len(miner0.SupportedProofTypes))
+	for t := range miner0.SupportedProofTypes {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// GetConsensusMinerMinPower returns the minimum power a miner sealing with the lowest
+// supported proof type must reach to take part in leader election.
+func GetConsensusMinerMinPower() (abi.StoragePower, error) {
+	types := GetSupportedProofTypes()
+	if len(types) == 0 {
+		return abi.StoragePower{}, fmt.Errorf("no supported proof types")
+	}
+	wpp, err := types[0].RegisteredWindowPoStProof()
+	if err != nil {
+		return abi.StoragePower{}, err
+	}
+	return builtin11.ConsensusMinerMinPower(wpp)
+}
+
 // SetMinVerifiedDealSize sets the minimum size of a verified deal. This should
 // only be used for testing.
 func SetMinVerifiedDealSize(size abi.StoragePower) {
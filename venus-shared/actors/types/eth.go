@@ -381,6 +381,20 @@ func (ea *EthAddress) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalText allows EthAddress to be used as a json map key
+func (ea EthAddress) MarshalText() ([]byte, error) {
+	return []byte(ea.String()), nil
+}
+
+func (ea *EthAddress) UnmarshalText(b []byte) error {
+	addr, err := ParseEthAddress(string(b))
+	if err != nil {
+		return err
+	}
+	copy(ea[:], addr[:])
+	return nil
+}
+
 func (ea EthAddress) IsMaskedID() bool {
 	idmask := [12]byte{0xff}
 	return bytes.Equal(ea[:12], idmask[:])
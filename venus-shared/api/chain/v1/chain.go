@@ -9,6 +9,7 @@ import (
 	"github.com/filecoin-project/go-bitfield"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/v8/util/smoothing"
 	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/dline"
 	"github.com/filecoin-project/go-state-types/network"
@@ -44,13 +45,19 @@ type IChainInfo interface {
 	ChainGetTipSetAfterHeight(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                     //perm:read
 	StateGetRandomnessFromTickets(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) //perm:read
 	StateGetRandomnessFromBeacon(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error)  //perm:read
+	// ChainGetRandomnessDigest returns the randomness drawn for the domain tag at the given epoch,
+	// from the beacon for the challenge seeds, the tickets and the election proofs, from the tickets otherwise
+	ChainGetRandomnessDigest(ctx context.Context, domainTag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) ([]byte, error) //perm:read
 	// StateGetBeaconEntry returns the beacon entry for the given filecoin epoch. If
 	// the entry has not yet been produced, the call will block until the entry
 	// becomes available
-	StateGetBeaconEntry(ctx context.Context, epoch abi.ChainEpoch) (*types.BeaconEntry, error)                     //perm:read
-	ChainGetBlock(ctx context.Context, id cid.Cid) (*types.BlockHeader, error)                                     //perm:read
-	ChainGetMessage(ctx context.Context, msgID cid.Cid) (*types.Message, error)                                    //perm:read
-	ChainGetBlockMessages(ctx context.Context, bid cid.Cid) (*types.BlockMessages, error)                          //perm:read
+	StateGetBeaconEntry(ctx context.Context, epoch abi.ChainEpoch) (*types.BeaconEntry, error) //perm:read
+	ChainGetBlock(ctx context.Context, id cid.Cid) (*types.BlockHeader, error)                 //perm:read
+	ChainGetMessage(ctx context.Context, msgID cid.Cid) (*types.Message, error)                //perm:read
+	ChainGetBlockMessages(ctx context.Context, bid cid.Cid) (*types.BlockMessages, error)      //perm:read
+	// ChainGetBlockMessagesDecoded is ChainGetBlockMessages with the params of the calls to EVM contracts decoded
+	// when the method called is known
+	ChainGetBlockMessagesDecoded(ctx context.Context, bid cid.Cid) (*types.BlockMessagesDecoded, error)            //perm:read
 	ChainGetMessagesInTipset(ctx context.Context, key types.TipSetKey) ([]types.MessageCID, error)                 //perm:read
 	ChainGetReceipts(ctx context.Context, id cid.Cid) ([]types.MessageReceipt, error)                              //perm:read
 	ChainGetParentMessages(ctx context.Context, bcid cid.Cid) ([]types.MessageCID, error)                          //perm:read
@@ -150,6 +157,21 @@ type IChainInfo interface {
 	// Messages in the `apply` parameter must have the correct nonces, and gas
 	// values set.
 	StateCompute(context.Context, abi.ChainEpoch, []*types.Message, types.TipSetKey) (*types.ComputeStateOutput, error) //perm:read
+	// ChainGetLatestBeaconEntry returns the highest round beacon entry found walking back from the chain head
+	ChainGetLatestBeaconEntry(ctx context.Context) (*types.BeaconEntry, error) //perm:read
+	// ChainGetUpgradeSchedule returns the network upgrades enabled for this network ordered by height
+	ChainGetUpgradeSchedule(ctx context.Context) ([]types.UpgradeInfo, error) //perm:read
+	// StateGetMessageExecutionContext returns the block including the message within the tipset tsk
+	// and the context shared by the messages of the tipset
+	StateGetMessageExecutionContext(ctx context.Context, msgCID cid.Cid, tsk types.TipSetKey) (*types.MessageExecutionContext, error) //perm:read
+	// StateWaitMsgBatch waits for the messages concurrently like StateWaitMsg and returns the lookups in the order of cids.
+	// On the first failure the remaining waits are cancelled and the lookups of the leading messages found so far are
+	// returned with the error. At most 1000 messages are waited for at once.
+	StateWaitMsgBatch(ctx context.Context, cids []cid.Cid, confidence uint64, lookbackLimit abi.ChainEpoch, allowReplaced bool) ([]*types.MsgLookup, error) //perm:read
+	// ChainCheckConsistency walks the canonical chain between the epochs from and to and reports the parent state roots
+	// and message bundles referenced by its blocks that are missing from the blockstore. At most 2880 epochs are
+	// checked at once.
+	ChainCheckConsistency(ctx context.Context, from, to abi.ChainEpoch) (*types.ConsistencyReport, error) //perm:read
 }
 
 type IMinerState interface {
@@ -174,13 +196,24 @@ type IMinerState interface {
 	StateAllMinerFaults(ctx context.Context, lookback abi.ChainEpoch, ts types.TipSetKey) ([]*types.Fault, error)                                            //perm:read
 	StateMinerRecoveries(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                         //perm:read
 	StateMinerProvingDeadline(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*dline.Info, error)                                          //perm:read
-	StateMinerPartitions(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error)                           //perm:read
-	StateMinerDeadlines(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.Deadline, error)                                           //perm:read
-	StateMinerSectors(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)     //perm:read
-	StateMarketStorageDeal(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.MarketDeal, error)                                           //perm:read
+	// MinerGetProvingDeadlineForEpoch calculates the deadline open at the given epoch from the proving period start
+	// of the miner at tsk, the state at epoch itself is not looked at. Use IsOpen on the result to tell whether
+	// the deadline's challenge window is open at epoch.
+	MinerGetProvingDeadlineForEpoch(ctx context.Context, maddr address.Address, epoch abi.ChainEpoch, tsk types.TipSetKey) (*dline.Info, error) //perm:read
+	// StateMinerVerifyWindowPoSt checks proofs as a SubmitWindowedPoSt of the partitions of deadline would, without
+	// sending the message. The proofs are verified against the latest challenge of the deadline at tsk. As in the
+	// message, proofs holds a single proof and partitions at most the partitions a message may prove.
+	StateMinerVerifyWindowPoSt(ctx context.Context, maddr address.Address, deadline uint64, partitions []types.PoStPartition, proofs []types.PoStProof, tsk types.TipSetKey) (bool, error) //perm:read
+	StateMinerPartitions(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error)                                                         //perm:read
+	StateMinerDeadlines(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.Deadline, error)                                                                         //perm:read
+	StateMinerSectors(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)                                   //perm:read
+	StateMarketStorageDeal(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.MarketDeal, error)                                                                         //perm:read
 	// StateGetAllocationForPendingDeal returns the allocation for a given deal ID of a pending deal. Returns nil if
 	// pending allocation is not found.
 	StateGetAllocationForPendingDeal(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.Allocation, error) //perm:read
+	// StateGetAllocationsForSector returns the IDs of the verified allocations the deals of a pre-committed sector
+	// will claim once the sector is prove-committed.
+	StateGetAllocationsForSector(ctx context.Context, maddr address.Address, sectorNum abi.SectorNumber, tsk types.TipSetKey) ([]types.AllocationId, error) //perm:read
 	// StateGetAllocation returns the allocation for a given address and allocation ID.
 	StateGetAllocation(ctx context.Context, clientAddr address.Address, allocationID types.AllocationId, tsk types.TipSetKey) (*types.Allocation, error) //perm:read
 	// StateGetAllocations returns the all the allocations for a given client.
@@ -198,9 +231,27 @@ type IMinerState interface {
 	StateMarketDeals(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error)                                                              //perm:read
 	StateMinerActiveSectors(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)                                  //perm:read
 	StateLookupID(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)                                                        //perm:read
+	// StateMinerPreCommitDeposit returns the precommit deposit the miner actor requires for the specified miner's sector
+	// at tsk, StateMinerPreCommitDepositForPower returns it with a margin of 10%
+	StateMinerPreCommitDeposit(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error) //perm:read
+	// StateMinerInitialPledge returns the initial pledge the miner actor requires for the specified miner's sector
+	// at tsk, split into the storage pledge and the consensus pledge. StateMinerInitialPledgeCollateral returns
+	// their sum with a margin of 10%
+	StateMinerInitialPledge(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (*types.InitialPledge, error) //perm:read
+	// StateMinerWithdrawableAmount returns the amount the miner can withdraw at tsk, its balance minus the initial pledge,
+	// the precommit deposits, the fee debt and the funds that are not vested yet, or zero if they are not covered
+	StateMinerWithdrawableAmount(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error) //perm:read
+	// StateGetRewardSmoothingEstimate returns the smoothed estimate of the block reward per epoch of the reward actor at tsk
+	StateGetRewardSmoothingEstimate(ctx context.Context, tsk types.TipSetKey) (*smoothing.FilterEstimate, error) //perm:read
+	// StateGetPowerSmoothingEstimate returns the smoothed estimate of the network quality adjusted power of the power actor at tsk
+	StateGetPowerSmoothingEstimate(ctx context.Context, tsk types.TipSetKey) (*smoothing.FilterEstimate, error) //perm:read
 	// StateLookupRobustAddress returns the public key address of the given ID address for non-account addresses (multisig, miners etc)
-	StateLookupRobustAddress(context.Context, address.Address, types.TipSetKey) (address.Address, error)                                                     //perm:read
-	StateListMiners(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                     //perm:read
+	StateLookupRobustAddress(context.Context, address.Address, types.TipSetKey) (address.Address, error) //perm:read
+	StateListMiners(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                 //perm:read
+	// StateListMinersPaginated returns a page of the miners with a claim in the power actor. A nil pageToken starts
+	// from the first miner, the token of the returned page is nil on the last page. pageSize is capped at 10000,
+	// 0 picks the default of 1000. All pages have to be requested for the same tsk.
+	StateListMinersPaginated(ctx context.Context, tsk types.TipSetKey, pageToken *types.MinerPageToken, pageSize int) (*types.MinerPage, error)              //perm:read
 	StateListActors(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                     //perm:read
 	StateMinerPower(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*types.MinerPower, error)                                               //perm:read
 	StateMinerAvailableBalance(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error)                                             //perm:read
@@ -210,6 +261,22 @@ type IMinerState interface {
 	StateMarketBalance(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MarketBalance, error)                                          //perm:read
 	StateDealProviderCollateralBounds(ctx context.Context, size abi.PaddedPieceSize, verified bool, tsk types.TipSetKey) (types.DealCollateralBounds, error) //perm:read
 	StateVerifiedClientStatus(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                     //perm:read
+	// StateGetSectorExpirationBatch returns the expirations of the given sectors, loading the miner state once.
+	// The sectors that are not in the sector set of the miner are absent from the result.
+	StateGetSectorExpirationBatch(ctx context.Context, maddr address.Address, sectorNums []abi.SectorNumber, tsk types.TipSetKey) (map[abi.SectorNumber]*lminer.SectorExpiration, error) //perm:read
 	// StateMinerAllocated returns a bitfield containing all sector numbers marked as allocated in miner state
 	StateMinerAllocated(context.Context, address.Address, types.TipSetKey) (*bitfield.BitField, error) //perm:read
+	// StateDecodeReturnValue decodes the return value in the receipt of a message found looking back from tsk,
+	// using the return type of the called method of the receiving actor. The search looks back at most a week of epochs.
+	StateDecodeReturnValue(ctx context.Context, msgCID cid.Cid, tsk types.TipSetKey) (interface{}, error) //perm:read
+	// MinerGetQualityAdjustedPower returns the raw and quality adjusted power of the active sectors of a miner,
+	// read from the miner state instead of the claims table of the power actor
+	MinerGetQualityAdjustedPower(ctx context.Context, minerAddr address.Address, tsk types.TipSetKey) (*types.MinerSectorPower, error) //perm:read
+	// StateListVerifiedDatacapAllocations returns a page of the pending allocations of the verified registry in the order
+	// of its allocations table, which groups them by client, only those of clientAddr unless it is address.Undef. Only the
+	// table entries up to the end of the page are loaded. A nil pageToken starts from the first allocation and
+	// pageSize is capped at 1000, 0 picks the default of 100.
+	StateListVerifiedDatacapAllocations(ctx context.Context, clientAddr address.Address, tsk types.TipSetKey, pageToken *types.AllocationPageToken, pageSize int) (*types.AllocationPage, error) //perm:read
+	// StateListDatacapClaims returns the claims of the verified registry made by providerAddr ordered by allocation id.
+	StateListDatacapClaims(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) ([]types.DatacapClaim, error) //perm:read
 }
@@ -14,4 +14,8 @@ type ICommon interface {
 	NodeStatus(ctx context.Context, inclChainStatus bool) (types.NodeStatus, error) //perm:read
 	// StartTime returns node start time
 	StartTime(context.Context) (time.Time, error) //perm:read
+	// NodeVersion returns the build metadata of the node, the current network version and the supported api versions
+	NodeVersion(ctx context.Context) (*types.NodeVersion, error) //perm:read
+	// NodeHealthScore returns the health of the node as a score between 0 and 100, it is also served at GET /health
+	NodeHealthScore(ctx context.Context) (*types.HealthScore, error) //perm:read
 }
@@ -30,15 +30,15 @@ type IETH interface {
 	// EthGetBlockTransactionCountByHash returns the number of messages in the TipSet
 	EthGetBlockTransactionCountByHash(ctx context.Context, blkHash types.EthHash) (types.EthUint64, error) //perm:read
 
-	EthGetBlockByHash(ctx context.Context, blkHash types.EthHash, fullTxInfo bool) (types.EthBlock, error)                            //perm:read
-	EthGetBlockByNumber(ctx context.Context, blkNum string, fullTxInfo bool) (types.EthBlock, error)                                  //perm:read
-	EthGetTransactionByHash(ctx context.Context, txHash *types.EthHash) (*types.EthTx, error)                                         //perm:read
-	EthGetTransactionHashByCid(ctx context.Context, cid cid.Cid) (*types.EthHash, error)                                              //perm:read
-	EthGetMessageCidByTransactionHash(ctx context.Context, txHash *types.EthHash) (*cid.Cid, error)                                   //perm:read
-	EthGetTransactionCount(ctx context.Context, sender types.EthAddress, blkOpt string) (types.EthUint64, error)                      //perm:read
-	EthGetTransactionReceipt(ctx context.Context, txHash types.EthHash) (*types.EthTxReceipt, error)                                  //perm:read
-	EthGetTransactionByBlockHashAndIndex(ctx context.Context, blkHash types.EthHash, txIndex types.EthUint64) (types.EthTx, error)    //perm:read
-	EthGetTransactionByBlockNumberAndIndex(ctx context.Context, blkNum types.EthUint64, txIndex types.EthUint64) (types.EthTx, error) //perm:read
+	EthGetBlockByHash(ctx context.Context, blkHash types.EthHash, fullTxInfo bool) (types.EthBlock, error)                          //perm:read
+	EthGetBlockByNumber(ctx context.Context, blkNum string, fullTxInfo bool) (types.EthBlock, error)                                //perm:read
+	EthGetTransactionByHash(ctx context.Context, txHash *types.EthHash) (*types.EthTx, error)                                       //perm:read
+	EthGetTransactionHashByCid(ctx context.Context, cid cid.Cid) (*types.EthHash, error)                                            //perm:read
+	EthGetMessageCidByTransactionHash(ctx context.Context, txHash *types.EthHash) (*cid.Cid, error)                                 //perm:read
+	EthGetTransactionCount(ctx context.Context, sender types.EthAddress, blkOpt string) (types.EthUint64, error)                    //perm:read
+	EthGetTransactionReceipt(ctx context.Context, txHash types.EthHash) (*types.EthTxReceipt, error)                                //perm:read
+	EthGetTransactionByBlockHashAndIndex(ctx context.Context, blkHash types.EthHash, txIndex types.EthUint64) (*types.EthTx, error) //perm:read
+	EthGetTransactionByBlockNumberAndIndex(ctx context.Context, blkParam string, txIndex types.EthUint64) (*types.EthTx, error)     //perm:read
 
 	EthGetCode(ctx context.Context, address types.EthAddress, blkOpt string) (types.EthBytes, error)                                 //perm:read
 	EthGetStorageAt(ctx context.Context, address types.EthAddress, position types.EthBytes, blkParam string) (types.EthBytes, error) //perm:read
@@ -50,14 +50,35 @@ type IETH interface {
 	EthGasPrice(ctx context.Context) (types.EthBigInt, error)                                                                        //perm:read
 	EthFeeHistory(ctx context.Context, p jsonrpc.RawParams) (types.EthFeeHistory, error)                                             //perm:read
 
-	EthMaxPriorityFeePerGas(ctx context.Context) (types.EthBigInt, error)                   //perm:read
-	EthEstimateGas(ctx context.Context, tx types.EthCall) (types.EthUint64, error)          //perm:read
+	EthMaxPriorityFeePerGas(ctx context.Context) (types.EthBigInt, error)          //perm:read
+	EthEstimateGas(ctx context.Context, tx types.EthCall) (types.EthUint64, error) //perm:read
+	// EthCall executes the call on the state of a block number or a block hash, the state of
+	// a pruned block is not available
 	EthCall(ctx context.Context, tx types.EthCall, blkParam string) (types.EthBytes, error) //perm:read
 
 	EthSendRawTransaction(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error) //perm:read
 
 	// Returns the client version
 	Web3ClientVersion(ctx context.Context) (string, error) //perm:read
+
+	// EthGetBlockReceipts returns the receipts of all the transactions of a block, in transaction order,
+	// the block is given by number or by hash
+	EthGetBlockReceipts(ctx context.Context, blkParam string) ([]*types.EthTxReceipt, error) //perm:read
+
+	// EthNetPeerCount returns the number of peers the node is connected to
+	EthNetPeerCount(ctx context.Context) (types.EthUint64, error) //perm:read
+	// EthNetListening returns true once the node is connected to a peer
+	EthNetListening(ctx context.Context) (bool, error) //perm:read
+
+	// EthGetUncleByBlockHashAndIndex always returns null, Filecoin has no uncle blocks
+	EthGetUncleByBlockHashAndIndex(ctx context.Context, blkHash types.EthHash, index types.EthUint64) (*types.EthBlock, error) //perm:read
+	// EthGetUncleCountByBlockHash always returns 0, Filecoin has no uncle blocks
+	EthGetUncleCountByBlockHash(ctx context.Context, blkHash types.EthHash) (types.EthUint64, error) //perm:read
+	// EthDebugGetBadBlocks always returns an empty list, venus does not keep the blocks failing validation
+	EthDebugGetBadBlocks(ctx context.Context) ([]types.EthBlock, error) //perm:read
+	// EthTxpoolContent returns the eth transactions of the message pool by sender and nonce,
+	// the transactions following a nonce gap are reported as queued
+	EthTxpoolContent(ctx context.Context) (*types.EthTxpoolContentResult, error) //perm:read
 }
 
 type IETHEvent interface {
@@ -95,6 +116,17 @@ type IETHEvent interface {
 
 	// Unsubscribe from a websocket subscription
 	EthUnsubscribe(ctx context.Context, id types.EthSubscriptionID) (bool, error) //perm:write
+
+	// GetActorEventsRaw streams the actor events matching filter, events of past epochs are replayed
+	// from the event index before new ones are delivered as the chain advances.
+	// The channel is closed when ctx is cancelled, or after the replay when ToEpoch is already in the past.
+	GetActorEventsRaw(ctx context.Context, filter *types.ActorEventFilter) (<-chan *types.ActorEvent, error) //perm:read
+
+	// GetFVMEvents returns the native actor events matching filter from the persisted event store,
+	// both epochs are inclusive. The store only keeps the events of the last MaxFilterHeightRange
+	// epochs, an open from epoch starts there and an older one is rejected.
+	// The store is enabled by the EnableFVMEventStore event config.
+	GetFVMEvents(ctx context.Context, filter *types.ActorEventFilter) ([]*types.ActorEvent, error) //perm:read
 }
 
 // reverse interface to the client, called after EthSubscribe
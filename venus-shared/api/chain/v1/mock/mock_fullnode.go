@@ -16,11 +16,13 @@ import (
 	abi "github.com/filecoin-project/go-state-types/abi"
 	big "github.com/filecoin-project/go-state-types/big"
 	paych "github.com/filecoin-project/go-state-types/builtin/v8/paych"
+	smoothing "github.com/filecoin-project/go-state-types/builtin/v8/util/smoothing"
 	miner "github.com/filecoin-project/go-state-types/builtin/v9/miner"
 	verifreg "github.com/filecoin-project/go-state-types/builtin/v9/verifreg"
 	crypto "github.com/filecoin-project/go-state-types/crypto"
 	dline "github.com/filecoin-project/go-state-types/dline"
 	network "github.com/filecoin-project/go-state-types/network"
+	proof "github.com/filecoin-project/go-state-types/proof"
 	miner0 "github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
 	types "github.com/filecoin-project/venus/venus-shared/actors/types"
 	types0 "github.com/filecoin-project/venus/venus-shared/types"
@@ -70,6 +72,21 @@ func (mr *MockFullNodeMockRecorder) BlockTime(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockTime", reflect.TypeOf((*MockFullNode)(nil).BlockTime), arg0)
 }
 
+// ChainCheckConsistency mocks base method.
+func (m *MockFullNode) ChainCheckConsistency(arg0 context.Context, arg1, arg2 abi.ChainEpoch) (*types0.ConsistencyReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainCheckConsistency", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.ConsistencyReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainCheckConsistency indicates an expected call of ChainCheckConsistency.
+func (mr *MockFullNodeMockRecorder) ChainCheckConsistency(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainCheckConsistency", reflect.TypeOf((*MockFullNode)(nil).ChainCheckConsistency), arg0, arg1, arg2)
+}
+
 // ChainDeleteObj mocks base method.
 func (m *MockFullNode) ChainDeleteObj(arg0 context.Context, arg1 cid.Cid) error {
 	m.ctrl.T.Helper()
@@ -129,6 +146,21 @@ func (mr *MockFullNodeMockRecorder) ChainGetBlockMessages(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetBlockMessages", reflect.TypeOf((*MockFullNode)(nil).ChainGetBlockMessages), arg0, arg1)
 }
 
+// ChainGetBlockMessagesDecoded mocks base method.
+func (m *MockFullNode) ChainGetBlockMessagesDecoded(arg0 context.Context, arg1 cid.Cid) (*types0.BlockMessagesDecoded, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetBlockMessagesDecoded", arg0, arg1)
+	ret0, _ := ret[0].(*types0.BlockMessagesDecoded)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetBlockMessagesDecoded indicates an expected call of ChainGetBlockMessagesDecoded.
+func (mr *MockFullNodeMockRecorder) ChainGetBlockMessagesDecoded(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetBlockMessagesDecoded", reflect.TypeOf((*MockFullNode)(nil).ChainGetBlockMessagesDecoded), arg0, arg1)
+}
+
 // ChainGetEvents mocks base method.
 func (m *MockFullNode) ChainGetEvents(arg0 context.Context, arg1 cid.Cid) ([]types0.Event, error) {
 	m.ctrl.T.Helper()
@@ -159,6 +191,21 @@ func (mr *MockFullNodeMockRecorder) ChainGetGenesis(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetGenesis", reflect.TypeOf((*MockFullNode)(nil).ChainGetGenesis), arg0)
 }
 
+// ChainGetLatestBeaconEntry mocks base method.
+func (m *MockFullNode) ChainGetLatestBeaconEntry(arg0 context.Context) (*types0.BeaconEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetLatestBeaconEntry", arg0)
+	ret0, _ := ret[0].(*types0.BeaconEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetLatestBeaconEntry indicates an expected call of ChainGetLatestBeaconEntry.
+func (mr *MockFullNodeMockRecorder) ChainGetLatestBeaconEntry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetLatestBeaconEntry", reflect.TypeOf((*MockFullNode)(nil).ChainGetLatestBeaconEntry), arg0)
+}
+
 // ChainGetMessage mocks base method.
 func (m *MockFullNode) ChainGetMessage(arg0 context.Context, arg1 cid.Cid) (*types.Message, error) {
 	m.ctrl.T.Helper()
@@ -234,6 +281,21 @@ func (mr *MockFullNodeMockRecorder) ChainGetPath(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetPath", reflect.TypeOf((*MockFullNode)(nil).ChainGetPath), arg0, arg1, arg2)
 }
 
+// ChainGetRandomnessDigest mocks base method.
+func (m *MockFullNode) ChainGetRandomnessDigest(arg0 context.Context, arg1 crypto.DomainSeparationTag, arg2 abi.ChainEpoch, arg3 []byte, arg4 types0.TipSetKey) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetRandomnessDigest", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetRandomnessDigest indicates an expected call of ChainGetRandomnessDigest.
+func (mr *MockFullNodeMockRecorder) ChainGetRandomnessDigest(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetRandomnessDigest", reflect.TypeOf((*MockFullNode)(nil).ChainGetRandomnessDigest), arg0, arg1, arg2, arg3, arg4)
+}
+
 // ChainGetReceipts mocks base method.
 func (m *MockFullNode) ChainGetReceipts(arg0 context.Context, arg1 cid.Cid) ([]types0.MessageReceipt, error) {
 	m.ctrl.T.Helper()
@@ -294,6 +356,21 @@ func (mr *MockFullNodeMockRecorder) ChainGetTipSetByHeight(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetTipSetByHeight", reflect.TypeOf((*MockFullNode)(nil).ChainGetTipSetByHeight), arg0, arg1, arg2)
 }
 
+// ChainGetUpgradeSchedule mocks base method.
+func (m *MockFullNode) ChainGetUpgradeSchedule(arg0 context.Context) ([]types0.UpgradeInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetUpgradeSchedule", arg0)
+	ret0, _ := ret[0].([]types0.UpgradeInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetUpgradeSchedule indicates an expected call of ChainGetUpgradeSchedule.
+func (mr *MockFullNodeMockRecorder) ChainGetUpgradeSchedule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetUpgradeSchedule", reflect.TypeOf((*MockFullNode)(nil).ChainGetUpgradeSchedule), arg0)
+}
+
 // ChainHasObj mocks base method.
 func (m *MockFullNode) ChainHasObj(arg0 context.Context, arg1 cid.Cid) (bool, error) {
 	m.ctrl.T.Helper()
@@ -441,6 +518,20 @@ func (mr *MockFullNodeMockRecorder) ChainTipSetWeight(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainTipSetWeight", reflect.TypeOf((*MockFullNode)(nil).ChainTipSetWeight), arg0, arg1)
 }
 
+// ChainValidateBlock mocks base method.
+func (m *MockFullNode) ChainValidateBlock(arg0 context.Context, arg1 *types0.BlockHeader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainValidateBlock", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChainValidateBlock indicates an expected call of ChainValidateBlock.
+func (mr *MockFullNodeMockRecorder) ChainValidateBlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainValidateBlock", reflect.TypeOf((*MockFullNode)(nil).ChainValidateBlock), arg0, arg1)
+}
+
 // Concurrent mocks base method.
 func (m *MockFullNode) Concurrent(arg0 context.Context) int64 {
 	m.ctrl.T.Helper()
@@ -530,6 +621,21 @@ func (mr *MockFullNodeMockRecorder) EthChainId(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthChainId", reflect.TypeOf((*MockFullNode)(nil).EthChainId), arg0)
 }
 
+// EthDebugGetBadBlocks mocks base method.
+func (m *MockFullNode) EthDebugGetBadBlocks(arg0 context.Context) ([]types.EthBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthDebugGetBadBlocks", arg0)
+	ret0, _ := ret[0].([]types.EthBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthDebugGetBadBlocks indicates an expected call of EthDebugGetBadBlocks.
+func (mr *MockFullNodeMockRecorder) EthDebugGetBadBlocks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthDebugGetBadBlocks", reflect.TypeOf((*MockFullNode)(nil).EthDebugGetBadBlocks), arg0)
+}
+
 // EthEstimateGas mocks base method.
 func (m *MockFullNode) EthEstimateGas(arg0 context.Context, arg1 types.EthCall) (types.EthUint64, error) {
 	m.ctrl.T.Helper()
@@ -620,6 +726,21 @@ func (mr *MockFullNodeMockRecorder) EthGetBlockByNumber(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetBlockByNumber", reflect.TypeOf((*MockFullNode)(nil).EthGetBlockByNumber), arg0, arg1, arg2)
 }
 
+// EthGetBlockReceipts mocks base method.
+func (m *MockFullNode) EthGetBlockReceipts(arg0 context.Context, arg1 string) ([]*types.EthTxReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetBlockReceipts", arg0, arg1)
+	ret0, _ := ret[0].([]*types.EthTxReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetBlockReceipts indicates an expected call of EthGetBlockReceipts.
+func (mr *MockFullNodeMockRecorder) EthGetBlockReceipts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetBlockReceipts", reflect.TypeOf((*MockFullNode)(nil).EthGetBlockReceipts), arg0, arg1)
+}
+
 // EthGetBlockTransactionCountByHash mocks base method.
 func (m *MockFullNode) EthGetBlockTransactionCountByHash(arg0 context.Context, arg1 types.EthHash) (types.EthUint64, error) {
 	m.ctrl.T.Helper()
@@ -741,10 +862,10 @@ func (mr *MockFullNodeMockRecorder) EthGetStorageAt(arg0, arg1, arg2, arg3 inter
 }
 
 // EthGetTransactionByBlockHashAndIndex mocks base method.
-func (m *MockFullNode) EthGetTransactionByBlockHashAndIndex(arg0 context.Context, arg1 types.EthHash, arg2 types.EthUint64) (types.EthTx, error) {
+func (m *MockFullNode) EthGetTransactionByBlockHashAndIndex(arg0 context.Context, arg1 types.EthHash, arg2 types.EthUint64) (*types.EthTx, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "EthGetTransactionByBlockHashAndIndex", arg0, arg1, arg2)
-	ret0, _ := ret[0].(types.EthTx)
+	ret0, _ := ret[0].(*types.EthTx)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -756,10 +877,10 @@ func (mr *MockFullNodeMockRecorder) EthGetTransactionByBlockHashAndIndex(arg0, a
 }
 
 // EthGetTransactionByBlockNumberAndIndex mocks base method.
-func (m *MockFullNode) EthGetTransactionByBlockNumberAndIndex(arg0 context.Context, arg1, arg2 types.EthUint64) (types.EthTx, error) {
+func (m *MockFullNode) EthGetTransactionByBlockNumberAndIndex(arg0 context.Context, arg1 string, arg2 types.EthUint64) (*types.EthTx, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "EthGetTransactionByBlockNumberAndIndex", arg0, arg1, arg2)
-	ret0, _ := ret[0].(types.EthTx)
+	ret0, _ := ret[0].(*types.EthTx)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -830,6 +951,36 @@ func (mr *MockFullNodeMockRecorder) EthGetTransactionReceipt(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetTransactionReceipt", reflect.TypeOf((*MockFullNode)(nil).EthGetTransactionReceipt), arg0, arg1)
 }
 
+// EthGetUncleByBlockHashAndIndex mocks base method.
+func (m *MockFullNode) EthGetUncleByBlockHashAndIndex(arg0 context.Context, arg1 types.EthHash, arg2 types.EthUint64) (*types.EthBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetUncleByBlockHashAndIndex", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types.EthBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetUncleByBlockHashAndIndex indicates an expected call of EthGetUncleByBlockHashAndIndex.
+func (mr *MockFullNodeMockRecorder) EthGetUncleByBlockHashAndIndex(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetUncleByBlockHashAndIndex", reflect.TypeOf((*MockFullNode)(nil).EthGetUncleByBlockHashAndIndex), arg0, arg1, arg2)
+}
+
+// EthGetUncleCountByBlockHash mocks base method.
+func (m *MockFullNode) EthGetUncleCountByBlockHash(arg0 context.Context, arg1 types.EthHash) (types.EthUint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetUncleCountByBlockHash", arg0, arg1)
+	ret0, _ := ret[0].(types.EthUint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetUncleCountByBlockHash indicates an expected call of EthGetUncleCountByBlockHash.
+func (mr *MockFullNodeMockRecorder) EthGetUncleCountByBlockHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetUncleCountByBlockHash", reflect.TypeOf((*MockFullNode)(nil).EthGetUncleCountByBlockHash), arg0, arg1)
+}
+
 // EthMaxPriorityFeePerGas mocks base method.
 func (m *MockFullNode) EthMaxPriorityFeePerGas(arg0 context.Context) (types.EthBigInt, error) {
 	m.ctrl.T.Helper()
@@ -845,6 +996,36 @@ func (mr *MockFullNodeMockRecorder) EthMaxPriorityFeePerGas(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthMaxPriorityFeePerGas", reflect.TypeOf((*MockFullNode)(nil).EthMaxPriorityFeePerGas), arg0)
 }
 
+// EthNetListening mocks base method.
+func (m *MockFullNode) EthNetListening(arg0 context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthNetListening", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthNetListening indicates an expected call of EthNetListening.
+func (mr *MockFullNodeMockRecorder) EthNetListening(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthNetListening", reflect.TypeOf((*MockFullNode)(nil).EthNetListening), arg0)
+}
+
+// EthNetPeerCount mocks base method.
+func (m *MockFullNode) EthNetPeerCount(arg0 context.Context) (types.EthUint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthNetPeerCount", arg0)
+	ret0, _ := ret[0].(types.EthUint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthNetPeerCount indicates an expected call of EthNetPeerCount.
+func (mr *MockFullNodeMockRecorder) EthNetPeerCount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthNetPeerCount", reflect.TypeOf((*MockFullNode)(nil).EthNetPeerCount), arg0)
+}
+
 // EthNewBlockFilter mocks base method.
 func (m *MockFullNode) EthNewBlockFilter(arg0 context.Context) (types.EthFilterID, error) {
 	m.ctrl.T.Helper()
@@ -935,6 +1116,21 @@ func (mr *MockFullNodeMockRecorder) EthSubscribe(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthSubscribe", reflect.TypeOf((*MockFullNode)(nil).EthSubscribe), arg0, arg1)
 }
 
+// EthTxpoolContent mocks base method.
+func (m *MockFullNode) EthTxpoolContent(arg0 context.Context) (*types0.EthTxpoolContentResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthTxpoolContent", arg0)
+	ret0, _ := ret[0].(*types0.EthTxpoolContentResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthTxpoolContent indicates an expected call of EthTxpoolContent.
+func (mr *MockFullNodeMockRecorder) EthTxpoolContent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTxpoolContent", reflect.TypeOf((*MockFullNode)(nil).EthTxpoolContent), arg0)
+}
+
 // EthUninstallFilter mocks base method.
 func (m *MockFullNode) EthUninstallFilter(arg0 context.Context, arg1 types.EthFilterID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1025,6 +1221,36 @@ func (mr *MockFullNodeMockRecorder) GasEstimateGasLimit(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateGasLimit", reflect.TypeOf((*MockFullNode)(nil).GasEstimateGasLimit), arg0, arg1, arg2)
 }
 
+// GasEstimateGasLimitWithPriors mocks base method.
+func (m *MockFullNode) GasEstimateGasLimitWithPriors(arg0 context.Context, arg1 *types.Message, arg2 []*types.Message, arg3 types0.TipSetKey) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GasEstimateGasLimitWithPriors", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GasEstimateGasLimitWithPriors indicates an expected call of GasEstimateGasLimitWithPriors.
+func (mr *MockFullNodeMockRecorder) GasEstimateGasLimitWithPriors(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateGasLimitWithPriors", reflect.TypeOf((*MockFullNode)(nil).GasEstimateGasLimitWithPriors), arg0, arg1, arg2, arg3)
+}
+
+// GasEstimateGasLimitWithTrace mocks base method.
+func (m *MockFullNode) GasEstimateGasLimitWithTrace(arg0 context.Context, arg1 *types.Message, arg2 types0.TipSetKey) (*types0.GasLimitBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GasEstimateGasLimitWithTrace", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.GasLimitBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GasEstimateGasLimitWithTrace indicates an expected call of GasEstimateGasLimitWithTrace.
+func (mr *MockFullNodeMockRecorder) GasEstimateGasLimitWithTrace(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateGasLimitWithTrace", reflect.TypeOf((*MockFullNode)(nil).GasEstimateGasLimitWithTrace), arg0, arg1, arg2)
+}
+
 // GasEstimateGasPremium mocks base method.
 func (m *MockFullNode) GasEstimateGasPremium(arg0 context.Context, arg1 uint64, arg2 address.Address, arg3 int64, arg4 types0.TipSetKey) (big.Int, error) {
 	m.ctrl.T.Helper()
@@ -1040,6 +1266,21 @@ func (mr *MockFullNodeMockRecorder) GasEstimateGasPremium(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateGasPremium", reflect.TypeOf((*MockFullNode)(nil).GasEstimateGasPremium), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GasEstimateGasPremiumWithHistory mocks base method.
+func (m *MockFullNode) GasEstimateGasPremiumWithHistory(arg0 context.Context, arg1, arg2 uint64, arg3 address.Address, arg4 int64, arg5 types0.TipSetKey) (big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GasEstimateGasPremiumWithHistory", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GasEstimateGasPremiumWithHistory indicates an expected call of GasEstimateGasPremiumWithHistory.
+func (mr *MockFullNodeMockRecorder) GasEstimateGasPremiumWithHistory(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateGasPremiumWithHistory", reflect.TypeOf((*MockFullNode)(nil).GasEstimateGasPremiumWithHistory), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // GasEstimateMessageGas mocks base method.
 func (m *MockFullNode) GasEstimateMessageGas(arg0 context.Context, arg1 *types.Message, arg2 *types0.MessageSendSpec, arg3 types0.TipSetKey) (*types.Message, error) {
 	m.ctrl.T.Helper()
@@ -1070,6 +1311,21 @@ func (mr *MockFullNodeMockRecorder) GetActor(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActor", reflect.TypeOf((*MockFullNode)(nil).GetActor), arg0, arg1)
 }
 
+// GetActorEventsRaw mocks base method.
+func (m *MockFullNode) GetActorEventsRaw(arg0 context.Context, arg1 *types0.ActorEventFilter) (<-chan *types0.ActorEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorEventsRaw", arg0, arg1)
+	ret0, _ := ret[0].(<-chan *types0.ActorEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorEventsRaw indicates an expected call of GetActorEventsRaw.
+func (mr *MockFullNodeMockRecorder) GetActorEventsRaw(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorEventsRaw", reflect.TypeOf((*MockFullNode)(nil).GetActorEventsRaw), arg0, arg1)
+}
+
 // GetEntry mocks base method.
 func (m *MockFullNode) GetEntry(arg0 context.Context, arg1 abi.ChainEpoch, arg2 uint64) (*types0.BeaconEntry, error) {
 	m.ctrl.T.Helper()
@@ -1085,6 +1341,21 @@ func (mr *MockFullNodeMockRecorder) GetEntry(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockFullNode)(nil).GetEntry), arg0, arg1, arg2)
 }
 
+// GetFVMEvents mocks base method.
+func (m *MockFullNode) GetFVMEvents(arg0 context.Context, arg1 *types0.ActorEventFilter) ([]*types0.ActorEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFVMEvents", arg0, arg1)
+	ret0, _ := ret[0].([]*types0.ActorEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFVMEvents indicates an expected call of GetFVMEvents.
+func (mr *MockFullNodeMockRecorder) GetFVMEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFVMEvents", reflect.TypeOf((*MockFullNode)(nil).GetFVMEvents), arg0, arg1)
+}
+
 // GetFullBlock mocks base method.
 func (m *MockFullNode) GetFullBlock(arg0 context.Context, arg1 cid.Cid) (*types0.FullBlock, error) {
 	m.ctrl.T.Helper()
@@ -1203,6 +1474,36 @@ func (mr *MockFullNodeMockRecorder) MinerGetBaseInfo(arg0, arg1, arg2, arg3 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinerGetBaseInfo", reflect.TypeOf((*MockFullNode)(nil).MinerGetBaseInfo), arg0, arg1, arg2, arg3)
 }
 
+// MinerGetProvingDeadlineForEpoch mocks base method.
+func (m *MockFullNode) MinerGetProvingDeadlineForEpoch(arg0 context.Context, arg1 address.Address, arg2 abi.ChainEpoch, arg3 types0.TipSetKey) (*dline.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinerGetProvingDeadlineForEpoch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dline.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MinerGetProvingDeadlineForEpoch indicates an expected call of MinerGetProvingDeadlineForEpoch.
+func (mr *MockFullNodeMockRecorder) MinerGetProvingDeadlineForEpoch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinerGetProvingDeadlineForEpoch", reflect.TypeOf((*MockFullNode)(nil).MinerGetProvingDeadlineForEpoch), arg0, arg1, arg2, arg3)
+}
+
+// MinerGetQualityAdjustedPower mocks base method.
+func (m *MockFullNode) MinerGetQualityAdjustedPower(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (*types0.MinerSectorPower, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinerGetQualityAdjustedPower", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.MinerSectorPower)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MinerGetQualityAdjustedPower indicates an expected call of MinerGetQualityAdjustedPower.
+func (mr *MockFullNodeMockRecorder) MinerGetQualityAdjustedPower(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinerGetQualityAdjustedPower", reflect.TypeOf((*MockFullNode)(nil).MinerGetQualityAdjustedPower), arg0, arg1, arg2)
+}
+
 // MpoolBatchPush mocks base method.
 func (m *MockFullNode) MpoolBatchPush(arg0 context.Context, arg1 []*types.SignedMessage) ([]cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -1307,6 +1608,35 @@ func (mr *MockFullNodeMockRecorder) MpoolClear(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolClear", reflect.TypeOf((*MockFullNode)(nil).MpoolClear), arg0, arg1)
 }
 
+// MpoolClearRange mocks base method.
+func (m *MockFullNode) MpoolClearRange(arg0 context.Context, arg1 address.Address, arg2, arg3 uint64, arg4 bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolClearRange", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolClearRange indicates an expected call of MpoolClearRange.
+func (mr *MockFullNodeMockRecorder) MpoolClearRange(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolClearRange", reflect.TypeOf((*MockFullNode)(nil).MpoolClearRange), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MpoolClearWithSafetyCheck mocks base method.
+func (m *MockFullNode) MpoolClearWithSafetyCheck(arg0 context.Context, arg1 bool, arg2 abi.ChainEpoch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolClearWithSafetyCheck", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MpoolClearWithSafetyCheck indicates an expected call of MpoolClearWithSafetyCheck.
+func (mr *MockFullNodeMockRecorder) MpoolClearWithSafetyCheck(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolClearWithSafetyCheck", reflect.TypeOf((*MockFullNode)(nil).MpoolClearWithSafetyCheck), arg0, arg1, arg2)
+}
+
 // MpoolDeleteByAdress mocks base method.
 func (m *MockFullNode) MpoolDeleteByAdress(arg0 context.Context, arg1 address.Address) error {
 	m.ctrl.T.Helper()
@@ -1321,6 +1651,21 @@ func (mr *MockFullNodeMockRecorder) MpoolDeleteByAdress(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolDeleteByAdress", reflect.TypeOf((*MockFullNode)(nil).MpoolDeleteByAdress), arg0, arg1)
 }
 
+// MpoolGetBestGasLimit mocks base method.
+func (m *MockFullNode) MpoolGetBestGasLimit(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolGetBestGasLimit", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolGetBestGasLimit indicates an expected call of MpoolGetBestGasLimit.
+func (mr *MockFullNodeMockRecorder) MpoolGetBestGasLimit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolGetBestGasLimit", reflect.TypeOf((*MockFullNode)(nil).MpoolGetBestGasLimit), arg0)
+}
+
 // MpoolGetConfig mocks base method.
 func (m *MockFullNode) MpoolGetConfig(arg0 context.Context) (*types0.MpoolConfig, error) {
 	m.ctrl.T.Helper()
@@ -1351,6 +1696,21 @@ func (mr *MockFullNodeMockRecorder) MpoolGetNonce(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolGetNonce", reflect.TypeOf((*MockFullNode)(nil).MpoolGetNonce), arg0, arg1)
 }
 
+// MpoolGetPendingNonce mocks base method.
+func (m *MockFullNode) MpoolGetPendingNonce(arg0 context.Context, arg1 address.Address) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolGetPendingNonce", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolGetPendingNonce indicates an expected call of MpoolGetPendingNonce.
+func (mr *MockFullNodeMockRecorder) MpoolGetPendingNonce(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolGetPendingNonce", reflect.TypeOf((*MockFullNode)(nil).MpoolGetPendingNonce), arg0, arg1)
+}
+
 // MpoolPending mocks base method.
 func (m *MockFullNode) MpoolPending(arg0 context.Context, arg1 types0.TipSetKey) ([]*types.SignedMessage, error) {
 	m.ctrl.T.Helper()
@@ -1439,6 +1799,36 @@ func (mr *MockFullNodeMockRecorder) MpoolPushUntrusted(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPushUntrusted", reflect.TypeOf((*MockFullNode)(nil).MpoolPushUntrusted), arg0, arg1)
 }
 
+// MpoolPushWithKey mocks base method.
+func (m *MockFullNode) MpoolPushWithKey(arg0 context.Context, arg1 *types.Message, arg2 address.Address) (*types.SignedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolPushWithKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types.SignedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolPushWithKey indicates an expected call of MpoolPushWithKey.
+func (mr *MockFullNodeMockRecorder) MpoolPushWithKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPushWithKey", reflect.TypeOf((*MockFullNode)(nil).MpoolPushWithKey), arg0, arg1, arg2)
+}
+
+// MpoolReplace mocks base method.
+func (m *MockFullNode) MpoolReplace(arg0 context.Context, arg1 address.Address, arg2 uint64, arg3 *types0.MessageSendSpec) (*types.SignedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolReplace", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types.SignedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolReplace indicates an expected call of MpoolReplace.
+func (mr *MockFullNodeMockRecorder) MpoolReplace(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolReplace", reflect.TypeOf((*MockFullNode)(nil).MpoolReplace), arg0, arg1, arg2, arg3)
+}
+
 // MpoolSelect mocks base method.
 func (m *MockFullNode) MpoolSelect(arg0 context.Context, arg1 types0.TipSetKey, arg2 float64) ([]*types.SignedMessage, error) {
 	m.ctrl.T.Helper()
@@ -1588,6 +1978,64 @@ func (mr *MockFullNodeMockRecorder) NetBandwidthStatsByProtocol(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetBandwidthStatsByProtocol", reflect.TypeOf((*MockFullNode)(nil).NetBandwidthStatsByProtocol), arg0)
 }
 
+// NetBlockAdd mocks base method.
+func (m *MockFullNode) NetBlockAdd(arg0 context.Context, arg1 types0.NetBlockList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetBlockAdd", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NetBlockAdd indicates an expected call of NetBlockAdd.
+func (mr *MockFullNodeMockRecorder) NetBlockAdd(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetBlockAdd", reflect.TypeOf((*MockFullNode)(nil).NetBlockAdd), arg0, arg1)
+}
+
+// NetBlockList mocks base method.
+func (m *MockFullNode) NetBlockList(arg0 context.Context) (types0.NetBlockList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetBlockList", arg0)
+	ret0, _ := ret[0].(types0.NetBlockList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetBlockList indicates an expected call of NetBlockList.
+func (mr *MockFullNodeMockRecorder) NetBlockList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetBlockList", reflect.TypeOf((*MockFullNode)(nil).NetBlockList), arg0)
+}
+
+// NetBlockRemove mocks base method.
+func (m *MockFullNode) NetBlockRemove(arg0 context.Context, arg1 types0.NetBlockList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetBlockRemove", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NetBlockRemove indicates an expected call of NetBlockRemove.
+func (mr *MockFullNodeMockRecorder) NetBlockRemove(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetBlockRemove", reflect.TypeOf((*MockFullNode)(nil).NetBlockRemove), arg0, arg1)
+}
+
+// NetBootstrapHealth mocks base method.
+func (m *MockFullNode) NetBootstrapHealth(arg0 context.Context) ([]types0.BootstrapStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetBootstrapHealth", arg0)
+	ret0, _ := ret[0].([]types0.BootstrapStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetBootstrapHealth indicates an expected call of NetBootstrapHealth.
+func (mr *MockFullNodeMockRecorder) NetBootstrapHealth(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetBootstrapHealth", reflect.TypeOf((*MockFullNode)(nil).NetBootstrapHealth), arg0)
+}
+
 // NetConnect mocks base method.
 func (m *MockFullNode) NetConnect(arg0 context.Context, arg1 peer.AddrInfo) error {
 	m.ctrl.T.Helper()
@@ -1690,6 +2138,21 @@ func (mr *MockFullNodeMockRecorder) NetListening(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetListening", reflect.TypeOf((*MockFullNode)(nil).NetListening), arg0)
 }
 
+// NetNATStatus mocks base method.
+func (m *MockFullNode) NetNATStatus(arg0 context.Context) (*types0.NATStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetNATStatus", arg0)
+	ret0, _ := ret[0].(*types0.NATStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetNATStatus indicates an expected call of NetNATStatus.
+func (mr *MockFullNodeMockRecorder) NetNATStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetNATStatus", reflect.TypeOf((*MockFullNode)(nil).NetNATStatus), arg0)
+}
+
 // NetPeerInfo mocks base method.
 func (m *MockFullNode) NetPeerInfo(arg0 context.Context, arg1 peer.ID) (*types0.ExtendedPeerInfo, error) {
 	m.ctrl.T.Helper()
@@ -1705,6 +2168,21 @@ func (mr *MockFullNodeMockRecorder) NetPeerInfo(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPeerInfo", reflect.TypeOf((*MockFullNode)(nil).NetPeerInfo), arg0, arg1)
 }
 
+// NetPeerStats mocks base method.
+func (m *MockFullNode) NetPeerStats(arg0 context.Context) ([]types0.PeerStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetPeerStats", arg0)
+	ret0, _ := ret[0].([]types0.PeerStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetPeerStats indicates an expected call of NetPeerStats.
+func (mr *MockFullNodeMockRecorder) NetPeerStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPeerStats", reflect.TypeOf((*MockFullNode)(nil).NetPeerStats), arg0)
+}
+
 // NetPeers mocks base method.
 func (m *MockFullNode) NetPeers(arg0 context.Context) ([]peer.AddrInfo, error) {
 	m.ctrl.T.Helper()
@@ -1793,6 +2271,34 @@ func (mr *MockFullNodeMockRecorder) NetPubsubScores(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPubsubScores", reflect.TypeOf((*MockFullNode)(nil).NetPubsubScores), arg0)
 }
 
+// NetTagPeer mocks base method.
+func (m *MockFullNode) NetTagPeer(arg0 context.Context, arg1 peer.ID, arg2 string, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetTagPeer", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NetTagPeer indicates an expected call of NetTagPeer.
+func (mr *MockFullNodeMockRecorder) NetTagPeer(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetTagPeer", reflect.TypeOf((*MockFullNode)(nil).NetTagPeer), arg0, arg1, arg2, arg3)
+}
+
+// NetUntagPeer mocks base method.
+func (m *MockFullNode) NetUntagPeer(arg0 context.Context, arg1 peer.ID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetUntagPeer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NetUntagPeer indicates an expected call of NetUntagPeer.
+func (mr *MockFullNodeMockRecorder) NetUntagPeer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetUntagPeer", reflect.TypeOf((*MockFullNode)(nil).NetUntagPeer), arg0, arg1, arg2)
+}
+
 // NetVersion mocks base method.
 func (m *MockFullNode) NetVersion(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -1808,6 +2314,21 @@ func (mr *MockFullNodeMockRecorder) NetVersion(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetVersion", reflect.TypeOf((*MockFullNode)(nil).NetVersion), arg0)
 }
 
+// NodeHealthScore mocks base method.
+func (m *MockFullNode) NodeHealthScore(arg0 context.Context) (*types0.HealthScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NodeHealthScore", arg0)
+	ret0, _ := ret[0].(*types0.HealthScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NodeHealthScore indicates an expected call of NodeHealthScore.
+func (mr *MockFullNodeMockRecorder) NodeHealthScore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeHealthScore", reflect.TypeOf((*MockFullNode)(nil).NodeHealthScore), arg0)
+}
+
 // NodeStatus mocks base method.
 func (m *MockFullNode) NodeStatus(arg0 context.Context, arg1 bool) (types0.NodeStatus, error) {
 	m.ctrl.T.Helper()
@@ -1823,6 +2344,21 @@ func (mr *MockFullNodeMockRecorder) NodeStatus(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeStatus", reflect.TypeOf((*MockFullNode)(nil).NodeStatus), arg0, arg1)
 }
 
+// NodeVersion mocks base method.
+func (m *MockFullNode) NodeVersion(arg0 context.Context) (*types0.NodeVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NodeVersion", arg0)
+	ret0, _ := ret[0].(*types0.NodeVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NodeVersion indicates an expected call of NodeVersion.
+func (mr *MockFullNodeMockRecorder) NodeVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeVersion", reflect.TypeOf((*MockFullNode)(nil).NodeVersion), arg0)
+}
+
 // PaychAllocateLane mocks base method.
 func (m *MockFullNode) PaychAllocateLane(arg0 context.Context, arg1 address.Address) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -1838,6 +2374,21 @@ func (mr *MockFullNodeMockRecorder) PaychAllocateLane(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaychAllocateLane", reflect.TypeOf((*MockFullNode)(nil).PaychAllocateLane), arg0, arg1)
 }
 
+// PaychAllocateLaneWithLabel mocks base method.
+func (m *MockFullNode) PaychAllocateLaneWithLabel(arg0 context.Context, arg1 address.Address, arg2 string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaychAllocateLaneWithLabel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaychAllocateLaneWithLabel indicates an expected call of PaychAllocateLaneWithLabel.
+func (mr *MockFullNodeMockRecorder) PaychAllocateLaneWithLabel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaychAllocateLaneWithLabel", reflect.TypeOf((*MockFullNode)(nil).PaychAllocateLaneWithLabel), arg0, arg1, arg2)
+}
+
 // PaychAvailableFunds mocks base method.
 func (m *MockFullNode) PaychAvailableFunds(arg0 context.Context, arg1 address.Address) (*types0.ChannelAvailableFunds, error) {
 	m.ctrl.T.Helper()
@@ -1913,6 +2464,21 @@ func (mr *MockFullNodeMockRecorder) PaychGet(arg0, arg1, arg2, arg3, arg4 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaychGet", reflect.TypeOf((*MockFullNode)(nil).PaychGet), arg0, arg1, arg2, arg3, arg4)
 }
 
+// PaychGetLaneLabels mocks base method.
+func (m *MockFullNode) PaychGetLaneLabels(arg0 context.Context, arg1 address.Address) (map[uint64]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaychGetLaneLabels", arg0, arg1)
+	ret0, _ := ret[0].(map[uint64]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaychGetLaneLabels indicates an expected call of PaychGetLaneLabels.
+func (mr *MockFullNodeMockRecorder) PaychGetLaneLabels(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaychGetLaneLabels", reflect.TypeOf((*MockFullNode)(nil).PaychGetLaneLabels), arg0, arg1)
+}
+
 // PaychGetWaitReady mocks base method.
 func (m *MockFullNode) PaychGetWaitReady(arg0 context.Context, arg1 cid.Cid) (address.Address, error) {
 	m.ctrl.T.Helper()
@@ -2047,6 +2613,21 @@ func (mr *MockFullNodeMockRecorder) PaychVoucherCreate(arg0, arg1, arg2, arg3 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaychVoucherCreate", reflect.TypeOf((*MockFullNode)(nil).PaychVoucherCreate), arg0, arg1, arg2, arg3)
 }
 
+// PaychVoucherCreateSigned mocks base method.
+func (m *MockFullNode) PaychVoucherCreateSigned(arg0 context.Context, arg1 address.Address, arg2 big.Int, arg3 uint64) (*paych.SignedVoucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaychVoucherCreateSigned", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*paych.SignedVoucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaychVoucherCreateSigned indicates an expected call of PaychVoucherCreateSigned.
+func (mr *MockFullNodeMockRecorder) PaychVoucherCreateSigned(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaychVoucherCreateSigned", reflect.TypeOf((*MockFullNode)(nil).PaychVoucherCreateSigned), arg0, arg1, arg2, arg3)
+}
+
 // PaychVoucherList mocks base method.
 func (m *MockFullNode) PaychVoucherList(arg0 context.Context, arg1 address.Address) ([]*paych.SignedVoucher, error) {
 	m.ctrl.T.Helper()
@@ -2092,6 +2673,35 @@ func (mr *MockFullNodeMockRecorder) ProtocolParameters(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProtocolParameters", reflect.TypeOf((*MockFullNode)(nil).ProtocolParameters), arg0)
 }
 
+// PubsubLeaveTopic mocks base method.
+func (m *MockFullNode) PubsubLeaveTopic(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PubsubLeaveTopic", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PubsubLeaveTopic indicates an expected call of PubsubLeaveTopic.
+func (mr *MockFullNodeMockRecorder) PubsubLeaveTopic(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PubsubLeaveTopic", reflect.TypeOf((*MockFullNode)(nil).PubsubLeaveTopic), arg0, arg1)
+}
+
+// PubsubListTopics mocks base method.
+func (m *MockFullNode) PubsubListTopics(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PubsubListTopics", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PubsubListTopics indicates an expected call of PubsubListTopics.
+func (mr *MockFullNodeMockRecorder) PubsubListTopics(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PubsubListTopics", reflect.TypeOf((*MockFullNode)(nil).PubsubListTopics), arg0)
+}
+
 // ResolveToKeyAddr mocks base method.
 func (m *MockFullNode) ResolveToKeyAddr(arg0 context.Context, arg1 address.Address, arg2 *types0.TipSet) (address.Address, error) {
 	m.ctrl.T.Helper()
@@ -2315,6 +2925,21 @@ func (mr *MockFullNodeMockRecorder) StateDecodeParams(arg0, arg1, arg2, arg3, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateDecodeParams", reflect.TypeOf((*MockFullNode)(nil).StateDecodeParams), arg0, arg1, arg2, arg3, arg4)
 }
 
+// StateDecodeReturnValue mocks base method.
+func (m *MockFullNode) StateDecodeReturnValue(arg0 context.Context, arg1 cid.Cid, arg2 types0.TipSetKey) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateDecodeReturnValue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateDecodeReturnValue indicates an expected call of StateDecodeReturnValue.
+func (mr *MockFullNodeMockRecorder) StateDecodeReturnValue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateDecodeReturnValue", reflect.TypeOf((*MockFullNode)(nil).StateDecodeReturnValue), arg0, arg1, arg2)
+}
+
 // StateEncodeParams mocks base method.
 func (m *MockFullNode) StateEncodeParams(arg0 context.Context, arg1 cid.Cid, arg2 abi.MethodNum, arg3 json.RawMessage) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -2390,6 +3015,21 @@ func (mr *MockFullNodeMockRecorder) StateGetAllocations(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetAllocations", reflect.TypeOf((*MockFullNode)(nil).StateGetAllocations), arg0, arg1, arg2)
 }
 
+// StateGetAllocationsForSector mocks base method.
+func (m *MockFullNode) StateGetAllocationsForSector(arg0 context.Context, arg1 address.Address, arg2 abi.SectorNumber, arg3 types0.TipSetKey) ([]verifreg.AllocationId, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateGetAllocationsForSector", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]verifreg.AllocationId)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateGetAllocationsForSector indicates an expected call of StateGetAllocationsForSector.
+func (mr *MockFullNodeMockRecorder) StateGetAllocationsForSector(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetAllocationsForSector", reflect.TypeOf((*MockFullNode)(nil).StateGetAllocationsForSector), arg0, arg1, arg2, arg3)
+}
+
 // StateGetBeaconEntry mocks base method.
 func (m *MockFullNode) StateGetBeaconEntry(arg0 context.Context, arg1 abi.ChainEpoch) (*types0.BeaconEntry, error) {
 	m.ctrl.T.Helper()
@@ -2435,6 +3075,21 @@ func (mr *MockFullNodeMockRecorder) StateGetClaims(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetClaims", reflect.TypeOf((*MockFullNode)(nil).StateGetClaims), arg0, arg1, arg2)
 }
 
+// StateGetMessageExecutionContext mocks base method.
+func (m *MockFullNode) StateGetMessageExecutionContext(arg0 context.Context, arg1 cid.Cid, arg2 types0.TipSetKey) (*types0.MessageExecutionContext, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateGetMessageExecutionContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.MessageExecutionContext)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateGetMessageExecutionContext indicates an expected call of StateGetMessageExecutionContext.
+func (mr *MockFullNodeMockRecorder) StateGetMessageExecutionContext(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetMessageExecutionContext", reflect.TypeOf((*MockFullNode)(nil).StateGetMessageExecutionContext), arg0, arg1, arg2)
+}
+
 // StateGetNetworkParams mocks base method.
 func (m *MockFullNode) StateGetNetworkParams(arg0 context.Context) (*types0.NetworkParams, error) {
 	m.ctrl.T.Helper()
@@ -2450,6 +3105,21 @@ func (mr *MockFullNodeMockRecorder) StateGetNetworkParams(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetNetworkParams", reflect.TypeOf((*MockFullNode)(nil).StateGetNetworkParams), arg0)
 }
 
+// StateGetPowerSmoothingEstimate mocks base method.
+func (m *MockFullNode) StateGetPowerSmoothingEstimate(arg0 context.Context, arg1 types0.TipSetKey) (*smoothing.FilterEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateGetPowerSmoothingEstimate", arg0, arg1)
+	ret0, _ := ret[0].(*smoothing.FilterEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateGetPowerSmoothingEstimate indicates an expected call of StateGetPowerSmoothingEstimate.
+func (mr *MockFullNodeMockRecorder) StateGetPowerSmoothingEstimate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetPowerSmoothingEstimate", reflect.TypeOf((*MockFullNode)(nil).StateGetPowerSmoothingEstimate), arg0, arg1)
+}
+
 // StateGetRandomnessFromBeacon mocks base method.
 func (m *MockFullNode) StateGetRandomnessFromBeacon(arg0 context.Context, arg1 crypto.DomainSeparationTag, arg2 abi.ChainEpoch, arg3 []byte, arg4 types0.TipSetKey) (abi.Randomness, error) {
 	m.ctrl.T.Helper()
@@ -2480,6 +3150,36 @@ func (mr *MockFullNodeMockRecorder) StateGetRandomnessFromTickets(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetRandomnessFromTickets", reflect.TypeOf((*MockFullNode)(nil).StateGetRandomnessFromTickets), arg0, arg1, arg2, arg3, arg4)
 }
 
+// StateGetRewardSmoothingEstimate mocks base method.
+func (m *MockFullNode) StateGetRewardSmoothingEstimate(arg0 context.Context, arg1 types0.TipSetKey) (*smoothing.FilterEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateGetRewardSmoothingEstimate", arg0, arg1)
+	ret0, _ := ret[0].(*smoothing.FilterEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateGetRewardSmoothingEstimate indicates an expected call of StateGetRewardSmoothingEstimate.
+func (mr *MockFullNodeMockRecorder) StateGetRewardSmoothingEstimate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetRewardSmoothingEstimate", reflect.TypeOf((*MockFullNode)(nil).StateGetRewardSmoothingEstimate), arg0, arg1)
+}
+
+// StateGetSectorExpirationBatch mocks base method.
+func (m *MockFullNode) StateGetSectorExpirationBatch(arg0 context.Context, arg1 address.Address, arg2 []abi.SectorNumber, arg3 types0.TipSetKey) (map[abi.SectorNumber]*miner0.SectorExpiration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateGetSectorExpirationBatch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(map[abi.SectorNumber]*miner0.SectorExpiration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateGetSectorExpirationBatch indicates an expected call of StateGetSectorExpirationBatch.
+func (mr *MockFullNodeMockRecorder) StateGetSectorExpirationBatch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetSectorExpirationBatch", reflect.TypeOf((*MockFullNode)(nil).StateGetSectorExpirationBatch), arg0, arg1, arg2, arg3)
+}
+
 // StateListActors mocks base method.
 func (m *MockFullNode) StateListActors(arg0 context.Context, arg1 types0.TipSetKey) ([]address.Address, error) {
 	m.ctrl.T.Helper()
@@ -2495,6 +3195,21 @@ func (mr *MockFullNodeMockRecorder) StateListActors(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListActors", reflect.TypeOf((*MockFullNode)(nil).StateListActors), arg0, arg1)
 }
 
+// StateListDatacapClaims mocks base method.
+func (m *MockFullNode) StateListDatacapClaims(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) ([]types0.DatacapClaim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateListDatacapClaims", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types0.DatacapClaim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateListDatacapClaims indicates an expected call of StateListDatacapClaims.
+func (mr *MockFullNodeMockRecorder) StateListDatacapClaims(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListDatacapClaims", reflect.TypeOf((*MockFullNode)(nil).StateListDatacapClaims), arg0, arg1, arg2)
+}
+
 // StateListMessages mocks base method.
 func (m *MockFullNode) StateListMessages(arg0 context.Context, arg1 *types0.MessageMatch, arg2 types0.TipSetKey, arg3 abi.ChainEpoch) ([]cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -2525,6 +3240,36 @@ func (mr *MockFullNodeMockRecorder) StateListMiners(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListMiners", reflect.TypeOf((*MockFullNode)(nil).StateListMiners), arg0, arg1)
 }
 
+// StateListMinersPaginated mocks base method.
+func (m *MockFullNode) StateListMinersPaginated(arg0 context.Context, arg1 types0.TipSetKey, arg2 *types0.MinerPageToken, arg3 int) (*types0.MinerPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateListMinersPaginated", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.MinerPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateListMinersPaginated indicates an expected call of StateListMinersPaginated.
+func (mr *MockFullNodeMockRecorder) StateListMinersPaginated(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListMinersPaginated", reflect.TypeOf((*MockFullNode)(nil).StateListMinersPaginated), arg0, arg1, arg2, arg3)
+}
+
+// StateListVerifiedDatacapAllocations mocks base method.
+func (m *MockFullNode) StateListVerifiedDatacapAllocations(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey, arg3 *types0.AllocationPageToken, arg4 int) (*types0.AllocationPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateListVerifiedDatacapAllocations", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*types0.AllocationPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateListVerifiedDatacapAllocations indicates an expected call of StateListVerifiedDatacapAllocations.
+func (mr *MockFullNodeMockRecorder) StateListVerifiedDatacapAllocations(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListVerifiedDatacapAllocations", reflect.TypeOf((*MockFullNode)(nil).StateListVerifiedDatacapAllocations), arg0, arg1, arg2, arg3, arg4)
+}
+
 // StateLookupID mocks base method.
 func (m *MockFullNode) StateLookupID(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (address.Address, error) {
 	m.ctrl.T.Helper()
@@ -2705,6 +3450,21 @@ func (mr *MockFullNodeMockRecorder) StateMinerInfo(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerInfo", reflect.TypeOf((*MockFullNode)(nil).StateMinerInfo), arg0, arg1, arg2)
 }
 
+// StateMinerInitialPledge mocks base method.
+func (m *MockFullNode) StateMinerInitialPledge(arg0 context.Context, arg1 address.Address, arg2 miner.SectorPreCommitInfo, arg3 types0.TipSetKey) (*types0.InitialPledge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMinerInitialPledge", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.InitialPledge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMinerInitialPledge indicates an expected call of StateMinerInitialPledge.
+func (mr *MockFullNodeMockRecorder) StateMinerInitialPledge(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerInitialPledge", reflect.TypeOf((*MockFullNode)(nil).StateMinerInitialPledge), arg0, arg1, arg2, arg3)
+}
+
 // StateMinerInitialPledgeCollateral mocks base method.
 func (m *MockFullNode) StateMinerInitialPledgeCollateral(arg0 context.Context, arg1 address.Address, arg2 miner.SectorPreCommitInfo, arg3 types0.TipSetKey) (big.Int, error) {
 	m.ctrl.T.Helper()
@@ -2750,6 +3510,21 @@ func (mr *MockFullNodeMockRecorder) StateMinerPower(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerPower", reflect.TypeOf((*MockFullNode)(nil).StateMinerPower), arg0, arg1, arg2)
 }
 
+// StateMinerPreCommitDeposit mocks base method.
+func (m *MockFullNode) StateMinerPreCommitDeposit(arg0 context.Context, arg1 address.Address, arg2 miner.SectorPreCommitInfo, arg3 types0.TipSetKey) (big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMinerPreCommitDeposit", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMinerPreCommitDeposit indicates an expected call of StateMinerPreCommitDeposit.
+func (mr *MockFullNodeMockRecorder) StateMinerPreCommitDeposit(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerPreCommitDeposit", reflect.TypeOf((*MockFullNode)(nil).StateMinerPreCommitDeposit), arg0, arg1, arg2, arg3)
+}
+
 // StateMinerPreCommitDepositForPower mocks base method.
 func (m *MockFullNode) StateMinerPreCommitDepositForPower(arg0 context.Context, arg1 address.Address, arg2 miner.SectorPreCommitInfo, arg3 types0.TipSetKey) (big.Int, error) {
 	m.ctrl.T.Helper()
@@ -2855,6 +3630,36 @@ func (mr *MockFullNodeMockRecorder) StateMinerSectors(arg0, arg1, arg2, arg3 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerSectors", reflect.TypeOf((*MockFullNode)(nil).StateMinerSectors), arg0, arg1, arg2, arg3)
 }
 
+// StateMinerVerifyWindowPoSt mocks base method.
+func (m *MockFullNode) StateMinerVerifyWindowPoSt(arg0 context.Context, arg1 address.Address, arg2 uint64, arg3 []miner.PoStPartition, arg4 []proof.PoStProof, arg5 types0.TipSetKey) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMinerVerifyWindowPoSt", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMinerVerifyWindowPoSt indicates an expected call of StateMinerVerifyWindowPoSt.
+func (mr *MockFullNodeMockRecorder) StateMinerVerifyWindowPoSt(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerVerifyWindowPoSt", reflect.TypeOf((*MockFullNode)(nil).StateMinerVerifyWindowPoSt), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// StateMinerWithdrawableAmount mocks base method.
+func (m *MockFullNode) StateMinerWithdrawableAmount(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMinerWithdrawableAmount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMinerWithdrawableAmount indicates an expected call of StateMinerWithdrawableAmount.
+func (mr *MockFullNodeMockRecorder) StateMinerWithdrawableAmount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerWithdrawableAmount", reflect.TypeOf((*MockFullNode)(nil).StateMinerWithdrawableAmount), arg0, arg1, arg2)
+}
+
 // StateMinerWorkerAddress mocks base method.
 func (m *MockFullNode) StateMinerWorkerAddress(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (address.Address, error) {
 	m.ctrl.T.Helper()
@@ -3080,6 +3885,21 @@ func (mr *MockFullNodeMockRecorder) StateWaitMsg(arg0, arg1, arg2, arg3, arg4 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateWaitMsg", reflect.TypeOf((*MockFullNode)(nil).StateWaitMsg), arg0, arg1, arg2, arg3, arg4)
 }
 
+// StateWaitMsgBatch mocks base method.
+func (m *MockFullNode) StateWaitMsgBatch(arg0 context.Context, arg1 []cid.Cid, arg2 uint64, arg3 abi.ChainEpoch, arg4 bool) ([]*types0.MsgLookup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateWaitMsgBatch", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*types0.MsgLookup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateWaitMsgBatch indicates an expected call of StateWaitMsgBatch.
+func (mr *MockFullNodeMockRecorder) StateWaitMsgBatch(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateWaitMsgBatch", reflect.TypeOf((*MockFullNode)(nil).StateWaitMsgBatch), arg0, arg1, arg2, arg3, arg4)
+}
+
 // SyncState mocks base method.
 func (m *MockFullNode) SyncState(arg0 context.Context) (*types0.SyncState, error) {
 	m.ctrl.T.Helper()
@@ -3195,6 +4015,21 @@ func (mr *MockFullNodeMockRecorder) WalletBalance(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletBalance", reflect.TypeOf((*MockFullNode)(nil).WalletBalance), arg0, arg1)
 }
 
+// WalletBalanceBatch mocks base method.
+func (m *MockFullNode) WalletBalanceBatch(arg0 context.Context, arg1 []address.Address, arg2 types0.TipSetKey) ([]types0.AddressBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletBalanceBatch", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types0.AddressBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletBalanceBatch indicates an expected call of WalletBalanceBatch.
+func (mr *MockFullNodeMockRecorder) WalletBalanceBatch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletBalanceBatch", reflect.TypeOf((*MockFullNode)(nil).WalletBalanceBatch), arg0, arg1, arg2)
+}
+
 // WalletDefaultAddress mocks base method.
 func (m *MockFullNode) WalletDefaultAddress(arg0 context.Context) (address.Address, error) {
 	m.ctrl.T.Helper()
@@ -3342,6 +4177,21 @@ func (mr *MockFullNodeMockRecorder) WalletState(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletState", reflect.TypeOf((*MockFullNode)(nil).WalletState), arg0)
 }
 
+// WalletValidateAddress mocks base method.
+func (m *MockFullNode) WalletValidateAddress(arg0 context.Context, arg1 string) (address.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletValidateAddress", arg0, arg1)
+	ret0, _ := ret[0].(address.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletValidateAddress indicates an expected call of WalletValidateAddress.
+func (mr *MockFullNodeMockRecorder) WalletValidateAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletValidateAddress", reflect.TypeOf((*MockFullNode)(nil).WalletValidateAddress), arg0, arg1)
+}
+
 // Web3ClientVersion mocks base method.
 func (m *MockFullNode) Web3ClientVersion(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
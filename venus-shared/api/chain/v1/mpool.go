@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/ipfs/go-cid"
 
@@ -11,14 +12,17 @@ import (
 )
 
 type IMessagePool interface {
-	MpoolDeleteByAdress(ctx context.Context, addr address.Address) error                                                                                               //perm:admin
-	MpoolPublishByAddr(context.Context, address.Address) error                                                                                                         //perm:write
-	MpoolPublishMessage(ctx context.Context, smsg *types.SignedMessage) error                                                                                          //perm:write
-	MpoolPush(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                                         //perm:write
-	MpoolGetConfig(context.Context) (*types.MpoolConfig, error)                                                                                                        //perm:read
-	MpoolSetConfig(ctx context.Context, cfg *types.MpoolConfig) error                                                                                                  //perm:admin
-	MpoolSelect(context.Context, types.TipSetKey, float64) ([]*types.SignedMessage, error)                                                                             //perm:read
-	MpoolSelects(context.Context, types.TipSetKey, []float64) ([][]*types.SignedMessage, error)                                                                        //perm:read
+	MpoolDeleteByAdress(ctx context.Context, addr address.Address) error                        //perm:admin
+	MpoolPublishByAddr(context.Context, address.Address) error                                  //perm:write
+	MpoolPublishMessage(ctx context.Context, smsg *types.SignedMessage) error                   //perm:write
+	MpoolPush(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                  //perm:write
+	MpoolGetConfig(context.Context) (*types.MpoolConfig, error)                                 //perm:read
+	MpoolSetConfig(ctx context.Context, cfg *types.MpoolConfig) error                           //perm:admin
+	MpoolSelect(context.Context, types.TipSetKey, float64) ([]*types.SignedMessage, error)      //perm:read
+	MpoolSelects(context.Context, types.TipSetKey, []float64) ([][]*types.SignedMessage, error) //perm:read
+	// MpoolGetBestGasLimit returns the gas left in the next block after the messages the pool would select for it,
+	// it is cached until the head changes
+	MpoolGetBestGasLimit(ctx context.Context) (int64, error)                                                                                                           //perm:read
 	MpoolPending(ctx context.Context, tsk types.TipSetKey) ([]*types.SignedMessage, error)                                                                             //perm:read
 	MpoolClear(ctx context.Context, local bool) error                                                                                                                  //perm:write
 	MpoolPushUntrusted(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                                //perm:write
@@ -39,4 +43,25 @@ type IMessagePool interface {
 	MpoolCheckPendingMessages(ctx context.Context, addr address.Address) ([][]types.MessageCheckStatus, error) //perm:read
 	// MpoolCheckReplaceMessages performs logical checks on pending messages with replacement
 	MpoolCheckReplaceMessages(ctx context.Context, msg []*types.Message) ([][]types.MessageCheckStatus, error) //perm:read
+	// MpoolGetPendingNonce returns max(chain nonce, highest pending nonce + 1) for the given address
+	MpoolGetPendingNonce(ctx context.Context, addr address.Address) (uint64, error) //perm:read
+	// MpoolReplace replaces the pending message of from with the given nonce by a fee-bumped version
+	MpoolReplace(ctx context.Context, from address.Address, nonce uint64, spec *types.MessageSendSpec) (*types.SignedMessage, error) //perm:sign
+	// MpoolClearRange clears the pending messages of addr with nonce in [fromNonce, toNonce] and returns the number of removed messages
+	MpoolClearRange(ctx context.Context, addr address.Address, fromNonce, toNonce uint64, local bool) (int, error) //perm:admin
+	// GasEstimateGasLimitWithPriors estimates the gas limit of msgIn on top of tsk with priors applied first instead of
+	// the pending messages of the sender, e.g. to estimate a multisig approve before its propose is pushed
+	GasEstimateGasLimitWithPriors(ctx context.Context, msgIn *types.Message, priors []*types.Message, tsk types.TipSetKey) (int64, error) //perm:read
+	// GasEstimateGasLimitWithTrace estimates the gas limit of msgIn like GasEstimateGasLimit and breaks the gas used
+	// down by the calls made executing it
+	GasEstimateGasLimitWithTrace(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (*types.GasLimitBreakdown, error) //perm:read
+	// GasEstimateGasPremiumWithHistory is GasEstimateGasPremium looking back historyBlocks epochs for the premiums
+	// paid instead of nblocksincl*2, 0 keeps the default. The history is capped at 300 epochs.
+	GasEstimateGasPremiumWithHistory(ctx context.Context, nblocksincl uint64, historyBlocks uint64, sender address.Address, gaslimit int64, tsk types.TipSetKey) (big.Int, error) //perm:read
+	// MpoolPushWithKey assigns the nonce, estimates the unset gas fields, signs msg with the wallet key of key and
+	// pushes it, all under the nonce lock of the sender. msg.From defaults to key and otherwise has to resolve to it.
+	MpoolPushWithKey(ctx context.Context, msg *types.Message, key address.Address) (*types.SignedMessage, error) //perm:sign
+	// MpoolClearWithSafetyCheck clears pending messages like MpoolClear. When safetyCheckHeight is positive only the
+	// messages whose nonce was already used on chain at that height are cleared, the ones still needed are kept.
+	MpoolClearWithSafetyCheck(ctx context.Context, local bool, safetyCheckHeight abi.ChainEpoch) error //perm:write
 }
@@ -44,4 +44,32 @@ type INetwork interface {
 	NetProtectAdd(ctx context.Context, acl []peer.ID) error    //perm:admin
 	NetProtectRemove(ctx context.Context, acl []peer.ID) error //perm:admin
 	NetProtectList(ctx context.Context) ([]peer.ID, error)     //perm:read
+
+	NetBlockAdd(ctx context.Context, acl types.NetBlockList) error    //perm:admin
+	NetBlockRemove(ctx context.Context, acl types.NetBlockList) error //perm:admin
+	NetBlockList(ctx context.Context) (types.NetBlockList, error)     //perm:read
+
+	// NetPeerStats returns the bytes sent to and received from each connected peer since it connected,
+	// the counters restart when a peer reconnects. The peers exchanging the most bytes come first.
+	NetPeerStats(ctx context.Context) ([]types.PeerStats, error) //perm:read
+
+	// NetBootstrapHealth returns the health of the configured bootstrap peers, which are dialed periodically.
+	// Peers failing too many consecutive checks are not used to find peers until they respond again.
+	NetBootstrapHealth(ctx context.Context) ([]types.BootstrapStatus, error) //perm:read
+
+	// NetTagPeer tags the peer with value in the connection manager, peers with higher tag values are trimmed last.
+	// The value of the tags configured in swarm.highPriorityPeerTags is multiplied by their weight.
+	NetTagPeer(ctx context.Context, p peer.ID, tag string, value int) error //perm:admin
+	// NetUntagPeer removes the tag from the peer in the connection manager
+	NetUntagPeer(ctx context.Context, p peer.ID, tag string) error //perm:admin
+
+	// PubsubListTopics returns the pubsub topics the node is subscribed to
+	PubsubListTopics(ctx context.Context) ([]string, error) //perm:admin
+	// PubsubLeaveTopic cancels the subscriptions of the node to the topic, e.g. leaving the block topic stops
+	// receiving new blocks until the node restarts
+	PubsubLeaveTopic(ctx context.Context, topic string) error //perm:admin
+
+	// NetNATStatus returns the reachability and public addresses found by autonat and identify, and the type of
+	// the NAT device in front of the node
+	NetNATStatus(ctx context.Context) (*types.NATStatus, error) //perm:read
 }
@@ -36,6 +36,14 @@ type IPaychan interface {
 	// PaychAllocateLane Allocate late creates a lane within a payment channel so that calls to
 	// CreatePaymentVoucher will automatically make vouchers only for the difference in total
 	PaychAllocateLane(ctx context.Context, ch address.Address) (uint64, error) //perm:sign
+	// PaychAllocateLaneWithLabel allocates a lane like PaychAllocateLane and stores the label
+	// alongside the lane ID, so that apps using several lanes per channel can tell them apart
+	// @pch: payment channel address
+	// @label: label of the lane
+	PaychAllocateLaneWithLabel(ctx context.Context, ch address.Address, label string) (uint64, error) //perm:sign
+	// PaychGetLaneLabels get the labels of the lanes allocated with PaychAllocateLaneWithLabel, keyed by lane ID
+	// @pch: payment channel address
+	PaychGetLaneLabels(ctx context.Context, ch address.Address) (map[uint64]string, error) //perm:read
 	// PaychNewPayment aggregate vouchers into a new lane
 	// @from: the payment channel sender
 	// @to: the payment channel recipient
@@ -78,6 +86,13 @@ type IPaychan interface {
 	// If there are insufficient funds in the channel to create the voucher,
 	// returns a nil voucher and the shortfall.
 	PaychVoucherCreate(ctx context.Context, pch address.Address, amt big.Int, lane uint64) (*types.VoucherCreateResult, error) //perm:sign
+	// PaychVoucherCreateSigned creates a new voucher like PaychVoucherCreate and returns it signed
+	// by the From address of the channel.
+	// If there are insufficient funds in the channel, returns an error with the shortfall.
+	// @pch: payment channel address
+	// @amt: voucher amount
+	// @lane: voucher lane
+	PaychVoucherCreateSigned(ctx context.Context, pch address.Address, amt big.Int, lane uint64) (*types.SignedVoucher, error) //perm:sign
 	// PaychVoucherList list vouchers in payment channel
 	// @pch: payment channel address
 	PaychVoucherList(ctx context.Context, pch address.Address) ([]*types.SignedVoucher, error) //perm:write
@@ -11,6 +11,7 @@ import (
 	jsonrpc "github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/v8/util/smoothing"
 	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/dline"
 	"github.com/filecoin-project/go-state-types/network"
@@ -77,53 +78,73 @@ func (s *IActorStruct) StateGetActor(p0 context.Context, p1 address.Address, p2
 
 type IMinerStateStruct struct {
 	Internal struct {
-		StateAllMinerFaults                func(ctx context.Context, lookback abi.ChainEpoch, ts types.TipSetKey) ([]*types.Fault, error)                                                 `perm:"read"`
-		StateChangedActors                 func(context.Context, cid.Cid, cid.Cid) (map[string]types.Actor, error)                                                                        `perm:"read"`
-		StateCirculatingSupply             func(ctx context.Context, tsk types.TipSetKey) (abi.TokenAmount, error)                                                                        `perm:"read"`
-		StateComputeDataCID                func(ctx context.Context, maddr address.Address, sectorType abi.RegisteredSealProof, deals []abi.DealID, tsk types.TipSetKey) (cid.Cid, error) `perm:"read"`
-		StateDealProviderCollateralBounds  func(ctx context.Context, size abi.PaddedPieceSize, verified bool, tsk types.TipSetKey) (types.DealCollateralBounds, error)                    `perm:"read"`
-		StateDecodeParams                  func(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, tsk types.TipSetKey) (interface{}, error)               `perm:"read"`
-		StateEncodeParams                  func(ctx context.Context, toActCode cid.Cid, method abi.MethodNum, params json.RawMessage) ([]byte, error)                                     `perm:"read"`
-		StateGetAllocation                 func(ctx context.Context, clientAddr address.Address, allocationID types.AllocationId, tsk types.TipSetKey) (*types.Allocation, error)         `perm:"read"`
-		StateGetAllocationForPendingDeal   func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.Allocation, error)                                                   `perm:"read"`
-		StateGetAllocations                func(ctx context.Context, clientAddr address.Address, tsk types.TipSetKey) (map[types.AllocationId]types.Allocation, error)                    `perm:"read"`
-		StateGetClaim                      func(ctx context.Context, providerAddr address.Address, claimID types.ClaimId, tsk types.TipSetKey) (*types.Claim, error)                      `perm:"read"`
-		StateGetClaims                     func(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) (map[types.ClaimId]types.Claim, error)                            `perm:"read"`
-		StateListActors                    func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                      `perm:"read"`
-		StateListMessages                  func(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch) ([]cid.Cid, error)                              `perm:"read"`
-		StateListMiners                    func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                      `perm:"read"`
-		StateLookupID                      func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)                                                  `perm:"read"`
-		StateLookupRobustAddress           func(context.Context, address.Address, types.TipSetKey) (address.Address, error)                                                               `perm:"read"`
-		StateMarketBalance                 func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MarketBalance, error)                                              `perm:"read"`
-		StateMarketDeals                   func(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error)                                                           `perm:"read"`
-		StateMarketStorageDeal             func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.MarketDeal, error)                                                   `perm:"read"`
-		StateMinerActiveSectors            func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)                                      `perm:"read"`
-		StateMinerAllocated                func(context.Context, address.Address, types.TipSetKey) (*bitfield.BitField, error)                                                            `perm:"read"`
-		StateMinerAvailableBalance         func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error)                                                         `perm:"read"`
-		StateMinerDeadlines                func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.Deadline, error)                                                `perm:"read"`
-		StateMinerFaults                   func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                               `perm:"read"`
-		StateMinerInfo                     func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (types.MinerInfo, error)                                                 `perm:"read"`
-		StateMinerInitialPledgeCollateral  func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                          `perm:"read"`
-		StateMinerPartitions               func(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error)                                 `perm:"read"`
-		StateMinerPower                    func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*types.MinerPower, error)                                                `perm:"read"`
-		StateMinerPreCommitDepositForPower func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                          `perm:"read"`
-		StateMinerProvingDeadline          func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*dline.Info, error)                                                     `perm:"read"`
-		StateMinerRecoveries               func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                               `perm:"read"`
-		StateMinerSectorAllocated          func(ctx context.Context, maddr address.Address, s abi.SectorNumber, tsk types.TipSetKey) (bool, error)                                        `perm:"read"`
-		StateMinerSectorCount              func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MinerSectors, error)                                               `perm:"read"`
-		StateMinerSectorSize               func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (abi.SectorSize, error)                                                  `perm:"read"`
-		StateMinerSectors                  func(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)        `perm:"read"`
-		StateMinerWorkerAddress            func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (address.Address, error)                                                 `perm:"read"`
-		StateReadState                     func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.ActorState, error)                                               `perm:"read"`
-		StateSectorExpiration              func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorExpiration, error)         `perm:"read"`
-		StateSectorGetInfo                 func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*types.SectorOnChainInfo, error)                    `perm:"read"`
-		StateSectorPartition               func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorLocation, error)           `perm:"read"`
-		StateSectorPreCommitInfo           func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*types.SectorPreCommitOnChainInfo, error)           `perm:"read"`
-		StateVMCirculatingSupplyInternal   func(ctx context.Context, tsk types.TipSetKey) (types.CirculatingSupply, error)                                                                `perm:"read"`
-		StateVerifiedClientStatus          func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                                `perm:"read"`
+		MinerGetProvingDeadlineForEpoch     func(ctx context.Context, maddr address.Address, epoch abi.ChainEpoch, tsk types.TipSetKey) (*dline.Info, error)                                                 `perm:"read"`
+		MinerGetQualityAdjustedPower        func(ctx context.Context, minerAddr address.Address, tsk types.TipSetKey) (*types.MinerSectorPower, error)                                                       `perm:"read"`
+		StateAllMinerFaults                 func(ctx context.Context, lookback abi.ChainEpoch, ts types.TipSetKey) ([]*types.Fault, error)                                                                   `perm:"read"`
+		StateChangedActors                  func(context.Context, cid.Cid, cid.Cid) (map[string]types.Actor, error)                                                                                          `perm:"read"`
+		StateCirculatingSupply              func(ctx context.Context, tsk types.TipSetKey) (abi.TokenAmount, error)                                                                                          `perm:"read"`
+		StateComputeDataCID                 func(ctx context.Context, maddr address.Address, sectorType abi.RegisteredSealProof, deals []abi.DealID, tsk types.TipSetKey) (cid.Cid, error)                   `perm:"read"`
+		StateDealProviderCollateralBounds   func(ctx context.Context, size abi.PaddedPieceSize, verified bool, tsk types.TipSetKey) (types.DealCollateralBounds, error)                                      `perm:"read"`
+		StateDecodeParams                   func(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, tsk types.TipSetKey) (interface{}, error)                                 `perm:"read"`
+		StateDecodeReturnValue              func(ctx context.Context, msgCID cid.Cid, tsk types.TipSetKey) (interface{}, error)                                                                              `perm:"read"`
+		StateEncodeParams                   func(ctx context.Context, toActCode cid.Cid, method abi.MethodNum, params json.RawMessage) ([]byte, error)                                                       `perm:"read"`
+		StateGetAllocation                  func(ctx context.Context, clientAddr address.Address, allocationID types.AllocationId, tsk types.TipSetKey) (*types.Allocation, error)                           `perm:"read"`
+		StateGetAllocationForPendingDeal    func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.Allocation, error)                                                                     `perm:"read"`
+		StateGetAllocations                 func(ctx context.Context, clientAddr address.Address, tsk types.TipSetKey) (map[types.AllocationId]types.Allocation, error)                                      `perm:"read"`
+		StateGetAllocationsForSector        func(ctx context.Context, maddr address.Address, sectorNum abi.SectorNumber, tsk types.TipSetKey) ([]types.AllocationId, error)                                  `perm:"read"`
+		StateGetClaim                       func(ctx context.Context, providerAddr address.Address, claimID types.ClaimId, tsk types.TipSetKey) (*types.Claim, error)                                        `perm:"read"`
+		StateGetClaims                      func(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) (map[types.ClaimId]types.Claim, error)                                              `perm:"read"`
+		StateGetPowerSmoothingEstimate      func(ctx context.Context, tsk types.TipSetKey) (*smoothing.FilterEstimate, error)                                                                                `perm:"read"`
+		StateGetRewardSmoothingEstimate     func(ctx context.Context, tsk types.TipSetKey) (*smoothing.FilterEstimate, error)                                                                                `perm:"read"`
+		StateGetSectorExpirationBatch       func(ctx context.Context, maddr address.Address, sectorNums []abi.SectorNumber, tsk types.TipSetKey) (map[abi.SectorNumber]*lminer.SectorExpiration, error)      `perm:"read"`
+		StateListActors                     func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                                        `perm:"read"`
+		StateListDatacapClaims              func(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) ([]types.DatacapClaim, error)                                                       `perm:"read"`
+		StateListMessages                   func(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch) ([]cid.Cid, error)                                                `perm:"read"`
+		StateListMiners                     func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                                        `perm:"read"`
+		StateListMinersPaginated            func(ctx context.Context, tsk types.TipSetKey, pageToken *types.MinerPageToken, pageSize int) (*types.MinerPage, error)                                          `perm:"read"`
+		StateListVerifiedDatacapAllocations func(ctx context.Context, clientAddr address.Address, tsk types.TipSetKey, pageToken *types.AllocationPageToken, pageSize int) (*types.AllocationPage, error)    `perm:"read"`
+		StateLookupID                       func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)                                                                    `perm:"read"`
+		StateLookupRobustAddress            func(context.Context, address.Address, types.TipSetKey) (address.Address, error)                                                                                 `perm:"read"`
+		StateMarketBalance                  func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MarketBalance, error)                                                                `perm:"read"`
+		StateMarketDeals                    func(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error)                                                                             `perm:"read"`
+		StateMarketStorageDeal              func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.MarketDeal, error)                                                                     `perm:"read"`
+		StateMinerActiveSectors             func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)                                                        `perm:"read"`
+		StateMinerAllocated                 func(context.Context, address.Address, types.TipSetKey) (*bitfield.BitField, error)                                                                              `perm:"read"`
+		StateMinerAvailableBalance          func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error)                                                                           `perm:"read"`
+		StateMinerDeadlines                 func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.Deadline, error)                                                                  `perm:"read"`
+		StateMinerFaults                    func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                                                 `perm:"read"`
+		StateMinerInfo                      func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (types.MinerInfo, error)                                                                   `perm:"read"`
+		StateMinerInitialPledge             func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (*types.InitialPledge, error)                               `perm:"read"`
+		StateMinerInitialPledgeCollateral   func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                                            `perm:"read"`
+		StateMinerPartitions                func(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error)                                                   `perm:"read"`
+		StateMinerPower                     func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*types.MinerPower, error)                                                                  `perm:"read"`
+		StateMinerPreCommitDeposit          func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                                            `perm:"read"`
+		StateMinerPreCommitDepositForPower  func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                                            `perm:"read"`
+		StateMinerProvingDeadline           func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*dline.Info, error)                                                                       `perm:"read"`
+		StateMinerRecoveries                func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                                                 `perm:"read"`
+		StateMinerSectorAllocated           func(ctx context.Context, maddr address.Address, s abi.SectorNumber, tsk types.TipSetKey) (bool, error)                                                          `perm:"read"`
+		StateMinerSectorCount               func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MinerSectors, error)                                                                 `perm:"read"`
+		StateMinerSectorSize                func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (abi.SectorSize, error)                                                                    `perm:"read"`
+		StateMinerSectors                   func(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)                          `perm:"read"`
+		StateMinerVerifyWindowPoSt          func(ctx context.Context, maddr address.Address, deadline uint64, partitions []types.PoStPartition, proofs []types.PoStProof, tsk types.TipSetKey) (bool, error) `perm:"read"`
+		StateMinerWithdrawableAmount        func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error)                                                                           `perm:"read"`
+		StateMinerWorkerAddress             func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (address.Address, error)                                                                   `perm:"read"`
+		StateReadState                      func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.ActorState, error)                                                                 `perm:"read"`
+		StateSectorExpiration               func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorExpiration, error)                           `perm:"read"`
+		StateSectorGetInfo                  func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*types.SectorOnChainInfo, error)                                      `perm:"read"`
+		StateSectorPartition                func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorLocation, error)                             `perm:"read"`
+		StateSectorPreCommitInfo            func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*types.SectorPreCommitOnChainInfo, error)                             `perm:"read"`
+		StateVMCirculatingSupplyInternal    func(ctx context.Context, tsk types.TipSetKey) (types.CirculatingSupply, error)                                                                                  `perm:"read"`
+		StateVerifiedClientStatus           func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                                                  `perm:"read"`
 	}
 }
 
+func (s *IMinerStateStruct) MinerGetProvingDeadlineForEpoch(p0 context.Context, p1 address.Address, p2 abi.ChainEpoch, p3 types.TipSetKey) (*dline.Info, error) {
+	return s.Internal.MinerGetProvingDeadlineForEpoch(p0, p1, p2, p3)
+}
+func (s *IMinerStateStruct) MinerGetQualityAdjustedPower(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*types.MinerSectorPower, error) {
+	return s.Internal.MinerGetQualityAdjustedPower(p0, p1, p2)
+}
 func (s *IMinerStateStruct) StateAllMinerFaults(p0 context.Context, p1 abi.ChainEpoch, p2 types.TipSetKey) ([]*types.Fault, error) {
 	return s.Internal.StateAllMinerFaults(p0, p1, p2)
 }
@@ -142,6 +163,9 @@ func (s *IMinerStateStruct) StateDealProviderCollateralBounds(p0 context.Context
 func (s *IMinerStateStruct) StateDecodeParams(p0 context.Context, p1 address.Address, p2 abi.MethodNum, p3 []byte, p4 types.TipSetKey) (interface{}, error) {
 	return s.Internal.StateDecodeParams(p0, p1, p2, p3, p4)
 }
+func (s *IMinerStateStruct) StateDecodeReturnValue(p0 context.Context, p1 cid.Cid, p2 types.TipSetKey) (interface{}, error) {
+	return s.Internal.StateDecodeReturnValue(p0, p1, p2)
+}
 func (s *IMinerStateStruct) StateEncodeParams(p0 context.Context, p1 cid.Cid, p2 abi.MethodNum, p3 json.RawMessage) ([]byte, error) {
 	return s.Internal.StateEncodeParams(p0, p1, p2, p3)
 }
@@ -154,21 +178,42 @@ func (s *IMinerStateStruct) StateGetAllocationForPendingDeal(p0 context.Context,
 func (s *IMinerStateStruct) StateGetAllocations(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (map[types.AllocationId]types.Allocation, error) {
 	return s.Internal.StateGetAllocations(p0, p1, p2)
 }
+func (s *IMinerStateStruct) StateGetAllocationsForSector(p0 context.Context, p1 address.Address, p2 abi.SectorNumber, p3 types.TipSetKey) ([]types.AllocationId, error) {
+	return s.Internal.StateGetAllocationsForSector(p0, p1, p2, p3)
+}
 func (s *IMinerStateStruct) StateGetClaim(p0 context.Context, p1 address.Address, p2 types.ClaimId, p3 types.TipSetKey) (*types.Claim, error) {
 	return s.Internal.StateGetClaim(p0, p1, p2, p3)
 }
 func (s *IMinerStateStruct) StateGetClaims(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (map[types.ClaimId]types.Claim, error) {
 	return s.Internal.StateGetClaims(p0, p1, p2)
 }
+func (s *IMinerStateStruct) StateGetPowerSmoothingEstimate(p0 context.Context, p1 types.TipSetKey) (*smoothing.FilterEstimate, error) {
+	return s.Internal.StateGetPowerSmoothingEstimate(p0, p1)
+}
+func (s *IMinerStateStruct) StateGetRewardSmoothingEstimate(p0 context.Context, p1 types.TipSetKey) (*smoothing.FilterEstimate, error) {
+	return s.Internal.StateGetRewardSmoothingEstimate(p0, p1)
+}
+func (s *IMinerStateStruct) StateGetSectorExpirationBatch(p0 context.Context, p1 address.Address, p2 []abi.SectorNumber, p3 types.TipSetKey) (map[abi.SectorNumber]*lminer.SectorExpiration, error) {
+	return s.Internal.StateGetSectorExpirationBatch(p0, p1, p2, p3)
+}
 func (s *IMinerStateStruct) StateListActors(p0 context.Context, p1 types.TipSetKey) ([]address.Address, error) {
 	return s.Internal.StateListActors(p0, p1)
 }
+func (s *IMinerStateStruct) StateListDatacapClaims(p0 context.Context, p1 address.Address, p2 types.TipSetKey) ([]types.DatacapClaim, error) {
+	return s.Internal.StateListDatacapClaims(p0, p1, p2)
+}
 func (s *IMinerStateStruct) StateListMessages(p0 context.Context, p1 *types.MessageMatch, p2 types.TipSetKey, p3 abi.ChainEpoch) ([]cid.Cid, error) {
 	return s.Internal.StateListMessages(p0, p1, p2, p3)
 }
 func (s *IMinerStateStruct) StateListMiners(p0 context.Context, p1 types.TipSetKey) ([]address.Address, error) {
 	return s.Internal.StateListMiners(p0, p1)
 }
+func (s *IMinerStateStruct) StateListMinersPaginated(p0 context.Context, p1 types.TipSetKey, p2 *types.MinerPageToken, p3 int) (*types.MinerPage, error) {
+	return s.Internal.StateListMinersPaginated(p0, p1, p2, p3)
+}
+func (s *IMinerStateStruct) StateListVerifiedDatacapAllocations(p0 context.Context, p1 address.Address, p2 types.TipSetKey, p3 *types.AllocationPageToken, p4 int) (*types.AllocationPage, error) {
+	return s.Internal.StateListVerifiedDatacapAllocations(p0, p1, p2, p3, p4)
+}
 func (s *IMinerStateStruct) StateLookupID(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (address.Address, error) {
 	return s.Internal.StateLookupID(p0, p1, p2)
 }
@@ -202,6 +247,9 @@ func (s *IMinerStateStruct) StateMinerFaults(p0 context.Context, p1 address.Addr
 func (s *IMinerStateStruct) StateMinerInfo(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (types.MinerInfo, error) {
 	return s.Internal.StateMinerInfo(p0, p1, p2)
 }
+func (s *IMinerStateStruct) StateMinerInitialPledge(p0 context.Context, p1 address.Address, p2 types.SectorPreCommitInfo, p3 types.TipSetKey) (*types.InitialPledge, error) {
+	return s.Internal.StateMinerInitialPledge(p0, p1, p2, p3)
+}
 func (s *IMinerStateStruct) StateMinerInitialPledgeCollateral(p0 context.Context, p1 address.Address, p2 types.SectorPreCommitInfo, p3 types.TipSetKey) (big.Int, error) {
 	return s.Internal.StateMinerInitialPledgeCollateral(p0, p1, p2, p3)
 }
@@ -211,6 +259,9 @@ func (s *IMinerStateStruct) StateMinerPartitions(p0 context.Context, p1 address.
 func (s *IMinerStateStruct) StateMinerPower(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*types.MinerPower, error) {
 	return s.Internal.StateMinerPower(p0, p1, p2)
 }
+func (s *IMinerStateStruct) StateMinerPreCommitDeposit(p0 context.Context, p1 address.Address, p2 types.SectorPreCommitInfo, p3 types.TipSetKey) (big.Int, error) {
+	return s.Internal.StateMinerPreCommitDeposit(p0, p1, p2, p3)
+}
 func (s *IMinerStateStruct) StateMinerPreCommitDepositForPower(p0 context.Context, p1 address.Address, p2 types.SectorPreCommitInfo, p3 types.TipSetKey) (big.Int, error) {
 	return s.Internal.StateMinerPreCommitDepositForPower(p0, p1, p2, p3)
 }
@@ -232,6 +283,12 @@ func (s *IMinerStateStruct) StateMinerSectorSize(p0 context.Context, p1 address.
 func (s *IMinerStateStruct) StateMinerSectors(p0 context.Context, p1 address.Address, p2 *bitfield.BitField, p3 types.TipSetKey) ([]*types.SectorOnChainInfo, error) {
 	return s.Internal.StateMinerSectors(p0, p1, p2, p3)
 }
+func (s *IMinerStateStruct) StateMinerVerifyWindowPoSt(p0 context.Context, p1 address.Address, p2 uint64, p3 []types.PoStPartition, p4 []types.PoStProof, p5 types.TipSetKey) (bool, error) {
+	return s.Internal.StateMinerVerifyWindowPoSt(p0, p1, p2, p3, p4, p5)
+}
+func (s *IMinerStateStruct) StateMinerWithdrawableAmount(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (big.Int, error) {
+	return s.Internal.StateMinerWithdrawableAmount(p0, p1, p2)
+}
 func (s *IMinerStateStruct) StateMinerWorkerAddress(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (address.Address, error) {
 	return s.Internal.StateMinerWorkerAddress(p0, p1, p2)
 }
@@ -259,53 +316,63 @@ func (s *IMinerStateStruct) StateVerifiedClientStatus(p0 context.Context, p1 add
 
 type IChainInfoStruct struct {
 	Internal struct {
-		BlockTime                     func(ctx context.Context) time.Duration                                                                                                                      `perm:"read"`
-		ChainExport                   func(context.Context, abi.ChainEpoch, bool, types.TipSetKey) (<-chan []byte, error)                                                                          `perm:"read"`
-		ChainGetBlock                 func(ctx context.Context, id cid.Cid) (*types.BlockHeader, error)                                                                                            `perm:"read"`
-		ChainGetBlockMessages         func(ctx context.Context, bid cid.Cid) (*types.BlockMessages, error)                                                                                         `perm:"read"`
-		ChainGetEvents                func(context.Context, cid.Cid) ([]types.Event, error)                                                                                                        `perm:"read"`
-		ChainGetGenesis               func(context.Context) (*types.TipSet, error)                                                                                                                 `perm:"read"`
-		ChainGetMessage               func(ctx context.Context, msgID cid.Cid) (*types.Message, error)                                                                                             `perm:"read"`
-		ChainGetMessagesInTipset      func(ctx context.Context, key types.TipSetKey) ([]types.MessageCID, error)                                                                                   `perm:"read"`
-		ChainGetParentMessages        func(ctx context.Context, bcid cid.Cid) ([]types.MessageCID, error)                                                                                          `perm:"read"`
-		ChainGetParentReceipts        func(ctx context.Context, bcid cid.Cid) ([]*types.MessageReceipt, error)                                                                                     `perm:"read"`
-		ChainGetPath                  func(ctx context.Context, from types.TipSetKey, to types.TipSetKey) ([]*types.HeadChange, error)                                                             `perm:"read"`
-		ChainGetReceipts              func(ctx context.Context, id cid.Cid) ([]types.MessageReceipt, error)                                                                                        `perm:"read"`
-		ChainGetTipSet                func(ctx context.Context, key types.TipSetKey) (*types.TipSet, error)                                                                                        `perm:"read"`
-		ChainGetTipSetAfterHeight     func(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                 `perm:"read"`
-		ChainGetTipSetByHeight        func(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                 `perm:"read"`
-		ChainHead                     func(ctx context.Context) (*types.TipSet, error)                                                                                                             `perm:"read"`
-		ChainList                     func(ctx context.Context, tsKey types.TipSetKey, count int) ([]types.TipSetKey, error)                                                                       `perm:"read"`
-		ChainNotify                   func(ctx context.Context) (<-chan []*types.HeadChange, error)                                                                                                `perm:"read"`
-		ChainSetHead                  func(ctx context.Context, key types.TipSetKey) error                                                                                                         `perm:"admin"`
-		GetActor                      func(ctx context.Context, addr address.Address) (*types.Actor, error)                                                                                        `perm:"read"`
-		GetEntry                      func(ctx context.Context, height abi.ChainEpoch, round uint64) (*types.BeaconEntry, error)                                                                   `perm:"read"`
-		GetFullBlock                  func(ctx context.Context, id cid.Cid) (*types.FullBlock, error)                                                                                              `perm:"read"`
-		GetParentStateRootActor       func(ctx context.Context, ts *types.TipSet, addr address.Address) (*types.Actor, error)                                                                      `perm:"read"`
-		ProtocolParameters            func(ctx context.Context) (*types.ProtocolParams, error)                                                                                                     `perm:"read"`
-		ResolveToKeyAddr              func(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error)                                                                   `perm:"read"`
-		StateActorCodeCIDs            func(context.Context, network.Version) (map[string]cid.Cid, error)                                                                                           `perm:"read"`
-		StateActorManifestCID         func(context.Context, network.Version) (cid.Cid, error)                                                                                                      `perm:"read"`
-		StateCall                     func(ctx context.Context, msg *types.Message, tsk types.TipSetKey) (*types.InvocResult, error)                                                               `perm:"read"`
-		StateCompute                  func(context.Context, abi.ChainEpoch, []*types.Message, types.TipSetKey) (*types.ComputeStateOutput, error)                                                  `perm:"read"`
-		StateGetBeaconEntry           func(ctx context.Context, epoch abi.ChainEpoch) (*types.BeaconEntry, error)                                                                                  `perm:"read"`
-		StateGetNetworkParams         func(ctx context.Context) (*types.NetworkParams, error)                                                                                                      `perm:"read"`
-		StateGetRandomnessFromBeacon  func(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) `perm:"read"`
-		StateGetRandomnessFromTickets func(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) `perm:"read"`
-		StateNetworkName              func(ctx context.Context) (types.NetworkName, error)                                                                                                         `perm:"read"`
-		StateNetworkVersion           func(ctx context.Context, tsk types.TipSetKey) (network.Version, error)                                                                                      `perm:"read"`
-		StateReplay                   func(context.Context, types.TipSetKey, cid.Cid) (*types.InvocResult, error)                                                                                  `perm:"read"`
-		StateSearchMsg                func(ctx context.Context, from types.TipSetKey, msg cid.Cid, limit abi.ChainEpoch, allowReplaced bool) (*types.MsgLookup, error)                             `perm:"read"`
-		StateVerifiedRegistryRootKey  func(ctx context.Context, tsk types.TipSetKey) (address.Address, error)                                                                                      `perm:"read"`
-		StateVerifierStatus           func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                                              `perm:"read"`
-		StateWaitMsg                  func(ctx context.Context, cid cid.Cid, confidence uint64, limit abi.ChainEpoch, allowReplaced bool) (*types.MsgLookup, error)                                `perm:"read"`
-		VerifyEntry                   func(parent, child *types.BeaconEntry, height abi.ChainEpoch) bool                                                                                           `perm:"read"`
+		BlockTime                       func(ctx context.Context) time.Duration                                                                                                                      `perm:"read"`
+		ChainCheckConsistency           func(ctx context.Context, from, to abi.ChainEpoch) (*types.ConsistencyReport, error)                                                                         `perm:"read"`
+		ChainExport                     func(context.Context, abi.ChainEpoch, bool, types.TipSetKey) (<-chan []byte, error)                                                                          `perm:"read"`
+		ChainGetBlock                   func(ctx context.Context, id cid.Cid) (*types.BlockHeader, error)                                                                                            `perm:"read"`
+		ChainGetBlockMessages           func(ctx context.Context, bid cid.Cid) (*types.BlockMessages, error)                                                                                         `perm:"read"`
+		ChainGetBlockMessagesDecoded    func(ctx context.Context, bid cid.Cid) (*types.BlockMessagesDecoded, error)                                                                                  `perm:"read"`
+		ChainGetEvents                  func(context.Context, cid.Cid) ([]types.Event, error)                                                                                                        `perm:"read"`
+		ChainGetGenesis                 func(context.Context) (*types.TipSet, error)                                                                                                                 `perm:"read"`
+		ChainGetLatestBeaconEntry       func(ctx context.Context) (*types.BeaconEntry, error)                                                                                                        `perm:"read"`
+		ChainGetMessage                 func(ctx context.Context, msgID cid.Cid) (*types.Message, error)                                                                                             `perm:"read"`
+		ChainGetMessagesInTipset        func(ctx context.Context, key types.TipSetKey) ([]types.MessageCID, error)                                                                                   `perm:"read"`
+		ChainGetParentMessages          func(ctx context.Context, bcid cid.Cid) ([]types.MessageCID, error)                                                                                          `perm:"read"`
+		ChainGetParentReceipts          func(ctx context.Context, bcid cid.Cid) ([]*types.MessageReceipt, error)                                                                                     `perm:"read"`
+		ChainGetPath                    func(ctx context.Context, from types.TipSetKey, to types.TipSetKey) ([]*types.HeadChange, error)                                                             `perm:"read"`
+		ChainGetRandomnessDigest        func(ctx context.Context, domainTag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) ([]byte, error)                   `perm:"read"`
+		ChainGetReceipts                func(ctx context.Context, id cid.Cid) ([]types.MessageReceipt, error)                                                                                        `perm:"read"`
+		ChainGetTipSet                  func(ctx context.Context, key types.TipSetKey) (*types.TipSet, error)                                                                                        `perm:"read"`
+		ChainGetTipSetAfterHeight       func(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                 `perm:"read"`
+		ChainGetTipSetByHeight          func(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                 `perm:"read"`
+		ChainGetUpgradeSchedule         func(ctx context.Context) ([]types.UpgradeInfo, error)                                                                                                       `perm:"read"`
+		ChainHead                       func(ctx context.Context) (*types.TipSet, error)                                                                                                             `perm:"read"`
+		ChainList                       func(ctx context.Context, tsKey types.TipSetKey, count int) ([]types.TipSetKey, error)                                                                       `perm:"read"`
+		ChainNotify                     func(ctx context.Context) (<-chan []*types.HeadChange, error)                                                                                                `perm:"read"`
+		ChainSetHead                    func(ctx context.Context, key types.TipSetKey) error                                                                                                         `perm:"admin"`
+		GetActor                        func(ctx context.Context, addr address.Address) (*types.Actor, error)                                                                                        `perm:"read"`
+		GetEntry                        func(ctx context.Context, height abi.ChainEpoch, round uint64) (*types.BeaconEntry, error)                                                                   `perm:"read"`
+		GetFullBlock                    func(ctx context.Context, id cid.Cid) (*types.FullBlock, error)                                                                                              `perm:"read"`
+		GetParentStateRootActor         func(ctx context.Context, ts *types.TipSet, addr address.Address) (*types.Actor, error)                                                                      `perm:"read"`
+		ProtocolParameters              func(ctx context.Context) (*types.ProtocolParams, error)                                                                                                     `perm:"read"`
+		ResolveToKeyAddr                func(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error)                                                                   `perm:"read"`
+		StateActorCodeCIDs              func(context.Context, network.Version) (map[string]cid.Cid, error)                                                                                           `perm:"read"`
+		StateActorManifestCID           func(context.Context, network.Version) (cid.Cid, error)                                                                                                      `perm:"read"`
+		StateCall                       func(ctx context.Context, msg *types.Message, tsk types.TipSetKey) (*types.InvocResult, error)                                                               `perm:"read"`
+		StateCompute                    func(context.Context, abi.ChainEpoch, []*types.Message, types.TipSetKey) (*types.ComputeStateOutput, error)                                                  `perm:"read"`
+		StateGetBeaconEntry             func(ctx context.Context, epoch abi.ChainEpoch) (*types.BeaconEntry, error)                                                                                  `perm:"read"`
+		StateGetMessageExecutionContext func(ctx context.Context, msgCID cid.Cid, tsk types.TipSetKey) (*types.MessageExecutionContext, error)                                                       `perm:"read"`
+		StateGetNetworkParams           func(ctx context.Context) (*types.NetworkParams, error)                                                                                                      `perm:"read"`
+		StateGetRandomnessFromBeacon    func(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) `perm:"read"`
+		StateGetRandomnessFromTickets   func(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) `perm:"read"`
+		StateNetworkName                func(ctx context.Context) (types.NetworkName, error)                                                                                                         `perm:"read"`
+		StateNetworkVersion             func(ctx context.Context, tsk types.TipSetKey) (network.Version, error)                                                                                      `perm:"read"`
+		StateReplay                     func(context.Context, types.TipSetKey, cid.Cid) (*types.InvocResult, error)                                                                                  `perm:"read"`
+		StateSearchMsg                  func(ctx context.Context, from types.TipSetKey, msg cid.Cid, limit abi.ChainEpoch, allowReplaced bool) (*types.MsgLookup, error)                             `perm:"read"`
+		StateVerifiedRegistryRootKey    func(ctx context.Context, tsk types.TipSetKey) (address.Address, error)                                                                                      `perm:"read"`
+		StateVerifierStatus             func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                                              `perm:"read"`
+		StateWaitMsg                    func(ctx context.Context, cid cid.Cid, confidence uint64, limit abi.ChainEpoch, allowReplaced bool) (*types.MsgLookup, error)                                `perm:"read"`
+		StateWaitMsgBatch               func(ctx context.Context, cids []cid.Cid, confidence uint64, lookbackLimit abi.ChainEpoch, allowReplaced bool) ([]*types.MsgLookup, error)                   `perm:"read"`
+		VerifyEntry                     func(parent, child *types.BeaconEntry, height abi.ChainEpoch) bool                                                                                           `perm:"read"`
 	}
 }
 
 func (s *IChainInfoStruct) BlockTime(p0 context.Context) time.Duration {
 	return s.Internal.BlockTime(p0)
 }
+func (s *IChainInfoStruct) ChainCheckConsistency(p0 context.Context, p1, p2 abi.ChainEpoch) (*types.ConsistencyReport, error) {
+	return s.Internal.ChainCheckConsistency(p0, p1, p2)
+}
 func (s *IChainInfoStruct) ChainExport(p0 context.Context, p1 abi.ChainEpoch, p2 bool, p3 types.TipSetKey) (<-chan []byte, error) {
 	return s.Internal.ChainExport(p0, p1, p2, p3)
 }
@@ -315,12 +382,18 @@ func (s *IChainInfoStruct) ChainGetBlock(p0 context.Context, p1 cid.Cid) (*types
 func (s *IChainInfoStruct) ChainGetBlockMessages(p0 context.Context, p1 cid.Cid) (*types.BlockMessages, error) {
 	return s.Internal.ChainGetBlockMessages(p0, p1)
 }
+func (s *IChainInfoStruct) ChainGetBlockMessagesDecoded(p0 context.Context, p1 cid.Cid) (*types.BlockMessagesDecoded, error) {
+	return s.Internal.ChainGetBlockMessagesDecoded(p0, p1)
+}
 func (s *IChainInfoStruct) ChainGetEvents(p0 context.Context, p1 cid.Cid) ([]types.Event, error) {
 	return s.Internal.ChainGetEvents(p0, p1)
 }
 func (s *IChainInfoStruct) ChainGetGenesis(p0 context.Context) (*types.TipSet, error) {
 	return s.Internal.ChainGetGenesis(p0)
 }
+func (s *IChainInfoStruct) ChainGetLatestBeaconEntry(p0 context.Context) (*types.BeaconEntry, error) {
+	return s.Internal.ChainGetLatestBeaconEntry(p0)
+}
 func (s *IChainInfoStruct) ChainGetMessage(p0 context.Context, p1 cid.Cid) (*types.Message, error) {
 	return s.Internal.ChainGetMessage(p0, p1)
 }
@@ -336,6 +409,9 @@ func (s *IChainInfoStruct) ChainGetParentReceipts(p0 context.Context, p1 cid.Cid
 func (s *IChainInfoStruct) ChainGetPath(p0 context.Context, p1 types.TipSetKey, p2 types.TipSetKey) ([]*types.HeadChange, error) {
 	return s.Internal.ChainGetPath(p0, p1, p2)
 }
+func (s *IChainInfoStruct) ChainGetRandomnessDigest(p0 context.Context, p1 crypto.DomainSeparationTag, p2 abi.ChainEpoch, p3 []byte, p4 types.TipSetKey) ([]byte, error) {
+	return s.Internal.ChainGetRandomnessDigest(p0, p1, p2, p3, p4)
+}
 func (s *IChainInfoStruct) ChainGetReceipts(p0 context.Context, p1 cid.Cid) ([]types.MessageReceipt, error) {
 	return s.Internal.ChainGetReceipts(p0, p1)
 }
@@ -348,6 +424,9 @@ func (s *IChainInfoStruct) ChainGetTipSetAfterHeight(p0 context.Context, p1 abi.
 func (s *IChainInfoStruct) ChainGetTipSetByHeight(p0 context.Context, p1 abi.ChainEpoch, p2 types.TipSetKey) (*types.TipSet, error) {
 	return s.Internal.ChainGetTipSetByHeight(p0, p1, p2)
 }
+func (s *IChainInfoStruct) ChainGetUpgradeSchedule(p0 context.Context) ([]types.UpgradeInfo, error) {
+	return s.Internal.ChainGetUpgradeSchedule(p0)
+}
 func (s *IChainInfoStruct) ChainHead(p0 context.Context) (*types.TipSet, error) {
 	return s.Internal.ChainHead(p0)
 }
@@ -393,6 +472,9 @@ func (s *IChainInfoStruct) StateCompute(p0 context.Context, p1 abi.ChainEpoch, p
 func (s *IChainInfoStruct) StateGetBeaconEntry(p0 context.Context, p1 abi.ChainEpoch) (*types.BeaconEntry, error) {
 	return s.Internal.StateGetBeaconEntry(p0, p1)
 }
+func (s *IChainInfoStruct) StateGetMessageExecutionContext(p0 context.Context, p1 cid.Cid, p2 types.TipSetKey) (*types.MessageExecutionContext, error) {
+	return s.Internal.StateGetMessageExecutionContext(p0, p1, p2)
+}
 func (s *IChainInfoStruct) StateGetNetworkParams(p0 context.Context) (*types.NetworkParams, error) {
 	return s.Internal.StateGetNetworkParams(p0)
 }
@@ -423,6 +505,9 @@ func (s *IChainInfoStruct) StateVerifierStatus(p0 context.Context, p1 address.Ad
 func (s *IChainInfoStruct) StateWaitMsg(p0 context.Context, p1 cid.Cid, p2 uint64, p3 abi.ChainEpoch, p4 bool) (*types.MsgLookup, error) {
 	return s.Internal.StateWaitMsg(p0, p1, p2, p3, p4)
 }
+func (s *IChainInfoStruct) StateWaitMsgBatch(p0 context.Context, p1 []cid.Cid, p2 uint64, p3 abi.ChainEpoch, p4 bool) ([]*types.MsgLookup, error) {
+	return s.Internal.StateWaitMsgBatch(p0, p1, p2, p3, p4)
+}
 func (s *IChainInfoStruct) VerifyEntry(p0, p1 *types.BeaconEntry, p2 abi.ChainEpoch) bool {
 	return s.Internal.VerifyEntry(p0, p1, p2)
 }
@@ -460,31 +545,40 @@ func (s *IMiningStruct) MinerGetBaseInfo(p0 context.Context, p1 address.Address,
 
 type IMessagePoolStruct struct {
 	Internal struct {
-		GasBatchEstimateMessageGas func(ctx context.Context, estimateMessages []*types.EstimateMessage, fromNonce uint64, tsk types.TipSetKey) ([]*types.EstimateResult, error) `perm:"read"`
-		GasEstimateFeeCap          func(ctx context.Context, msg *types.Message, maxqueueblks int64, tsk types.TipSetKey) (big.Int, error)                                      `perm:"read"`
-		GasEstimateGasLimit        func(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (int64, error)                                                          `perm:"read"`
-		GasEstimateGasPremium      func(ctx context.Context, nblocksincl uint64, sender address.Address, gaslimit int64, tsk types.TipSetKey) (big.Int, error)                  `perm:"read"`
-		GasEstimateMessageGas      func(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec, tsk types.TipSetKey) (*types.Message, error)                      `perm:"read"`
-		MpoolBatchPush             func(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                   `perm:"write"`
-		MpoolBatchPushMessage      func(ctx context.Context, msgs []*types.Message, spec *types.MessageSendSpec) ([]*types.SignedMessage, error)                                `perm:"sign"`
-		MpoolBatchPushUntrusted    func(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                   `perm:"write"`
-		MpoolCheckMessages         func(ctx context.Context, protos []*types.MessagePrototype) ([][]types.MessageCheckStatus, error)                                            `perm:"read"`
-		MpoolCheckPendingMessages  func(ctx context.Context, addr address.Address) ([][]types.MessageCheckStatus, error)                                                        `perm:"read"`
-		MpoolCheckReplaceMessages  func(ctx context.Context, msg []*types.Message) ([][]types.MessageCheckStatus, error)                                                        `perm:"read"`
-		MpoolClear                 func(ctx context.Context, local bool) error                                                                                                  `perm:"write"`
-		MpoolDeleteByAdress        func(ctx context.Context, addr address.Address) error                                                                                        `perm:"admin"`
-		MpoolGetConfig             func(context.Context) (*types.MpoolConfig, error)                                                                                            `perm:"read"`
-		MpoolGetNonce              func(ctx context.Context, addr address.Address) (uint64, error)                                                                              `perm:"read"`
-		MpoolPending               func(ctx context.Context, tsk types.TipSetKey) ([]*types.SignedMessage, error)                                                               `perm:"read"`
-		MpoolPublishByAddr         func(context.Context, address.Address) error                                                                                                 `perm:"write"`
-		MpoolPublishMessage        func(ctx context.Context, smsg *types.SignedMessage) error                                                                                   `perm:"write"`
-		MpoolPush                  func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                        `perm:"write"`
-		MpoolPushMessage           func(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error)                                     `perm:"sign"`
-		MpoolPushUntrusted         func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                        `perm:"write"`
-		MpoolSelect                func(context.Context, types.TipSetKey, float64) ([]*types.SignedMessage, error)                                                              `perm:"read"`
-		MpoolSelects               func(context.Context, types.TipSetKey, []float64) ([][]*types.SignedMessage, error)                                                          `perm:"read"`
-		MpoolSetConfig             func(ctx context.Context, cfg *types.MpoolConfig) error                                                                                      `perm:"admin"`
-		MpoolSub                   func(ctx context.Context) (<-chan types.MpoolUpdate, error)                                                                                  `perm:"read"`
+		GasBatchEstimateMessageGas       func(ctx context.Context, estimateMessages []*types.EstimateMessage, fromNonce uint64, tsk types.TipSetKey) ([]*types.EstimateResult, error)      `perm:"read"`
+		GasEstimateFeeCap                func(ctx context.Context, msg *types.Message, maxqueueblks int64, tsk types.TipSetKey) (big.Int, error)                                           `perm:"read"`
+		GasEstimateGasLimit              func(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (int64, error)                                                               `perm:"read"`
+		GasEstimateGasLimitWithPriors    func(ctx context.Context, msgIn *types.Message, priors []*types.Message, tsk types.TipSetKey) (int64, error)                                      `perm:"read"`
+		GasEstimateGasLimitWithTrace     func(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (*types.GasLimitBreakdown, error)                                            `perm:"read"`
+		GasEstimateGasPremium            func(ctx context.Context, nblocksincl uint64, sender address.Address, gaslimit int64, tsk types.TipSetKey) (big.Int, error)                       `perm:"read"`
+		GasEstimateGasPremiumWithHistory func(ctx context.Context, nblocksincl uint64, historyBlocks uint64, sender address.Address, gaslimit int64, tsk types.TipSetKey) (big.Int, error) `perm:"read"`
+		GasEstimateMessageGas            func(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec, tsk types.TipSetKey) (*types.Message, error)                           `perm:"read"`
+		MpoolBatchPush                   func(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                        `perm:"write"`
+		MpoolBatchPushMessage            func(ctx context.Context, msgs []*types.Message, spec *types.MessageSendSpec) ([]*types.SignedMessage, error)                                     `perm:"sign"`
+		MpoolBatchPushUntrusted          func(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                        `perm:"write"`
+		MpoolCheckMessages               func(ctx context.Context, protos []*types.MessagePrototype) ([][]types.MessageCheckStatus, error)                                                 `perm:"read"`
+		MpoolCheckPendingMessages        func(ctx context.Context, addr address.Address) ([][]types.MessageCheckStatus, error)                                                             `perm:"read"`
+		MpoolCheckReplaceMessages        func(ctx context.Context, msg []*types.Message) ([][]types.MessageCheckStatus, error)                                                             `perm:"read"`
+		MpoolClear                       func(ctx context.Context, local bool) error                                                                                                       `perm:"write"`
+		MpoolClearRange                  func(ctx context.Context, addr address.Address, fromNonce, toNonce uint64, local bool) (int, error)                                               `perm:"admin"`
+		MpoolClearWithSafetyCheck        func(ctx context.Context, local bool, safetyCheckHeight abi.ChainEpoch) error                                                                     `perm:"write"`
+		MpoolDeleteByAdress              func(ctx context.Context, addr address.Address) error                                                                                             `perm:"admin"`
+		MpoolGetBestGasLimit             func(ctx context.Context) (int64, error)                                                                                                          `perm:"read"`
+		MpoolGetConfig                   func(context.Context) (*types.MpoolConfig, error)                                                                                                 `perm:"read"`
+		MpoolGetNonce                    func(ctx context.Context, addr address.Address) (uint64, error)                                                                                   `perm:"read"`
+		MpoolGetPendingNonce             func(ctx context.Context, addr address.Address) (uint64, error)                                                                                   `perm:"read"`
+		MpoolPending                     func(ctx context.Context, tsk types.TipSetKey) ([]*types.SignedMessage, error)                                                                    `perm:"read"`
+		MpoolPublishByAddr               func(context.Context, address.Address) error                                                                                                      `perm:"write"`
+		MpoolPublishMessage              func(ctx context.Context, smsg *types.SignedMessage) error                                                                                        `perm:"write"`
+		MpoolPush                        func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                             `perm:"write"`
+		MpoolPushMessage                 func(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error)                                          `perm:"sign"`
+		MpoolPushUntrusted               func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                             `perm:"write"`
+		MpoolPushWithKey                 func(ctx context.Context, msg *types.Message, key address.Address) (*types.SignedMessage, error)                                                  `perm:"sign"`
+		MpoolReplace                     func(ctx context.Context, from address.Address, nonce uint64, spec *types.MessageSendSpec) (*types.SignedMessage, error)                          `perm:"sign"`
+		MpoolSelect                      func(context.Context, types.TipSetKey, float64) ([]*types.SignedMessage, error)                                                                   `perm:"read"`
+		MpoolSelects                     func(context.Context, types.TipSetKey, []float64) ([][]*types.SignedMessage, error)                                                               `perm:"read"`
+		MpoolSetConfig                   func(ctx context.Context, cfg *types.MpoolConfig) error                                                                                           `perm:"admin"`
+		MpoolSub                         func(ctx context.Context) (<-chan types.MpoolUpdate, error)                                                                                       `perm:"read"`
 	}
 }
 
@@ -497,9 +591,18 @@ func (s *IMessagePoolStruct) GasEstimateFeeCap(p0 context.Context, p1 *types.Mes
 func (s *IMessagePoolStruct) GasEstimateGasLimit(p0 context.Context, p1 *types.Message, p2 types.TipSetKey) (int64, error) {
 	return s.Internal.GasEstimateGasLimit(p0, p1, p2)
 }
+func (s *IMessagePoolStruct) GasEstimateGasLimitWithPriors(p0 context.Context, p1 *types.Message, p2 []*types.Message, p3 types.TipSetKey) (int64, error) {
+	return s.Internal.GasEstimateGasLimitWithPriors(p0, p1, p2, p3)
+}
+func (s *IMessagePoolStruct) GasEstimateGasLimitWithTrace(p0 context.Context, p1 *types.Message, p2 types.TipSetKey) (*types.GasLimitBreakdown, error) {
+	return s.Internal.GasEstimateGasLimitWithTrace(p0, p1, p2)
+}
 func (s *IMessagePoolStruct) GasEstimateGasPremium(p0 context.Context, p1 uint64, p2 address.Address, p3 int64, p4 types.TipSetKey) (big.Int, error) {
 	return s.Internal.GasEstimateGasPremium(p0, p1, p2, p3, p4)
 }
+func (s *IMessagePoolStruct) GasEstimateGasPremiumWithHistory(p0 context.Context, p1 uint64, p2 uint64, p3 address.Address, p4 int64, p5 types.TipSetKey) (big.Int, error) {
+	return s.Internal.GasEstimateGasPremiumWithHistory(p0, p1, p2, p3, p4, p5)
+}
 func (s *IMessagePoolStruct) GasEstimateMessageGas(p0 context.Context, p1 *types.Message, p2 *types.MessageSendSpec, p3 types.TipSetKey) (*types.Message, error) {
 	return s.Internal.GasEstimateMessageGas(p0, p1, p2, p3)
 }
@@ -524,15 +627,27 @@ func (s *IMessagePoolStruct) MpoolCheckReplaceMessages(p0 context.Context, p1 []
 func (s *IMessagePoolStruct) MpoolClear(p0 context.Context, p1 bool) error {
 	return s.Internal.MpoolClear(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolClearRange(p0 context.Context, p1 address.Address, p2, p3 uint64, p4 bool) (int, error) {
+	return s.Internal.MpoolClearRange(p0, p1, p2, p3, p4)
+}
+func (s *IMessagePoolStruct) MpoolClearWithSafetyCheck(p0 context.Context, p1 bool, p2 abi.ChainEpoch) error {
+	return s.Internal.MpoolClearWithSafetyCheck(p0, p1, p2)
+}
 func (s *IMessagePoolStruct) MpoolDeleteByAdress(p0 context.Context, p1 address.Address) error {
 	return s.Internal.MpoolDeleteByAdress(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolGetBestGasLimit(p0 context.Context) (int64, error) {
+	return s.Internal.MpoolGetBestGasLimit(p0)
+}
 func (s *IMessagePoolStruct) MpoolGetConfig(p0 context.Context) (*types.MpoolConfig, error) {
 	return s.Internal.MpoolGetConfig(p0)
 }
 func (s *IMessagePoolStruct) MpoolGetNonce(p0 context.Context, p1 address.Address) (uint64, error) {
 	return s.Internal.MpoolGetNonce(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolGetPendingNonce(p0 context.Context, p1 address.Address) (uint64, error) {
+	return s.Internal.MpoolGetPendingNonce(p0, p1)
+}
 func (s *IMessagePoolStruct) MpoolPending(p0 context.Context, p1 types.TipSetKey) ([]*types.SignedMessage, error) {
 	return s.Internal.MpoolPending(p0, p1)
 }
@@ -551,6 +666,12 @@ func (s *IMessagePoolStruct) MpoolPushMessage(p0 context.Context, p1 *types.Mess
 func (s *IMessagePoolStruct) MpoolPushUntrusted(p0 context.Context, p1 *types.SignedMessage) (cid.Cid, error) {
 	return s.Internal.MpoolPushUntrusted(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolPushWithKey(p0 context.Context, p1 *types.Message, p2 address.Address) (*types.SignedMessage, error) {
+	return s.Internal.MpoolPushWithKey(p0, p1, p2)
+}
+func (s *IMessagePoolStruct) MpoolReplace(p0 context.Context, p1 address.Address, p2 uint64, p3 *types.MessageSendSpec) (*types.SignedMessage, error) {
+	return s.Internal.MpoolReplace(p0, p1, p2, p3)
+}
 func (s *IMessagePoolStruct) MpoolSelect(p0 context.Context, p1 types.TipSetKey, p2 float64) ([]*types.SignedMessage, error) {
 	return s.Internal.MpoolSelect(p0, p1, p2)
 }
@@ -573,19 +694,29 @@ type INetworkStruct struct {
 		NetBandwidthStats           func(ctx context.Context) (metrics.Stats, error)                       `perm:"read"`
 		NetBandwidthStatsByPeer     func(ctx context.Context) (map[string]metrics.Stats, error)            `perm:"read"`
 		NetBandwidthStatsByProtocol func(ctx context.Context) (map[protocol.ID]metrics.Stats, error)       `perm:"read"`
+		NetBlockAdd                 func(ctx context.Context, acl types.NetBlockList) error                `perm:"admin"`
+		NetBlockList                func(ctx context.Context) (types.NetBlockList, error)                  `perm:"read"`
+		NetBlockRemove              func(ctx context.Context, acl types.NetBlockList) error                `perm:"admin"`
+		NetBootstrapHealth          func(ctx context.Context) ([]types.BootstrapStatus, error)             `perm:"read"`
 		NetConnect                  func(ctx context.Context, pi peer.AddrInfo) error                      `perm:"admin"`
 		NetConnectedness            func(context.Context, peer.ID) (network2.Connectedness, error)         `perm:"read"`
 		NetDisconnect               func(ctx context.Context, p peer.ID) error                             `perm:"admin"`
 		NetFindPeer                 func(ctx context.Context, p peer.ID) (peer.AddrInfo, error)            `perm:"read"`
 		NetFindProvidersAsync       func(ctx context.Context, key cid.Cid, count int) <-chan peer.AddrInfo `perm:"read"`
 		NetGetClosestPeers          func(ctx context.Context, key string) ([]peer.ID, error)               `perm:"read"`
+		NetNATStatus                func(ctx context.Context) (*types.NATStatus, error)                    `perm:"read"`
 		NetPeerInfo                 func(ctx context.Context, p peer.ID) (*types.ExtendedPeerInfo, error)  `perm:"read"`
+		NetPeerStats                func(ctx context.Context) ([]types.PeerStats, error)                   `perm:"read"`
 		NetPeers                    func(ctx context.Context) ([]peer.AddrInfo, error)                     `perm:"read"`
 		NetPing                     func(ctx context.Context, p peer.ID) (time.Duration, error)            `perm:"read"`
 		NetProtectAdd               func(ctx context.Context, acl []peer.ID) error                         `perm:"admin"`
 		NetProtectList              func(ctx context.Context) ([]peer.ID, error)                           `perm:"read"`
 		NetProtectRemove            func(ctx context.Context, acl []peer.ID) error                         `perm:"admin"`
 		NetPubsubScores             func(context.Context) ([]types.PubsubScore, error)                     `perm:"read"`
+		NetTagPeer                  func(ctx context.Context, p peer.ID, tag string, value int) error      `perm:"admin"`
+		NetUntagPeer                func(ctx context.Context, p peer.ID, tag string) error                 `perm:"admin"`
+		PubsubLeaveTopic            func(ctx context.Context, topic string) error                          `perm:"admin"`
+		PubsubListTopics            func(ctx context.Context) ([]string, error)                            `perm:"admin"`
 	}
 }
 
@@ -608,6 +739,18 @@ func (s *INetworkStruct) NetBandwidthStatsByPeer(p0 context.Context) (map[string
 func (s *INetworkStruct) NetBandwidthStatsByProtocol(p0 context.Context) (map[protocol.ID]metrics.Stats, error) {
 	return s.Internal.NetBandwidthStatsByProtocol(p0)
 }
+func (s *INetworkStruct) NetBlockAdd(p0 context.Context, p1 types.NetBlockList) error {
+	return s.Internal.NetBlockAdd(p0, p1)
+}
+func (s *INetworkStruct) NetBlockList(p0 context.Context) (types.NetBlockList, error) {
+	return s.Internal.NetBlockList(p0)
+}
+func (s *INetworkStruct) NetBlockRemove(p0 context.Context, p1 types.NetBlockList) error {
+	return s.Internal.NetBlockRemove(p0, p1)
+}
+func (s *INetworkStruct) NetBootstrapHealth(p0 context.Context) ([]types.BootstrapStatus, error) {
+	return s.Internal.NetBootstrapHealth(p0)
+}
 func (s *INetworkStruct) NetConnect(p0 context.Context, p1 peer.AddrInfo) error {
 	return s.Internal.NetConnect(p0, p1)
 }
@@ -626,9 +769,15 @@ func (s *INetworkStruct) NetFindProvidersAsync(p0 context.Context, p1 cid.Cid, p
 func (s *INetworkStruct) NetGetClosestPeers(p0 context.Context, p1 string) ([]peer.ID, error) {
 	return s.Internal.NetGetClosestPeers(p0, p1)
 }
+func (s *INetworkStruct) NetNATStatus(p0 context.Context) (*types.NATStatus, error) {
+	return s.Internal.NetNATStatus(p0)
+}
 func (s *INetworkStruct) NetPeerInfo(p0 context.Context, p1 peer.ID) (*types.ExtendedPeerInfo, error) {
 	return s.Internal.NetPeerInfo(p0, p1)
 }
+func (s *INetworkStruct) NetPeerStats(p0 context.Context) ([]types.PeerStats, error) {
+	return s.Internal.NetPeerStats(p0)
+}
 func (s *INetworkStruct) NetPeers(p0 context.Context) ([]peer.AddrInfo, error) {
 	return s.Internal.NetPeers(p0)
 }
@@ -647,15 +796,29 @@ func (s *INetworkStruct) NetProtectRemove(p0 context.Context, p1 []peer.ID) erro
 func (s *INetworkStruct) NetPubsubScores(p0 context.Context) ([]types.PubsubScore, error) {
 	return s.Internal.NetPubsubScores(p0)
 }
+func (s *INetworkStruct) NetTagPeer(p0 context.Context, p1 peer.ID, p2 string, p3 int) error {
+	return s.Internal.NetTagPeer(p0, p1, p2, p3)
+}
+func (s *INetworkStruct) NetUntagPeer(p0 context.Context, p1 peer.ID, p2 string) error {
+	return s.Internal.NetUntagPeer(p0, p1, p2)
+}
+func (s *INetworkStruct) PubsubLeaveTopic(p0 context.Context, p1 string) error {
+	return s.Internal.PubsubLeaveTopic(p0, p1)
+}
+func (s *INetworkStruct) PubsubListTopics(p0 context.Context) ([]string, error) {
+	return s.Internal.PubsubListTopics(p0)
+}
 
 type IPaychanStruct struct {
 	Internal struct {
 		PaychAllocateLane           func(ctx context.Context, ch address.Address) (uint64, error)                                                              `perm:"sign"`
+		PaychAllocateLaneWithLabel  func(ctx context.Context, ch address.Address, label string) (uint64, error)                                                `perm:"sign"`
 		PaychAvailableFunds         func(ctx context.Context, ch address.Address) (*types.ChannelAvailableFunds, error)                                        `perm:"sign"`
 		PaychAvailableFundsByFromTo func(ctx context.Context, from, to address.Address) (*types.ChannelAvailableFunds, error)                                  `perm:"sign"`
 		PaychCollect                func(ctx context.Context, addr address.Address) (cid.Cid, error)                                                           `perm:"sign"`
 		PaychFund                   func(ctx context.Context, from, to address.Address, amt types.BigInt) (*types.ChannelInfo, error)                          `perm:"sign"`
 		PaychGet                    func(ctx context.Context, from, to address.Address, amt types.BigInt, opts types.PaychGetOpts) (*types.ChannelInfo, error) `perm:"sign"`
+		PaychGetLaneLabels          func(ctx context.Context, ch address.Address) (map[uint64]string, error)                                                   `perm:"read"`
 		PaychGetWaitReady           func(ctx context.Context, sentinel cid.Cid) (address.Address, error)                                                       `perm:"sign"`
 		PaychList                   func(ctx context.Context) ([]address.Address, error)                                                                       `perm:"read"`
 		PaychNewPayment             func(ctx context.Context, from, to address.Address, vouchers []types.VoucherSpec) (*types.PaymentInfo, error)              `perm:"sign"`
@@ -665,6 +828,7 @@ type IPaychanStruct struct {
 		PaychVoucherCheckSpendable  func(ctx context.Context, ch address.Address, sv *types.SignedVoucher, secret []byte, proof []byte) (bool, error)          `perm:"read"`
 		PaychVoucherCheckValid      func(ctx context.Context, ch address.Address, sv *types.SignedVoucher) error                                               `perm:"read"`
 		PaychVoucherCreate          func(ctx context.Context, pch address.Address, amt big.Int, lane uint64) (*types.VoucherCreateResult, error)               `perm:"sign"`
+		PaychVoucherCreateSigned    func(ctx context.Context, pch address.Address, amt big.Int, lane uint64) (*types.SignedVoucher, error)                     `perm:"sign"`
 		PaychVoucherList            func(ctx context.Context, pch address.Address) ([]*types.SignedVoucher, error)                                             `perm:"write"`
 		PaychVoucherSubmit          func(ctx context.Context, ch address.Address, sv *types.SignedVoucher, secret []byte, proof []byte) (cid.Cid, error)       `perm:"sign"`
 	}
@@ -673,6 +837,9 @@ type IPaychanStruct struct {
 func (s *IPaychanStruct) PaychAllocateLane(p0 context.Context, p1 address.Address) (uint64, error) {
 	return s.Internal.PaychAllocateLane(p0, p1)
 }
+func (s *IPaychanStruct) PaychAllocateLaneWithLabel(p0 context.Context, p1 address.Address, p2 string) (uint64, error) {
+	return s.Internal.PaychAllocateLaneWithLabel(p0, p1, p2)
+}
 func (s *IPaychanStruct) PaychAvailableFunds(p0 context.Context, p1 address.Address) (*types.ChannelAvailableFunds, error) {
 	return s.Internal.PaychAvailableFunds(p0, p1)
 }
@@ -688,6 +855,9 @@ func (s *IPaychanStruct) PaychFund(p0 context.Context, p1, p2 address.Address, p
 func (s *IPaychanStruct) PaychGet(p0 context.Context, p1, p2 address.Address, p3 types.BigInt, p4 types.PaychGetOpts) (*types.ChannelInfo, error) {
 	return s.Internal.PaychGet(p0, p1, p2, p3, p4)
 }
+func (s *IPaychanStruct) PaychGetLaneLabels(p0 context.Context, p1 address.Address) (map[uint64]string, error) {
+	return s.Internal.PaychGetLaneLabels(p0, p1)
+}
 func (s *IPaychanStruct) PaychGetWaitReady(p0 context.Context, p1 cid.Cid) (address.Address, error) {
 	return s.Internal.PaychGetWaitReady(p0, p1)
 }
@@ -715,6 +885,9 @@ func (s *IPaychanStruct) PaychVoucherCheckValid(p0 context.Context, p1 address.A
 func (s *IPaychanStruct) PaychVoucherCreate(p0 context.Context, p1 address.Address, p2 big.Int, p3 uint64) (*types.VoucherCreateResult, error) {
 	return s.Internal.PaychVoucherCreate(p0, p1, p2, p3)
 }
+func (s *IPaychanStruct) PaychVoucherCreateSigned(p0 context.Context, p1 address.Address, p2 big.Int, p3 uint64) (*types.SignedVoucher, error) {
+	return s.Internal.PaychVoucherCreateSigned(p0, p1, p2, p3)
+}
 func (s *IPaychanStruct) PaychVoucherList(p0 context.Context, p1 address.Address) ([]*types.SignedVoucher, error) {
 	return s.Internal.PaychVoucherList(p0, p1)
 }
@@ -726,6 +899,7 @@ type ISyncerStruct struct {
 	Internal struct {
 		ChainSyncHandleNewTipSet func(ctx context.Context, ci *types.ChainInfo) error            `perm:"write"`
 		ChainTipSetWeight        func(ctx context.Context, tsk types.TipSetKey) (big.Int, error) `perm:"read"`
+		ChainValidateBlock       func(ctx context.Context, blk *types.BlockHeader) error         `perm:"admin"`
 		Concurrent               func(ctx context.Context) int64                                 `perm:"read"`
 		SetConcurrent            func(ctx context.Context, concurrent int64) error               `perm:"admin"`
 		SyncState                func(ctx context.Context) (*types.SyncState, error)             `perm:"read"`
@@ -740,6 +914,9 @@ func (s *ISyncerStruct) ChainSyncHandleNewTipSet(p0 context.Context, p1 *types.C
 func (s *ISyncerStruct) ChainTipSetWeight(p0 context.Context, p1 types.TipSetKey) (big.Int, error) {
 	return s.Internal.ChainTipSetWeight(p0, p1)
 }
+func (s *ISyncerStruct) ChainValidateBlock(p0 context.Context, p1 *types.BlockHeader) error {
+	return s.Internal.ChainValidateBlock(p0, p1)
+}
 func (s *ISyncerStruct) Concurrent(p0 context.Context) int64 { return s.Internal.Concurrent(p0) }
 func (s *ISyncerStruct) SetConcurrent(p0 context.Context, p1 int64) error {
 	return s.Internal.SetConcurrent(p0, p1)
@@ -756,22 +933,24 @@ func (s *ISyncerStruct) SyncerTracker(p0 context.Context) *types.TargetTracker {
 
 type IWalletStruct struct {
 	Internal struct {
-		HasPassword          func(ctx context.Context) bool                                                                          `perm:"admin"`
-		LockWallet           func(ctx context.Context) error                                                                         `perm:"admin"`
-		SetPassword          func(ctx context.Context, password []byte) error                                                        `perm:"admin"`
-		UnLockWallet         func(ctx context.Context, password []byte) error                                                        `perm:"admin"`
-		WalletAddresses      func(ctx context.Context) []address.Address                                                             `perm:"admin"`
-		WalletBalance        func(ctx context.Context, addr address.Address) (abi.TokenAmount, error)                                `perm:"read"`
-		WalletDefaultAddress func(ctx context.Context) (address.Address, error)                                                      `perm:"write"`
-		WalletDelete         func(ctx context.Context, addr address.Address) error                                                   `perm:"admin"`
-		WalletExport         func(ctx context.Context, addr address.Address, password string) (*types.KeyInfo, error)                `perm:"admin"`
-		WalletHas            func(ctx context.Context, addr address.Address) (bool, error)                                           `perm:"write"`
-		WalletImport         func(ctx context.Context, key *types.KeyInfo) (address.Address, error)                                  `perm:"admin"`
-		WalletNewAddress     func(ctx context.Context, protocol address.Protocol) (address.Address, error)                           `perm:"write"`
-		WalletSetDefault     func(ctx context.Context, addr address.Address) error                                                   `perm:"write"`
-		WalletSign           func(ctx context.Context, k address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) `perm:"sign"`
-		WalletSignMessage    func(ctx context.Context, k address.Address, msg *types.Message) (*types.SignedMessage, error)          `perm:"sign"`
-		WalletState          func(ctx context.Context) int                                                                           `perm:"admin"`
+		HasPassword           func(ctx context.Context) bool                                                                          `perm:"admin"`
+		LockWallet            func(ctx context.Context) error                                                                         `perm:"admin"`
+		SetPassword           func(ctx context.Context, password []byte) error                                                        `perm:"admin"`
+		UnLockWallet          func(ctx context.Context, password []byte) error                                                        `perm:"admin"`
+		WalletAddresses       func(ctx context.Context) []address.Address                                                             `perm:"admin"`
+		WalletBalance         func(ctx context.Context, addr address.Address) (abi.TokenAmount, error)                                `perm:"read"`
+		WalletBalanceBatch    func(ctx context.Context, addrs []address.Address, tsk types.TipSetKey) ([]types.AddressBalance, error) `perm:"read"`
+		WalletDefaultAddress  func(ctx context.Context) (address.Address, error)                                                      `perm:"write"`
+		WalletDelete          func(ctx context.Context, addr address.Address) error                                                   `perm:"admin"`
+		WalletExport          func(ctx context.Context, addr address.Address, password string) (*types.KeyInfo, error)                `perm:"admin"`
+		WalletHas             func(ctx context.Context, addr address.Address) (bool, error)                                           `perm:"write"`
+		WalletImport          func(ctx context.Context, key *types.KeyInfo) (address.Address, error)                                  `perm:"admin"`
+		WalletNewAddress      func(ctx context.Context, protocol address.Protocol) (address.Address, error)                           `perm:"write"`
+		WalletSetDefault      func(ctx context.Context, addr address.Address) error                                                   `perm:"write"`
+		WalletSign            func(ctx context.Context, k address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) `perm:"sign"`
+		WalletSignMessage     func(ctx context.Context, k address.Address, msg *types.Message) (*types.SignedMessage, error)          `perm:"sign"`
+		WalletState           func(ctx context.Context) int                                                                           `perm:"admin"`
+		WalletValidateAddress func(ctx context.Context, s string) (address.Address, error)                                            `perm:"read"`
 	}
 }
 
@@ -789,6 +968,9 @@ func (s *IWalletStruct) WalletAddresses(p0 context.Context) []address.Address {
 func (s *IWalletStruct) WalletBalance(p0 context.Context, p1 address.Address) (abi.TokenAmount, error) {
 	return s.Internal.WalletBalance(p0, p1)
 }
+func (s *IWalletStruct) WalletBalanceBatch(p0 context.Context, p1 []address.Address, p2 types.TipSetKey) ([]types.AddressBalance, error) {
+	return s.Internal.WalletBalanceBatch(p0, p1, p2)
+}
 func (s *IWalletStruct) WalletDefaultAddress(p0 context.Context) (address.Address, error) {
 	return s.Internal.WalletDefaultAddress(p0)
 }
@@ -817,18 +999,29 @@ func (s *IWalletStruct) WalletSignMessage(p0 context.Context, p1 address.Address
 	return s.Internal.WalletSignMessage(p0, p1, p2)
 }
 func (s *IWalletStruct) WalletState(p0 context.Context) int { return s.Internal.WalletState(p0) }
+func (s *IWalletStruct) WalletValidateAddress(p0 context.Context, p1 string) (address.Address, error) {
+	return s.Internal.WalletValidateAddress(p0, p1)
+}
 
 type ICommonStruct struct {
 	Internal struct {
-		NodeStatus func(ctx context.Context, inclChainStatus bool) (types.NodeStatus, error) `perm:"read"`
-		StartTime  func(context.Context) (time.Time, error)                                  `perm:"read"`
-		Version    func(ctx context.Context) (types.Version, error)                          `perm:"read"`
+		NodeHealthScore func(ctx context.Context) (*types.HealthScore, error)                     `perm:"read"`
+		NodeStatus      func(ctx context.Context, inclChainStatus bool) (types.NodeStatus, error) `perm:"read"`
+		NodeVersion     func(ctx context.Context) (*types.NodeVersion, error)                     `perm:"read"`
+		StartTime       func(context.Context) (time.Time, error)                                  `perm:"read"`
+		Version         func(ctx context.Context) (types.Version, error)                          `perm:"read"`
 	}
 }
 
+func (s *ICommonStruct) NodeHealthScore(p0 context.Context) (*types.HealthScore, error) {
+	return s.Internal.NodeHealthScore(p0)
+}
 func (s *ICommonStruct) NodeStatus(p0 context.Context, p1 bool) (types.NodeStatus, error) {
 	return s.Internal.NodeStatus(p0, p1)
 }
+func (s *ICommonStruct) NodeVersion(p0 context.Context) (*types.NodeVersion, error) {
+	return s.Internal.NodeVersion(p0)
+}
 func (s *ICommonStruct) StartTime(p0 context.Context) (time.Time, error) {
 	return s.Internal.StartTime(p0)
 }
@@ -843,26 +1036,33 @@ type IETHStruct struct {
 		EthBlockNumber                         func(ctx context.Context) (types.EthUint64, error)                                                                    `perm:"read"`
 		EthCall                                func(ctx context.Context, tx types.EthCall, blkParam string) (types.EthBytes, error)                                  `perm:"read"`
 		EthChainId                             func(ctx context.Context) (types.EthUint64, error)                                                                    `perm:"read"`
+		EthDebugGetBadBlocks                   func(ctx context.Context) ([]types.EthBlock, error)                                                                   `perm:"read"`
 		EthEstimateGas                         func(ctx context.Context, tx types.EthCall) (types.EthUint64, error)                                                  `perm:"read"`
 		EthFeeHistory                          func(ctx context.Context, p jsonrpc.RawParams) (types.EthFeeHistory, error)                                           `perm:"read"`
 		EthGasPrice                            func(ctx context.Context) (types.EthBigInt, error)                                                                    `perm:"read"`
 		EthGetBalance                          func(ctx context.Context, address types.EthAddress, blkParam string) (types.EthBigInt, error)                         `perm:"read"`
 		EthGetBlockByHash                      func(ctx context.Context, blkHash types.EthHash, fullTxInfo bool) (types.EthBlock, error)                             `perm:"read"`
 		EthGetBlockByNumber                    func(ctx context.Context, blkNum string, fullTxInfo bool) (types.EthBlock, error)                                     `perm:"read"`
+		EthGetBlockReceipts                    func(ctx context.Context, blkParam string) ([]*types.EthTxReceipt, error)                                             `perm:"read"`
 		EthGetBlockTransactionCountByHash      func(ctx context.Context, blkHash types.EthHash) (types.EthUint64, error)                                             `perm:"read"`
 		EthGetBlockTransactionCountByNumber    func(ctx context.Context, blkNum types.EthUint64) (types.EthUint64, error)                                            `perm:"read"`
 		EthGetCode                             func(ctx context.Context, address types.EthAddress, blkOpt string) (types.EthBytes, error)                            `perm:"read"`
 		EthGetMessageCidByTransactionHash      func(ctx context.Context, txHash *types.EthHash) (*cid.Cid, error)                                                    `perm:"read"`
 		EthGetStorageAt                        func(ctx context.Context, address types.EthAddress, position types.EthBytes, blkParam string) (types.EthBytes, error) `perm:"read"`
-		EthGetTransactionByBlockHashAndIndex   func(ctx context.Context, blkHash types.EthHash, txIndex types.EthUint64) (types.EthTx, error)                        `perm:"read"`
-		EthGetTransactionByBlockNumberAndIndex func(ctx context.Context, blkNum types.EthUint64, txIndex types.EthUint64) (types.EthTx, error)                       `perm:"read"`
+		EthGetTransactionByBlockHashAndIndex   func(ctx context.Context, blkHash types.EthHash, txIndex types.EthUint64) (*types.EthTx, error)                       `perm:"read"`
+		EthGetTransactionByBlockNumberAndIndex func(ctx context.Context, blkParam string, txIndex types.EthUint64) (*types.EthTx, error)                             `perm:"read"`
 		EthGetTransactionByHash                func(ctx context.Context, txHash *types.EthHash) (*types.EthTx, error)                                                `perm:"read"`
 		EthGetTransactionCount                 func(ctx context.Context, sender types.EthAddress, blkOpt string) (types.EthUint64, error)                            `perm:"read"`
 		EthGetTransactionHashByCid             func(ctx context.Context, cid cid.Cid) (*types.EthHash, error)                                                        `perm:"read"`
 		EthGetTransactionReceipt               func(ctx context.Context, txHash types.EthHash) (*types.EthTxReceipt, error)                                          `perm:"read"`
+		EthGetUncleByBlockHashAndIndex         func(ctx context.Context, blkHash types.EthHash, index types.EthUint64) (*types.EthBlock, error)                      `perm:"read"`
+		EthGetUncleCountByBlockHash            func(ctx context.Context, blkHash types.EthHash) (types.EthUint64, error)                                             `perm:"read"`
 		EthMaxPriorityFeePerGas                func(ctx context.Context) (types.EthBigInt, error)                                                                    `perm:"read"`
+		EthNetListening                        func(ctx context.Context) (bool, error)                                                                               `perm:"read"`
+		EthNetPeerCount                        func(ctx context.Context) (types.EthUint64, error)                                                                    `perm:"read"`
 		EthProtocolVersion                     func(ctx context.Context) (types.EthUint64, error)                                                                    `perm:"read"`
 		EthSendRawTransaction                  func(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error)                                                `perm:"read"`
+		EthTxpoolContent                       func(ctx context.Context) (*types.EthTxpoolContentResult, error)                                                      `perm:"read"`
 		FilecoinAddressToEthAddress            func(ctx context.Context, filecoinAddress address.Address) (types.EthAddress, error)                                  `perm:"read"`
 		NetListening                           func(ctx context.Context) (bool, error)                                                                               `perm:"read"`
 		NetVersion                             func(ctx context.Context) (string, error)                                                                             `perm:"read"`
@@ -885,6 +1085,9 @@ func (s *IETHStruct) EthCall(p0 context.Context, p1 types.EthCall, p2 string) (t
 func (s *IETHStruct) EthChainId(p0 context.Context) (types.EthUint64, error) {
 	return s.Internal.EthChainId(p0)
 }
+func (s *IETHStruct) EthDebugGetBadBlocks(p0 context.Context) ([]types.EthBlock, error) {
+	return s.Internal.EthDebugGetBadBlocks(p0)
+}
 func (s *IETHStruct) EthEstimateGas(p0 context.Context, p1 types.EthCall) (types.EthUint64, error) {
 	return s.Internal.EthEstimateGas(p0, p1)
 }
@@ -903,6 +1106,9 @@ func (s *IETHStruct) EthGetBlockByHash(p0 context.Context, p1 types.EthHash, p2
 func (s *IETHStruct) EthGetBlockByNumber(p0 context.Context, p1 string, p2 bool) (types.EthBlock, error) {
 	return s.Internal.EthGetBlockByNumber(p0, p1, p2)
 }
+func (s *IETHStruct) EthGetBlockReceipts(p0 context.Context, p1 string) ([]*types.EthTxReceipt, error) {
+	return s.Internal.EthGetBlockReceipts(p0, p1)
+}
 func (s *IETHStruct) EthGetBlockTransactionCountByHash(p0 context.Context, p1 types.EthHash) (types.EthUint64, error) {
 	return s.Internal.EthGetBlockTransactionCountByHash(p0, p1)
 }
@@ -918,10 +1124,10 @@ func (s *IETHStruct) EthGetMessageCidByTransactionHash(p0 context.Context, p1 *t
 func (s *IETHStruct) EthGetStorageAt(p0 context.Context, p1 types.EthAddress, p2 types.EthBytes, p3 string) (types.EthBytes, error) {
 	return s.Internal.EthGetStorageAt(p0, p1, p2, p3)
 }
-func (s *IETHStruct) EthGetTransactionByBlockHashAndIndex(p0 context.Context, p1 types.EthHash, p2 types.EthUint64) (types.EthTx, error) {
+func (s *IETHStruct) EthGetTransactionByBlockHashAndIndex(p0 context.Context, p1 types.EthHash, p2 types.EthUint64) (*types.EthTx, error) {
 	return s.Internal.EthGetTransactionByBlockHashAndIndex(p0, p1, p2)
 }
-func (s *IETHStruct) EthGetTransactionByBlockNumberAndIndex(p0 context.Context, p1 types.EthUint64, p2 types.EthUint64) (types.EthTx, error) {
+func (s *IETHStruct) EthGetTransactionByBlockNumberAndIndex(p0 context.Context, p1 string, p2 types.EthUint64) (*types.EthTx, error) {
 	return s.Internal.EthGetTransactionByBlockNumberAndIndex(p0, p1, p2)
 }
 func (s *IETHStruct) EthGetTransactionByHash(p0 context.Context, p1 *types.EthHash) (*types.EthTx, error) {
@@ -936,15 +1142,30 @@ func (s *IETHStruct) EthGetTransactionHashByCid(p0 context.Context, p1 cid.Cid)
 func (s *IETHStruct) EthGetTransactionReceipt(p0 context.Context, p1 types.EthHash) (*types.EthTxReceipt, error) {
 	return s.Internal.EthGetTransactionReceipt(p0, p1)
 }
+func (s *IETHStruct) EthGetUncleByBlockHashAndIndex(p0 context.Context, p1 types.EthHash, p2 types.EthUint64) (*types.EthBlock, error) {
+	return s.Internal.EthGetUncleByBlockHashAndIndex(p0, p1, p2)
+}
+func (s *IETHStruct) EthGetUncleCountByBlockHash(p0 context.Context, p1 types.EthHash) (types.EthUint64, error) {
+	return s.Internal.EthGetUncleCountByBlockHash(p0, p1)
+}
 func (s *IETHStruct) EthMaxPriorityFeePerGas(p0 context.Context) (types.EthBigInt, error) {
 	return s.Internal.EthMaxPriorityFeePerGas(p0)
 }
+func (s *IETHStruct) EthNetListening(p0 context.Context) (bool, error) {
+	return s.Internal.EthNetListening(p0)
+}
+func (s *IETHStruct) EthNetPeerCount(p0 context.Context) (types.EthUint64, error) {
+	return s.Internal.EthNetPeerCount(p0)
+}
 func (s *IETHStruct) EthProtocolVersion(p0 context.Context) (types.EthUint64, error) {
 	return s.Internal.EthProtocolVersion(p0)
 }
 func (s *IETHStruct) EthSendRawTransaction(p0 context.Context, p1 types.EthBytes) (types.EthHash, error) {
 	return s.Internal.EthSendRawTransaction(p0, p1)
 }
+func (s *IETHStruct) EthTxpoolContent(p0 context.Context) (*types.EthTxpoolContentResult, error) {
+	return s.Internal.EthTxpoolContent(p0)
+}
 func (s *IETHStruct) FilecoinAddressToEthAddress(p0 context.Context, p1 address.Address) (types.EthAddress, error) {
 	return s.Internal.FilecoinAddressToEthAddress(p0, p1)
 }
@@ -958,15 +1179,17 @@ func (s *IETHStruct) Web3ClientVersion(p0 context.Context) (string, error) {
 
 type IETHEventStruct struct {
 	Internal struct {
-		EthGetFilterChanges            func(ctx context.Context, id types.EthFilterID) (*types.EthFilterResult, error)        `perm:"write"`
-		EthGetFilterLogs               func(ctx context.Context, id types.EthFilterID) (*types.EthFilterResult, error)        `perm:"write"`
-		EthGetLogs                     func(ctx context.Context, filter *types.EthFilterSpec) (*types.EthFilterResult, error) `perm:"read"`
-		EthNewBlockFilter              func(ctx context.Context) (types.EthFilterID, error)                                   `perm:"write"`
-		EthNewFilter                   func(ctx context.Context, filter *types.EthFilterSpec) (types.EthFilterID, error)      `perm:"write"`
-		EthNewPendingTransactionFilter func(ctx context.Context) (types.EthFilterID, error)                                   `perm:"write"`
-		EthSubscribe                   func(ctx context.Context, params jsonrpc.RawParams) (types.EthSubscriptionID, error)   `perm:"write"`
-		EthUninstallFilter             func(ctx context.Context, id types.EthFilterID) (bool, error)                          `perm:"write"`
-		EthUnsubscribe                 func(ctx context.Context, id types.EthSubscriptionID) (bool, error)                    `perm:"write"`
+		EthGetFilterChanges            func(ctx context.Context, id types.EthFilterID) (*types.EthFilterResult, error)             `perm:"write"`
+		EthGetFilterLogs               func(ctx context.Context, id types.EthFilterID) (*types.EthFilterResult, error)             `perm:"write"`
+		EthGetLogs                     func(ctx context.Context, filter *types.EthFilterSpec) (*types.EthFilterResult, error)      `perm:"read"`
+		EthNewBlockFilter              func(ctx context.Context) (types.EthFilterID, error)                                        `perm:"write"`
+		EthNewFilter                   func(ctx context.Context, filter *types.EthFilterSpec) (types.EthFilterID, error)           `perm:"write"`
+		EthNewPendingTransactionFilter func(ctx context.Context) (types.EthFilterID, error)                                        `perm:"write"`
+		EthSubscribe                   func(ctx context.Context, params jsonrpc.RawParams) (types.EthSubscriptionID, error)        `perm:"write"`
+		EthUninstallFilter             func(ctx context.Context, id types.EthFilterID) (bool, error)                               `perm:"write"`
+		EthUnsubscribe                 func(ctx context.Context, id types.EthSubscriptionID) (bool, error)                         `perm:"write"`
+		GetActorEventsRaw              func(ctx context.Context, filter *types.ActorEventFilter) (<-chan *types.ActorEvent, error) `perm:"read"`
+		GetFVMEvents                   func(ctx context.Context, filter *types.ActorEventFilter) ([]*types.ActorEvent, error)      `perm:"read"`
 	}
 }
 
@@ -997,6 +1220,12 @@ func (s *IETHEventStruct) EthUninstallFilter(p0 context.Context, p1 types.EthFil
 func (s *IETHEventStruct) EthUnsubscribe(p0 context.Context, p1 types.EthSubscriptionID) (bool, error) {
 	return s.Internal.EthUnsubscribe(p0, p1)
 }
+func (s *IETHEventStruct) GetActorEventsRaw(p0 context.Context, p1 *types.ActorEventFilter) (<-chan *types.ActorEvent, error) {
+	return s.Internal.GetActorEventsRaw(p0, p1)
+}
+func (s *IETHEventStruct) GetFVMEvents(p0 context.Context, p1 *types.ActorEventFilter) ([]*types.ActorEvent, error) {
+	return s.Internal.GetFVMEvents(p0, p1)
+}
 
 type FullETHStruct struct {
 	IETHStruct
@@ -16,4 +16,7 @@ type ISyncer interface {
 	ChainTipSetWeight(ctx context.Context, tsk types.TipSetKey) (big.Int, error) //perm:read
 	SyncSubmitBlock(ctx context.Context, blk *types.BlockMsg) error              //perm:write
 	SyncState(ctx context.Context) (*types.SyncState, error)                     //perm:read
+	// ChainValidateBlock runs the block validation of the syncer against blk without adding it to the chain,
+	// all failed checks are reported. The messages of blk must already be in the local store.
+	ChainValidateBlock(ctx context.Context, blk *types.BlockHeader) error //perm:admin
 }
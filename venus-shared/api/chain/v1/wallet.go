@@ -27,4 +27,9 @@ type IWallet interface {
 	SetPassword(ctx context.Context, password []byte) error                                                       //perm:admin
 	HasPassword(ctx context.Context) bool                                                                         //perm:admin
 	WalletState(ctx context.Context) int                                                                          //perm:admin
+	// WalletValidateAddress parses s as an address and checks its protocol and checksum, it does not read the chain
+	WalletValidateAddress(ctx context.Context, s string) (address.Address, error) //perm:read
+	// WalletBalanceBatch returns the balances of up to 1000 addresses at tsk read from a single state tree in the
+	// order of addrs, address.Undef and addresses without an actor have a zero balance
+	WalletBalanceBatch(ctx context.Context, addrs []address.Address, tsk types.TipSetKey) ([]types.AddressBalance, error) //perm:read
 }
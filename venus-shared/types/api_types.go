@@ -8,6 +8,7 @@ import (
 	"github.com/filecoin-project/go-bitfield"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
 	"github.com/ipfs/go-cid"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -58,6 +59,32 @@ type BlockMessages struct {
 	Cids          []cid.Cid
 }
 
+// BlockMessagesDecoded is BlockMessages with the params of the messages decoded where they are known
+type BlockMessagesDecoded struct {
+	BlsMessages   []*MessageDecoded
+	SecpkMessages []*SignedMessageDecoded
+	Cids          []cid.Cid
+}
+
+// MessageDecoded is a message and its decoded params, DecodedParams is nil when the params are not known
+type MessageDecoded struct {
+	Message       *Message
+	DecodedParams interface{}
+}
+
+// SignedMessageDecoded is a signed message and its decoded params, DecodedParams is nil when the params are not known
+type SignedMessageDecoded struct {
+	Message       *SignedMessage
+	DecodedParams interface{}
+}
+
+// EthCallDecoded is the calldata of a call to an EVM contract decoded with a known ABI
+type EthCallDecoded struct {
+	// Method is the signature of the method called, e.g. transfer(address,uint256)
+	Method string
+	Args   []interface{}
+}
+
 type MessageCID struct {
 	Cid     cid.Cid
 	Message *Message
@@ -170,6 +197,12 @@ type ProtocolParams struct {
 type Deadline struct {
 	PostSubmissions      bitfield.BitField
 	DisputableProofCount uint64
+	// Number of sectors of the deadline that are not terminated, faulty ones included.
+	LiveSectors uint64
+	// Number of sectors of the deadline, terminated ones included.
+	TotalSectors uint64
+	FaultyPower  PowerPair
+	LivePower    PowerPair
 }
 
 var MarketBalanceNil = MarketBalance{}
@@ -185,6 +218,61 @@ type MinerPower struct {
 	HasMinPower bool
 }
 
+// AllocationPageToken is the position after the last allocation of a page returned by
+// StateListVerifiedDatacapAllocations, it is passed back unchanged to fetch the next page
+type AllocationPageToken struct {
+	// Client is the id address of the client of the last allocation
+	Client  address.Address
+	AfterID AllocationId
+}
+
+// AllocationListItem is an allocation of the verified registry together with its id
+type AllocationListItem struct {
+	ID AllocationId
+	Allocation
+}
+
+// AllocationPage is a page of allocations of the verified registry in the order of its allocations table
+type AllocationPage struct {
+	Allocations []AllocationListItem
+	// NextPageToken fetches the next page, it is nil on the last page
+	NextPageToken *AllocationPageToken
+}
+
+// MinerPageToken is the position after the last miner of a page returned by StateListMinersPaginated in
+// the claims table of the power actor. Its content is opaque, it is passed back unchanged to fetch the next page
+type MinerPageToken struct {
+	Cursor []byte
+}
+
+// MinerPage is a page of the miners with a claim in the power actor, in the order of the claims table
+type MinerPage struct {
+	Miners []address.Address
+	// NextPageToken fetches the next page, it is nil on the last page
+	NextPageToken *MinerPageToken
+}
+
+// DatacapClaim is a claim of the verified registry together with its id, which is the id of
+// the allocation it was made from
+type DatacapClaim struct {
+	AllocationID ClaimId
+	Claim
+}
+
+// MinerSectorPower is the power of the active sectors of a miner, computed from the miner state alone
+type MinerSectorPower struct {
+	RawBytePower    abi.StoragePower
+	QualityAdjPower abi.StoragePower
+}
+
+// InitialPledge is the initial pledge the miner actor requires to commit a sector
+type InitialPledge struct {
+	// StoragePledge is the block reward the sector is expected to earn over the projection period
+	StoragePledge abi.TokenAmount
+	// ConsensusPledge is the share of the circulating supply locked for the power of the sector
+	ConsensusPledge abi.TokenAmount
+}
+
 type MinerSectors struct {
 	// Live sectors that should be proven.
 	Live uint64
@@ -192,6 +280,8 @@ type MinerSectors struct {
 	Active uint64
 	// Sectors with failed proofs.
 	Faulty uint64
+	// Faulty sectors declared as recovered, they are proven again in their next deadline.
+	Recovering uint64
 }
 
 type MarketBalance struct {
@@ -204,6 +294,14 @@ type DealCollateralBounds struct {
 	Max abi.TokenAmount
 }
 
+// ConsistencyReport lists the data of the canonical chain missing from the blockstore
+type ConsistencyReport struct {
+	// MissingStateRoots are the epochs of the tipsets whose parent state root is missing
+	MissingStateRoots []abi.ChainEpoch
+	// MissingMessageBundles are the message roots of blocks that are missing
+	MissingMessageBundles []cid.Cid
+}
+
 type MsgLookup struct {
 	Message   cid.Cid // Can be different than requested, in case it was replaced, but only gas values changed
 	Receipt   MessageReceipt
@@ -212,6 +310,8 @@ type MsgLookup struct {
 	Height    abi.ChainEpoch
 }
 
+type PoStProof = builtin.PoStProof
+
 type MiningBaseInfo struct { //nolint
 	MinerPower        abi.StoragePower
 	NetworkPower      abi.StoragePower
@@ -235,9 +335,20 @@ type BlockTemplate struct {
 	WinningPoStProof []builtin.PoStProof
 }
 
+// MessageType describes how the params of a message are encoded
+type MessageType int
+
+const (
+	// MessageTypeNative is a message calling a built-in actor method
+	MessageTypeNative MessageType = iota
+	// MessageTypeEVM is a message carrying ABI-encoded EVM params
+	MessageTypeEVM
+)
+
 type EstimateMessage struct {
-	Msg  *Message
-	Spec *MessageSendSpec
+	Msg         *Message
+	Spec        *MessageSendSpec
+	MessageType MessageType
 }
 
 type EstimateResult struct {
@@ -261,6 +372,18 @@ type Version struct {
 	APIVersion APIVersion
 }
 
+// NodeVersion describes the running node build and the chain it follows
+type NodeVersion struct {
+	// Build is the release version of the node, e.g. 1.11.0
+	Build string
+	// GitCommit is the commit the node was built from, abbreviated when the full hash was not recorded
+	GitCommit            string
+	GoVersion            string
+	NetworkVersion       network.Version
+	SupportedAPIVersions []int
+	ChainName            string
+}
+
 type ChannelAvailableFunds struct {
 	// Channel is the address of the channel
 	Channel *address.Address
@@ -397,6 +520,32 @@ type NetworkParams struct {
 	SupportedProofTypes     []abi.RegisteredSealProof
 	PreCommitChallengeDelay abi.ChainEpoch
 	ForkUpgradeParams       ForkUpgradeParams
+	Eip155ChainID           int
+}
+
+// UpgradeInfo describes a scheduled network upgrade
+type UpgradeInfo struct {
+	Height  abi.ChainEpoch
+	Network network.Version
+	// Migration is the human-readable name of the upgrade
+	Migration string
+	// Passed is true once the chain head reached Height
+	Passed bool
+}
+
+// MessageExecutionContext describes the block and tipset a message was included in
+type MessageExecutionContext struct {
+	TipSetKey TipSetKey
+	Epoch     abi.ChainEpoch
+	// Block is the first block of the tipset including the message
+	Block cid.Cid
+	Miner address.Address
+	// BlockReward is what the miner of Block is awarded for its winning tickets, gas rewards excluded
+	BlockReward abi.TokenAmount
+	// ParentBaseFee is the base fee the message was charged with
+	ParentBaseFee abi.TokenAmount
+	// ParentStateRoot is the state the messages of the tipset are applied on
+	ParentStateRoot cid.Cid
 }
 
 type ForkUpgradeParams struct {
@@ -446,3 +595,30 @@ type NodeChainStatus struct {
 	BlocksPerTipsetLast100      float64
 	BlocksPerTipsetLastFinality float64
 }
+
+// HealthScore is a machine readable health signal of the node, e.g. for load balancers
+type HealthScore struct {
+	// Score ranges from 0 (unusable) to 100 (healthy)
+	Score      int
+	Synced     bool
+	BehindHead abi.ChainEpoch
+	MpoolSize  int
+	PeerCount  int
+	// APILatencyMs is the time it took to gather the health data
+	APILatencyMs int
+	// Warnings describes every check that lowered the score
+	Warnings []string
+}
+
+// EthTxpoolContentResult lists the eth transactions of the message pool by sender and nonce,
+// Queued holds the transactions that can't be executed yet because of a nonce gap
+type EthTxpoolContentResult struct {
+	Pending map[EthAddress]map[string]*EthTx `json:"pending"`
+	Queued  map[EthAddress]map[string]*EthTx `json:"queued"`
+}
+
+// AddressBalance is the balance of an address returned by WalletBalanceBatch
+type AddressBalance struct {
+	Address address.Address
+	Balance abi.TokenAmount
+}
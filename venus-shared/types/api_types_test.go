@@ -0,0 +1,33 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestAddressBalanceJSONRoundTrip(t *testing.T) {
+	tf.UnitTest(t)
+
+	id, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	actor, err := address.NewActorAddress([]byte("actor"))
+	require.NoError(t, err)
+
+	src := []AddressBalance{
+		{Address: id, Balance: abi.NewTokenAmount(42)},
+		{Address: actor, Balance: abi.NewTokenAmount(0)},
+		{Address: address.Undef, Balance: abi.NewTokenAmount(0)},
+	}
+	data, err := json.Marshal(src)
+	require.NoError(t, err)
+
+	var dst []AddressBalance
+	require.NoError(t, json.Unmarshal(data, &dst))
+	require.Equal(t, src, dst)
+}
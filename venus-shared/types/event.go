@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
 	cbg "github.com/whyrusleeping/cbor-gen"
 )
 
@@ -38,6 +40,72 @@ type EventEntry struct {
 
 type FilterID [32]byte // compatible with EthHash
 
+// EventCodecCbor is the IPLD codec of the CBOR encoded values of builtin actor events
+const EventCodecCbor = 0x51
+
+// EventTypeKey is the key of the entry carrying the type of builtin actor events
+const EventTypeKey = "$type"
+
+// ActorEventType reads the type of a builtin actor event from its $type entry.
+func ActorEventType(entries []EventEntry) (string, error) {
+	for _, entry := range entries {
+		if entry.Key != EventTypeKey {
+			continue
+		}
+		if entry.Codec != EventCodecCbor {
+			return "", fmt.Errorf("unexpected codec %d of event type", entry.Codec)
+		}
+		return cbg.ReadString(bytes.NewReader(entry.Value))
+	}
+	return "", fmt.Errorf("event has no %s entry", EventTypeKey)
+}
+
+// ActorEvent is an event emitted by an actor together with where it was emitted
+type ActorEvent struct {
+	// Entries are the key values making up the event
+	Entries []EventEntry
+	// Emitter is the address of the actor that emitted the event
+	Emitter address.Address
+	// Reverted is true if the tipset including the message was reverted
+	Reverted bool
+	// Height is the epoch of the tipset including the message
+	Height abi.ChainEpoch
+	// TipSetKey is the key of the tipset including the message
+	TipSetKey TipSetKey
+	// MsgCid is the cid of the message that emitted the event
+	MsgCid cid.Cid
+}
+
+// ActorEventFilter selects the actor events delivered by GetActorEventsRaw
+type ActorEventFilter struct {
+	// Addresses restricts the emitters, all emitters match when empty
+	Addresses []address.Address
+	// Topics are matched against the t1..t4 entries of the event in order, a nil topic matches any value
+	Topics [][]byte
+	// FromEpoch is the first epoch to replay events from, -1 starts from the current head
+	FromEpoch abi.ChainEpoch
+	// ToEpoch is the last epoch to deliver events for, -1 keeps following the chain
+	ToEpoch abi.ChainEpoch
+}
+
+// DecodedActorEvent is an ActorEvent whose entries were decoded with the schema of the emitter
+type DecodedActorEvent struct {
+	Emitter address.Address
+	// Actor is the builtin actor name of the emitter, e.g. storageminer
+	Actor string
+	// Type is the event type, e.g. sector-activated
+	Type   string
+	Fields []DecodedEventField
+}
+
+// DecodedEventField is a decoded event entry
+type DecodedEventField struct {
+	Name string
+	// Type is the schema type the value was decoded as, raw values are left as bytes
+	Type  string
+	Value interface{}
+}
+
 // DecodeEvents decodes a CBOR list of CBOR-encoded events.
 func DecodeEvents(input []byte) ([]Event, error) {
 	r := bytes.NewReader(input)
@@ -59,3 +59,25 @@ func (gt *GasTrace) MarshalJSON() ([]byte, error) {
 	cpy := (*GasTraceCopy)(gt)
 	return json.Marshal(cpy)
 }
+
+// GasLimitBreakdown is a gas limit estimate with the gas used by the calls made executing the message
+type GasLimitBreakdown struct {
+	// GasLimit is GasUsed with the gas limit overestimation applied
+	GasLimit int64
+	GasUsed  int64
+	// SubCallBreakdown lists the subcalls of the message, the calls using the most gas first
+	SubCallBreakdown []SubCallGas
+}
+
+// SubCallGas is the gas used by a call made executing a message
+type SubCallGas struct {
+	// Depth is 1 for the calls made by the message itself
+	Depth  int
+	From   address.Address
+	To     address.Address
+	Method abi.MethodNum
+	// GasUsed includes the gas used by the subcalls of the call, CallGas does not
+	GasUsed  int64
+	CallGas  int64
+	ExitCode exitcode.ExitCode
+}
@@ -131,6 +131,35 @@ func (ci *ChannelInfo) MarkVoucherSubmitted(sv *types.SignedVoucher) error {
 	return nil
 }
 
+// PendingVoucherAmount returns the amount of the vouchers that have not been submitted.
+// The amount of a voucher is the total redeemable on its lane, so on each lane it is
+// the amount of the highest-nonce voucher minus the amount of the last submitted one.
+func (ci *ChannelInfo) PendingVoucherAmount() big.Int {
+	latest := make(map[uint64]*types.SignedVoucher)
+	submitted := make(map[uint64]*types.SignedVoucher)
+	for _, vi := range ci.Vouchers {
+		sv := vi.Voucher
+		if v, ok := latest[sv.Lane]; !ok || sv.Nonce > v.Nonce {
+			latest[sv.Lane] = sv
+		}
+		if v, ok := submitted[sv.Lane]; vi.Submitted && (!ok || sv.Nonce > v.Nonce) {
+			submitted[sv.Lane] = sv
+		}
+	}
+
+	pending := big.Zero()
+	for lane, sv := range latest {
+		redeemed := big.Zero()
+		if v, ok := submitted[lane]; ok {
+			redeemed = v.Amount
+		}
+		if sv.Amount.GreaterThan(redeemed) {
+			pending = big.Add(pending, big.Sub(sv.Amount, redeemed))
+		}
+	}
+	return pending
+}
+
 // wasVoucherSubmitted returns true if the voucher has been submitted
 func (ci *ChannelInfo) WasVoucherSubmitted(sv *types.SignedVoucher) (bool, error) {
 	vi, err := ci.InfoForVoucher(sv)
@@ -0,0 +1,33 @@
+package market
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestPendingVoucherAmount(t *testing.T) {
+	voucher := func(lane, nonce uint64, amt int64, submitted bool) *VoucherInfo {
+		return &VoucherInfo{
+			Voucher:   &types.SignedVoucher{Lane: lane, Nonce: nonce, Amount: big.NewInt(amt)},
+			Submitted: submitted,
+		}
+	}
+
+	ci := &ChannelInfo{}
+	require.Equal(t, big.Zero(), ci.PendingVoucherAmount())
+
+	ci.Vouchers = []*VoucherInfo{
+		// the amounts of a lane are cumulative, only the latest voucher counts
+		voucher(0, 1, 10, false),
+		voucher(0, 2, 30, false),
+		// the submitted amount is already redeemed
+		voucher(1, 1, 20, true),
+		voucher(1, 2, 50, false),
+		voucher(2, 1, 40, true),
+	}
+	require.Equal(t, big.NewInt(60), ci.PendingVoucherAmount())
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 )
 
 type MpoolConfig struct {
@@ -13,4 +14,12 @@ type MpoolConfig struct {
 	ReplaceByFeeRatio      Percent
 	PruneCooldown          time.Duration
 	GasLimitOverestimation float64
+	EVMGasOverestimation   float64
+
+	GasEstimateAdaptiveOverestimation bool
+	OverestimationTarget              float64
+
+	// MethodMaxFees caps the fee of the messages calling a method of an actor instead of the MaxFee of the
+	// node, keyed by "<actor code cid>/<method number>"
+	MethodMaxFees map[string]abi.TokenAmount
 }
@@ -1,11 +1,13 @@
 package types
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
 )
 
 type RawHost host.Host
@@ -29,3 +31,64 @@ type NatInfo struct {
 	Reachability network.Reachability
 	PublicAddr   string
 }
+
+// NetBlockList is the set of peers, IP addresses and IP subnets (CIDR) the node refuses to connect to
+type NetBlockList struct {
+	Peers     []peer.ID
+	IPAddrs   []string
+	IPSubnets []string
+}
+
+// PeerStats is the traffic exchanged with a connected peer since the connection was established
+type PeerStats struct {
+	ID             peer.ID
+	Addrs          []string
+	BytesSent      int64
+	BytesRecv      int64
+	ConnectedSince time.Time
+}
+
+// BootstrapStatus is the result of the latest health checks of a bootstrap peer
+type BootstrapStatus struct {
+	ID    peer.ID
+	Addrs []string
+	// Active is false once the peer failed too many consecutive checks, it is not used to find peers then
+	Active              bool
+	ConsecutiveFailures int
+	LastChecked         time.Time
+	LastError           string
+}
+
+// NATStatus is what the node learned about its reachability through autonat and identify
+type NATStatus struct {
+	Reachability network.Reachability
+	// PublicAddresses are the public addresses confirmed by autonat or observed by peers
+	PublicAddresses []multiaddr.Multiaddr
+	// NATType is Cone (full-cone or restricted, not told apart), Symmetric or Unknown
+	NATType string
+	// LastDiscovery is when the reachability or the NAT type was last determined
+	LastDiscovery time.Time
+}
+
+// UnmarshalJSON decodes the public addresses from their string form, a Multiaddr interface can't be decoded itself
+func (s *NATStatus) UnmarshalJSON(b []byte) error {
+	type natStatus NATStatus
+	var raw struct {
+		natStatus
+		PublicAddresses []string
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	*s = NATStatus(raw.natStatus)
+	s.PublicAddresses = nil
+	for _, addr := range raw.PublicAddresses {
+		maddr, err := multiaddr.NewMultiaddr(addr)
+		if err != nil {
+			return err
+		}
+		s.PublicAddresses = append(s.PublicAddresses, maddr)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNATStatusJSON(t *testing.T) {
+	addr, err := multiaddr.NewMultiaddr("/ip4/52.36.61.156/tcp/1347")
+	require.NoError(t, err)
+	status := NATStatus{
+		Reachability:    network.ReachabilityPublic,
+		PublicAddresses: []multiaddr.Multiaddr{addr},
+		NATType:         network.NATDeviceTypeCone.String(),
+		LastDiscovery:   time.Unix(1_700_000_000, 0).UTC(),
+	}
+
+	b, err := json.Marshal(status)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"PublicAddresses":["/ip4/52.36.61.156/tcp/1347"]`)
+
+	var decoded NATStatus
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Equal(t, status, decoded)
+
+	require.Error(t, json.Unmarshal([]byte(`{"PublicAddresses":["not an address"]}`), &decoded))
+}
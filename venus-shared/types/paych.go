@@ -17,6 +17,10 @@ const (
 type Status struct {
 	ControlAddr address.Address
 	Direction   PCHDir
+	// PendingIncoming is the amount of the received vouchers that have not been submitted
+	PendingIncoming abi.TokenAmount
+	// PendingOutgoing is the amount of the issued vouchers that have not been redeemed on chain
+	PendingOutgoing abi.TokenAmount
 }
 
 type PaychGetOpts struct {